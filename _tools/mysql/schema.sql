@@ -4,24 +4,191 @@ create table `users`
     `name`     VARCHAR(20)     NOT NULL COMMENT 'ユーザ名',
     `password` VARCHAR(80)     NOT NULL COMMENT 'パスワードハッシュ',
     `role`     VARCHAR(80)     NOT NULL COMMENT 'ロール',
+    `email`    VARCHAR(254)    NULL COMMENT 'メールアドレス',
+    `display_name` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '表示名',
+    `time_zone`    VARCHAR(64) NOT NULL DEFAULT 'UTC' COMMENT 'タイムゾーン(IANA)',
+    `locale`       VARCHAR(35) NOT NULL DEFAULT 'en' COMMENT 'ロケール(BCP 47)',
+    `password_reset_required` BOOLEAN NOT NULL DEFAULT FALSE COMMENT '次回ログイン時のパスワード再設定要否',
+    `totp_secret`  VARCHAR(64) NULL COMMENT 'TOTPのシークレット(Base32)',
+    `totp_enabled` BOOLEAN     NOT NULL DEFAULT FALSE COMMENT '二要素認証の有効化有無',
+    `disabled_at`  DATETIME(6) NULL COMMENT '管理者による無効化日時',
     `created`  DATETIME(6)     NOT NULL COMMENT '作成日時',
     `modified` DATETIME(6)     NOT NULL COMMENT '更新日時',
     PRIMARY KEY (`id`),
-    UNIQUE KEY `uix_name` (`name`) USING BTREE
+    UNIQUE KEY `uix_name` (`name`) USING BTREE,
+    UNIQUE KEY `uix_email` (`email`) USING BTREE
 ) ENGINE = InnoDB
   DEFAULT CHARSET = utf8mb4 COMMENT ='ユーザ';
 
+create table `projects`
+(
+    `id`          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'プロジェクトID',
+    `user_id`     BIGINT UNSIGNED NOT NULL COMMENT 'プロジェクトを作成したユーザID',
+    `name`        VARCHAR(128)    NOT NULL COMMENT 'プロジェクト名',
+    `archived_at` DATETIME(6)     NULL COMMENT 'アーカイブ日時',
+    `created`     DATETIME(6)     NOT NULL COMMENT '作成日時',
+    `modified`    DATETIME(6)     NOT NULL COMMENT '更新日時',
+    PRIMARY KEY (`id`),
+    KEY `idx_user_id_archived_at` (`user_id`, `archived_at`) USING BTREE,
+    CONSTRAINT `fk_project_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+            ON DELETE RESTRICT ON UPDATE RESTRICT
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT ='プロジェクト';
+
 create table `tasks`
 (
-    `id`       BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'タスクID',
-    `user_id`  BIGINT UNSIGNED NOT NULL COMMENT 'タスクを作成したユーザID',
-    `title`    VARCHAR(128)    NOT NULL COMMENT 'タイトル',
-    `status`   VARCHAR(20)     NOT NULL COMMENT 'ステータス',
+    `id`          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'タスクID',
+    `user_id`     BIGINT UNSIGNED NOT NULL COMMENT 'タスクを作成したユーザID',
+    `project_id`  BIGINT UNSIGNED NULL COMMENT '所属するプロジェクトID',
+    `parent_id`   BIGINT UNSIGNED NULL COMMENT '親タスクID',
+    `title`       VARCHAR(128)    NOT NULL COMMENT 'タイトル',
+    `description` TEXT            NOT NULL COMMENT '詳細説明(Markdown)',
+    `status`      VARCHAR(20)     NOT NULL COMMENT 'ステータス',
+    `priority`    VARCHAR(20)     NOT NULL DEFAULT 'normal' COMMENT '優先度',
+    `due_at`      DATETIME(6)     NULL COMMENT '期限日時',
+    `recurrence`  VARCHAR(255)    NULL COMMENT '繰り返し規則(RRULE)',
+    `recurrence_source_id` BIGINT UNSIGNED NULL COMMENT '次回分として生成した元の繰り返しタスクID',
+    `created`     DATETIME(6)     NOT NULL COMMENT '作成日時',
+    `modified`    DATETIME(6)     NOT NULL COMMENT '更新日時',
+    `deleted_at`  DATETIME(6)     NULL COMMENT 'ゴミ箱への移動日時',
+    PRIMARY KEY (`id`),
+    KEY `idx_user_id_created` (`user_id`, `created`, `id`) USING BTREE,
+    KEY `idx_user_id_modified` (`user_id`, `modified`, `id`) USING BTREE,
+    KEY `idx_user_id_status_created` (`user_id`, `status`, `created`, `id`) USING BTREE,
+    KEY `idx_user_id_title` (`user_id`, `title`) USING BTREE,
+    KEY `idx_user_id_due_at` (`user_id`, `due_at`) USING BTREE,
+    KEY `idx_project_id_created` (`project_id`, `created`, `id`) USING BTREE,
+    KEY `idx_parent_id_created` (`parent_id`, `created`, `id`) USING BTREE,
+    KEY `idx_deleted_at` (`deleted_at`) USING BTREE,
+    UNIQUE KEY `uk_recurrence_source_id` (`recurrence_source_id`) USING BTREE,
+    CONSTRAINT `fk_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+            ON DELETE RESTRICT ON UPDATE RESTRICT,
+    CONSTRAINT `fk_project_id`
+        FOREIGN KEY (`project_id`) REFERENCES `projects` (`id`)
+            ON DELETE SET NULL ON UPDATE RESTRICT,
+    CONSTRAINT `fk_parent_id`
+        FOREIGN KEY (`parent_id`) REFERENCES `tasks` (`id`)
+            ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT `fk_recurrence_source_id`
+        FOREIGN KEY (`recurrence_source_id`) REFERENCES `tasks` (`id`)
+            ON DELETE SET NULL ON UPDATE RESTRICT
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT ='タスク';
+
+create table `task_transitions`
+(
+    `id`          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ステータス遷移ID',
+    `task_id`     BIGINT UNSIGNED NOT NULL COMMENT '遷移したタスクID',
+    `user_id`     BIGINT UNSIGNED NOT NULL COMMENT '遷移を実行したユーザID',
+    `from_status` VARCHAR(20)     NOT NULL COMMENT '遷移元ステータス',
+    `to_status`   VARCHAR(20)     NOT NULL COMMENT '遷移先ステータス',
+    `created`     DATETIME(6)     NOT NULL COMMENT '遷移日時',
+    PRIMARY KEY (`id`),
+    KEY `idx_task_id` (`task_id`) USING BTREE,
+    CONSTRAINT `fk_transition_task_id`
+        FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`)
+            ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT `fk_transition_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+            ON DELETE RESTRICT ON UPDATE RESTRICT
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT ='タスクステータス遷移履歴';
+
+create table `tags`
+(
+    `id`       BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'タグID',
+    `user_id`  BIGINT UNSIGNED NOT NULL COMMENT 'タグを作成したユーザID',
+    `name`     VARCHAR(64)     NOT NULL COMMENT 'タグ名',
     `created`  DATETIME(6)     NOT NULL COMMENT '作成日時',
     `modified` DATETIME(6)     NOT NULL COMMENT '更新日時',
     PRIMARY KEY (`id`),
-    CONSTRAINT `fk_user_id`
+    UNIQUE KEY `uix_user_id_name` (`user_id`, `name`) USING BTREE,
+    CONSTRAINT `fk_tag_user_id`
         FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
             ON DELETE RESTRICT ON UPDATE RESTRICT
 ) ENGINE = InnoDB
-  DEFAULT CHARSET = utf8mb4 COMMENT ='タスク';
+  DEFAULT CHARSET = utf8mb4 COMMENT ='タグ';
+
+create table `task_tags`
+(
+    `task_id` BIGINT UNSIGNED NOT NULL COMMENT 'タスクID',
+    `tag_id`  BIGINT UNSIGNED NOT NULL COMMENT 'タグID',
+    `created` DATETIME(6)     NOT NULL COMMENT '付与日時',
+    PRIMARY KEY (`task_id`, `tag_id`),
+    KEY `idx_tag_id_task_id` (`tag_id`, `task_id`) USING BTREE,
+    CONSTRAINT `fk_task_tag_task_id`
+        FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`)
+            ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT `fk_task_tag_tag_id`
+        FOREIGN KEY (`tag_id`) REFERENCES `tags` (`id`)
+            ON DELETE CASCADE ON UPDATE RESTRICT
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT ='タスクとタグの関連';
+
+create table `task_dependencies`
+(
+    `blocker_id` BIGINT UNSIGNED NOT NULL COMMENT '先行するタスクID',
+    `blocked_id` BIGINT UNSIGNED NOT NULL COMMENT '先行タスクの完了を待つタスクID',
+    `created`    DATETIME(6)     NOT NULL COMMENT '登録日時',
+    PRIMARY KEY (`blocker_id`, `blocked_id`),
+    KEY `idx_blocked_id_blocker_id` (`blocked_id`, `blocker_id`) USING BTREE,
+    CONSTRAINT `fk_dependency_blocker_id`
+        FOREIGN KEY (`blocker_id`) REFERENCES `tasks` (`id`)
+            ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT `fk_dependency_blocked_id`
+        FOREIGN KEY (`blocked_id`) REFERENCES `tasks` (`id`)
+            ON DELETE CASCADE ON UPDATE RESTRICT
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT ='タスク間の依存関係';
+
+create table `personal_access_tokens`
+(
+    `id`         BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'パーソナルアクセストークンID',
+    `user_id`    BIGINT UNSIGNED NOT NULL COMMENT 'トークンを発行したユーザID',
+    `name`       VARCHAR(100)    NOT NULL COMMENT 'トークン名',
+    `token_hash` CHAR(64)        NOT NULL COMMENT 'トークンのSHA-256ハッシュ値',
+    `scopes`     VARCHAR(255)    NOT NULL COMMENT '許可するスコープ(空白区切り)',
+    `expires_at` DATETIME(6)     NULL COMMENT '有効期限',
+    `created`    DATETIME(6)     NOT NULL COMMENT '作成日時',
+    `modified`   DATETIME(6)     NOT NULL COMMENT '更新日時',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uix_token_hash` (`token_hash`) USING BTREE,
+    KEY `idx_user_id` (`user_id`) USING BTREE,
+    CONSTRAINT `fk_pat_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+            ON DELETE RESTRICT ON UPDATE RESTRICT
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT ='パーソナルアクセストークン';
+
+create table `recovery_codes`
+(
+    `id`        BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'リカバリーコードID',
+    `user_id`   BIGINT UNSIGNED NOT NULL COMMENT 'リカバリーコードを発行したユーザID',
+    `code_hash` CHAR(64)        NOT NULL COMMENT 'リカバリーコードのSHA-256ハッシュ値',
+    `used_at`   DATETIME(6)     NULL COMMENT '使用日時',
+    `created`   DATETIME(6)     NOT NULL COMMENT '作成日時',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uix_user_id_code_hash` (`user_id`, `code_hash`) USING BTREE,
+    CONSTRAINT `fk_recovery_code_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+            ON DELETE RESTRICT ON UPDATE RESTRICT
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT ='二要素認証のリカバリーコード';
+
+create table `user_identities`
+(
+    `id`      BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'IDプロバイダ連携ID',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT '連携したユーザID',
+    `issuer`  VARCHAR(255)    NOT NULL COMMENT 'IDプロバイダの発行者(iss)',
+    `subject` VARCHAR(255)    NOT NULL COMMENT 'IDプロバイダ上のユーザ識別子(sub)',
+    `created` DATETIME(6)     NOT NULL COMMENT '作成日時',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uix_issuer_subject` (`issuer`, `subject`) USING BTREE,
+    KEY `idx_user_id` (`user_id`) USING BTREE,
+    CONSTRAINT `fk_user_identity_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+            ON DELETE RESTRICT ON UPDATE RESTRICT
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4 COMMENT ='外部IDプロバイダのユーザとの連携';
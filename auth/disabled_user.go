@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+// ErrUserDisabled は管理者により無効化されたユーザが認証を試みたことを表す
+var ErrUserDisabled = errors.New("user disabled")
+
+// DisableUser はユーザを無効化済としてKVSに記録し、発行済のすべてのトークンを失効させる
+// 記録はトークンの検証時に参照し、失効前に発行されたトークンによるアクセスも拒否する
+func (j JWTer) DisableUser(ctx context.Context, uid entity.UserID) error {
+	if err := j.Store.SetUserDisabled(ctx, uid, true); err != nil {
+		return fmt.Errorf("DisableUser: failed to mark user %d: %w", uid, err)
+	}
+	return j.RevokeAllTokens(ctx, uid)
+}
+
+// EnableUser はユーザの無効化の記録をKVSから削除する
+func (j JWTer) EnableUser(ctx context.Context, uid entity.UserID) error {
+	if err := j.Store.SetUserDisabled(ctx, uid, false); err != nil {
+		return fmt.Errorf("EnableUser: failed to unmark user %d: %w", uid, err)
+	}
+	return nil
+}
+
+// checkDisabled はユーザが無効化済の場合にErrUserDisabledを返却する
+func (j JWTer) checkDisabled(ctx context.Context, uid entity.UserID) error {
+	disabled, err := j.Store.IsUserDisabled(ctx, uid)
+	if err != nil {
+		return fmt.Errorf("FillContext: failed to check user %d: %w", uid, err)
+	}
+	if disabled {
+		return fmt.Errorf("FillContext: user %d: %w", uid, ErrUserDisabled)
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 )
 
@@ -21,36 +22,112 @@ var rawPrivKey []byte
 //go:embed cert/public.pem
 var rawPubKey []byte
 
+const (
+	DefaultIssuer   = `github.com/ac0mz/go_todo_app`
+	DefaultAudience = `github.com/ac0mz/go_todo_app`
+	DefaultLifetime = 30 * time.Minute
+)
+
 // JWTer はPEMキーから変換したJWKと、KVSに保存するStoreインターフェースを持つ
 type JWTer struct {
-	PrivateKey, PublicKey jwk.Key
-	Store                 Store
-	Clocker               clock.Clocker
+	Keys            *KeySet
+	Algorithm       jwa.SignatureAlgorithm // 署名アルゴリズム(署名用の鍵の種類と一致すること)
+	Issuer          string                 // issクレームに設定し、検証時に一致を確認する値
+	Audience        string                 // audクレームに設定し、検証時に含まれることを確認する値
+	Lifetime        time.Duration          // アクセストークンの有効期間
+	Store           Store
+	Clocker         clock.Clocker
+	RefreshTokenTTL time.Duration
+	// PATs はパーソナルアクセストークンの取得に使用し、nilの場合はJWTのみを受け付ける
+	PATs PersonalAccessTokenFinder
+	// SessionTouchInterval はセッションの最終アクセス日時を更新する最小の間隔
+	SessionTouchInterval time.Duration
 }
 
-//go:generate go run github.com/matryer/moq -out moq_test.go . Store
+//go:generate go run github.com/matryer/moq -out moq_test.go . Store PersonalAccessTokenFinder
 type Store interface {
-	Save(ctx context.Context, key string, userID entity.UserID) error
+	Save(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error
 	Load(ctx context.Context, key string) (entity.UserID, error)
+	Delete(ctx context.Context, key string) error
+	DeleteAll(ctx context.Context, userID entity.UserID) error
+	SaveRefreshFamily(ctx context.Context, family string, userID entity.UserID, hash string, ttl time.Duration) error
+	RotateRefreshFamily(ctx context.Context, family, oldHash, newHash string, ttl time.Duration) (entity.UserID, error)
+	SaveSession(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error
+	ListSessions(ctx context.Context, userID entity.UserID) (entity.Sessions, error)
+	TouchSession(ctx context.Context, sid string, now time.Time, interval time.Duration) (bool, error)
+	DeleteSession(ctx context.Context, userID entity.UserID, sid string) error
+	SetUserDisabled(ctx context.Context, userID entity.UserID, disabled bool) error
+	IsUserDisabled(ctx context.Context, userID entity.UserID) (bool, error)
 }
 
-// NewJWTer はPEMキーの解析と構造体の初期化を行う
+// NewJWTer は埋め込みのPEMキーの解析と、既定値による構造体の初期化を行う
 func NewJWTer(s Store, c clock.Clocker) (*JWTer, error) {
-	privKey, err := parse(rawPrivKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed in NewJWTer: private key: %w", err)
-	}
-	pubKey, err := parse(rawPubKey)
+	keys, err := EmbeddedKeySet()
 	if err != nil {
-		return nil, fmt.Errorf("failed in NewJWTer: public key: %w", err)
+		return nil, fmt.Errorf("failed in NewJWTer: %w", err)
 	}
 
 	j := &JWTer{
-		Store:      s,
-		PrivateKey: privKey,
-		PublicKey:  pubKey,
-		Clocker:    c,
+		Keys:                 keys,
+		Algorithm:            jwa.RS256,
+		Issuer:               DefaultIssuer,
+		Audience:             DefaultAudience,
+		Lifetime:             DefaultLifetime,
+		Store:                s,
+		Clocker:              c,
+		RefreshTokenTTL:      DefaultRefreshTokenTTL,
+		SessionTouchInterval: DefaultSessionTouchInterval,
+	}
+	return j, nil
+}
+
+// Options はNewJWTerWithOptionsで使用する鍵と発行するトークンの設定値
+type Options struct {
+	KeyDir               string // 鍵のディレクトリ(未指定の場合は埋め込みのPEMキーを使用する)
+	SigningKeyID         string
+	Algorithm            string
+	Issuer               string
+	Audience             string
+	Lifetime             time.Duration
+	RefreshTokenTTL      time.Duration
+	SessionTouchInterval time.Duration
+}
+
+// NewJWTerWithOptions は設定値に従い鍵の読み込みと構造体の初期化を行う
+// 鍵のディレクトリが未指定の場合は埋め込みのPEMキー(RS256)を使用する
+func NewJWTerWithOptions(s Store, c clock.Clocker, opts Options) (*JWTer, error) {
+	// 有効期間が0以下の場合は発行直後に失効するトークンとなるため、起動時に検出する
+	if opts.Lifetime <= 0 {
+		return nil, fmt.Errorf("failed in NewJWTerWithOptions: lifetime must be positive, got %v", opts.Lifetime)
+	}
+	if opts.RefreshTokenTTL <= 0 {
+		return nil, fmt.Errorf("failed in NewJWTerWithOptions: refresh token ttl must be positive, got %v", opts.RefreshTokenTTL)
+	}
+	if opts.SessionTouchInterval < 0 {
+		return nil, fmt.Errorf("failed in NewJWTerWithOptions: session touch interval must not be negative, got %v", opts.SessionTouchInterval)
+	}
+	j, err := NewJWTer(s, c)
+	if err != nil {
+		return nil, err
+	}
+	if j.Algorithm, err = ParseAlgorithm(opts.Algorithm); err != nil {
+		return nil, fmt.Errorf("failed in NewJWTerWithOptions: %w", err)
+	}
+	if opts.KeyDir != "" {
+		if j.Keys, err = LoadKeySet(opts.KeyDir, opts.SigningKeyID); err != nil {
+			return nil, fmt.Errorf("failed in NewJWTerWithOptions: %w", err)
+		}
 	}
+	// 署名用の鍵の種類と署名アルゴリズムの不一致は、トークン発行時ではなく起動時に検出する
+	if alg := j.Keys.Signing.Algorithm(); alg != j.Algorithm {
+		return nil, fmt.Errorf("failed in NewJWTerWithOptions: signing key %q is for %s, not %s",
+			j.Keys.Signing.KeyID(), alg, j.Algorithm)
+	}
+	j.Issuer = opts.Issuer
+	j.Audience = opts.Audience
+	j.Lifetime = opts.Lifetime
+	j.RefreshTokenTTL = opts.RefreshTokenTTL
+	j.SessionTouchInterval = opts.SessionTouchInterval
 	return j, nil
 }
 
@@ -64,33 +141,57 @@ func parse(rawKey []byte) (jwk.Key, error) {
 }
 
 const (
-	RoleKey     = "role"
-	UserNameKey = "user_name"
+	RoleKey      = "role"
+	UserNameKey  = "user_name"
+	ScopeKey     = "scope"
+	SessionIDKey = "sid"
+	AuthTimeKey  = "auth_time"
 )
 
 // GenerateToken はユーザ情報と秘密鍵を元にJWTトークンを生成する。
 // また、トークン生成時に作成したUUID（JWT ID）をキーにRedisへユーザIDを登録する。
+// 併せて、SetClientおよびSetRefreshTokenで設定された情報をセッション情報として記録する。
+// セッションはリフレッシュトークンのファミリー単位とし、sidクレームにセッションIDを設定する。
+// SetAuthTimeで認証日時が設定されている場合は、auth_timeクレームに設定する。
 func (j JWTer) GenerateToken(ctx context.Context, u entity.User) ([]byte, error) {
-	token, err := jwt.NewBuilder().
-		JwtID(uuid.New().String()).
-		Issuer(`github.com/ac0mz/go_todo_app`).
+	now := j.Clocker.Now()
+	jti := uuid.New().String()
+	// リフレッシュトークンを発行していない場合は、トークン単位のセッションとする
+	sid := getRefreshFamily(ctx)
+	if sid == "" {
+		sid = jti
+	}
+	b := jwt.NewBuilder()
+	if at, ok := GetAuthTime(ctx); ok {
+		b = b.Claim(AuthTimeKey, at.Unix()) // 独自クレーム(認証日時)
+	}
+	token, err := b.
+		JwtID(jti).
+		Issuer(j.Issuer).
+		Audience([]string{j.Audience}).
 		Subject("access_token").
-		IssuedAt(j.Clocker.Now()).
-		Expiration(j.Clocker.Now().Add(30*time.Minute)).
+		IssuedAt(now).
+		Expiration(now.Add(j.Lifetime)).
 		Claim(RoleKey, u.Role).     // 独自クレーム(ロール)
 		Claim(UserNameKey, u.Name). // 独自クレーム(ユーザ名)
+		// 許可するスコープ(RFC 8693と同様に空白区切りの文字列)
+		Claim(ScopeKey, entity.AllScopes.String()).
+		Claim(SessionIDKey, sid). // 独自クレーム(セッションID)
 		Build()
 	if err != nil {
 		return nil, fmt.Errorf("GetToken: failed to build token: %w", err)
 	}
 
 	// UUIDをキーにユーザIDを登録
-	if err := j.Store.Save(ctx, token.JwtID(), u.ID); err != nil {
+	if err := j.Store.Save(ctx, token.JwtID(), u.ID, j.Lifetime); err != nil {
+		return nil, err
+	}
+	if err := j.saveSession(ctx, sid, jti, u.ID, now); err != nil {
 		return nil, err
 	}
 
-	// 秘密鍵による署名を付与したJWTトークンの生成
-	signed, err := jwt.Sign(token, jwt.WithKey(jwa.RS256, j.PrivateKey))
+	// 秘密鍵による署名を付与したJWTトークンの生成(ヘッダーには鍵のkidが設定される)
+	signed, err := jwt.Sign(token, jwt.WithKey(j.Algorithm, j.Keys.Signing))
 	if err != nil {
 		return nil, err
 	}
@@ -102,8 +203,9 @@ func (j JWTer) GetToken(ctx context.Context, r *http.Request) (jwt.Token, error)
 	// HTTPリクエストヘッダーからjwt.Tokenインターフェースを満たす型の値を取得
 	token, err := jwt.ParseRequest(
 		r,
-		// 署名検証用アルゴリズムと公開鍵を指定
-		jwt.WithKey(jwa.RS256, j.PublicKey),
+		// ヘッダーのkidに対応する公開鍵で署名を検証する
+		// kid導入前に発行したトークンは、すべての公開鍵を検証対象とする
+		jwt.WithKeySet(j.Keys.Public, jws.WithRequireKid(false)),
 		// 後続処理にて時刻情報(*auth.JWTer.Clocker)をベースに検証するため、ここでの検証は無視する
 		jwt.WithValidate(false),
 	)
@@ -111,8 +213,13 @@ func (j JWTer) GetToken(ctx context.Context, r *http.Request) (jwt.Token, error)
 		return nil, err
 	}
 
-	// 署名の検証
-	if err = jwt.Validate(token, jwt.WithClock(j.Clocker)); err != nil {
+	// 有効期限、発行者、および自インスタンス宛てのトークンであることの検証
+	if err = jwt.Validate(token,
+		jwt.WithClock(j.Clocker),
+		jwt.WithRequiredClaim(jwt.ExpirationKey),
+		jwt.WithIssuer(j.Issuer),
+		jwt.WithAudience(j.Audience),
+	); err != nil {
 		return nil, fmt.Errorf("GetToken: failed to validate token: %w", err)
 	}
 	// Redisに格納されたトークン存在有無チェック
@@ -123,8 +230,32 @@ func (j JWTer) GetToken(ctx context.Context, r *http.Request) (jwt.Token, error)
 	return token, nil
 }
 
+// RevokeToken はJWT IDに対応するトークンをRedisから削除し、以降の検証で無効とする
+func (j JWTer) RevokeToken(ctx context.Context, jti string) error {
+	if err := j.Store.Delete(ctx, jti); err != nil {
+		return fmt.Errorf("RevokeToken: failed to delete %q: %w", jti, err)
+	}
+	return nil
+}
+
+// RevokeAllTokens はユーザに発行済のすべてのトークンをRedisから削除する
+func (j JWTer) RevokeAllTokens(ctx context.Context, uid entity.UserID) error {
+	if err := j.Store.DeleteAll(ctx, uid); err != nil {
+		return fmt.Errorf("RevokeAllTokens: failed to delete tokens of user %d: %w", uid, err)
+	}
+	return nil
+}
+
+// PublicKeys はトークンの検証に使用する公開鍵の一覧をJWK Set形式で返却する
+func (j JWTer) PublicKeys(ctx context.Context) (jwk.Set, error) {
+	return j.Keys.Public, nil
+}
+
 type userIDKey struct{}
 type roleKey struct{}
+type tokenIDKey struct{}
+type scopesKey struct{}
+type authTimeKey struct{}
 
 // SetUserID はcontext.Contextにキーバリューの形式でユーザIDを設定する
 func SetUserID(ctx context.Context, uid entity.UserID) context.Context {
@@ -138,23 +269,66 @@ func GetUserID(ctx context.Context) (entity.UserID, bool) {
 	return id, ok
 }
 
+// SetTokenID はcontext.Contextにキーバリューの形式でJWT IDを設定する
+func SetTokenID(ctx context.Context, jti string) context.Context {
+	return context.WithValue(ctx, tokenIDKey{}, jti)
+}
+
+// GetTokenID はcontext.ContextからJWT IDを取得し、値と取得成否を返却する
+func GetTokenID(ctx context.Context) (string, bool) {
+	jti, ok := ctx.Value(tokenIDKey{}).(string)
+	return jti, ok
+}
+
+// SetAuthTime はcontext.Contextにキーバリューの形式でユーザを認証した日時を設定する
+// GenerateTokenは設定された値をトークンに記録し、パスワードを持たないユーザの再認証の判定に使用する
+func SetAuthTime(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, authTimeKey{}, t)
+}
+
+// GetAuthTime はcontext.Contextから認証日時を取得し、値と取得成否を返却する
+// リフレッシュトークンにより再発行したトークン等、認証日時を記録していないトークンの場合は設定されていない
+func GetAuthTime(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(authTimeKey{}).(time.Time)
+	return t, ok
+}
+
+// SetScopes はcontext.Contextにキーバリューの形式でトークンに許可されたスコープを設定する
+func SetScopes(ctx context.Context, scopes entity.Scopes) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// GetScopes はcontext.Contextからスコープを取得し、値と取得成否を返却する
+func GetScopes(ctx context.Context) (entity.Scopes, bool) {
+	scopes, ok := ctx.Value(scopesKey{}).(entity.Scopes)
+	return scopes, ok
+}
+
 // SetRole はcontext.Contextにキーバリューの形式でロールを設定する
+// ロールのクレームが存在しない場合は空のロールとし、いずれの操作も許可しない
 func SetRole(ctx context.Context, token jwt.Token) context.Context {
-	role, ok := token.Get(RoleKey)
-	if !ok {
-		return context.WithValue(ctx, roleKey{}, "")
+	var role entity.Role
+	if v, ok := token.Get(RoleKey); ok {
+		if s, ok := v.(string); ok {
+			role = entity.Role(s)
+		}
 	}
 	return context.WithValue(ctx, roleKey{}, role)
 }
 
 // GetRole はcontext.Contextからロールを取得し、値と取得成否を返却する
-func GetRole(ctx context.Context) (string, bool) {
-	role, ok := ctx.Value(roleKey{}).(string)
+func GetRole(ctx context.Context) (entity.Role, bool) {
+	role, ok := ctx.Value(roleKey{}).(entity.Role)
 	return role, ok
 }
 
 // FillContext は*http.Request型の値にユーザIDやロール権限の情報を設定する
+// ユーザが無効化済の場合は、トークンが有効であってもErrUserDisabledを返却する
+// Bearerトークンがパーソナルアクセストークンの場合は、JWTの代わりに当該トークンを検証する
 func (j JWTer) FillContext(r *http.Request) (*http.Request, error) {
+	if token, ok := bearerPersonalAccessToken(r); ok {
+		return j.fillContextWithPAT(r, token)
+	}
 	token, err := j.GetToken(r.Context(), r)
 	if err != nil {
 		return nil, err
@@ -164,18 +338,80 @@ func (j JWTer) FillContext(r *http.Request) (*http.Request, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := j.checkDisabled(r.Context(), uid); err != nil {
+		return nil, err
+	}
 	ctx := SetUserID(r.Context(), uid)
 	ctx = SetRole(ctx, token)
+	ctx = SetTokenID(ctx, token.JwtID())
+	ctx = SetSessionID(ctx, tokenSessionID(token))
+	if at, ok := tokenAuthTime(token); ok {
+		ctx = SetAuthTime(ctx, at)
+	}
+	scopes, err := tokenScopes(token)
+	if err != nil {
+		return nil, err
+	}
+	ctx = SetScopes(ctx, scopes)
 
 	// context.Context型の値を入れ替えた*http.Request型の値をディープコピー
 	clone := r.Clone(ctx)
 	return clone, nil
 }
 
+// tokenScopes はJWTのscopeクレームからスコープを取得する
+// scopeクレーム導入前に発行したトークンは、従来どおりすべてのスコープを許可する
+func tokenScopes(token jwt.Token) (entity.Scopes, error) {
+	v, ok := token.Get(ScopeKey)
+	if !ok {
+		return entity.AllScopes, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s claim type %T", ScopeKey, v)
+	}
+	return entity.ParseScopes(s)
+}
+
+// tokenSessionID はJWTのsidクレームからセッションIDを取得する
+// sidクレーム導入前に発行したトークンは、従来どおりJWT IDをセッションIDとする
+func tokenSessionID(token jwt.Token) string {
+	if v, ok := token.Get(SessionIDKey); ok {
+		if sid, ok := v.(string); ok && sid != "" {
+			return sid
+		}
+	}
+	return token.JwtID()
+}
+
+// tokenAuthTime はJWTのauth_timeクレームから認証日時を取得する
+func tokenAuthTime(token jwt.Token) (time.Time, bool) {
+	v, ok := token.Get(AuthTimeKey)
+	if !ok {
+		return time.Time{}, false
+	}
+	// 数値のクレームはfloat64として復元される
+	sec, ok := v.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(sec), 0).UTC(), true
+}
+
+// HasPermission はログインユーザのロールに指定した操作が許可されているかを判定する
+func HasPermission(ctx context.Context, p entity.Permission) bool {
+	role, ok := GetRole(ctx)
+	if !ok {
+		return false
+	}
+	return role.Can(p)
+}
+
+// IsAdmin はログインユーザが管理者ロールであるかを判定する
 func IsAdmin(ctx context.Context) bool {
 	role, ok := GetRole(ctx)
 	if !ok {
 		return false
 	}
-	return role == "admin"
+	return role == entity.RoleAdmin
 }
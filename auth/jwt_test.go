@@ -3,6 +3,7 @@ package auth
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -43,15 +44,36 @@ func TestEmbed(t *testing.T) {
 }
 
 func TestJWTer_GenerateToken(t *testing.T) {
-	ctx := context.Background()
+	ctx := SetClient(context.Background(), "test-agent", "192.0.2.1")
+	ctx = SetRefreshToken(ctx, "family.secret")
 	wantID := entity.UserID(20)
 	u := fixture.User(&entity.User{ID: wantID})
 
+	var saved string
 	moq := &StoreMock{}
-	moq.SaveFunc = func(ctx context.Context, key string, userID entity.UserID) error {
+	moq.SaveFunc = func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
 		if userID != wantID {
 			t.Errorf("want %d, but got %d", wantID, userID)
 		}
+		saved = key
+		return nil
+	}
+	// リフレッシュトークンと同じ有効期間で、リクエスト元をファミリー単位のセッション情報として記録する
+	moq.SaveSessionFunc = func(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error {
+		now := clock.FixedClocker{}.Now()
+		want := &entity.Session{
+			ID: "family", UserID: wantID, UserAgent: "test-agent", IP: "192.0.2.1",
+			Created: now, LastSeen: now, RefreshFamily: "family",
+		}
+		if !reflect.DeepEqual(s, want) {
+			t.Errorf("want %+v, but got %+v", want, s)
+		}
+		if jti != saved {
+			t.Errorf("want jti %q, but got %q", saved, jti)
+		}
+		if ttl != DefaultRefreshTokenTTL {
+			t.Errorf("want ttl %s, but got %s", DefaultRefreshTokenTTL, ttl)
+		}
 		return nil
 	}
 
@@ -68,6 +90,135 @@ func TestJWTer_GenerateToken(t *testing.T) {
 	if len(got) == 0 {
 		t.Errorf("got token is empty")
 	}
+	token, err := jwt.Parse(got, jwt.WithVerify(false), jwt.WithValidate(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sid, _ := token.Get(SessionIDKey); sid != "family" {
+		t.Errorf("want sid %q, but got %v", "family", sid)
+	}
+}
+
+// TestJWTer_FillContext_sessionID ローテーションをまたいで同一のセッションIDがcontext.Contextに設定されることを確認する
+func TestJWTer_FillContext_sessionID(t *testing.T) {
+	t.Parallel()
+
+	c := clock.FixedClocker{}
+	u := fixture.User(&entity.User{ID: 20})
+	var ttls []time.Duration
+	moq := &StoreMock{}
+	moq.SaveFunc = func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+		return nil
+	}
+	moq.SaveSessionFunc = func(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error {
+		ttls = append(ttls, ttl)
+		return nil
+	}
+	moq.LoadFunc = func(ctx context.Context, key string) (entity.UserID, error) {
+		return u.ID, nil
+	}
+	moq.IsUserDisabledFunc = func(ctx context.Context, userID entity.UserID) (bool, error) {
+		return false, nil
+	}
+	sut, err := NewJWTer(moq, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fill := func(ctx context.Context) (string, string) {
+		t.Helper()
+		signed, err := sut.GenerateToken(ctx, *u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := sut.FillContext(createRequest(signed))
+		if err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+		sid, _ := GetSessionID(req.Context())
+		jti, _ := GetTokenID(req.Context())
+		return sid, jti
+	}
+
+	// ローテーション前後のリフレッシュトークンは同一のファミリーに属する
+	first, firstJTI := fill(SetRefreshToken(context.Background(), "family.first"))
+	second, secondJTI := fill(SetRefreshToken(context.Background(), "family.second"))
+	if first != "family" || second != "family" || firstJTI == secondJTI {
+		t.Errorf("want same session %q with different jti, but got %q(%q), %q(%q)",
+			"family", first, firstJTI, second, secondJTI)
+	}
+	// リフレッシュトークンを発行していない場合はトークン単位のセッションとする
+	if sid, jti := fill(context.Background()); sid != jti {
+		t.Errorf("want session id %q, but got %q", jti, sid)
+	}
+	want := []time.Duration{DefaultRefreshTokenTTL, DefaultRefreshTokenTTL, DefaultLifetime}
+	if !reflect.DeepEqual(ttls, want) {
+		t.Errorf("want ttls %v, but got %v", want, ttls)
+	}
+
+	// sidクレーム導入前に発行したトークンは、JWT IDをセッションIDとする
+	token, legacy := createToken(t, c)
+	req, err := sut.FillContext(createRequest(legacy))
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if sid, _ := GetSessionID(req.Context()); sid != token.JwtID() {
+		t.Errorf("want session id %q, but got %q", token.JwtID(), sid)
+	}
+}
+
+func TestJWTer_FillContext_authTime(t *testing.T) {
+	t.Parallel()
+
+	c := clock.FixedClocker{}
+	u := fixture.User(&entity.User{ID: 20})
+	moq := &StoreMock{}
+	moq.SaveFunc = func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+		return nil
+	}
+	moq.SaveSessionFunc = func(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error {
+		return nil
+	}
+	moq.LoadFunc = func(ctx context.Context, key string) (entity.UserID, error) {
+		return u.ID, nil
+	}
+	moq.IsUserDisabledFunc = func(ctx context.Context, userID entity.UserID) (bool, error) {
+		return false, nil
+	}
+	sut, err := NewJWTer(moq, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		authTime time.Time // ゼロ値の場合は認証日時を設定しない
+		wantOK   bool
+	}{
+		"withAuthTime":    {authTime: c.Now().Add(-time.Minute), wantOK: true},
+		"withoutAuthTime": {},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if !tt.authTime.IsZero() {
+				ctx = SetAuthTime(ctx, tt.authTime)
+			}
+			signed, err := sut.GenerateToken(ctx, *u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req, err := sut.FillContext(createRequest(signed))
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			got, ok := GetAuthTime(req.Context())
+			if ok != tt.wantOK || !got.Equal(tt.authTime) {
+				t.Errorf("want auth time %v(%v), but got %v(%v)", tt.authTime, tt.wantOK, got, ok)
+			}
+		})
+	}
 }
 
 func TestJWTer_GetToken(t *testing.T) {
@@ -81,6 +232,9 @@ func TestJWTer_GetToken(t *testing.T) {
 	moq.LoadFunc = func(ctx context.Context, key string) (entity.UserID, error) {
 		return entity.UserID(20), nil
 	}
+	moq.IsUserDisabledFunc = func(ctx context.Context, userID entity.UserID) (bool, error) {
+		return false, nil
+	}
 	sut, err := NewJWTer(moq, c)
 	if err != nil {
 		t.Fatal(err)
@@ -164,7 +318,8 @@ func TestJWTer_GetToken_NG(t *testing.T) {
 func createToken(t *testing.T, c clock.Clocker) (jwt.Token, []byte) {
 	token, err := jwt.NewBuilder().
 		JwtID(uuid.New().String()).
-		Issuer(`github.com/ac0mz/go_todo_app`).
+		Issuer(DefaultIssuer).
+		Audience([]string{DefaultAudience}).
 		Subject("access_token").
 		IssuedAt(c.Now()).
 		Expiration(c.Now().Add(30*time.Minute)).
@@ -196,3 +351,179 @@ func createRequest(signed []byte) *http.Request {
 	req.Header.Set(`Authorization`, fmt.Sprintf(`Bearer %s`, signed))
 	return req
 }
+
+// TestJWTer_RevokeToken 失効させたトークンのみが検証に失敗することを確認する
+func TestJWTer_RevokeToken(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	u := fixture.User(&entity.User{ID: 20})
+	// Redisの代わりにマップでトークンを管理する
+	tokens := map[string]entity.UserID{}
+	moq := &StoreMock{}
+	moq.SaveFunc = func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+		tokens[key] = userID
+		return nil
+	}
+	moq.SaveSessionFunc = func(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error {
+		return nil
+	}
+	moq.LoadFunc = func(ctx context.Context, key string) (entity.UserID, error) {
+		uid, ok := tokens[key]
+		if !ok {
+			return 0, store.ErrNotFound
+		}
+		return uid, nil
+	}
+	moq.DeleteFunc = func(ctx context.Context, key string) error {
+		delete(tokens, key)
+		return nil
+	}
+	moq.IsUserDisabledFunc = func(ctx context.Context, userID entity.UserID) (bool, error) {
+		return false, nil
+	}
+	moq.DeleteAllFunc = func(ctx context.Context, userID entity.UserID) error {
+		for k, v := range tokens {
+			if v == userID {
+				delete(tokens, k)
+			}
+		}
+		return nil
+	}
+	sut, err := NewJWTer(moq, clock.FixedClocker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	generate := func() *http.Request {
+		signed, err := sut.GenerateToken(ctx, *u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return createRequest(signed)
+	}
+	revoked, alive := generate(), generate()
+
+	// 実行と検証
+	req, err := sut.FillContext(revoked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jti, ok := GetTokenID(req.Context())
+	if !ok {
+		t.Fatal("want jti in context")
+	}
+	if err := sut.RevokeToken(ctx, jti); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if _, err := sut.GetToken(ctx, revoked); err == nil {
+		t.Error("want error for revoked token, but got nil")
+	}
+	if _, err := sut.GetToken(ctx, alive); err != nil {
+		t.Errorf("want no error for other token, but got %v", err)
+	}
+
+	if err := sut.RevokeAllTokens(ctx, u.ID); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if _, err := sut.GetToken(ctx, alive); err == nil {
+		t.Error("want error after revoking all tokens, but got nil")
+	}
+}
+
+// TestJWTer_FillContext_scopes トークンのscopeクレームがcontext.Contextに設定されることを確認する
+func TestJWTer_FillContext_scopes(t *testing.T) {
+	t.Parallel()
+
+	c := clock.FixedClocker{}
+	moq := &StoreMock{}
+	moq.SaveFunc = func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+		return nil
+	}
+	moq.SaveSessionFunc = func(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error {
+		return nil
+	}
+	moq.LoadFunc = func(ctx context.Context, key string) (entity.UserID, error) {
+		return entity.UserID(20), nil
+	}
+	moq.IsUserDisabledFunc = func(ctx context.Context, userID entity.UserID) (bool, error) {
+		return false, nil
+	}
+	sut, err := NewJWTer(moq, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := sut.GenerateToken(context.Background(), *fixture.User(&entity.User{ID: 20}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// scopeクレーム導入前に発行したトークン
+	_, legacy := createToken(t, c)
+
+	for n, signed := range map[string][]byte{"generated": signed, "legacy": legacy} {
+		req, err := sut.FillContext(createRequest(signed))
+		if err != nil {
+			t.Fatalf("%s: want no error, but got %v", n, err)
+		}
+		got, ok := GetScopes(req.Context())
+		if !ok || !reflect.DeepEqual(got, entity.AllScopes) {
+			t.Errorf("%s: want scopes %v, but got %v", n, entity.AllScopes, got)
+		}
+	}
+}
+
+// TestJWTer_DisableUser 無効化したユーザのトークンが失効し、失効前のトークンによるアクセスも拒否されることを確認する
+func TestJWTer_DisableUser(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	u := fixture.User(&entity.User{ID: 20})
+	disabled := map[entity.UserID]bool{}
+	revoked := false
+	moq := &StoreMock{}
+	moq.SaveFunc = func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+		return nil
+	}
+	moq.SaveSessionFunc = func(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error {
+		return nil
+	}
+	// 失効の処理中に検証されたトークンを想定し、トークン自体は有効とする
+	moq.LoadFunc = func(ctx context.Context, key string) (entity.UserID, error) {
+		return u.ID, nil
+	}
+	moq.DeleteAllFunc = func(ctx context.Context, userID entity.UserID) error {
+		revoked = userID == u.ID
+		return nil
+	}
+	moq.SetUserDisabledFunc = func(ctx context.Context, userID entity.UserID, d bool) error {
+		disabled[userID] = d
+		return nil
+	}
+	moq.IsUserDisabledFunc = func(ctx context.Context, userID entity.UserID) (bool, error) {
+		return disabled[userID], nil
+	}
+	sut, err := NewJWTer(moq, clock.FixedClocker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := sut.GenerateToken(ctx, *u)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sut.DisableUser(ctx, u.ID); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if !revoked {
+		t.Error("want all tokens revoked")
+	}
+	if _, err := sut.FillContext(createRequest(signed)); !errors.Is(err, ErrUserDisabled) {
+		t.Errorf("want %v, but got %v", ErrUserDisabled, err)
+	}
+
+	if err := sut.EnableUser(ctx, u.ID); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if _, err := sut.FillContext(createRequest(signed)); err != nil {
+		t.Errorf("want no error after enabling, but got %v", err)
+	}
+}
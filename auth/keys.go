@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"crypto"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// KeySet はJWTの署名に使用する秘密鍵と、検証に使用する公開鍵の一覧を保持する
+// 鍵のローテーション中は、旧鍵の公開鍵をPublicに残すことで旧鍵で署名したトークンも検証できる
+type KeySet struct {
+	Signing jwk.Key // kidを設定した署名用の秘密鍵
+	Public  jwk.Set // kidを設定した検証用の公開鍵(署名用の鍵に対応する公開鍵を含む)
+}
+
+// EmbeddedKeySet はバイナリに埋め込んだPEMキーから鍵を生成する
+// kidには公開鍵のJWK Thumbprint(RFC 7638)を使用する
+func EmbeddedKeySet() (*KeySet, error) {
+	privKey, err := parse(rawPrivKey)
+	if err != nil {
+		return nil, fmt.Errorf("private key: %w", err)
+	}
+	pubKey, err := parse(rawPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("public key: %w", err)
+	}
+	tp, err := pubKey.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute thumbprint: %w", err)
+	}
+	kid := base64.RawURLEncoding.EncodeToString(tp)
+
+	ks := &KeySet{Signing: privKey, Public: jwk.NewSet()}
+	if err := ks.add(kid, privKey, pubKey); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// LoadKeySet はdir配下のPEMファイル(*.pem)から鍵を読み込む
+// ファイル名から拡張子を除いた値をkidとし、秘密鍵は署名と検証、公開鍵は検証のみに使用する
+// 鍵ごとのアルゴリズムは鍵の種類から決定するため、異なるアルゴリズムの鍵へのローテーションも可能とする
+// 署名に使用する鍵はsigningKIDで指定し、未指定の場合はkidの辞書順で最後の秘密鍵とする
+func LoadKeySet(dir, signingKID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	// 日付などを含むファイル名とした場合に、最新の鍵が既定の署名用の鍵となるよう整列する
+	sort.Strings(paths)
+
+	ks := &KeySet{Public: jwk.NewSet()}
+	privKeys := map[string]jwk.Key{}
+	var lastKID string
+	for _, path := range paths {
+		kid := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", kid, err)
+		}
+		if !isPrivateKey(key) {
+			if err := ks.add(kid, nil, key); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		pubKey, err := key.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", kid, err)
+		}
+		if err := ks.add(kid, key, pubKey); err != nil {
+			return nil, err
+		}
+		privKeys[kid] = key
+		lastKID = kid
+	}
+
+	if signingKID == "" {
+		signingKID = lastKID
+	}
+	signing, ok := privKeys[signingKID]
+	if !ok {
+		return nil, fmt.Errorf("signing key %q not found in %s", signingKID, dir)
+	}
+	ks.Signing = signing
+	return ks, nil
+}
+
+// add は鍵にkidとアルゴリズムを設定し、公開鍵を検証用の一覧に追加する
+// privKeyがnilの場合は検証のみに使用する公開鍵とする
+func (ks *KeySet) add(kid string, privKey, pubKey jwk.Key) error {
+	if _, ok := ks.Public.LookupKeyID(kid); ok {
+		return fmt.Errorf("duplicate key id %q", kid)
+	}
+	alg, err := algorithmOf(pubKey)
+	if err != nil {
+		return fmt.Errorf("key %q: %w", kid, err)
+	}
+	for _, key := range []jwk.Key{privKey, pubKey} {
+		if key == nil {
+			continue
+		}
+		if err := key.Set(jwk.KeyIDKey, kid); err != nil {
+			return err
+		}
+		// 検証時はトークンのヘッダーではなく鍵のアルゴリズムを信頼する
+		if err := key.Set(jwk.AlgorithmKey, alg); err != nil {
+			return err
+		}
+	}
+	return ks.Public.AddKey(pubKey)
+}
+
+// ParseAlgorithm は設定値の署名アルゴリズムを解析する
+// 対応するアルゴリズムはRS256、ES256、EdDSAとする
+func ParseAlgorithm(s string) (jwa.SignatureAlgorithm, error) {
+	switch alg := jwa.SignatureAlgorithm(s); alg {
+	case jwa.RS256, jwa.ES256, jwa.EdDSA:
+		return alg, nil
+	}
+	return "", fmt.Errorf("unsupported signature algorithm %q", s)
+}
+
+// algorithmOf は鍵の種類から署名アルゴリズムを決定する
+// RSA鍵はRS256、P-256曲線のEC鍵はES256、Ed25519鍵はEdDSAとする
+func algorithmOf(key jwk.Key) (jwa.SignatureAlgorithm, error) {
+	switch k := key.(type) {
+	case jwk.RSAPublicKey:
+		return jwa.RS256, nil
+	case jwk.ECDSAPublicKey:
+		if k.Crv() == jwa.P256 {
+			return jwa.ES256, nil
+		}
+		return "", fmt.Errorf("unsupported curve %s", k.Crv())
+	case jwk.OKPPublicKey:
+		if k.Crv() == jwa.Ed25519 {
+			return jwa.EdDSA, nil
+		}
+		return "", fmt.Errorf("unsupported curve %s", k.Crv())
+	}
+	return "", fmt.Errorf("unsupported key type %s", key.KeyType())
+}
+
+// isPrivateKey は鍵が秘密鍵であるかを判定する
+func isPrivateKey(key jwk.Key) bool {
+	switch key.(type) {
+	case jwk.RSAPrivateKey, jwk.ECDSAPrivateKey, jwk.OKPPrivateKey:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,324 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/testutil/fixture"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+// writeRSAKey はRSA鍵を生成してPEM形式でファイルに書き込む
+// publicOnlyがtrueの場合は公開鍵のみを書き込む
+func writeRSAKey(t *testing.T, path string, publicOnly bool) {
+	t.Helper()
+	writeKey(t, path, jwa.RS256, publicOnly)
+}
+
+// writeKey は署名アルゴリズムに対応する鍵を生成してPEM形式でファイルに書き込む
+func writeKey(t *testing.T, path string, alg jwa.SignatureAlgorithm, publicOnly bool) {
+	t.Helper()
+	var key crypto.Signer
+	var err error
+	switch alg {
+	case jwa.RS256:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case jwa.ES256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case jwa.EdDSA:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		t.Fatalf("unsupported algorithm %s", alg)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	var block *pem.Block
+	if publicOnly {
+		b, err := x509.MarshalPKIXPublicKey(key.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		block = &pem.Block{Type: "PUBLIC KEY", Bytes: b}
+	} else {
+		b, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: b}
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeRSAKey(t, filepath.Join(dir, "2022-06.pem"), true)
+	writeRSAKey(t, filepath.Join(dir, "2022-07.pem"), false)
+	writeRSAKey(t, filepath.Join(dir, "2022-08.pem"), false)
+
+	tests := map[string]struct {
+		signingKID string
+		wantKID    string
+		wantErr    bool
+	}{
+		"latest":    {signingKID: "", wantKID: "2022-08"},
+		"specified": {signingKID: "2022-07", wantKID: "2022-07"},
+		// 公開鍵のみの鍵は署名に使用できない
+		"publicOnly": {signingKID: "2022-06", wantErr: true},
+		"unknown":    {signingKID: "2021-01", wantErr: true},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := LoadKeySet(dir, tt.signingKID)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("want error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if got.Signing.KeyID() != tt.wantKID {
+				t.Errorf("want signing kid %q, but got %q", tt.wantKID, got.Signing.KeyID())
+			}
+			if got.Public.Len() != 3 {
+				t.Errorf("want 3 public keys, but got %d", got.Public.Len())
+			}
+		})
+	}
+}
+
+// TestJWTer_GetToken_rotation 署名鍵のローテーション後も、旧鍵で署名したトークンを検証できることを確認する
+func TestJWTer_GetToken_rotation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeRSAKey(t, filepath.Join(dir, "old.pem"), false)
+	moq := &StoreMock{
+		SaveFunc:        func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error { return nil },
+		SaveSessionFunc: func(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error { return nil },
+		LoadFunc:        func(ctx context.Context, key string) (entity.UserID, error) { return 20, nil },
+	}
+	sut, err := NewJWTer(moq, clock.FixedClocker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := fixture.User(&entity.User{ID: 20})
+
+	// 旧鍵で署名したトークン
+	if sut.Keys, err = LoadKeySet(dir, ""); err != nil {
+		t.Fatal(err)
+	}
+	old, err := sut.GenerateToken(ctx, *u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := jws.Parse(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kid := msg.Signatures()[0].ProtectedHeaders().KeyID(); kid != "old" {
+		t.Errorf("want kid %q, but got %q", "old", kid)
+	}
+
+	// 新鍵を追加してローテーションする
+	writeRSAKey(t, filepath.Join(dir, "new.pem"), false)
+	if sut.Keys, err = LoadKeySet(dir, "new"); err != nil {
+		t.Fatal(err)
+	}
+	current, err := sut.GenerateToken(ctx, *u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, signed := range map[string][]byte{"old": old, "new": current} {
+		if _, err := sut.GetToken(ctx, createRequest(signed)); err != nil {
+			t.Errorf("%s: want no error, but got %v", name, err)
+		}
+	}
+
+	// 旧鍵を削除した後は、旧鍵で署名したトークンを検証できない
+	if err := os.Remove(filepath.Join(dir, "old.pem")); err != nil {
+		t.Fatal(err)
+	}
+	if sut.Keys, err = LoadKeySet(dir, "new"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sut.GetToken(ctx, createRequest(old)); err == nil {
+		t.Error("want error for token signed by removed key, but got nil")
+	}
+}
+
+// newTestOptions はdir配下の鍵とalgの署名アルゴリズムを使用する設定値を生成する
+func newTestOptions(t *testing.T, dir string, alg jwa.SignatureAlgorithm) Options {
+	t.Helper()
+	return Options{
+		KeyDir:          dir,
+		Algorithm:       alg.String(),
+		Issuer:          "https://todo.example.com",
+		Audience:        "todo-api-a",
+		Lifetime:        10 * time.Minute,
+		RefreshTokenTTL: time.Hour,
+	}
+}
+
+// TestJWTer_algorithms 署名アルゴリズムごとにトークンの発行と検証ができることを確認する
+func TestJWTer_algorithms(t *testing.T) {
+	t.Parallel()
+
+	for _, alg := range []jwa.SignatureAlgorithm{jwa.RS256, jwa.ES256, jwa.EdDSA} {
+		alg := alg
+		t.Run(alg.String(), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			dir := t.TempDir()
+			writeKey(t, filepath.Join(dir, "key.pem"), alg, false)
+			var ttl time.Duration
+			moq := &StoreMock{
+				SaveFunc: func(ctx context.Context, key string, userID entity.UserID, d time.Duration) error {
+					ttl = d
+					return nil
+				},
+				SaveSessionFunc: func(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error { return nil },
+				LoadFunc:        func(ctx context.Context, key string) (entity.UserID, error) { return 20, nil },
+			}
+			sut, err := NewJWTerWithOptions(moq, clock.FixedClocker{}, newTestOptions(t, dir, alg))
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+
+			signed, err := sut.GenerateToken(ctx, *fixture.User(&entity.User{ID: 20}))
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			msg, err := jws.Parse(signed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := msg.Signatures()[0].ProtectedHeaders().Algorithm(); got != alg {
+				t.Errorf("want alg %s, but got %s", alg, got)
+			}
+			if ttl != 10*time.Minute {
+				t.Errorf("want ttl %v, but got %v", 10*time.Minute, ttl)
+			}
+
+			got, err := sut.GetToken(ctx, createRequest(signed))
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if got.Issuer() != "https://todo.example.com" {
+				t.Errorf("want issuer %q, but got %q", "https://todo.example.com", got.Issuer())
+			}
+			want := clock.FixedClocker{}.Now().Add(10 * time.Minute)
+			if !got.Expiration().Equal(want) {
+				t.Errorf("want expiration %v, but got %v", want, got.Expiration())
+			}
+		})
+	}
+}
+
+// TestJWTer_GetToken_claims 発行者、または受信者が異なるインスタンスのトークンを拒否することを確認する
+func TestJWTer_GetToken_claims(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeKey(t, filepath.Join(dir, "key.pem"), jwa.ES256, false)
+	tests := map[string]struct {
+		modify  func(opts *Options)
+		wantErr bool
+	}{
+		"sameInstance":  {modify: func(opts *Options) {}},
+		"otherAudience": {modify: func(opts *Options) { opts.Audience = "todo-api-b" }, wantErr: true},
+		"otherIssuer":   {modify: func(opts *Options) { opts.Issuer = "https://evil.example.com" }, wantErr: true},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			moq := &StoreMock{
+				SaveFunc:        func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error { return nil },
+				SaveSessionFunc: func(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error { return nil },
+				LoadFunc:        func(ctx context.Context, key string) (entity.UserID, error) { return 20, nil },
+			}
+			issuer, err := NewJWTerWithOptions(moq, clock.FixedClocker{}, newTestOptions(t, dir, jwa.ES256))
+			if err != nil {
+				t.Fatal(err)
+			}
+			signed, err := issuer.GenerateToken(ctx, *fixture.User(&entity.User{ID: 20}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// 同一の鍵を共有し、発行者または受信者の設定のみが異なるインスタンスで検証する
+			opts := newTestOptions(t, dir, jwa.ES256)
+			tt.modify(&opts)
+			sut, err := NewJWTerWithOptions(moq, clock.FixedClocker{}, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = sut.GetToken(ctx, createRequest(signed))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("want error %v, but got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewJWTerWithOptions_NG(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeKey(t, filepath.Join(dir, "key.pem"), jwa.EdDSA, false)
+	valid := newTestOptions(t, dir, jwa.EdDSA)
+	tests := map[string]func(opts *Options){
+		// 署名用の鍵の種類と署名アルゴリズムが一致しない
+		"algorithmMismatch": func(opts *Options) { opts.Algorithm = jwa.ES256.String() },
+		// 埋め込みの鍵はRSA鍵のみ
+		"embeddedKeyMismatch":   func(opts *Options) { opts.KeyDir = "" },
+		"unsupported":           func(opts *Options) { opts.Algorithm = jwa.HS256.String() },
+		"zeroLifetime":          func(opts *Options) { opts.Lifetime = 0 },
+		"negativeLifetime":      func(opts *Options) { opts.Lifetime = -time.Minute },
+		"zeroRefreshTokenTTL":   func(opts *Options) { opts.RefreshTokenTTL = 0 },
+		"negativeRefreshTTL":    func(opts *Options) { opts.RefreshTokenTTL = -time.Hour },
+		"negativeTouchInterval": func(opts *Options) { opts.SessionTouchInterval = -time.Minute },
+	}
+	if _, err := NewJWTerWithOptions(&StoreMock{}, clock.FixedClocker{}, valid); err != nil {
+		t.Fatalf("want no error for valid options, but got %v", err)
+	}
+	for n, modify := range tests {
+		modify := modify
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			opts := valid
+			modify(&opts)
+			if _, err := NewJWTerWithOptions(&StoreMock{}, clock.FixedClocker{}, opts); err == nil {
+				t.Error("want error, but got nil")
+			}
+		})
+	}
+}
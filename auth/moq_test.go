@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/ac0mz/go_todo_app/entity"
 	"sync"
+	"time"
 )
 
 // Ensure, that StoreMock does implement Store.
@@ -19,12 +20,42 @@ var _ Store = &StoreMock{}
 //
 //		// make and configure a mocked Store
 //		mockedStore := &StoreMock{
+//			DeleteFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the Delete method")
+//			},
+//			DeleteAllFunc: func(ctx context.Context, userID entity.UserID) error {
+//				panic("mock out the DeleteAll method")
+//			},
+//			DeleteSessionFunc: func(ctx context.Context, userID entity.UserID, sid string) error {
+//				panic("mock out the DeleteSession method")
+//			},
+//			IsUserDisabledFunc: func(ctx context.Context, userID entity.UserID) (bool, error) {
+//				panic("mock out the IsUserDisabled method")
+//			},
+//			ListSessionsFunc: func(ctx context.Context, userID entity.UserID) (entity.Sessions, error) {
+//				panic("mock out the ListSessions method")
+//			},
 //			LoadFunc: func(ctx context.Context, key string) (entity.UserID, error) {
 //				panic("mock out the Load method")
 //			},
-//			SaveFunc: func(ctx context.Context, key string, userID entity.UserID) error {
+//			RotateRefreshFamilyFunc: func(ctx context.Context, family string, oldHash string, newHash string, ttl time.Duration) (entity.UserID, error) {
+//				panic("mock out the RotateRefreshFamily method")
+//			},
+//			SaveFunc: func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
 //				panic("mock out the Save method")
 //			},
+//			SaveRefreshFamilyFunc: func(ctx context.Context, family string, userID entity.UserID, hash string, ttl time.Duration) error {
+//				panic("mock out the SaveRefreshFamily method")
+//			},
+//			SaveSessionFunc: func(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error {
+//				panic("mock out the SaveSession method")
+//			},
+//			SetUserDisabledFunc: func(ctx context.Context, userID entity.UserID, disabled bool) error {
+//				panic("mock out the SetUserDisabled method")
+//			},
+//			TouchSessionFunc: func(ctx context.Context, sid string, now time.Time, interval time.Duration) (bool, error) {
+//				panic("mock out the TouchSession method")
+//			},
 //		}
 //
 //		// use mockedStore in code that requires Store
@@ -32,14 +63,81 @@ var _ Store = &StoreMock{}
 //
 //	}
 type StoreMock struct {
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, key string) error
+
+	// DeleteAllFunc mocks the DeleteAll method.
+	DeleteAllFunc func(ctx context.Context, userID entity.UserID) error
+
+	// DeleteSessionFunc mocks the DeleteSession method.
+	DeleteSessionFunc func(ctx context.Context, userID entity.UserID, sid string) error
+
+	// IsUserDisabledFunc mocks the IsUserDisabled method.
+	IsUserDisabledFunc func(ctx context.Context, userID entity.UserID) (bool, error)
+
+	// ListSessionsFunc mocks the ListSessions method.
+	ListSessionsFunc func(ctx context.Context, userID entity.UserID) (entity.Sessions, error)
+
 	// LoadFunc mocks the Load method.
 	LoadFunc func(ctx context.Context, key string) (entity.UserID, error)
 
+	// RotateRefreshFamilyFunc mocks the RotateRefreshFamily method.
+	RotateRefreshFamilyFunc func(ctx context.Context, family string, oldHash string, newHash string, ttl time.Duration) (entity.UserID, error)
+
 	// SaveFunc mocks the Save method.
-	SaveFunc func(ctx context.Context, key string, userID entity.UserID) error
+	SaveFunc func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error
+
+	// SaveRefreshFamilyFunc mocks the SaveRefreshFamily method.
+	SaveRefreshFamilyFunc func(ctx context.Context, family string, userID entity.UserID, hash string, ttl time.Duration) error
+
+	// SaveSessionFunc mocks the SaveSession method.
+	SaveSessionFunc func(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error
+
+	// SetUserDisabledFunc mocks the SetUserDisabled method.
+	SetUserDisabledFunc func(ctx context.Context, userID entity.UserID, disabled bool) error
+
+	// TouchSessionFunc mocks the TouchSession method.
+	TouchSessionFunc func(ctx context.Context, sid string, now time.Time, interval time.Duration) (bool, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// DeleteAll holds details about calls to the DeleteAll method.
+		DeleteAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+		// DeleteSession holds details about calls to the DeleteSession method.
+		DeleteSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Sid is the sid argument value.
+			Sid string
+		}
+		// IsUserDisabled holds details about calls to the IsUserDisabled method.
+		IsUserDisabled []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+		// ListSessions holds details about calls to the ListSessions method.
+		ListSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
 		// Load holds details about calls to the Load method.
 		Load []struct {
 			// Ctx is the ctx argument value.
@@ -47,6 +145,19 @@ type StoreMock struct {
 			// Key is the key argument value.
 			Key string
 		}
+		// RotateRefreshFamily holds details about calls to the RotateRefreshFamily method.
+		RotateRefreshFamily []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Family is the family argument value.
+			Family string
+			// OldHash is the oldHash argument value.
+			OldHash string
+			// NewHash is the newHash argument value.
+			NewHash string
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
 		// Save holds details about calls to the Save method.
 		Save []struct {
 			// Ctx is the ctx argument value.
@@ -55,10 +166,250 @@ type StoreMock struct {
 			Key string
 			// UserID is the userID argument value.
 			UserID entity.UserID
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// SaveRefreshFamily holds details about calls to the SaveRefreshFamily method.
+		SaveRefreshFamily []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Family is the family argument value.
+			Family string
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Hash is the hash argument value.
+			Hash string
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// SaveSession holds details about calls to the SaveSession method.
+		SaveSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// S is the s argument value.
+			S *entity.Session
+			// Jti is the jti argument value.
+			Jti string
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// SetUserDisabled holds details about calls to the SetUserDisabled method.
+		SetUserDisabled []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Disabled is the disabled argument value.
+			Disabled bool
+		}
+		// TouchSession holds details about calls to the TouchSession method.
+		TouchSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Sid is the sid argument value.
+			Sid string
+			// Now is the now argument value.
+			Now time.Time
+			// Interval is the interval argument value.
+			Interval time.Duration
 		}
 	}
-	lockLoad sync.RWMutex
-	lockSave sync.RWMutex
+	lockDelete              sync.RWMutex
+	lockDeleteAll           sync.RWMutex
+	lockDeleteSession       sync.RWMutex
+	lockIsUserDisabled      sync.RWMutex
+	lockListSessions        sync.RWMutex
+	lockLoad                sync.RWMutex
+	lockRotateRefreshFamily sync.RWMutex
+	lockSave                sync.RWMutex
+	lockSaveRefreshFamily   sync.RWMutex
+	lockSaveSession         sync.RWMutex
+	lockSetUserDisabled     sync.RWMutex
+	lockTouchSession        sync.RWMutex
+}
+
+// Delete calls DeleteFunc.
+func (mock *StoreMock) Delete(ctx context.Context, key string) error {
+	if mock.DeleteFunc == nil {
+		panic("StoreMock.DeleteFunc: method is nil but Store.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, key)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedStore.DeleteCalls())
+func (mock *StoreMock) DeleteCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// DeleteAll calls DeleteAllFunc.
+func (mock *StoreMock) DeleteAll(ctx context.Context, userID entity.UserID) error {
+	if mock.DeleteAllFunc == nil {
+		panic("StoreMock.DeleteAllFunc: method is nil but Store.DeleteAll was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteAll.Lock()
+	mock.calls.DeleteAll = append(mock.calls.DeleteAll, callInfo)
+	mock.lockDeleteAll.Unlock()
+	return mock.DeleteAllFunc(ctx, userID)
+}
+
+// DeleteAllCalls gets all the calls that were made to DeleteAll.
+// Check the length with:
+//
+//	len(mockedStore.DeleteAllCalls())
+func (mock *StoreMock) DeleteAllCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}
+	mock.lockDeleteAll.RLock()
+	calls = mock.calls.DeleteAll
+	mock.lockDeleteAll.RUnlock()
+	return calls
+}
+
+// DeleteSession calls DeleteSessionFunc.
+func (mock *StoreMock) DeleteSession(ctx context.Context, userID entity.UserID, sid string) error {
+	if mock.DeleteSessionFunc == nil {
+		panic("StoreMock.DeleteSessionFunc: method is nil but Store.DeleteSession was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+		Sid    string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Sid:    sid,
+	}
+	mock.lockDeleteSession.Lock()
+	mock.calls.DeleteSession = append(mock.calls.DeleteSession, callInfo)
+	mock.lockDeleteSession.Unlock()
+	return mock.DeleteSessionFunc(ctx, userID, sid)
+}
+
+// DeleteSessionCalls gets all the calls that were made to DeleteSession.
+// Check the length with:
+//
+//	len(mockedStore.DeleteSessionCalls())
+func (mock *StoreMock) DeleteSessionCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+	Sid    string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+		Sid    string
+	}
+	mock.lockDeleteSession.RLock()
+	calls = mock.calls.DeleteSession
+	mock.lockDeleteSession.RUnlock()
+	return calls
+}
+
+// IsUserDisabled calls IsUserDisabledFunc.
+func (mock *StoreMock) IsUserDisabled(ctx context.Context, userID entity.UserID) (bool, error) {
+	if mock.IsUserDisabledFunc == nil {
+		panic("StoreMock.IsUserDisabledFunc: method is nil but Store.IsUserDisabled was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockIsUserDisabled.Lock()
+	mock.calls.IsUserDisabled = append(mock.calls.IsUserDisabled, callInfo)
+	mock.lockIsUserDisabled.Unlock()
+	return mock.IsUserDisabledFunc(ctx, userID)
+}
+
+// IsUserDisabledCalls gets all the calls that were made to IsUserDisabled.
+// Check the length with:
+//
+//	len(mockedStore.IsUserDisabledCalls())
+func (mock *StoreMock) IsUserDisabledCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}
+	mock.lockIsUserDisabled.RLock()
+	calls = mock.calls.IsUserDisabled
+	mock.lockIsUserDisabled.RUnlock()
+	return calls
+}
+
+// ListSessions calls ListSessionsFunc.
+func (mock *StoreMock) ListSessions(ctx context.Context, userID entity.UserID) (entity.Sessions, error) {
+	if mock.ListSessionsFunc == nil {
+		panic("StoreMock.ListSessionsFunc: method is nil but Store.ListSessions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListSessions.Lock()
+	mock.calls.ListSessions = append(mock.calls.ListSessions, callInfo)
+	mock.lockListSessions.Unlock()
+	return mock.ListSessionsFunc(ctx, userID)
+}
+
+// ListSessionsCalls gets all the calls that were made to ListSessions.
+// Check the length with:
+//
+//	len(mockedStore.ListSessionsCalls())
+func (mock *StoreMock) ListSessionsCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}
+	mock.lockListSessions.RLock()
+	calls = mock.calls.ListSessions
+	mock.lockListSessions.RUnlock()
+	return calls
 }
 
 // Load calls LoadFunc.
@@ -97,8 +448,56 @@ func (mock *StoreMock) LoadCalls() []struct {
 	return calls
 }
 
+// RotateRefreshFamily calls RotateRefreshFamilyFunc.
+func (mock *StoreMock) RotateRefreshFamily(ctx context.Context, family string, oldHash string, newHash string, ttl time.Duration) (entity.UserID, error) {
+	if mock.RotateRefreshFamilyFunc == nil {
+		panic("StoreMock.RotateRefreshFamilyFunc: method is nil but Store.RotateRefreshFamily was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Family  string
+		OldHash string
+		NewHash string
+		TTL     time.Duration
+	}{
+		Ctx:     ctx,
+		Family:  family,
+		OldHash: oldHash,
+		NewHash: newHash,
+		TTL:     ttl,
+	}
+	mock.lockRotateRefreshFamily.Lock()
+	mock.calls.RotateRefreshFamily = append(mock.calls.RotateRefreshFamily, callInfo)
+	mock.lockRotateRefreshFamily.Unlock()
+	return mock.RotateRefreshFamilyFunc(ctx, family, oldHash, newHash, ttl)
+}
+
+// RotateRefreshFamilyCalls gets all the calls that were made to RotateRefreshFamily.
+// Check the length with:
+//
+//	len(mockedStore.RotateRefreshFamilyCalls())
+func (mock *StoreMock) RotateRefreshFamilyCalls() []struct {
+	Ctx     context.Context
+	Family  string
+	OldHash string
+	NewHash string
+	TTL     time.Duration
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Family  string
+		OldHash string
+		NewHash string
+		TTL     time.Duration
+	}
+	mock.lockRotateRefreshFamily.RLock()
+	calls = mock.calls.RotateRefreshFamily
+	mock.lockRotateRefreshFamily.RUnlock()
+	return calls
+}
+
 // Save calls SaveFunc.
-func (mock *StoreMock) Save(ctx context.Context, key string, userID entity.UserID) error {
+func (mock *StoreMock) Save(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
 	if mock.SaveFunc == nil {
 		panic("StoreMock.SaveFunc: method is nil but Store.Save was just called")
 	}
@@ -106,15 +505,17 @@ func (mock *StoreMock) Save(ctx context.Context, key string, userID entity.UserI
 		Ctx    context.Context
 		Key    string
 		UserID entity.UserID
+		TTL    time.Duration
 	}{
 		Ctx:    ctx,
 		Key:    key,
 		UserID: userID,
+		TTL:    ttl,
 	}
 	mock.lockSave.Lock()
 	mock.calls.Save = append(mock.calls.Save, callInfo)
 	mock.lockSave.Unlock()
-	return mock.SaveFunc(ctx, key, userID)
+	return mock.SaveFunc(ctx, key, userID, ttl)
 }
 
 // SaveCalls gets all the calls that were made to Save.
@@ -125,14 +526,264 @@ func (mock *StoreMock) SaveCalls() []struct {
 	Ctx    context.Context
 	Key    string
 	UserID entity.UserID
+	TTL    time.Duration
 } {
 	var calls []struct {
 		Ctx    context.Context
 		Key    string
 		UserID entity.UserID
+		TTL    time.Duration
 	}
 	mock.lockSave.RLock()
 	calls = mock.calls.Save
 	mock.lockSave.RUnlock()
 	return calls
 }
+
+// SaveRefreshFamily calls SaveRefreshFamilyFunc.
+func (mock *StoreMock) SaveRefreshFamily(ctx context.Context, family string, userID entity.UserID, hash string, ttl time.Duration) error {
+	if mock.SaveRefreshFamilyFunc == nil {
+		panic("StoreMock.SaveRefreshFamilyFunc: method is nil but Store.SaveRefreshFamily was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Family string
+		UserID entity.UserID
+		Hash   string
+		TTL    time.Duration
+	}{
+		Ctx:    ctx,
+		Family: family,
+		UserID: userID,
+		Hash:   hash,
+		TTL:    ttl,
+	}
+	mock.lockSaveRefreshFamily.Lock()
+	mock.calls.SaveRefreshFamily = append(mock.calls.SaveRefreshFamily, callInfo)
+	mock.lockSaveRefreshFamily.Unlock()
+	return mock.SaveRefreshFamilyFunc(ctx, family, userID, hash, ttl)
+}
+
+// SaveRefreshFamilyCalls gets all the calls that were made to SaveRefreshFamily.
+// Check the length with:
+//
+//	len(mockedStore.SaveRefreshFamilyCalls())
+func (mock *StoreMock) SaveRefreshFamilyCalls() []struct {
+	Ctx    context.Context
+	Family string
+	UserID entity.UserID
+	Hash   string
+	TTL    time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Family string
+		UserID entity.UserID
+		Hash   string
+		TTL    time.Duration
+	}
+	mock.lockSaveRefreshFamily.RLock()
+	calls = mock.calls.SaveRefreshFamily
+	mock.lockSaveRefreshFamily.RUnlock()
+	return calls
+}
+
+// SaveSession calls SaveSessionFunc.
+func (mock *StoreMock) SaveSession(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error {
+	if mock.SaveSessionFunc == nil {
+		panic("StoreMock.SaveSessionFunc: method is nil but Store.SaveSession was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		S   *entity.Session
+		Jti string
+		TTL time.Duration
+	}{
+		Ctx: ctx,
+		S:   s,
+		Jti: jti,
+		TTL: ttl,
+	}
+	mock.lockSaveSession.Lock()
+	mock.calls.SaveSession = append(mock.calls.SaveSession, callInfo)
+	mock.lockSaveSession.Unlock()
+	return mock.SaveSessionFunc(ctx, s, jti, ttl)
+}
+
+// SaveSessionCalls gets all the calls that were made to SaveSession.
+// Check the length with:
+//
+//	len(mockedStore.SaveSessionCalls())
+func (mock *StoreMock) SaveSessionCalls() []struct {
+	Ctx context.Context
+	S   *entity.Session
+	Jti string
+	TTL time.Duration
+} {
+	var calls []struct {
+		Ctx context.Context
+		S   *entity.Session
+		Jti string
+		TTL time.Duration
+	}
+	mock.lockSaveSession.RLock()
+	calls = mock.calls.SaveSession
+	mock.lockSaveSession.RUnlock()
+	return calls
+}
+
+// SetUserDisabled calls SetUserDisabledFunc.
+func (mock *StoreMock) SetUserDisabled(ctx context.Context, userID entity.UserID, disabled bool) error {
+	if mock.SetUserDisabledFunc == nil {
+		panic("StoreMock.SetUserDisabledFunc: method is nil but Store.SetUserDisabled was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   entity.UserID
+		Disabled bool
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		Disabled: disabled,
+	}
+	mock.lockSetUserDisabled.Lock()
+	mock.calls.SetUserDisabled = append(mock.calls.SetUserDisabled, callInfo)
+	mock.lockSetUserDisabled.Unlock()
+	return mock.SetUserDisabledFunc(ctx, userID, disabled)
+}
+
+// SetUserDisabledCalls gets all the calls that were made to SetUserDisabled.
+// Check the length with:
+//
+//	len(mockedStore.SetUserDisabledCalls())
+func (mock *StoreMock) SetUserDisabledCalls() []struct {
+	Ctx      context.Context
+	UserID   entity.UserID
+	Disabled bool
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   entity.UserID
+		Disabled bool
+	}
+	mock.lockSetUserDisabled.RLock()
+	calls = mock.calls.SetUserDisabled
+	mock.lockSetUserDisabled.RUnlock()
+	return calls
+}
+
+// TouchSession calls TouchSessionFunc.
+func (mock *StoreMock) TouchSession(ctx context.Context, sid string, now time.Time, interval time.Duration) (bool, error) {
+	if mock.TouchSessionFunc == nil {
+		panic("StoreMock.TouchSessionFunc: method is nil but Store.TouchSession was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Sid      string
+		Now      time.Time
+		Interval time.Duration
+	}{
+		Ctx:      ctx,
+		Sid:      sid,
+		Now:      now,
+		Interval: interval,
+	}
+	mock.lockTouchSession.Lock()
+	mock.calls.TouchSession = append(mock.calls.TouchSession, callInfo)
+	mock.lockTouchSession.Unlock()
+	return mock.TouchSessionFunc(ctx, sid, now, interval)
+}
+
+// TouchSessionCalls gets all the calls that were made to TouchSession.
+// Check the length with:
+//
+//	len(mockedStore.TouchSessionCalls())
+func (mock *StoreMock) TouchSessionCalls() []struct {
+	Ctx      context.Context
+	Sid      string
+	Now      time.Time
+	Interval time.Duration
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Sid      string
+		Now      time.Time
+		Interval time.Duration
+	}
+	mock.lockTouchSession.RLock()
+	calls = mock.calls.TouchSession
+	mock.lockTouchSession.RUnlock()
+	return calls
+}
+
+// Ensure, that PersonalAccessTokenFinderMock does implement PersonalAccessTokenFinder.
+// If this is not the case, regenerate this file with moq.
+var _ PersonalAccessTokenFinder = &PersonalAccessTokenFinderMock{}
+
+// PersonalAccessTokenFinderMock is a mock implementation of PersonalAccessTokenFinder.
+//
+//	func TestSomethingThatUsesPersonalAccessTokenFinder(t *testing.T) {
+//
+//		// make and configure a mocked PersonalAccessTokenFinder
+//		mockedPersonalAccessTokenFinder := &PersonalAccessTokenFinderMock{
+//			FindPersonalAccessTokenFunc: func(ctx context.Context, hash string) (*entity.PersonalAccessToken, error) {
+//				panic("mock out the FindPersonalAccessToken method")
+//			},
+//		}
+//
+//		// use mockedPersonalAccessTokenFinder in code that requires PersonalAccessTokenFinder
+//		// and then make assertions.
+//
+//	}
+type PersonalAccessTokenFinderMock struct {
+	// FindPersonalAccessTokenFunc mocks the FindPersonalAccessToken method.
+	FindPersonalAccessTokenFunc func(ctx context.Context, hash string) (*entity.PersonalAccessToken, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// FindPersonalAccessToken holds details about calls to the FindPersonalAccessToken method.
+		FindPersonalAccessToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+		}
+	}
+	lockFindPersonalAccessToken sync.RWMutex
+}
+
+// FindPersonalAccessToken calls FindPersonalAccessTokenFunc.
+func (mock *PersonalAccessTokenFinderMock) FindPersonalAccessToken(ctx context.Context, hash string) (*entity.PersonalAccessToken, error) {
+	if mock.FindPersonalAccessTokenFunc == nil {
+		panic("PersonalAccessTokenFinderMock.FindPersonalAccessTokenFunc: method is nil but PersonalAccessTokenFinder.FindPersonalAccessToken was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash string
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockFindPersonalAccessToken.Lock()
+	mock.calls.FindPersonalAccessToken = append(mock.calls.FindPersonalAccessToken, callInfo)
+	mock.lockFindPersonalAccessToken.Unlock()
+	return mock.FindPersonalAccessTokenFunc(ctx, hash)
+}
+
+// FindPersonalAccessTokenCalls gets all the calls that were made to FindPersonalAccessToken.
+// Check the length with:
+//
+//	len(mockedPersonalAccessTokenFinder.FindPersonalAccessTokenCalls())
+func (mock *PersonalAccessTokenFinderMock) FindPersonalAccessTokenCalls() []struct {
+	Ctx  context.Context
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash string
+	}
+	mock.lockFindPersonalAccessToken.RLock()
+	calls = mock.calls.FindPersonalAccessToken
+	mock.lockFindPersonalAccessToken.RUnlock()
+	return calls
+}
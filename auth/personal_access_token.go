@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+// PersonalAccessTokenPrefix はパーソナルアクセストークンの接頭辞
+// JWTと区別するほか、シークレットスキャンによる漏洩検知を容易にする
+const PersonalAccessTokenPrefix = "todo_pat_"
+
+var ErrPersonalAccessTokenExpired = errors.New("personal access token expired")
+
+// PersonalAccessTokenFinder はハッシュ値からパーソナルアクセストークンを取得する
+type PersonalAccessTokenFinder interface {
+	FindPersonalAccessToken(ctx context.Context, hash string) (*entity.PersonalAccessToken, error)
+}
+
+// NewPersonalAccessToken はパーソナルアクセストークンと、保存用のハッシュ値を生成する
+func NewPersonalAccessToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate personal access token: %w", err)
+	}
+	token := PersonalAccessTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, HashPersonalAccessToken(token), nil
+}
+
+// HashPersonalAccessToken はパーソナルアクセストークンをSHA-256でハッシュ化する
+// トークンは十分な長さのランダム値を含むため、パスワードと異なりソルトやストレッチングは行わない
+func HashPersonalAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// bearerPersonalAccessToken はAuthorizationヘッダーからパーソナルアクセストークンを取得する
+// Bearerトークンが接頭辞を持たない場合はfalseを返却する
+func bearerPersonalAccessToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
+
+// fillContextWithPAT はパーソナルアクセストークンを検証し、ユーザIDとスコープを設定した*http.Request型の値を返却する
+// パーソナルアクセストークンにはロールを設定せず、管理者権限のAPIは利用できない
+func (j JWTer) fillContextWithPAT(r *http.Request, token string) (*http.Request, error) {
+	if j.PATs == nil {
+		return nil, fmt.Errorf("personal access token is not supported")
+	}
+	t, err := j.PATs.FindPersonalAccessToken(r.Context(), HashPersonalAccessToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("FillContext: %w", err)
+	}
+	if t.IsExpired(j.Clocker.Now()) {
+		return nil, fmt.Errorf("FillContext: token %d: %w", t.ID, ErrPersonalAccessTokenExpired)
+	}
+	if err := j.checkDisabled(r.Context(), t.UserID); err != nil {
+		return nil, err
+	}
+	ctx := SetUserID(r.Context(), t.UserID)
+	ctx = context.WithValue(ctx, roleKey{}, entity.Role(""))
+	// アカウント操作はJWTのみに許可するため、登録済みのスコープに含まれていても付与しない
+	scopes := make(entity.Scopes, 0, len(t.Scopes))
+	for _, s := range t.Scopes {
+		if s != entity.ScopeAccount {
+			scopes = append(scopes, s)
+		}
+	}
+	ctx = SetScopes(ctx, scopes)
+	return r.Clone(ctx), nil
+}
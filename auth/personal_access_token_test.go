@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+func TestNewPersonalAccessToken(t *testing.T) {
+	t.Parallel()
+
+	token, hash, err := NewPersonalAccessToken()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if !strings.HasPrefix(token, PersonalAccessTokenPrefix) {
+		t.Errorf("want prefix %q, but got %q", PersonalAccessTokenPrefix, token)
+	}
+	if hash != HashPersonalAccessToken(token) || strings.Contains(hash, token) {
+		t.Errorf("want hash of token, but got %q", hash)
+	}
+}
+
+func TestJWTer_FillContext_personalAccessToken(t *testing.T) {
+	t.Parallel()
+
+	c := clock.FixedClocker{}
+	token, hash, err := NewPersonalAccessToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	past, future := c.Now().Add(-time.Second), c.Now().Add(time.Hour)
+	tests := map[string]struct {
+		expiresAt *time.Time
+		findErr   error
+		disabled  bool
+		wantErr   bool
+	}{
+		"noExpiry":   {},
+		"notExpired": {expiresAt: &future},
+		"expired":    {expiresAt: &past, wantErr: true},
+		"revoked":    {findErr: store.ErrNotFound, wantErr: true},
+		"disabled":   {disabled: true, wantErr: true},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			// モック設定
+			moq := &PersonalAccessTokenFinderMock{}
+			moq.FindPersonalAccessTokenFunc = func(ctx context.Context, h string) (*entity.PersonalAccessToken, error) {
+				if h != hash {
+					t.Errorf("want hash %q, but got %q", hash, h)
+				}
+				if tt.findErr != nil {
+					return nil, fmt.Errorf("failed to find: %w", tt.findErr)
+				}
+				return &entity.PersonalAccessToken{
+					ID: 1, UserID: 20, Scopes: entity.Scopes{entity.ScopeTasksRead, entity.ScopeAccount}, ExpiresAt: tt.expiresAt,
+				}, nil
+			}
+			kvs := &StoreMock{}
+			kvs.IsUserDisabledFunc = func(ctx context.Context, userID entity.UserID) (bool, error) {
+				return tt.disabled, nil
+			}
+			sut, err := NewJWTer(kvs, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sut.PATs = moq
+			r := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+			r.Header.Set("Authorization", "Bearer "+token)
+
+			// 実行と検証
+			got, err := sut.FillContext(r)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("want error, but got nil")
+				}
+				if tt.expiresAt != nil && !errors.Is(err, ErrPersonalAccessTokenExpired) {
+					t.Errorf("want %v, but got %v", ErrPersonalAccessTokenExpired, err)
+				}
+				if tt.disabled && !errors.Is(err, ErrUserDisabled) {
+					t.Errorf("want %v, but got %v", ErrUserDisabled, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if uid, _ := GetUserID(got.Context()); uid != 20 {
+				t.Errorf("want user 20, but got %d", uid)
+			}
+			if scopes, ok := GetScopes(got.Context()); !ok || !scopes.Contains(entity.ScopeTasksRead) {
+				t.Errorf("want scopes %v, but got %v", entity.Scopes{entity.ScopeTasksRead}, scopes)
+			}
+			// パーソナルアクセストークンではアカウント操作を利用できない
+			if scopes, _ := GetScopes(got.Context()); scopes.Contains(entity.ScopeAccount) {
+				t.Errorf("want no %q scope, but got %v", entity.ScopeAccount, scopes)
+			}
+			// パーソナルアクセストークンでは管理者権限を利用できない
+			if IsAdmin(got.Context()) {
+				t.Error("want not admin")
+			}
+		})
+	}
+}
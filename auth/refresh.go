@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/google/uuid"
+)
+
+// DefaultRefreshTokenTTL はリフレッシュトークンの既定の有効期間
+// ローテーションの都度、ファミリーの有効期限を当該期間だけ延長する
+const DefaultRefreshTokenTTL = 7 * 24 * time.Hour
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+)
+
+// TokenPair はアクセストークンとリフレッシュトークンの組
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// GenerateRefreshToken は新たなトークンファミリーを作成し、最初のリフレッシュトークンを発行する
+// リフレッシュトークンは"<ファミリーID>.<ランダム値>"形式の不透明な文字列とし、Redisにはランダム値のハッシュ値のみを保存する
+func (j JWTer) GenerateRefreshToken(ctx context.Context, u entity.User) (string, error) {
+	family := uuid.New().String()
+	token, hash, err := newRefreshToken(family)
+	if err != nil {
+		return "", err
+	}
+	if err := j.Store.SaveRefreshFamily(ctx, family, u.ID, hash, j.RefreshTokenTTL); err != nil {
+		return "", fmt.Errorf("GenerateRefreshToken: failed to save: %w", err)
+	}
+	return token, nil
+}
+
+// RotateRefreshToken はリフレッシュトークンを消費し、同一ファミリーの新しいリフレッシュトークンとユーザIDを返却する
+// ローテーション済のトークンが提示された場合は漏洩とみなし、ファミリー全体と、
+// ファミリーのセッションで発行したアクセストークンを失効させてErrRefreshTokenReusedを返却する
+func (j JWTer) RotateRefreshToken(ctx context.Context, token string) (string, entity.UserID, error) {
+	family, secret, ok := strings.Cut(token, ".")
+	if !ok || family == "" || secret == "" {
+		return "", 0, ErrInvalidRefreshToken
+	}
+	next, nextHash, err := newRefreshToken(family)
+	if err != nil {
+		return "", 0, err
+	}
+	uid, err := j.Store.RotateRefreshFamily(ctx, family, hashRefreshSecret(secret), nextHash, j.RefreshTokenTTL)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrTokenReused):
+			// 漏洩したトークンで発行済のアクセストークンも利用させないため、ファミリーをIDとするセッションを削除する
+			// ファミリー導入前のトークン等でセッションが存在しない場合は、ファミリーの失効のみとする
+			if err := j.Store.DeleteSession(ctx, uid, family); err != nil && !errors.Is(err, store.ErrNotFound) {
+				return "", 0, fmt.Errorf("RotateRefreshToken: failed to revoke session of family %q: %w", family, err)
+			}
+			return "", 0, fmt.Errorf("RotateRefreshToken: family %q revoked: %w", family, ErrRefreshTokenReused)
+		case errors.Is(err, store.ErrNotFound):
+			// 有効期限切れ、またはログアウトや再使用の検知により失効済の場合
+			return "", 0, fmt.Errorf("RotateRefreshToken: family %q: %w", family, ErrInvalidRefreshToken)
+		}
+		return "", 0, fmt.Errorf("RotateRefreshToken: failed to rotate: %w", err)
+	}
+	return next, uid, nil
+}
+
+// newRefreshToken はファミリーに属するリフレッシュトークンと、保存用のハッシュ値を生成する
+func newRefreshToken(family string) (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(b)
+	return family + "." + secret, hashRefreshSecret(secret), nil
+}
+
+// hashRefreshSecret はリフレッシュトークンのランダム値をSHA-256でハッシュ化する
+// ランダム値は十分な長さを持つため、パスワードと異なりソルトやストレッチングは行わない
+func hashRefreshSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
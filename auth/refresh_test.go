@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil/fixture"
+)
+
+// refreshFamilies はRedisの代わりにリフレッシュトークンのファミリーを管理する
+type refreshFamilies map[string]struct {
+	uid  entity.UserID
+	hash string
+}
+
+func (f refreshFamilies) mock() *StoreMock {
+	return &StoreMock{
+		SaveRefreshFamilyFunc: func(ctx context.Context, family string, userID entity.UserID, hash string, ttl time.Duration) error {
+			f[family] = struct {
+				uid  entity.UserID
+				hash string
+			}{userID, hash}
+			return nil
+		},
+		RotateRefreshFamilyFunc: func(ctx context.Context, family, oldHash, newHash string, ttl time.Duration) (entity.UserID, error) {
+			cur, ok := f[family]
+			if !ok {
+				return 0, store.ErrNotFound
+			}
+			if cur.hash != oldHash {
+				delete(f, family)
+				return cur.uid, fmt.Errorf("family %q: %w", family, store.ErrTokenReused)
+			}
+			cur.hash = newHash
+			f[family] = cur
+			return cur.uid, nil
+		},
+		DeleteSessionFunc: func(ctx context.Context, userID entity.UserID, sid string) error {
+			return store.ErrNotFound
+		},
+	}
+}
+
+func TestJWTer_RotateRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	u := fixture.User(&entity.User{ID: 20})
+	families := refreshFamilies{}
+	sut, err := NewJWTer(families.mock(), clock.FixedClocker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := sut.GenerateRefreshToken(ctx, *u)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	family, _, _ := strings.Cut(first, ".")
+	if len(families) != 1 {
+		t.Fatalf("want 1 family, but got %d", len(families))
+	}
+	if families[family].hash == strings.TrimPrefix(first, family+".") {
+		t.Error("want hashed secret to be stored")
+	}
+
+	// ローテーションにより同一ファミリーの新しいトークンが発行されること
+	second, uid, err := sut.RotateRefreshToken(ctx, first)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if uid != u.ID {
+		t.Errorf("want user %d, but got %d", u.ID, uid)
+	}
+	if second == first || !strings.HasPrefix(second, family+".") {
+		t.Errorf("want rotated token in family %q, but got %q", family, second)
+	}
+
+	// 消費済のトークンを再使用した場合はファミリー全体が失効すること
+	if _, _, err := sut.RotateRefreshToken(ctx, first); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("want %v, but got %v", ErrRefreshTokenReused, err)
+	}
+	if _, _, err := sut.RotateRefreshToken(ctx, second); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("want %v, but got %v", ErrInvalidRefreshToken, err)
+	}
+}
+
+// TestJWTer_RotateRefreshToken_revokesSession 再使用を検知したファミリーのセッションで発行済の
+// アクセストークンが、有効期限内であっても検証に失敗することを確認する
+func TestJWTer_RotateRefreshToken_revokesSession(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	u := fixture.User(&entity.User{ID: 20})
+	families := refreshFamilies{}
+	// アクセストークンのJWT IDと、セッションごとのJWT IDをマップで管理する
+	tokens := map[string]entity.UserID{}
+	sessions := map[string][]string{}
+	moq := families.mock()
+	moq.SaveFunc = func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+		tokens[key] = userID
+		return nil
+	}
+	moq.LoadFunc = func(ctx context.Context, key string) (entity.UserID, error) {
+		uid, ok := tokens[key]
+		if !ok {
+			return 0, store.ErrNotFound
+		}
+		return uid, nil
+	}
+	moq.SaveSessionFunc = func(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error {
+		sessions[s.ID] = append(sessions[s.ID], jti)
+		return nil
+	}
+	moq.DeleteSessionFunc = func(ctx context.Context, userID entity.UserID, sid string) error {
+		jtis, ok := sessions[sid]
+		if !ok || userID != u.ID {
+			return store.ErrNotFound
+		}
+		for _, jti := range jtis {
+			delete(tokens, jti)
+		}
+		delete(sessions, sid)
+		return nil
+	}
+	moq.IsUserDisabledFunc = func(ctx context.Context, userID entity.UserID) (bool, error) {
+		return false, nil
+	}
+	sut, err := NewJWTer(moq, clock.FixedClocker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := sut.GenerateRefreshToken(ctx, *u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _, err := sut.RotateRefreshToken(ctx, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ローテーション後のリフレッシュトークンで発行したアクセストークン
+	access, err := sut.GenerateToken(SetRefreshToken(ctx, second), *u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sut.FillContext(createRequest(access)); err != nil {
+		t.Fatalf("want no error before reuse, but got %v", err)
+	}
+
+	if _, _, err := sut.RotateRefreshToken(ctx, first); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("want %v, but got %v", ErrRefreshTokenReused, err)
+	}
+	if _, err := sut.FillContext(createRequest(access)); err == nil {
+		t.Error("want access token of compromised family rejected, but got nil")
+	}
+}
+
+func TestJWTer_RotateRefreshToken_malformed(t *testing.T) {
+	t.Parallel()
+
+	sut, err := NewJWTer(refreshFamilies{}.mock(), clock.FixedClocker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{"", "no-separator", ".secret", "family."} {
+		if _, _, err := sut.RotateRefreshToken(context.Background(), token); !errors.Is(err, ErrInvalidRefreshToken) {
+			t.Errorf("%q: want %v, but got %v", token, ErrInvalidRefreshToken, err)
+		}
+	}
+}
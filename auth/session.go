@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+// DefaultSessionTouchInterval はセッションの最終アクセス日時を更新する最小の間隔
+// リクエストの都度KVSへ書き込むことを避けるため、間隔内のアクセスは記録しない
+const DefaultSessionTouchInterval = time.Minute
+
+type clientKey struct{}
+type refreshFamilyKey struct{}
+type sessionIDKey struct{}
+
+// client はセッション情報として記録するリクエスト元の情報
+type client struct {
+	userAgent string
+	ip        string
+}
+
+// SetClient はcontext.Contextにキーバリューの形式でリクエスト元のUser-AgentとIPアドレスを設定する
+// GenerateTokenは設定された値をセッション情報として記録する
+func SetClient(ctx context.Context, userAgent, ip string) context.Context {
+	return context.WithValue(ctx, clientKey{}, client{userAgent: userAgent, ip: ip})
+}
+
+// GetClient はcontext.ContextからリクエストのUser-AgentとIPアドレスを取得する
+// 設定されていない場合は空文字を返却する
+func GetClient(ctx context.Context) (string, string) {
+	c, _ := ctx.Value(clientKey{}).(client)
+	return c.userAgent, c.ip
+}
+
+// SetRefreshToken はcontext.Contextにキーバリューの形式でリフレッシュトークンのファミリーを設定する
+// GenerateTokenで発行するアクセストークンのセッションを当該ファミリーに関連付け、セッションの削除時に併せて失効させる
+func SetRefreshToken(ctx context.Context, refreshToken string) context.Context {
+	family, _, _ := strings.Cut(refreshToken, ".")
+	return context.WithValue(ctx, refreshFamilyKey{}, family)
+}
+
+// getRefreshFamily はcontext.Contextからリフレッシュトークンのファミリーを取得する
+func getRefreshFamily(ctx context.Context) string {
+	family, _ := ctx.Value(refreshFamilyKey{}).(string)
+	return family
+}
+
+// SetSessionID はcontext.Contextにキーバリューの形式でセッションIDを設定する
+func SetSessionID(ctx context.Context, sid string) context.Context {
+	return context.WithValue(ctx, sessionIDKey{}, sid)
+}
+
+// GetSessionID はcontext.ContextからセッションIDを取得し、値と取得成否を返却する
+// パーソナルアクセストークンによるリクエストの場合、セッションIDは設定されていない
+func GetSessionID(ctx context.Context) (string, bool) {
+	sid, ok := ctx.Value(sessionIDKey{}).(string)
+	return sid, ok
+}
+
+// saveSession はセッション情報を記録し、アクセストークンのJWT IDをセッションに関連付ける
+// リフレッシュトークンのファミリーに関連するセッションは、ローテーションの都度リフレッシュトークンと同じ有効期間に延長する
+// 関連しないセッションは、アクセストークンと同じ有効期間とする
+func (j JWTer) saveSession(ctx context.Context, sid, jti string, uid entity.UserID, now time.Time) error {
+	ua, ip := GetClient(ctx)
+	family := getRefreshFamily(ctx)
+	ttl := j.Lifetime
+	if family != "" {
+		ttl = j.RefreshTokenTTL
+	}
+	s := &entity.Session{
+		ID:            sid,
+		UserID:        uid,
+		UserAgent:     ua,
+		IP:            ip,
+		Created:       now,
+		LastSeen:      now,
+		RefreshFamily: family,
+	}
+	if err := j.Store.SaveSession(ctx, s, jti, ttl); err != nil {
+		return fmt.Errorf("failed to save session %q: %w", sid, err)
+	}
+	return nil
+}
+
+// TouchSession はセッションの最終アクセス日時を現在時刻に更新する
+// 前回の更新からSessionTouchInterval未満の場合はKVSへ書き込まない
+func (j JWTer) TouchSession(ctx context.Context, sid string) error {
+	if _, err := j.Store.TouchSession(ctx, sid, j.Clocker.Now(), j.SessionTouchInterval); err != nil {
+		return fmt.Errorf("TouchSession: failed to touch %q: %w", sid, err)
+	}
+	return nil
+}
+
+// ListSessions はユーザの有効なセッションの一覧を返却する
+func (j JWTer) ListSessions(ctx context.Context, uid entity.UserID) (entity.Sessions, error) {
+	sessions, err := j.Store.ListSessions(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("ListSessions: failed to list sessions of user %d: %w", uid, err)
+	}
+	return sessions, nil
+}
+
+// RevokeSession はユーザのセッションを削除し、セッションで発行したすべてのアクセストークンと関連するリフレッシュトークンを失効させる
+// ユーザのセッションが存在しない場合はstore.ErrNotFoundをラップしたエラーを返却する
+func (j JWTer) RevokeSession(ctx context.Context, uid entity.UserID, sid string) error {
+	if err := j.Store.DeleteSession(ctx, uid, sid); err != nil {
+		return fmt.Errorf("RevokeSession: failed to delete %q: %w", sid, err)
+	}
+	return nil
+}
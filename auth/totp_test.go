@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret はRFC 6238のテストベクタ(SHA-1)の鍵"12345678901234567890"をBase32で表現した値
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// stepClocker は指定したUNIX時刻を返却するclock.Clockerの実装
+type stepClocker int64
+
+func (c stepClocker) Now() time.Time {
+	return time.Unix(int64(c), 0).UTC()
+}
+
+func TestTOTPCode(t *testing.T) {
+	t.Parallel()
+
+	// RFC 6238 Appendix Bの8桁のコードの下6桁
+	tests := map[stepClocker]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for c, want := range tests {
+		got, err := TOTPCode(rfc6238Secret, TOTPStep(c.Now()))
+		if err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+		if got != want {
+			t.Errorf("at %d: want %q, but got %q", c, want, got)
+		}
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	t.Parallel()
+
+	now := stepClocker(1111111111)
+	code, err := TOTPCode(rfc6238Secret, TOTPStep(now.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		at   stepClocker
+		code string
+		want bool
+	}{
+		"sameStep":     {at: now, code: code, want: true},
+		"previousStep": {at: now + 30, code: code, want: true},
+		"nextStep":     {at: now - 30, code: code, want: true},
+		"expired":      {at: now + 60, code: code},
+		"wrongCode":    {at: now, code: "000000"},
+		"wrongLength":  {at: now, code: code[:5]},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			step, ok := VerifyTOTP(rfc6238Secret, tt.code, tt.at.Now())
+			if ok != tt.want {
+				t.Fatalf("want %v, but got %v", tt.want, ok)
+			}
+			if ok && step != TOTPStep(now.Now()) {
+				t.Errorf("want matched step %d, but got %d", TOTPStep(now.Now()), step)
+			}
+		})
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	t.Parallel()
+
+	u, err := url.Parse(TOTPURI("todo", "john", rfc6238Secret))
+	if err != nil {
+		t.Fatalf("want valid uri, but got %v", err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/todo:john" {
+		t.Errorf("unexpected uri: %s", u)
+	}
+	q := u.Query()
+	if q.Get("secret") != rfc6238Secret || q.Get("issuer") != "todo" || q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Errorf("unexpected query: %v", q)
+	}
+}
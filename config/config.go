@@ -1,6 +1,9 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/caarlos0/env/v6"
 )
 
@@ -15,6 +18,59 @@ type Config struct {
 	DBName     string `env:"TODO_DB_NAME" envDefault:"todo"`
 	RedisHost  string `env:"TODO_REDIS_HOST" envDefault:"127.0.0.1"`
 	RedisPort  int    `env:"TODO_REDIS_PORT" envDefault:"36379"`
+	// JWTの署名鍵と検証鍵を格納するディレクトリ(未指定の場合はバイナリに埋め込んだ鍵を使用)
+	// ファイル名から拡張子を除いた値をkidとし、署名用のkidが未指定の場合はkidの辞書順で最後の秘密鍵を使用する
+	JWTKeyDir       string `env:"TODO_JWT_KEY_DIR"`
+	JWTSigningKeyID string `env:"TODO_JWT_SIGNING_KID"`
+	// JWTの署名アルゴリズム(RS256/ES256/EdDSA)、およびインスタンスごとの発行者、受信者、有効期間
+	JWTAlgorithm string        `env:"TODO_JWT_ALG" envDefault:"RS256"`
+	JWTIssuer    string        `env:"TODO_JWT_ISSUER" envDefault:"github.com/ac0mz/go_todo_app"`
+	JWTAudience  string        `env:"TODO_JWT_AUDIENCE" envDefault:"github.com/ac0mz/go_todo_app"`
+	JWTLifetime  time.Duration `env:"TODO_JWT_LIFETIME" envDefault:"30m"`
+	// リフレッシュトークンの有効期間(ローテーションの都度延長する)
+	RefreshTokenTTL time.Duration `env:"TODO_REFRESH_TOKEN_TTL" envDefault:"168h"`
+	// セッションの最終アクセス日時を更新する最小の間隔(間隔内のアクセスはKVSへ書き込まない)
+	SessionTouchInterval time.Duration `env:"TODO_SESSION_TOUCH_INTERVAL" envDefault:"1m"`
+	// ログイン失敗回数を計上する期間、およびユーザ名ごとの失敗回数に応じたログインの停止条件
+	// BackoffAfter回目以降の失敗ではBackoffBaseから倍増する期間(上限BackoffMax)ログインを停止し、
+	// ユーザ名ごとにUserLockout回、IPアドレスごとにIPLockout回失敗した場合はLockoutDurationの間ログインを停止する
+	LoginFailureWindow   time.Duration `env:"TODO_LOGIN_FAILURE_WINDOW" envDefault:"15m"`
+	LoginBackoffAfter    int64         `env:"TODO_LOGIN_BACKOFF_AFTER" envDefault:"3"`
+	LoginBackoffBase     time.Duration `env:"TODO_LOGIN_BACKOFF_BASE" envDefault:"1s"`
+	LoginBackoffMax      time.Duration `env:"TODO_LOGIN_BACKOFF_MAX" envDefault:"1m"`
+	LoginUserLockout     int64         `env:"TODO_LOGIN_USER_LOCKOUT" envDefault:"10"`
+	LoginIPLockout       int64         `env:"TODO_LOGIN_IP_LOCKOUT" envDefault:"50"`
+	LoginLockoutDuration time.Duration `env:"TODO_LOGIN_LOCKOUT_DURATION" envDefault:"15m"`
+	// 認証アプリに表示する二要素認証の発行者名、およびログイン時に発行するチャレンジの有効期間と許容するコードの誤り回数
+	TOTPIssuer                string        `env:"TODO_TOTP_ISSUER" envDefault:"go_todo_app"`
+	LoginChallengeTTL         time.Duration `env:"TODO_LOGIN_CHALLENGE_TTL" envDefault:"5m"`
+	LoginChallengeMaxFailures int64         `env:"TODO_LOGIN_CHALLENGE_MAX_FAILURES" envDefault:"5"`
+	// パスワードの最小・最大文字数、および英大文字、英小文字、数字、記号のうち含める必要のある文字種の数
+	PasswordMinLength  int `env:"TODO_PASSWORD_MIN_LENGTH" envDefault:"10"`
+	PasswordMaxLength  int `env:"TODO_PASSWORD_MAX_LENGTH" envDefault:"64"`
+	PasswordMinClasses int `env:"TODO_PASSWORD_MIN_CLASSES" envDefault:"3"`
+	// パスワード再設定トークンの有効期間、およびメール本文に記載する再設定画面のURL("{token}"をトークンに置換)
+	PasswordResetTTL time.Duration `env:"TODO_PASSWORD_RESET_TTL" envDefault:"30m"`
+	PasswordResetURL string        `env:"TODO_PASSWORD_RESET_URL" envDefault:"http://localhost/password/reset?token={token}"`
+	// OpenID Connectによるログインに使用するIdPの発行者(未指定の場合はOpenID Connectによるログインを無効とする)
+	// 発行者のURLからメタデータを取得し、IdPに登録したクライアントIDとシークレット、コールバックのURLで認可を要求する
+	OIDCIssuer       string        `env:"TODO_OIDC_ISSUER"`
+	OIDCClientID     string        `env:"TODO_OIDC_CLIENT_ID"`
+	OIDCClientSecret string        `env:"TODO_OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL  string        `env:"TODO_OIDC_REDIRECT_URL" envDefault:"http://localhost/auth/oidc/callback"`
+	OIDCScopes       []string      `env:"TODO_OIDC_SCOPES" envDefault:"openid,email,profile"`
+	OIDCStateTTL     time.Duration `env:"TODO_OIDC_STATE_TTL" envDefault:"10m"`
+	// パスワードを持たないユーザがアカウント削除等を行う際に要求する、IdPでの認証からの経過期間の上限
+	ReauthMaxAge time.Duration `env:"TODO_REAUTH_MAX_AGE" envDefault:"5m"`
+	// メール送信に使用するSMTPサーバ(未指定の場合はメールを送信しない)
+	SMTPHost     string `env:"TODO_SMTP_HOST"`
+	SMTPPort     int    `env:"TODO_SMTP_PORT" envDefault:"587"`
+	SMTPUser     string `env:"TODO_SMTP_USER"`
+	SMTPPassword string `env:"TODO_SMTP_PASS"`
+	MailFrom     string `env:"TODO_MAIL_FROM" envDefault:"no-reply@localhost"`
+	// ゴミ箱のタスクの保持期間と、保持期間を過ぎたタスクの削除処理の実行間隔
+	TrashRetention     time.Duration `env:"TODO_TRASH_RETENTION" envDefault:"720h"`
+	TrashPurgeInterval time.Duration `env:"TODO_TRASH_PURGE_INTERVAL" envDefault:"1h"`
 }
 
 func New() (*Config, error) {
@@ -22,5 +78,24 @@ func New() (*Config, error) {
 	if err := env.Parse(cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
+
+// validate は環境変数の値の組み合わせや範囲を検証する
+func (cfg *Config) validate() error {
+	// 実行間隔が0以下の場合はtime.NewTickerがpanicし、保持期間が0以下の場合は移動直後のタスクを削除してしまう
+	for name, d := range map[string]time.Duration{
+		"TODO_TRASH_RETENTION":      cfg.TrashRetention,
+		"TODO_TRASH_PURGE_INTERVAL": cfg.TrashPurgeInterval,
+		// 0以下の場合はパスワードを持たないユーザが再認証できない
+		"TODO_REAUTH_MAX_AGE": cfg.ReauthMaxAge,
+	} {
+		if d <= 0 {
+			return fmt.Errorf("%s must be positive, but got %s", name, d)
+		}
+	}
+	return nil
+}
@@ -23,3 +23,19 @@ func Test_New_normal(t *testing.T) {
 		t.Errorf("want %s, but %s", wantEnv, got.Env)
 	}
 }
+
+func Test_New_invalidTrashDuration(t *testing.T) {
+	tests := map[string]string{
+		"TODO_TRASH_RETENTION":      "0s",
+		"TODO_TRASH_PURGE_INTERVAL": "-1h",
+	}
+	for key, value := range tests {
+		key, value := key, value
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, value)
+			if _, err := New(); err == nil {
+				t.Errorf("want error for %s=%s, but got nil", key, value)
+			}
+		})
+	}
+}
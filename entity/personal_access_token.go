@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+type PersonalAccessTokenID int64
+
+// PersonalAccessToken はスクリプトやCIから利用する、ユーザが発行した長期間有効なトークンを表す
+// トークン文字列は発行時のみ返却し、DBにはハッシュ値のみを格納する
+type PersonalAccessToken struct {
+	ID        PersonalAccessTokenID `json:"id" db:"id"`
+	UserID    UserID                `json:"user_id" db:"user_id"`
+	Name      string                `json:"name" db:"name"`
+	TokenHash string                `json:"-" db:"token_hash"`
+	Scopes    Scopes                `json:"scopes" db:"scopes"`
+	ExpiresAt *time.Time            `json:"expires_at,omitempty" db:"expires_at"` // 無期限の場合はnil
+	Created   time.Time             `json:"created" db:"created"`
+	Modified  time.Time             `json:"modified" db:"modified"`
+}
+
+// IsExpired はnow時点で有効期限を過ぎているかを判定する
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+type PersonalAccessTokens []*PersonalAccessToken
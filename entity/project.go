@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type ProjectID int64
+
+// Project はタスクをまとめるユーザごとのグループを表す
+type Project struct {
+	ID         ProjectID  `json:"id" db:"id"`
+	UserID     UserID     `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty" db:"archived_at"` // アーカイブされていない場合はnil
+	Created    time.Time  `json:"created" db:"created"`
+	Modified   time.Time  `json:"modified" db:"modified"`
+}
+
+// IsArchived はプロジェクトがアーカイブ済であるかを判定する
+func (p *Project) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+type Projects []*Project
@@ -0,0 +1,275 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceFrequency は繰り返しの単位を表す
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// maxRecurrenceSteps は次回日時の探索で試行する候補日の上限
+// 月末日指定で該当日が存在しない月を読み飛ばす場合も十分に収まる値とする
+const maxRecurrenceSteps = 1000
+
+const (
+	untilLayout     = "20060102T150405Z"
+	untilDateLayout = "20060102"
+)
+
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Recurrence はiCalendar(RFC 5545)のRRULEのサブセットで表現したタスクの繰り返し規則を表す
+// FREQ(DAILY/WEEKLY/MONTHLY)、INTERVAL、BYDAY(WEEKLYのみ)、BYMONTHDAY(MONTHLYのみ)、UNTILに対応する
+type Recurrence struct {
+	Freq       RecurrenceFrequency
+	Interval   int            // 1以上の繰り返し間隔
+	ByDay      []time.Weekday // 毎週の繰り返し曜日、未指定の場合は起点と同じ曜日
+	ByMonthDay int            // 毎月の繰り返し日、未指定(0)の場合は起点と同じ日
+	Until      *time.Time     // 繰り返しの終了日時、nilの場合は無期限
+}
+
+// ParseRecurrence はRRULE形式の文字列を解析する
+// 先頭の"RRULE:"は省略可能とする
+func ParseRecurrence(s string) (*Recurrence, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, errors.New("empty recurrence rule")
+	}
+	r := &Recurrence{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+		switch key {
+		case "FREQ":
+			r.Freq = RecurrenceFrequency(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", val)
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				wd, ok := parseWeekday(code)
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY %q", code)
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 || n > 31 {
+				return nil, fmt.Errorf("invalid BYMONTHDAY %q", val)
+			}
+			r.ByMonthDay = n
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			r.Until = &until
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %q", key)
+		}
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Recurrence) validate() error {
+	switch r.Freq {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+	case "":
+		return errors.New("FREQ is required")
+	default:
+		return fmt.Errorf("unsupported FREQ %q", r.Freq)
+	}
+	if len(r.ByDay) > 0 && r.Freq != RecurrenceWeekly {
+		return errors.New("BYDAY is only supported with FREQ=WEEKLY")
+	}
+	if r.ByMonthDay != 0 && r.Freq != RecurrenceMonthly {
+		return errors.New("BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+	return nil
+}
+
+func parseWeekday(code string) (time.Weekday, bool) {
+	for i, c := range weekdayCodes {
+		if c == code {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+// parseUntil はUTCの日時形式、または日付形式のUNTILを解析する
+// 日付形式の場合は当日中を繰り返しの対象とする
+func parseUntil(s string) (time.Time, error) {
+	if t, err := time.Parse(untilLayout, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(untilDateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid UNTIL %q", s)
+	}
+	return t.Add(24*time.Hour - time.Second), nil
+}
+
+// String はRRULE形式の文字列に変換する
+// 各項目はFREQ、INTERVAL、BYDAY、BYMONTHDAY、UNTILの順に出力し、既定値の項目は省略する
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, wd := range r.ByDay {
+			codes = append(codes, weekdayCodes[wd])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.ByMonthDay != 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.ByMonthDay))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next はfrom以降で直近の繰り返し日時を返却する
+// 時刻はfromの時刻を引き継ぎ、UNTILを過ぎる場合はfalseを返却する
+func (r Recurrence) Next(from time.Time) (time.Time, bool) {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	var next time.Time
+	switch r.Freq {
+	case RecurrenceDaily:
+		next = from.AddDate(0, 0, interval)
+	case RecurrenceWeekly:
+		next = r.nextWeekly(from, interval)
+	case RecurrenceMonthly:
+		next = r.nextMonthly(from, interval)
+	default:
+		return time.Time{}, false
+	}
+	if next.IsZero() || (r.Until != nil && next.After(*r.Until)) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// NextAfter はprevを起点に繰り返し日時を辿り、nowより後の直近の繰り返し日時を返却する
+// 期限を過ぎてから完了した場合も、過去の日時を次回の期限としないために使用する
+func (r Recurrence) NextAfter(prev, now time.Time) (time.Time, bool) {
+	next := prev
+	for i := 0; i < maxRecurrenceSteps; i++ {
+		var ok bool
+		if next, ok = r.Next(next); !ok {
+			return time.Time{}, false
+		}
+		if next.After(now) {
+			return next, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// nextWeekly は繰り返し対象の週に含まれる、fromより後の直近の対象曜日を返却する
+// 週の始まりは月曜日とし、fromを含む週からinterval週ごとの週を繰り返し対象とする
+func (r Recurrence) nextWeekly(from time.Time, interval int) time.Time {
+	days := r.ByDay
+	if len(days) == 0 {
+		days = []time.Weekday{from.Weekday()}
+	}
+	start := weekStart(from)
+	for d := from.AddDate(0, 0, 1); d.Before(from.AddDate(0, 0, 7*(interval+1))); d = d.AddDate(0, 0, 1) {
+		weeks := int(weekStart(d).Sub(start).Hours()/24+0.5) / 7
+		if weeks%interval != 0 {
+			continue
+		}
+		for _, wd := range days {
+			if d.Weekday() == wd {
+				return d
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// weekStart はtを含む週の月曜日の同時刻を返却する
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return t.AddDate(0, 0, -offset)
+}
+
+// nextMonthly はfromより後の直近の繰り返し日を返却する
+// 繰り返し日が存在しない月(31日指定に対する30日までの月など)は読み飛ばす
+func (r Recurrence) nextMonthly(from time.Time, interval int) time.Time {
+	day := r.ByMonthDay
+	if day == 0 {
+		day = from.Day()
+	}
+	y, m, _ := from.Date()
+	for i := 0; i < maxRecurrenceSteps; i++ {
+		month := m + time.Month(i*interval)
+		next := time.Date(y, month, day, from.Hour(), from.Minute(), from.Second(), from.Nanosecond(), from.Location())
+		// time.Dateは存在しない日付を翌月に繰り越すため、日が一致しない場合は該当日なしとする
+		if next.Day() != day {
+			continue
+		}
+		if next.After(from) {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// MarshalText はRRULE形式の文字列に変換する
+func (r Recurrence) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText はRRULE形式の文字列を解析する
+func (r *Recurrence) UnmarshalText(b []byte) error {
+	parsed, err := ParseRecurrence(string(b))
+	if err != nil {
+		return err
+	}
+	*r = *parsed
+	return nil
+}
+
+// Value はRRULE形式の文字列としてDBに格納する
+func (r Recurrence) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan はDBに格納されたRRULE形式の文字列を解析する
+func (r *Recurrence) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return r.UnmarshalText([]byte(v))
+	case []byte:
+		return r.UnmarshalText(v)
+	}
+	return fmt.Errorf("cannot scan %T into Recurrence", src)
+}
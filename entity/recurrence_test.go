@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/clock"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		rule    string
+		want    string // 再度文字列に変換した結果
+		wantErr bool
+	}{
+		"daily":           {rule: "FREQ=DAILY", want: "FREQ=DAILY"},
+		"defaultInterval": {rule: "FREQ=DAILY;INTERVAL=1", want: "FREQ=DAILY"},
+		"prefix":          {rule: "RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR", want: "FREQ=WEEKLY;BYDAY=MO,WE,FR"},
+		"interval":        {rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU"},
+		"untilDate": {
+			rule: "FREQ=MONTHLY;BYMONTHDAY=31;UNTIL=20221231",
+			want: "FREQ=MONTHLY;BYMONTHDAY=31;UNTIL=20221231T235959Z",
+		},
+		"untilDateTime": {
+			rule: "FREQ=MONTHLY;UNTIL=20230101T090000Z",
+			want: "FREQ=MONTHLY;UNTIL=20230101T090000Z",
+		},
+		"empty":            {rule: "", wantErr: true},
+		"noFreq":           {rule: "INTERVAL=2", wantErr: true},
+		"unsupportedFreq":  {rule: "FREQ=YEARLY", wantErr: true},
+		"unsupportedPart":  {rule: "FREQ=DAILY;COUNT=3", wantErr: true},
+		"byDayNotWeekly":   {rule: "FREQ=DAILY;BYDAY=MO", wantErr: true},
+		"invalidByDay":     {rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		"invalidMonthDay":  {rule: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		"invalidInterval":  {rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		"invalidUntil":     {rule: "FREQ=DAILY;UNTIL=tomorrow", wantErr: true},
+		"missingSeparator": {rule: "FREQ", wantErr: true},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseRecurrence(tt.rule)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("want error, but got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("want %q, but got %q", tt.want, got.String())
+			}
+		})
+	}
+}
+
+func TestRecurrence_Next(t *testing.T) {
+	t.Parallel()
+
+	// 2022-08-23(火) 23:59:59 UTC
+	now := clock.FixedClocker{}.Now()
+	at := func(month time.Month, day int) time.Time {
+		return time.Date(2022, month, day, 23, 59, 59, 0, time.UTC)
+	}
+	tests := map[string]struct {
+		rule   string
+		from   time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		"daily":            {rule: "FREQ=DAILY", from: now, want: at(8, 24), wantOK: true},
+		"dailyInterval":    {rule: "FREQ=DAILY;INTERVAL=3", from: now, want: at(8, 26), wantOK: true},
+		"weeklySameDay":    {rule: "FREQ=WEEKLY", from: now, want: at(8, 30), wantOK: true},
+		"weekdays":         {rule: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", from: now, want: at(8, 24), wantOK: true},
+		"weekdaysWeekend":  {rule: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", from: at(8, 26), want: at(8, 29), wantOK: true},
+		"biweekly":         {rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TU", from: now, want: at(9, 5), wantOK: true},
+		"biweeklySameWeek": {rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,FR", from: now, want: at(8, 26), wantOK: true},
+		"monthDay":         {rule: "FREQ=MONTHLY;BYMONTHDAY=15", from: now, want: at(9, 15), wantOK: true},
+		"monthDayThisMon":  {rule: "FREQ=MONTHLY;BYMONTHDAY=31", from: now, want: at(8, 31), wantOK: true},
+		// 9月は31日が存在しないため読み飛ばす
+		"monthEnd":      {rule: "FREQ=MONTHLY;BYMONTHDAY=31", from: at(8, 31), want: at(10, 31), wantOK: true},
+		"monthSameDay":  {rule: "FREQ=MONTHLY;INTERVAL=3", from: now, want: at(11, 23), wantOK: true},
+		"untilInclude":  {rule: "FREQ=DAILY;UNTIL=20220824", from: now, want: at(8, 24), wantOK: true},
+		"untilExceeded": {rule: "FREQ=DAILY;UNTIL=20220823", from: now, wantOK: false},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			got, ok := r.Next(tt.from)
+			if ok != tt.wantOK {
+				t.Fatalf("want ok %v, but got %v", tt.wantOK, ok)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("want %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRecurrence_NextAfter(t *testing.T) {
+	t.Parallel()
+
+	now := clock.FixedClocker{}.Now()
+	tests := map[string]struct {
+		rule   string
+		prev   time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		"onTime": {
+			rule: "FREQ=WEEKLY;BYDAY=MO", prev: now.AddDate(0, 0, 6),
+			want: now.AddDate(0, 0, 13), wantOK: true,
+		},
+		// 期限を過ぎて完了した場合は、現在日時より後の繰り返し日時まで進める
+		"overdue": {
+			rule: "FREQ=WEEKLY;BYDAY=MO", prev: now.AddDate(0, 0, -15),
+			want: now.AddDate(0, 0, 6), wantOK: true,
+		},
+		"ended": {
+			rule: "FREQ=DAILY;UNTIL=20220820", prev: now.AddDate(0, 0, -5),
+			wantOK: false,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			got, ok := r.NextAfter(tt.prev, now)
+			if ok != tt.wantOK {
+				t.Fatalf("want ok %v, but got %v", tt.wantOK, ok)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("want %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
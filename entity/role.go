@@ -0,0 +1,64 @@
+package entity
+
+import "fmt"
+
+// Role はユーザのロールを表す
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+// DefaultRole はユーザ登録時に付与するロール
+const DefaultRole = RoleUser
+
+// Permission はロールに許可する操作を表す
+type Permission string
+
+const (
+	// PermissionManageOwnResources は自身のタスク、タグ、プロジェクト等の操作を許可する
+	PermissionManageOwnResources Permission = "resources:own"
+	// PermissionManageUsers は他ユーザの参照および管理を許可する
+	PermissionManageUsers Permission = "users:manage"
+	// PermissionAssignRoles はユーザへのロールの付与を許可する
+	PermissionAssignRoles Permission = "roles:assign"
+)
+
+// rolePermissions はロールごとに許可する操作の対応表
+// 当該対応表に定義されていないロールには、いずれの操作も許可しない
+var rolePermissions = map[Role][]Permission{
+	RoleUser: {
+		PermissionManageOwnResources,
+	},
+	RoleAdmin: {
+		PermissionManageOwnResources,
+		PermissionManageUsers,
+		PermissionAssignRoles,
+	},
+}
+
+// ParseRole はロール文字列を解析する
+func ParseRole(s string) (Role, error) {
+	r := Role(s)
+	if !r.Valid() {
+		return "", fmt.Errorf("unknown role %q", s)
+	}
+	return r, nil
+}
+
+// Valid は定義済のロールであるかを判定する
+func (r Role) Valid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can はロールに指定した操作が許可されているかを判定する
+func (r Role) Can(p Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "testing"
+
+func TestRole_Can(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		role Role
+		p    Permission
+		want bool
+	}{
+		"userOwnResources": {role: RoleUser, p: PermissionManageOwnResources, want: true},
+		"userManageUsers":  {role: RoleUser, p: PermissionManageUsers, want: false},
+		"userAssignRoles":  {role: RoleUser, p: PermissionAssignRoles, want: false},
+		"adminManageUsers": {role: RoleAdmin, p: PermissionManageUsers, want: true},
+		"adminAssignRoles": {role: RoleAdmin, p: PermissionAssignRoles, want: true},
+		"unknownRole":      {role: Role("root"), p: PermissionManageOwnResources, want: false},
+		"emptyRole":        {role: Role(""), p: PermissionManageOwnResources, want: false},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.role.Can(tt.p); got != tt.want {
+				t.Errorf("want %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	t.Parallel()
+
+	if got, err := ParseRole("admin"); err != nil || got != RoleAdmin {
+		t.Errorf("want %q, but got %q, %v", RoleAdmin, got, err)
+	}
+	if _, err := ParseRole("root"); err == nil {
+		t.Error("want error for unknown role, but got nil")
+	}
+}
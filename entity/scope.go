@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Scope はトークンに許可する操作の範囲を表す
+// "<リソース>:<操作>"の形式とし、writeはreadを包含しない
+type Scope string
+
+const (
+	ScopeTasksRead     Scope = "tasks:read"
+	ScopeTasksWrite    Scope = "tasks:write"
+	ScopeTagsRead      Scope = "tags:read"
+	ScopeTagsWrite     Scope = "tags:write"
+	ScopeProjectsRead  Scope = "projects:read"
+	ScopeProjectsWrite Scope = "projects:write"
+	// ScopeAccount はトークンの発行やログアウト等のアカウント操作を許可する
+	// パスワードによるログインで発行したトークンのみに付与し、パーソナルアクセストークンには付与できない
+	ScopeAccount Scope = "account"
+)
+
+// AllScopes は定義済のすべてのスコープ
+// パスワードによるログインで発行したアクセストークンに付与する
+var AllScopes = Scopes{
+	ScopeTasksRead, ScopeTasksWrite, ScopeTagsRead, ScopeTagsWrite, ScopeProjectsRead, ScopeProjectsWrite, ScopeAccount,
+}
+
+// Valid は定義済のスコープであるかを判定する
+func (s Scope) Valid() bool {
+	for _, d := range AllScopes {
+		if s == d {
+			return true
+		}
+	}
+	return false
+}
+
+// Scopes はスコープの集合を表す
+// DBにはOAuth 2.0のscopeパラメータと同様に空白区切りの文字列として格納する
+type Scopes []Scope
+
+// ParseScopes は空白区切りのスコープ文字列を解析する
+func ParseScopes(s string) (Scopes, error) {
+	fields := strings.Fields(s)
+	scopes := make(Scopes, 0, len(fields))
+	for _, f := range fields {
+		sc := Scope(f)
+		if !sc.Valid() {
+			return nil, fmt.Errorf("unknown scope %q", f)
+		}
+		scopes = append(scopes, sc)
+	}
+	return scopes, nil
+}
+
+// Contains は指定したスコープをすべて含むかを判定する
+func (ss Scopes) Contains(required ...Scope) bool {
+	for _, r := range required {
+		found := false
+		for _, s := range ss {
+			if s == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// String は空白区切りのスコープ文字列に変換する
+func (ss Scopes) String() string {
+	strs := make([]string, 0, len(ss))
+	for _, s := range ss {
+		strs = append(strs, string(s))
+	}
+	return strings.Join(strs, " ")
+}
+
+// Value は空白区切りの文字列としてDBに格納する
+func (ss Scopes) Value() (driver.Value, error) {
+	return ss.String(), nil
+}
+
+// Scan はDBに格納された空白区切りの文字列を解析する
+func (ss *Scopes) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Scopes", src)
+	}
+	parsed, err := ParseScopes(s)
+	if err != nil {
+		return err
+	}
+	*ss = parsed
+	return nil
+}
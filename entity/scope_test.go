@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseScopes(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		in      string
+		want    Scopes
+		wantErr bool
+	}{
+		"single":   {in: "tasks:read", want: Scopes{ScopeTasksRead}},
+		"multiple": {in: " tasks:read  tags:write ", want: Scopes{ScopeTasksRead, ScopeTagsWrite}},
+		"empty":    {in: "", want: Scopes{}},
+		"unknown":  {in: "tasks:read admin", wantErr: true},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseScopes(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, but got %v", tt.wantErr, err)
+			}
+			if d := cmp.Diff(tt.want, got); !tt.wantErr && d != "" {
+				t.Errorf("differs: (-want +got)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestScopes_Contains(t *testing.T) {
+	t.Parallel()
+
+	ss := Scopes{ScopeTasksRead, ScopeTagsRead}
+	if !ss.Contains(ScopeTasksRead, ScopeTagsRead) {
+		t.Error("want to contain all granted scopes")
+	}
+	if !ss.Contains() {
+		t.Error("want to contain empty requirement")
+	}
+	// writeはreadと別のスコープとして扱う
+	if ss.Contains(ScopeTasksWrite) {
+		t.Error("want not to contain tasks:write")
+	}
+}
+
+func TestScopes_Scan(t *testing.T) {
+	t.Parallel()
+
+	var got Scopes
+	if err := got.Scan([]byte("tasks:read tasks:write")); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if v, _ := got.Value(); v != "tasks:read tasks:write" {
+		t.Errorf("want round trip, but got %v", v)
+	}
+}
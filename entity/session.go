@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// Session はログインごとのセッションの情報を表す
+// IDはリフレッシュトークンのファミリーとし、リフレッシュトークンのローテーションをまたいで同一のセッションとして扱う
+// リフレッシュトークンを発行していない場合は、アクセストークンのJWT IDとする
+type Session struct {
+	ID        string    `json:"id"`
+	UserID    UserID    `json:"user_id"`
+	UserAgent string    `json:"user_agent"`
+	IP        string    `json:"ip"`
+	Created   time.Time `json:"created"`
+	LastSeen  time.Time `json:"last_seen"`
+	// RefreshFamily はトークンと同時に発行したリフレッシュトークンのファミリー(発行していない場合は空文字)
+	// セッションの削除時に併せて失効させ、端末がトークンを再発行できないようにする
+	RefreshFamily string `json:"-"`
+}
+
+type Sessions []*Session
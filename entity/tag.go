@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type TagID int64
+
+// Tag はユーザごとに定義するタスクのラベルを表す
+type Tag struct {
+	ID       TagID     `json:"id" db:"id"`
+	UserID   UserID    `json:"user_id" db:"user_id"`
+	Name     string    `json:"name" db:"name"`
+	Created  time.Time `json:"created" db:"created"`
+	Modified time.Time `json:"modified" db:"modified"`
+}
+
+type Tags []*Tag
@@ -11,13 +11,42 @@ const (
 	TaskStatusDone  TaskStatus = "done"
 )
 
+type TaskPriority string
+
+const (
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityNormal TaskPriority = "normal"
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityUrgent TaskPriority = "urgent"
+)
+
+// Valid は定義済の優先度であるかを判定する
+func (p TaskPriority) Valid() bool {
+	switch p {
+	case TaskPriorityLow, TaskPriorityNormal, TaskPriorityHigh, TaskPriorityUrgent:
+		return true
+	}
+	return false
+}
+
 type Task struct {
-	ID       TaskID     `json:"id" db:"id"`
-	UserID   UserID     `json:"user_id" db:"user_id"`
-	Title    string     `json:"title" db:"title"`
-	Status   TaskStatus `json:"status" db:"status"`
-	Created  time.Time  `json:"created" db:"created"`
-	Modified time.Time  `json:"modified" db:"modified"`
+	ID          TaskID        `json:"id" db:"id"`
+	UserID      UserID        `json:"user_id" db:"user_id"`
+	ProjectID   *ProjectID    `json:"project_id,omitempty" db:"project_id"` // プロジェクトに属さない場合はnil
+	ParentID    *TaskID       `json:"parent_id,omitempty" db:"parent_id"`   // 親タスクを持たない場合はnil
+	Title       string        `json:"title" db:"title"`
+	Description string        `json:"description" db:"description"` // Markdown形式の詳細説明
+	Status      TaskStatus    `json:"status" db:"status"`
+	Priority    TaskPriority  `json:"priority" db:"priority"`
+	DueAt       *time.Time    `json:"due_at,omitempty" db:"due_at"`         // 期限が未設定の場合はnil
+	Recurrence  *Recurrence   `json:"recurrence,omitempty" db:"recurrence"` // 繰り返さない場合はnil
+	Created     time.Time     `json:"created" db:"created"`
+	Modified    time.Time     `json:"modified" db:"modified"`
+	DeletedAt   *time.Time    `json:"deleted_at,omitempty" db:"deleted_at"` // ゴミ箱にない場合はnil
+	Tags        Tags          `json:"tags" db:"-"`                          // task_tagsテーブルから別途取得する
+	Progress    *TaskProgress `json:"progress,omitempty" db:"-"`            // 子タスクを持たない場合はnil
+	Blocked     bool          `json:"blocked" db:"-"`                       // 未完了のタスクに依存している場合はtrue
+	Children    Tasks         `json:"children,omitempty" db:"-"`            // 階層構造で取得した場合のみ格納する
 }
 
 type Tasks []*Task
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TaskDependency はBlockerIDのタスクが完了するまでBlockedIDのタスクに着手できないことを表す
+type TaskDependency struct {
+	BlockerID TaskID    `json:"blocker_id" db:"blocker_id"`
+	BlockedID TaskID    `json:"blocked_id" db:"blocked_id"`
+	Created   time.Time `json:"created" db:"created"`
+}
+
+type TaskDependencies []*TaskDependency
+
+// PathFrom は依存関係を辿ってfromからtoへ到達する経路を返却する
+// 到達できない場合はnilを返却する
+func (ds TaskDependencies) PathFrom(from, to TaskID) []TaskID {
+	next := make(map[TaskID][]TaskID, len(ds))
+	for _, d := range ds {
+		next[d.BlockerID] = append(next[d.BlockerID], d.BlockedID)
+	}
+	// 幅優先探索で最短の経路を求める
+	prev := map[TaskID]TaskID{from: from}
+	queue := []TaskID{from}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == to {
+			path := []TaskID{to}
+			for cur != from {
+				cur = prev[cur]
+				path = append([]TaskID{cur}, path...)
+			}
+			return path
+		}
+		for _, n := range next[cur] {
+			if _, ok := prev[n]; !ok {
+				prev[n] = cur
+				queue = append(queue, n)
+			}
+		}
+	}
+	return nil
+}
+
+// DependencyCycleError は依存関係の追加により循環が発生することを示す
+// Pathは循環する経路を表し、先頭と末尾は同じタスクとなる
+type DependencyCycleError struct {
+	Path []TaskID
+}
+
+func (e *DependencyCycleError) Error() string {
+	ids := make([]string, 0, len(e.Path))
+	for _, id := range e.Path {
+		ids = append(ids, fmt.Sprint(id))
+	}
+	return fmt.Sprintf("task dependency cycle: %s", strings.Join(ids, " -> "))
+}
+
+// BlockedError は未完了のタスクに依存しているタスクに着手しようとしたことを示す
+type BlockedError struct {
+	TaskID   TaskID
+	Blockers []TaskID
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("task %d is blocked by %v", e.TaskID, e.Blockers)
+}
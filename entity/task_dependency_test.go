@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTaskDependencies_PathFrom(t *testing.T) {
+	t.Parallel()
+
+	// 1 -> 2 -> 3 -> 4, 1 -> 4, 5 -> 6
+	ds := TaskDependencies{
+		{BlockerID: 1, BlockedID: 2},
+		{BlockerID: 2, BlockedID: 3},
+		{BlockerID: 3, BlockedID: 4},
+		{BlockerID: 1, BlockedID: 4},
+		{BlockerID: 5, BlockedID: 6},
+	}
+	tests := map[string]struct {
+		from, to TaskID
+		want     []TaskID
+	}{
+		"direct":      {from: 2, to: 3, want: []TaskID{2, 3}},
+		"transitive":  {from: 2, to: 4, want: []TaskID{2, 3, 4}},
+		"shortest":    {from: 1, to: 4, want: []TaskID{1, 4}},
+		"self":        {from: 3, to: 3, want: []TaskID{3}},
+		"unreachable": {from: 4, to: 1, want: nil},
+		"disjoint":    {from: 1, to: 6, want: nil},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			got := ds.PathFrom(tt.from, tt.to)
+			if d := cmp.Diff(got, tt.want); len(d) != 0 {
+				t.Errorf("differs: (-got +want)\n%s", d)
+			}
+		})
+	}
+}
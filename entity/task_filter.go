@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+// TaskSortKey はタスク一覧の並び順を表す
+// 先頭に"-"が付与されている場合は降順とする
+type TaskSortKey string
+
+const (
+	TaskSortCreatedAsc   TaskSortKey = "created"
+	TaskSortCreatedDesc  TaskSortKey = "-created"
+	TaskSortModifiedAsc  TaskSortKey = "modified"
+	TaskSortModifiedDesc TaskSortKey = "-modified"
+)
+
+// Valid は定義済の並び順であるかを判定する
+func (k TaskSortKey) Valid() bool {
+	switch k {
+	case TaskSortCreatedAsc, TaskSortCreatedDesc, TaskSortModifiedAsc, TaskSortModifiedDesc:
+		return true
+	}
+	return false
+}
+
+const (
+	DefaultTaskListLimit = 50
+	MaxTaskListLimit     = 100
+)
+
+// TaskFilter はタスク一覧取得時の絞り込み、並び替え、ページングの条件を表す
+// ゼロ値の項目は条件として扱わない
+type TaskFilter struct {
+	Statuses     []TaskStatus
+	CreatedFrom  time.Time // 指定日時以降に作成されたタスク
+	CreatedTo    time.Time // 指定日時より前に作成されたタスク
+	ModifiedFrom time.Time // 指定日時以降に更新されたタスク
+	ModifiedTo   time.Time // 指定日時より前に更新されたタスク
+	TitlePrefix  string
+	DueBefore    time.Time // 指定日時より前に期限を迎えるタスク
+	Overdue      bool      // 未完了のまま期限を過ぎたタスクのみ
+	Tags         []string  // 付与されたタグ名
+	TagMatchAll  bool      // trueの場合はTagsをすべて付与されたタスク、falseの場合はいずれかを付与されたタスク
+	ProjectID    *ProjectID
+	// IncludeArchived がfalseの場合、アーカイブ済プロジェクトに属するタスクを除外する
+	// ProjectIDを指定した場合は当該プロジェクトのアーカイブ有無に関わらず取得する
+	IncludeArchived bool
+	// Tree がtrueの場合、親タスクを持たないタスクのみをページングの対象とし、子孫タスクをChildrenに格納する
+	Tree   bool
+	Sort   TaskSortKey
+	Cursor string // 前ページのレスポンスで返却された不透明なカーソル
+	Limit  int
+}
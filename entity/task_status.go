@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// taskStatusTransitions は遷移元ステータスごとに、一般ユーザが遷移可能な遷移先ステータスを定義する
+var taskStatusTransitions = map[TaskStatus][]TaskStatus{
+	TaskStatusTodo:  {TaskStatusDoing, TaskStatusDone},
+	TaskStatusDoing: {TaskStatusTodo, TaskStatusDone},
+	TaskStatusDone:  {},
+}
+
+// adminTaskStatusTransitions は管理者のみに追加で許可する遷移を定義する
+// 完了済のタスクを再開できるのは管理者のみとする
+var adminTaskStatusTransitions = map[TaskStatus][]TaskStatus{
+	TaskStatusDone: {TaskStatusTodo, TaskStatusDoing},
+}
+
+// Valid は定義済のステータスであるかを判定する
+func (s TaskStatus) Valid() bool {
+	_, ok := taskStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo は遷移表を元に、ステータスをtoへ遷移可能であるかを判定する
+func (s TaskStatus) CanTransitionTo(to TaskStatus, admin bool) bool {
+	if contains(taskStatusTransitions[s], to) {
+		return true
+	}
+	return admin && contains(adminTaskStatusTransitions[s], to)
+}
+
+func contains(ss []TaskStatus, s TaskStatus) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionError は遷移表で許可されていないステータス遷移を示す
+type TransitionError struct {
+	From TaskStatus
+	To   TaskStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot transition task status from %q to %q", e.From, e.To)
+}
+
+// Transition はタスクのステータスをtoへ遷移させる
+// 遷移表で許可されていない場合は*TransitionErrorを返却し、タスクの状態は変更しない
+func (t *Task) Transition(to TaskStatus, admin bool) error {
+	if !t.Status.CanTransitionTo(to, admin) {
+		return &TransitionError{From: t.Status, To: to}
+	}
+	t.Status = to
+	return nil
+}
+
+type TaskTransitionID int64
+
+// TaskTransition はタスクのステータス遷移の履歴を表す
+type TaskTransition struct {
+	ID      TaskTransitionID `json:"id" db:"id"`
+	TaskID  TaskID           `json:"task_id" db:"task_id"`
+	UserID  UserID           `json:"user_id" db:"user_id"` // 遷移を実行したユーザID
+	From    TaskStatus       `json:"from" db:"from_status"`
+	To      TaskStatus       `json:"to" db:"to_status"`
+	Created time.Time        `json:"created" db:"created"`
+}
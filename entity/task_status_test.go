@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTask_Transition(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		from    TaskStatus
+		to      TaskStatus
+		admin   bool
+		wantErr bool
+	}{
+		"todoToDoing":       {from: TaskStatusTodo, to: TaskStatusDoing},
+		"todoToDone":        {from: TaskStatusTodo, to: TaskStatusDone},
+		"doingToTodo":       {from: TaskStatusDoing, to: TaskStatusTodo},
+		"doingToDone":       {from: TaskStatusDoing, to: TaskStatusDone},
+		"todoToTodo":        {from: TaskStatusTodo, to: TaskStatusTodo, wantErr: true},
+		"reopenByUser":      {from: TaskStatusDone, to: TaskStatusTodo, wantErr: true},
+		"reopenByAdmin":     {from: TaskStatusDone, to: TaskStatusTodo, admin: true},
+		"resumeByAdmin":     {from: TaskStatusDone, to: TaskStatusDoing, admin: true},
+		"undefinedByAdmin":  {from: TaskStatusTodo, to: TaskStatus("archived"), admin: true, wantErr: true},
+		"doneToDoneByAdmin": {from: TaskStatusDone, to: TaskStatusDone, admin: true, wantErr: true},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			sut := &Task{Status: tt.from}
+			err := sut.Transition(tt.to, tt.admin)
+
+			// 検証
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("want no error, but got %v", err)
+				}
+				if sut.Status != tt.to {
+					t.Errorf("want %q, but got %q", tt.to, sut.Status)
+				}
+				return
+			}
+			var terr *TransitionError
+			if !errors.As(err, &terr) {
+				t.Fatalf("want *TransitionError, but got %v", err)
+			}
+			if terr.From != tt.from || terr.To != tt.to {
+				t.Errorf("want %q -> %q, but got %q -> %q", tt.from, tt.to, terr.From, terr.To)
+			}
+			// 遷移に失敗した場合はステータスを変更しない
+			if sut.Status != tt.from {
+				t.Errorf("want %q, but got %q", tt.from, sut.Status)
+			}
+		})
+	}
+}
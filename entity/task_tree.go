@@ -0,0 +1,57 @@
+package entity
+
+import "fmt"
+
+// MaxTaskDepth は親子関係で連なるタスクの最大階層数
+// 親を持たないタスクを1階層目とする
+const MaxTaskDepth = 5
+
+// TaskProgress は子タスクの完了状況を表す
+type TaskProgress struct {
+	Total int `db:"total"` // 子タスクの件数
+	Done  int `db:"done"`  // 完了済の子タスクの件数
+}
+
+// Percent は子タスクの完了率を0〜100の整数で返却する
+func (p TaskProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Done * 100 / p.Total
+}
+
+// Open は未完了の子タスクの件数を返却する
+func (p TaskProgress) Open() int {
+	return p.Total - p.Done
+}
+
+// OpenChildrenError は未完了の子タスクを持つ親タスクを完了させようとしたことを示す
+type OpenChildrenError struct {
+	TaskID TaskID
+	Open   int
+}
+
+func (e *OpenChildrenError) Error() string {
+	return fmt.Sprintf("task %d has %d open child tasks", e.TaskID, e.Open)
+}
+
+// NestTasks は親子関係にあるタスクを親タスクのChildrenに格納し、最上位のタスクのみを返却する
+// 親タスクがtsに含まれないタスクは最上位のタスクとして扱い、各階層の並び順はtsの順序を維持する
+func NestTasks(ts Tasks) Tasks {
+	byID := make(map[TaskID]*Task, len(ts))
+	for _, t := range ts {
+		t.Children = Tasks{}
+		byID[t.ID] = t
+	}
+	roots := Tasks{}
+	for _, t := range ts {
+		if t.ParentID != nil {
+			if p, ok := byID[*t.ParentID]; ok {
+				p.Children = append(p.Children, t)
+				continue
+			}
+		}
+		roots = append(roots, t)
+	}
+	return roots
+}
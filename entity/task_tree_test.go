@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTaskProgress_Percent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		progress TaskProgress
+		want     int
+	}{
+		"noChildren": {progress: TaskProgress{}, want: 0},
+		"half":       {progress: TaskProgress{Total: 4, Done: 2}, want: 50},
+		"truncated":  {progress: TaskProgress{Total: 3, Done: 2}, want: 66},
+		"completed":  {progress: TaskProgress{Total: 3, Done: 3}, want: 100},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.progress.Percent(); got != tt.want {
+				t.Errorf("want %d, but got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNestTasks(t *testing.T) {
+	t.Parallel()
+
+	id := func(v TaskID) *TaskID { return &v }
+	ts := Tasks{
+		{ID: 1},
+		{ID: 2},
+		{ID: 3, ParentID: id(1)},
+		{ID: 4, ParentID: id(3)},
+		{ID: 5, ParentID: id(1)},
+		// 親タスクが含まれない場合は最上位のタスクとして扱う
+		{ID: 6, ParentID: id(99)},
+	}
+
+	got := NestTasks(ts)
+
+	ids := func(ts Tasks) []TaskID {
+		out := []TaskID{}
+		for _, t := range ts {
+			out = append(out, t.ID)
+		}
+		return out
+	}
+	if d := cmp.Diff(ids(got), []TaskID{1, 2, 6}); len(d) != 0 {
+		t.Errorf("roots differs: (-got +want)\n%s", d)
+	}
+	if d := cmp.Diff(ids(got[0].Children), []TaskID{3, 5}); len(d) != 0 {
+		t.Errorf("children differs: (-got +want)\n%s", d)
+	}
+	if d := cmp.Diff(ids(got[0].Children[0].Children), []TaskID{4}); len(d) != 0 {
+		t.Errorf("grandchildren differs: (-got +want)\n%s", d)
+	}
+}
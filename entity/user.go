@@ -8,13 +8,49 @@ import (
 
 type UserID int64
 
+// ユーザのプロフィールの既定値
+const (
+	DefaultTimeZone = "UTC"
+	DefaultLocale   = "en"
+)
+
 type User struct {
-	ID       UserID    `json:"id" db:"id"`
-	Name     string    `json:"name" db:"name"`
-	Password string    `json:"password" db:"password"`
-	Role     string    `json:"role" db:"role"`
-	Created  time.Time `json:"created" db:"created"`
-	Modified time.Time `json:"modified" db:"modified"`
+	ID   UserID `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+	// Password はパスワードのハッシュ値であり、いかなるレスポンスにも含めない
+	Password string `json:"-" db:"password"`
+	Role     Role   `json:"role" db:"role"`
+	// Email はパスワード再設定用のメールアドレス(未登録の場合はnil)
+	Email *string `json:"email,omitempty" db:"email"`
+	// DisplayName は画面に表示する名前(未設定の場合は空文字)
+	DisplayName string `json:"display_name" db:"display_name"`
+	// TimeZone はIANAタイムゾーンデータベースの名前、Locale はBCP 47の言語タグ
+	TimeZone string `json:"time_zone" db:"time_zone"`
+	Locale   string `json:"locale" db:"locale"`
+	// PasswordResetRequired がtrueの場合、次回のログイン時にパスワードの再設定を必須とする
+	PasswordResetRequired bool `json:"password_reset_required" db:"password_reset_required"`
+	// TOTPSecret は二要素認証に使用するTOTPのシークレット(未設定の場合はnil)
+	// 有効化前の設定中のシークレットも格納するため、有効化の有無はTOTPEnabledで判定する
+	TOTPSecret  *string `json:"-" db:"totp_secret"`
+	TOTPEnabled bool    `json:"totp_enabled" db:"totp_enabled"`
+	// DisabledAt は管理者がユーザを無効化した日時(有効なユーザの場合はnil)
+	// 無効化したユーザはログインできず、発行済のトークンも使用できない
+	DisabledAt *time.Time `json:"disabled_at,omitempty" db:"disabled_at"`
+	Created    time.Time  `json:"created" db:"created"`
+	Modified   time.Time  `json:"modified" db:"modified"`
+}
+
+type Users []*User
+
+// Disabled はユーザが無効化されているかを判定する
+func (u *User) Disabled() bool {
+	return u.DisabledAt != nil
+}
+
+// HasPassword はユーザがパスワードを持つかを判定する
+// OpenID Connectによるログインで登録したユーザはパスワードを持たない
+func (u *User) HasPassword() bool {
+	return u.Password != ""
 }
 
 // ComparePassword はハッシュ化されて永続化されたパスワードを入力値のパスワードと比較検証する。
@@ -0,0 +1,17 @@
+package entity
+
+const (
+	DefaultUserListLimit = 50
+	MaxUserListLimit     = 100
+)
+
+// UserFilter は管理者によるユーザ一覧取得時の絞り込み、ページングの条件を表す
+// ゼロ値の項目は条件として扱わない
+type UserFilter struct {
+	// Query はユーザ名、表示名、メールアドレスのいずれかに前方一致させる検索文字列
+	Query    string
+	Role     Role
+	Disabled *bool  // trueの場合は無効化されたユーザのみ、falseの場合は有効なユーザのみ
+	Cursor   string // 前ページのレスポンスで返却された不透明なカーソル
+	Limit    int
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type UserIdentityID int64
+
+// UserIdentity は外部のIdPのユーザと、ローカルのユーザの対応付けを表す
+// IdP上のユーザは発行者(iss)と、発行者内で一意な識別子(sub)の組み合わせで識別する
+type UserIdentity struct {
+	ID      UserIdentityID `json:"id" db:"id"`
+	UserID  UserID         `json:"user_id" db:"user_id"`
+	Issuer  string         `json:"issuer" db:"issuer"`
+	Subject string         `json:"subject" db:"subject"`
+	Created time.Time      `json:"created" db:"created"`
+}
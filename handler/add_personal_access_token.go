@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/go-playground/validator/v10"
+)
+
+type AddPersonalAccessToken struct {
+	Service   AddPersonalAccessTokenService
+	Validator *validator.Validate
+}
+
+func (ap *AddPersonalAccessToken) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Name      string     `json:"name" validate:"required,max=100"`
+		Scopes    []string   `json:"scopes" validate:"required,min=1"`
+		ExpiresAt *time.Time `json:"expires_at"` // 未指定の場合は無期限
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := ap.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	scopes, err := entity.ParseScopes(strings.Join(b.Scopes, " "))
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	if scopes.Contains(entity.ScopeAccount) {
+		// アカウント操作はパスワードによるログインで発行したトークンのみに許可する
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: fmt.Sprintf("scope %q cannot be granted to personal access token", entity.ScopeAccount),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	t, token, err := ap.Service.AddPersonalAccessToken(ctx, b.Name, scopes, b.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidExpiration) {
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	// トークン文字列は発行時のレスポンスでのみ返却する
+	rsp := struct {
+		personalAccessToken
+		Token string `json:"token"`
+	}{
+		personalAccessToken: newPersonalAccessToken(t),
+		Token:               token,
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
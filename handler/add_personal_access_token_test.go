@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestAddPersonalAccessToken(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/add_personal_access_token/ok_req.json.golden",
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/add_personal_access_token/ok_rsp.json.golden",
+			},
+		},
+		"badScope": {
+			reqFile: "testdata/add_personal_access_token/bad_scope_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/add_personal_access_token/bad_scope_rsp.json.golden",
+			},
+		},
+		"accountScope": {
+			reqFile: "testdata/add_personal_access_token/account_scope_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/add_personal_access_token/account_scope_rsp.json.golden",
+			},
+		},
+		"pastExpiration": {
+			reqFile: "testdata/add_personal_access_token/past_expiration_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/add_personal_access_token/past_expiration_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/me/tokens",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+
+			// モック準備
+			moq := &AddPersonalAccessTokenServiceMock{}
+			moq.AddPersonalAccessTokenFunc = func(
+				ctx context.Context, name string, scopes entity.Scopes, expiresAt *time.Time,
+			) (*entity.PersonalAccessToken, string, error) {
+				if expiresAt != nil && !expiresAt.After(clock.FixedClocker{}.Now()) {
+					return nil, "", service.ErrInvalidExpiration
+				}
+				return &entity.PersonalAccessToken{
+					ID: 1, Name: name, Scopes: scopes, Created: clock.FixedClocker{}.Now(),
+				}, "todo_pat_from_moq", nil
+			}
+
+			sut := AddPersonalAccessToken{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
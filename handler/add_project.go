@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type AddProject struct {
+	Service   AddProjectService
+	Validator *validator.Validate
+}
+
+func (ap *AddProject) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Name string `json:"name" validate:"required,max=128"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := ap.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	p, err := ap.Service.AddProject(ctx, b.Name)
+	if err != nil {
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, newProject(p), http.StatusOK)
+}
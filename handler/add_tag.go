@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/go-playground/validator/v10"
+)
+
+type AddTag struct {
+	Service   AddTagService
+	Validator *validator.Validate
+}
+
+func (at *AddTag) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Name string `json:"name" validate:"required,max=64"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := at.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	t, err := at.Service.AddTag(ctx, b.Name)
+	if err != nil {
+		if errors.Is(err, store.ErrAlreadyEntry) {
+			// 同名のタグが登録済の場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusConflict)
+			return
+		}
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, tag{ID: t.ID, Name: t.Name}, http.StatusOK)
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestAddTag(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		err     error
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/add_tag/ok_req.json.golden",
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/add_tag/ok_rsp.json.golden",
+			},
+		},
+		"badRequest": {
+			reqFile: "testdata/add_tag/bad_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/add_tag/bad_req_rsp.json.golden",
+			},
+		},
+		"conflict": {
+			reqFile: "testdata/add_tag/ok_req.json.golden",
+			err:     fmt.Errorf("failed to register: cannot create same name tag: %w", store.ErrAlreadyEntry),
+			want: want{
+				status:  http.StatusConflict,
+				rspFile: "testdata/add_tag/conflict_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/tags",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+
+			// モック準備
+			moq := &AddTagServiceMock{}
+			moq.AddTagFunc = func(ctx context.Context, name string) (*entity.Tag, error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &entity.Tag{ID: 1, Name: name}, nil
+			}
+
+			sut := AddTag{
+				Service:   moq,
+				Validator: validator.New(),
+			}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
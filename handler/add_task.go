@@ -2,9 +2,12 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -16,7 +19,13 @@ type AddTask struct {
 func (at *AddTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	var b struct {
-		Title string `json:"title" validate:"required"`
+		Title       string              `json:"title" validate:"required,max=128"`
+		Description string              `json:"description" validate:"max=10000"`
+		Priority    entity.TaskPriority `json:"priority" validate:"omitempty,oneof=low normal high urgent"`
+		DueAt       *time.Time          `json:"due_at"`
+		ProjectID   *entity.ProjectID   `json:"project_id"`
+		ParentID    *entity.TaskID      `json:"parent_id"`
+		Recurrence  string              `json:"recurrence"` // RRULE形式の繰り返し規則
 	}
 	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
 		// リクエスト情報の解析が失敗した場合
@@ -30,8 +39,34 @@ func (at *AddTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	t, err := at.Service.AddTask(ctx, b.Title)
-	if err != nil {
+	var rec *entity.Recurrence
+	if b.Recurrence != "" {
+		var err error
+		if rec, err = entity.ParseRecurrence(b.Recurrence); err != nil {
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+			return
+		}
+	}
+
+	t := &entity.Task{
+		Title:       b.Title,
+		Description: b.Description,
+		Priority:    b.Priority,
+		DueAt:       b.DueAt,
+		ProjectID:   b.ProjectID,
+		ParentID:    b.ParentID,
+		Recurrence:  rec,
+	}
+	if err := at.Service.AddTask(ctx, t); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			// 指定されたプロジェクトまたは親タスクがユーザの所有でない場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+			return
+		case errors.Is(err, store.ErrTaskDepthExceeded):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusConflict)
+			return
+		}
 		// DB操作が失敗した場合
 		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
 		return
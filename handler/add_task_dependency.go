@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type AddTaskDependency struct {
+	Service AddTaskDependencyService
+}
+
+func (ad *AddTaskDependency) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	blockedID, err := taskIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	blockerID, err := blockerIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	if err := ad.Service.AddTaskDependency(ctx, blockerID, blockedID); err != nil {
+		var cerr *entity.DependencyCycleError
+		switch {
+		case errors.As(err, &cerr):
+			// 依存関係が循環する場合は循環する経路を返却する
+			rsp := struct {
+				Message string          `json:"message"`
+				Cycle   []entity.TaskID `json:"cycle"`
+			}{Message: err.Error(), Cycle: cerr.Path}
+			RespondJSON(ctx, w, rsp, http.StatusConflict)
+		case errors.Is(err, store.ErrNotFound):
+			// いずれかのタスクが他ユーザの所有である場合も含む
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+		default:
+			// DB操作が失敗した場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	rsp := struct {
+		BlockerID entity.TaskID `json:"blocker_id"`
+		BlockedID entity.TaskID `json:"blocked_id"`
+	}{BlockerID: blockerID, BlockedID: blockedID}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
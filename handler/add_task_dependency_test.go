@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestAddTaskDependency(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		blockerID string
+		err       error
+		want      want
+	}{
+		"ok": {
+			blockerID: "2",
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/add_task_dependency/ok_rsp.json.golden",
+			},
+		},
+		"badRequest": {
+			blockerID: "abc",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/add_task_dependency/bad_req_rsp.json.golden",
+			},
+		},
+		"cycle": {
+			blockerID: "2",
+			err:       &entity.DependencyCycleError{Path: []entity.TaskID{2, 1, 3, 2}},
+			want: want{
+				status:  http.StatusConflict,
+				rspFile: "testdata/add_task_dependency/cycle_rsp.json.golden",
+			},
+		},
+		"notFound": {
+			blockerID: "2",
+			err:       fmt.Errorf("failed to add dependency: task 2 or 1: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/add_task_dependency/not_found_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/tasks/1/blockers/"+tt.blockerID, nil)
+			r = testutil.WithURLParams(r, map[string]string{"id": "1", "blocker_id": tt.blockerID})
+
+			// モック準備
+			moq := &AddTaskDependencyServiceMock{}
+			moq.AddTaskDependencyFunc = func(ctx context.Context, blockerID, blockedID entity.TaskID) error {
+				return tt.err
+			}
+
+			sut := AddTaskDependency{Service: moq}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
@@ -37,6 +37,20 @@ func TestAddTask(t *testing.T) {
 				rspFile: "testdata/add_task/bad_req_rsp.json.golden",
 			},
 		},
+		"badPriority": {
+			reqFile: "testdata/add_task/bad_priority_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/add_task/bad_priority_rsp.json.golden",
+			},
+		},
+		"badRecurrence": {
+			reqFile: "testdata/add_task/bad_recurrence_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/add_task/bad_recurrence_rsp.json.golden",
+			},
+		},
 	}
 
 	for n, tt := range tests {
@@ -52,13 +66,14 @@ func TestAddTask(t *testing.T) {
 
 			// モック準備
 			moq := &AddTaskServiceMock{}
-			moq.AddTaskFunc = func(ctx context.Context, title string) (*entity.Task, error) {
+			moq.AddTaskFunc = func(ctx context.Context, t *entity.Task) error {
 				// 正常系の戻り値
 				if tt.want.status == http.StatusOK {
-					return &entity.Task{ID: 1}, nil
+					t.ID = 1
+					return nil
 				}
 				// 異常系の戻り値
-				return nil, errors.New("error from mock")
+				return errors.New("error from mock")
 			}
 
 			sut := AddTask{
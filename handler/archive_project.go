@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// ArchiveProject はArchivedがtrueの場合はプロジェクトをアーカイブし、falseの場合はアーカイブを解除する
+type ArchiveProject struct {
+	Service  ArchiveProjectService
+	Archived bool
+}
+
+func (ap *ArchiveProject) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := projectIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	p, err := ap.Service.ArchiveProject(ctx, id, ap.Archived)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+			return
+		}
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, newProject(p), http.StatusOK)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/go-playground/validator/v10"
+)
+
+// ChangePassword はログインユーザのパスワードを変更するハンドラ
+type ChangePassword struct {
+	Service   ChangePasswordService
+	Validator *validator.Validate
+}
+
+func (cp *ChangePassword) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		// パスワードを持たないユーザは省略し、直近のIdPでの認証を経たトークンで要求する
+		CurrentPassword string `json:"current_password"`
+		NewPassword     string `json:"new_password" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := cp.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	// 他のセッションは失効するため、リクエスト元には新たなトークンを返却する
+	tokens, err := cp.Service.ChangePassword(ctx, b.CurrentPassword, b.NewPassword)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCredentials),
+			errors.Is(err, service.ErrReauthenticationRequired):
+			// 認証済のトークンは有効なため、401ではなく403とする
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusForbidden)
+		case errors.Is(err, service.ErrWeakPassword):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		default:
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	RespondJSON(ctx, w, newTokenPair(tokens), http.StatusOK)
+}
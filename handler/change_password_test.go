@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestChangePassword(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		err  error
+		want want
+	}{
+		"ok": {
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/change_password/ok_rsp.json.golden",
+			},
+		},
+		"wrongCurrent": {
+			err: service.ErrInvalidCredentials,
+			want: want{
+				status:  http.StatusForbidden,
+				rspFile: "testdata/change_password/forbidden_rsp.json.golden",
+			},
+		},
+		"reauthenticationRequired": {
+			err: service.ErrReauthenticationRequired,
+			want: want{
+				status:  http.StatusForbidden,
+				rspFile: "testdata/change_password/reauth_rsp.json.golden",
+			},
+		},
+		"weak": {
+			err: fmt.Errorf("%w: too common", service.ErrWeakPassword),
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/change_password/weak_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPut,
+				"/me/password",
+				bytes.NewReader(testutil.LoadFile(t, "testdata/change_password/ok_req.json.golden")),
+			)
+
+			// モック準備
+			moq := &ChangePasswordServiceMock{}
+			moq.ChangePasswordFunc = func(ctx context.Context, current, password string) (*auth.TokenPair, error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &auth.TokenPair{AccessToken: "from_moq", RefreshToken: "refresh_from_moq"}, nil
+			}
+
+			sut := ChangePassword{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/go-playground/validator/v10"
+)
+
+// ConfirmTwoFactor はTOTPのコードを確認し、ログインユーザの二要素認証を有効化するハンドラ
+type ConfirmTwoFactor struct {
+	Service   ConfirmTwoFactorService
+	Validator *validator.Validate
+}
+
+func (ct *ConfirmTwoFactor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Code string `json:"code" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := ct.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	codes, err := ct.Service.ConfirmTwoFactor(ctx, b.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTwoFactorCode):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		case errors.Is(err, service.ErrTwoFactorNotSetUp), errors.Is(err, service.ErrTwoFactorAlreadyEnabled):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusConflict)
+		default:
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	// リカバリーコードは再表示できないため、利用者に保管させる
+	rsp := struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}{RecoveryCodes: codes}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
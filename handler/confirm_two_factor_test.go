@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestConfirmTwoFactor(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		err  error
+		want want
+	}{
+		"ok": {
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/confirm_two_factor/ok_rsp.json.golden",
+			},
+		},
+		"invalidCode": {
+			err: service.ErrInvalidTwoFactorCode,
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/confirm_two_factor/invalid_code_rsp.json.golden",
+			},
+		},
+		"notSetUp": {
+			err: service.ErrTwoFactorNotSetUp,
+			want: want{
+				status:  http.StatusConflict,
+				rspFile: "testdata/confirm_two_factor/not_set_up_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/me/2fa/confirm",
+				bytes.NewReader(testutil.LoadFile(t, "testdata/confirm_two_factor/ok_req.json.golden")),
+			)
+
+			// モック準備
+			moq := &ConfirmTwoFactorServiceMock{}
+			moq.ConfirmTwoFactorFunc = func(ctx context.Context, code string) ([]string, error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return []string{"AAAA-BBBB-CCCC-DDDD", "EEEE-FFFF-GGGG-HHHH"}, nil
+			}
+
+			sut := ConfirmTwoFactor{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
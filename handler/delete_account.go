@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/go-playground/validator/v10"
+)
+
+// DeleteAccount はログインユーザのアカウントと、ユーザが作成したすべてのデータを削除するハンドラ
+type DeleteAccount struct {
+	Service   DeleteAccountService
+	Validator *validator.Validate
+}
+
+func (da *DeleteAccount) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	// 取り消しできない操作のため、パスワードを再確認する
+	var b struct {
+		// パスワードを持たないユーザは省略し、直近のIdPでの認証を経たトークンで要求する
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := da.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	if err := da.Service.DeleteAccount(ctx, b.Password); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCredentials),
+			errors.Is(err, service.ErrReauthenticationRequired):
+			// 認証済のトークンは有効なため、401ではなく403とする
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusForbidden)
+		default:
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	rsp := struct {
+		Message string `json:"message"`
+	}{Message: "account has been deleted"}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
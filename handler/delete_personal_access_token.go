@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type DeletePersonalAccessToken struct {
+	Service DeletePersonalAccessTokenService
+}
+
+func (dp *DeletePersonalAccessToken) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := patIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	if err := dp.Service.DeletePersonalAccessToken(ctx, id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+			return
+		}
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	// 削除したトークンのIDのみ返却する
+	rsp := struct {
+		ID entity.PersonalAccessTokenID `json:"id"`
+	}{ID: id}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/go-chi/chi/v5"
+)
+
+type DeleteSession struct {
+	Service DeleteSessionService
+}
+
+func (ds *DeleteSession) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sid := chi.URLParam(r, "id")
+	if sid == "" {
+		RespondJSON(ctx, w, &ErrResponse{Message: "session id is required"}, http.StatusBadRequest)
+		return
+	}
+
+	if err := ds.Service.DeleteSession(ctx, sid); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			// セッションが存在しない、または他のユーザのセッションの場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+			return
+		}
+		// Redis操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	// 削除したセッションのIDのみ返却する
+	rsp := struct {
+		ID string `json:"id"`
+	}{ID: sid}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestDeleteSession(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		err  error
+		want want
+	}{
+		"ok": {
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/delete_session/ok_rsp.json.golden",
+			},
+		},
+		"notFound": {
+			err: fmt.Errorf("failed to delete: RevokeSession: failed to delete \"abc\": session \"abc\": %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/delete_session/not_found_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, "/me/sessions/abc", nil)
+			r = testutil.WithURLParams(r, map[string]string{"id": "abc"})
+
+			// モック準備
+			moq := &DeleteSessionServiceMock{}
+			moq.DeleteSessionFunc = func(ctx context.Context, sid string) error {
+				if sid != "abc" {
+					t.Errorf("want session id %q, but got %q", "abc", sid)
+				}
+				return tt.err
+			}
+
+			sut := DeleteSession{Service: moq}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
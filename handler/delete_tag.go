@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type DeleteTag struct {
+	Service DeleteTagService
+}
+
+func (dt *DeleteTag) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := tagIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	if err := dt.Service.DeleteTag(ctx, id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+			return
+		}
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	rsp := struct {
+		ID entity.TagID `json:"id"`
+	}{ID: id}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type DeleteTask struct {
+	Service DeleteTaskService
+}
+
+func (dt *DeleteTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := taskIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	if err := dt.Service.DeleteTask(ctx, id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+			return
+		}
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	// 削除したタスクのIDのみ返却する
+	rsp := struct {
+		ID entity.TaskID `json:"id"`
+	}{ID: id}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
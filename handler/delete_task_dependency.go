@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type DeleteTaskDependency struct {
+	Service DeleteTaskDependencyService
+}
+
+func (dd *DeleteTaskDependency) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	blockedID, err := taskIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	blockerID, err := blockerIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	if err := dd.Service.DeleteTaskDependency(ctx, blockerID, blockedID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+			return
+		}
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	rsp := struct {
+		BlockerID entity.TaskID `json:"blocker_id"`
+		BlockedID entity.TaskID `json:"blocked_id"`
+	}{BlockerID: blockerID, BlockedID: blockedID}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestDeleteTask(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		err  error
+		want want
+	}{
+		"ok": {
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/delete_task/ok_rsp.json.golden",
+			},
+		},
+		"notFound": {
+			err: fmt.Errorf("failed to delete: task 1: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/delete_task/not_found_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, "/tasks/1", nil)
+			r = testutil.WithURLParams(r, map[string]string{"id": "1"})
+
+			// モック準備
+			moq := &DeleteTaskServiceMock{}
+			moq.DeleteTaskFunc = func(ctx context.Context, id entity.TaskID) error {
+				return tt.err
+			}
+
+			sut := DeleteTask{Service: moq}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
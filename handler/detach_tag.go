@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type DetachTag struct {
+	Service DetachTagService
+}
+
+func (dt *DetachTag) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskID, err := taskIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	tagID, err := tagIDParam(r, "tag_id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	if err := dt.Service.DetachTag(ctx, taskID, tagID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			// タグが付与されていない場合も含む
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+			return
+		}
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	rsp := struct {
+		TaskID entity.TaskID `json:"task_id"`
+		TagID  entity.TagID  `json:"tag_id"`
+	}{TaskID: taskID, TagID: tagID}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
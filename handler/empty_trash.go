@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+)
+
+type EmptyTrash struct {
+	Service EmptyTrashService
+}
+
+func (et *EmptyTrash) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	n, err := et.Service.EmptyTrash(ctx)
+	if err != nil {
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	// 完全に削除したタスクの件数のみ返却する
+	rsp := struct {
+		Deleted int64 `json:"deleted"`
+	}{Deleted: n}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
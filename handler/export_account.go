@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// ExportAccount はログインユーザのデータをZIP形式でダウンロードさせるハンドラ
+type ExportAccount struct {
+	Service ExportAccountService
+}
+
+func (ea *ExportAccount) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sw := &streamWriter{w: w}
+	if err := ea.Service.ExportAccount(ctx, sw); err != nil {
+		if !sw.started {
+			// 書き込み開始前であればエラーをJSONで返却できる
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+		// ステータスコードは送信済のため、ログの出力のみとし、不完全なZIPとしてクライアントに検出させる
+		log.Printf("failed to export account: %v", err)
+	}
+}
+
+// streamWriter は最初の書き込み時にZIPのダウンロード用のヘッダーを送信するhttp.ResponseWriterのラッパー
+type streamWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (sw *streamWriter) Write(p []byte) (int, error) {
+	if !sw.started {
+		sw.started = true
+		h := sw.w.Header()
+		h.Set("Content-Type", "application/zip")
+		h.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "export.zip"))
+		sw.w.WriteHeader(http.StatusOK)
+	}
+	return sw.w.Write(p)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExportAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		write       bool
+		err         error
+		wantStatus  int
+		wantType    string
+		wantContent string
+	}{
+		"ok": {
+			write:       true,
+			wantStatus:  http.StatusOK,
+			wantType:    "application/zip",
+			wantContent: "zip from moq",
+		},
+		// 書き込み開始前のエラーはJSONで返却する
+		"errorBeforeWrite": {
+			err:         errors.New("error from mock"),
+			wantStatus:  http.StatusInternalServerError,
+			wantType:    "application/json; charset=utf-8",
+			wantContent: `{"message":"error from mock"}`,
+		},
+		// 書き込み開始後のエラーではステータスコードを変更できない
+		"errorAfterWrite": {
+			write:       true,
+			err:         errors.New("error from mock"),
+			wantStatus:  http.StatusOK,
+			wantType:    "application/zip",
+			wantContent: "zip from moq",
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/me/export", nil)
+
+			// モック準備
+			moq := &ExportAccountServiceMock{}
+			moq.ExportAccountFunc = func(ctx context.Context, w io.Writer) error {
+				if tt.write {
+					if _, err := io.WriteString(w, "zip from moq"); err != nil {
+						return err
+					}
+				}
+				return tt.err
+			}
+
+			sut := ExportAccount{Service: moq}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			t.Cleanup(func() { _ = res.Body.Close() })
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("want status %d, but got %d", tt.wantStatus, res.StatusCode)
+			}
+			if got := res.Header.Get("Content-Type"); got != tt.wantType {
+				t.Errorf("want Content-Type %q, but got %q", tt.wantType, got)
+			}
+			b, err := io.ReadAll(res.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.wantContent {
+				t.Errorf("want body %q, but got %q", tt.wantContent, b)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestForceLogout(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		err  error
+		want want
+	}{
+		"ok": {
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/force_logout/ok_rsp.json.golden",
+			},
+		},
+		"notFound": {
+			err: fmt.Errorf("failed to get user: user 2: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/force_logout/not_found_rsp.json.golden",
+			},
+		},
+		"internalServerError": {
+			err: errors.New("error from mock"),
+			want: want{
+				status:  http.StatusInternalServerError,
+				rspFile: "testdata/force_logout/status500_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/admin/users/2/logout", nil)
+			r = testutil.WithURLParams(r, map[string]string{"id": "2"})
+
+			// モック準備
+			moq := &ForceLogoutServiceMock{}
+			moq.ForceLogoutFunc = func(ctx context.Context, id entity.UserID) error {
+				if id != 2 {
+					t.Errorf("want user 2, but got %d", id)
+				}
+				return tt.err
+			}
+
+			sut := ForceLogout{Service: moq}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ForgotPassword はパスワード再設定トークンをメールで送信するハンドラ
+type ForgotPassword struct {
+	Service   ForgotPasswordService
+	Validator *validator.Validate
+}
+
+func (fp *ForgotPassword) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Email string `json:"email" validate:"required,email"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := fp.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	// アカウントの存在有無を推測させないため、処理の失敗時も同一のレスポンスを返却する
+	if err := fp.Service.ForgotPassword(ctx, b.Email); err != nil {
+		log.Printf("failed to send password reset mail: %v", err)
+	}
+	rsp := struct {
+		Message string `json:"message"`
+	}{Message: "if the account exists, a password reset email has been sent"}
+	RespondJSON(ctx, w, rsp, http.StatusAccepted)
+}
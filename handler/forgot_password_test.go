@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestForgotPassword(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile    string
+		serviceErr error
+		want       want
+	}{
+		"ok": {
+			reqFile: "testdata/forgot_password/ok_req.json.golden",
+			want: want{
+				status:  http.StatusAccepted,
+				rspFile: "testdata/forgot_password/ok_rsp.json.golden",
+			},
+		},
+		"badRequest": {
+			reqFile: "testdata/forgot_password/bad_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/forgot_password/bad_rsp.json.golden",
+			},
+		},
+		// 処理の失敗をリクエスト元に伝えない
+		"serviceErr": {
+			reqFile:    "testdata/forgot_password/ok_req.json.golden",
+			serviceErr: errors.New("failed to save password reset token"),
+			want: want{
+				status:  http.StatusAccepted,
+				rspFile: "testdata/forgot_password/ok_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/password/forgot",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+
+			// モック準備
+			moq := &ForgotPasswordServiceMock{}
+			moq.ForgotPasswordFunc = func(ctx context.Context, email string) error {
+				return tt.serviceErr
+			}
+
+			sut := ForgotPassword{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
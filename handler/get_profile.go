@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+// GetProfile はログインユーザの情報を取得するハンドラ
+type GetProfile struct {
+	Service GetProfileService
+}
+
+// profile はログインユーザ本人に返却するユーザ情報
+type profile struct {
+	ID          entity.UserID `json:"id"`
+	Name        string        `json:"name"`
+	Email       *string       `json:"email,omitempty"`
+	Role        entity.Role   `json:"role"`
+	DisplayName string        `json:"display_name"`
+	TimeZone    string        `json:"time_zone"`
+	Locale      string        `json:"locale"`
+	TOTPEnabled bool          `json:"totp_enabled"`
+	Created     time.Time     `json:"created"`
+	Modified    time.Time     `json:"modified"`
+}
+
+func newProfile(u *entity.User) profile {
+	return profile{
+		ID:          u.ID,
+		Name:        u.Name,
+		Email:       u.Email,
+		Role:        u.Role,
+		DisplayName: u.DisplayName,
+		TimeZone:    u.TimeZone,
+		Locale:      u.Locale,
+		TOTPEnabled: u.TOTPEnabled,
+		Created:     u.Created,
+		Modified:    u.Modified,
+	}
+}
+
+func (gp *GetProfile) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	u, err := gp.Service.GetProfile(ctx)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, newProfile(u), http.StatusOK)
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestGetTask(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		id   string
+		task *entity.Task
+		err  error
+		want want
+	}{
+		"ok": {
+			id:   "1",
+			task: &entity.Task{ID: 1, Title: "test1", Status: entity.TaskStatusTodo, Priority: entity.TaskPriorityNormal},
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/get_task/ok_rsp.json.golden",
+			},
+		},
+		"badRequest": {
+			id: "abc",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/get_task/bad_req_rsp.json.golden",
+			},
+		},
+		"notFound": {
+			// 他ユーザのタスクもリポジトリ層でErrNotFoundとなる
+			id:  "1",
+			err: fmt.Errorf("failed to get: task 1: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/get_task/not_found_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/tasks/"+tt.id, nil)
+			r = testutil.WithURLParams(r, map[string]string{"id": tt.id})
+
+			// モック準備
+			moq := &GetTaskServiceMock{}
+			moq.GetTaskFunc = func(ctx context.Context, id entity.TaskID) (*entity.Task, error) {
+				return tt.task, tt.err
+			}
+
+			sut := GetTask{Service: moq}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
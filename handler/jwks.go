@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"net/http"
+)
+
+type JWKS struct {
+	Service JWKSService
+}
+
+// ServeHTTP はトークンの検証に使用する公開鍵の一覧をJWK Set(RFC 7517)形式で返却する
+func (j *JWKS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	set, err := j.Service.PublicKeys(ctx)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	// 検証側でのキャッシュを許可し、鍵のローテーション時は旧鍵を一定期間残すことで整合性を保つ
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	RespondJSON(ctx, w, set, http.StatusOK)
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+func TestJWKS(t *testing.T) {
+	t.Parallel()
+
+	keys, err := auth.EmbeddedKeySet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+
+	// モック準備
+	moq := &JWKSServiceMock{}
+	moq.PublicKeysFunc = func(ctx context.Context) (jwk.Set, error) {
+		return keys.Public, nil
+	}
+
+	sut := JWKS{Service: moq}
+	sut.ServeHTTP(w, r)
+
+	res := w.Result()
+	// 秘密鍵のパラメータを含まない公開鍵のみが返却されること
+	testutil.AssertResponse(t,
+		res, http.StatusOK, testutil.LoadFile(t, "testdata/jwks/ok_rsp.json.golden"),
+	)
+}
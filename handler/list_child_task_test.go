@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestListChildTask(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	parentID := entity.TaskID(1)
+	tests := map[string]struct {
+		tasks entity.Tasks
+		err   error
+		want  want
+	}{
+		"ok": {
+			tasks: entity.Tasks{
+				{
+					ID: 2, ParentID: &parentID, Title: "child1", Status: entity.TaskStatusDoing, Priority: entity.TaskPriorityNormal,
+					// 子タスクを持つ場合のみ完了率を返却する
+					Progress: &entity.TaskProgress{Total: 3, Done: 1},
+				},
+				{ID: 3, ParentID: &parentID, Title: "child2", Status: entity.TaskStatusDone, Priority: entity.TaskPriorityNormal},
+			},
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/list_child_task/ok_rsp.json.golden",
+			},
+		},
+		"notFound": {
+			err: fmt.Errorf("failed to get: task 1: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/list_child_task/not_found_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/tasks/1/children", nil)
+			r = testutil.WithURLParams(r, map[string]string{"id": "1"})
+
+			// モック準備
+			moq := &ListChildTasksServiceMock{}
+			moq.ListChildTasksFunc = func(ctx context.Context, id entity.TaskID) (entity.Tasks, error) {
+				return tt.tasks, tt.err
+			}
+
+			sut := ListChildTask{Service: moq}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
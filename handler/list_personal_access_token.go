@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+type ListPersonalAccessToken struct {
+	Service ListPersonalAccessTokensService
+}
+
+type personalAccessToken struct {
+	ID        entity.PersonalAccessTokenID `json:"id"`
+	Name      string                       `json:"name"`
+	Scopes    []entity.Scope               `json:"scopes"`
+	ExpiresAt *time.Time                   `json:"expires_at,omitempty"`
+	Created   time.Time                    `json:"created"`
+}
+
+func (lp *ListPersonalAccessToken) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ts, err := lp.Service.ListPersonalAccessTokens(ctx)
+	if err != nil {
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	rsp := make([]personalAccessToken, 0, len(ts))
+	for _, t := range ts {
+		rsp = append(rsp, newPersonalAccessToken(t))
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
+
+// newPersonalAccessToken は*entity.PersonalAccessToken型の値をレスポンス用の構造体に変換する
+// トークンのハッシュ値はレスポンスに含めない
+func newPersonalAccessToken(t *entity.PersonalAccessToken) personalAccessToken {
+	scopes := make([]entity.Scope, 0, len(t.Scopes))
+	scopes = append(scopes, t.Scopes...)
+	return personalAccessToken{
+		ID:        t.ID,
+		Name:      t.Name,
+		Scopes:    scopes,
+		ExpiresAt: t.ExpiresAt,
+		Created:   t.Created,
+	}
+}
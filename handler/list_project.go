@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+type ListProject struct {
+	Service ListProjectsService
+}
+
+type project struct {
+	ID         entity.ProjectID `json:"id"`
+	Name       string           `json:"name"`
+	ArchivedAt *time.Time       `json:"archived_at,omitempty"`
+}
+
+func (lp *ListProject) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	includeArchived := false
+	if v := r.URL.Query().Get("include_archived"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			RespondJSON(ctx, w, &ErrResponse{Message: fmt.Sprintf("invalid include_archived: %v", err)}, http.StatusBadRequest)
+			return
+		}
+		includeArchived = b
+	}
+
+	ps, err := lp.Service.ListProjects(ctx, includeArchived)
+	if err != nil {
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	rsp := make([]project, 0, len(ps))
+	for _, p := range ps {
+		rsp = append(rsp, newProject(p))
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
+
+// newProject は*entity.Project型の値をレスポンス用の構造体に変換する
+func newProject(p *entity.Project) project {
+	return project{ID: p.ID, Name: p.Name, ArchivedAt: p.ArchivedAt}
+}
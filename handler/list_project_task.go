@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type ListProjectTask struct {
+	Service ListProjectTasksService
+}
+
+func (lp *ListProjectTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := projectIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	f, err := parseTaskFilter(r.URL.Query())
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	tasks, next, err := lp.Service.ListProjectTasks(ctx, id, f)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+		case errors.Is(err, store.ErrInvalidCursor):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		default:
+			// DB操作が失敗した場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	respondTasks(ctx, w, tasks, next)
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestListProjectTask(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	projectID := entity.ProjectID(3)
+	tests := map[string]struct {
+		id    string
+		query string
+		tasks entity.Tasks
+		err   error
+		want  want
+	}{
+		"ok": {
+			id: "3",
+			tasks: entity.Tasks{
+				{ID: 1, ProjectID: &projectID, Title: "test1", Status: entity.TaskStatusTodo, Priority: entity.TaskPriorityNormal},
+				{ID: 2, ProjectID: &projectID, Title: "test2", Status: entity.TaskStatusDone, Priority: entity.TaskPriorityHigh},
+			},
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/list_project_task/ok_rsp.json.golden",
+			},
+		},
+		"badFilter": {
+			id:    "3",
+			query: "?status=unknown",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/list_project_task/bad_filter_rsp.json.golden",
+			},
+		},
+		"notFound": {
+			// 他ユーザのプロジェクトもリポジトリ層でErrNotFoundとなる
+			id:  "3",
+			err: fmt.Errorf("failed to get project: project 3: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/list_project_task/not_found_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/projects/"+tt.id+"/tasks"+tt.query, nil)
+			r = testutil.WithURLParams(r, map[string]string{"id": tt.id})
+
+			// モック準備
+			moq := &ListProjectTasksServiceMock{}
+			moq.ListProjectTasksFunc = func(
+				ctx context.Context, id entity.ProjectID, f *entity.TaskFilter,
+			) (entity.Tasks, string, error) {
+				if id != projectID {
+					t.Errorf("want project id %d, but got %d", projectID, id)
+				}
+				return tt.tasks, "", tt.err
+			}
+
+			sut := ListProjectTask{Service: moq}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/auth"
+)
+
+type ListSession struct {
+	Service ListSessionsService
+}
+
+type session struct {
+	ID        string    `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	IP        string    `json:"ip"`
+	Created   time.Time `json:"created"`
+	LastSeen  time.Time `json:"last_seen"`
+	Current   bool      `json:"current"` // リクエストに使用したトークンのセッションの場合はtrue
+}
+
+func (ls *ListSession) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	ss, err := ls.Service.ListSessions(ctx)
+	if err != nil {
+		// Redis操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	// パーソナルアクセストークンによるリクエストの場合、セッションIDは設定されていない
+	sid, _ := auth.GetSessionID(ctx)
+	rsp := make([]session, 0, len(ss))
+	for _, s := range ss {
+		rsp = append(rsp, session{
+			ID:        s.ID,
+			UserAgent: s.UserAgent,
+			IP:        s.IP,
+			Created:   s.Created,
+			LastSeen:  s.LastSeen,
+			Current:   s.ID == sid,
+		})
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
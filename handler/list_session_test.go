@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestListSession(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	now := clock.FixedClocker{}.Now()
+	tests := map[string]struct {
+		sessions entity.Sessions
+		err      error
+		want     want
+	}{
+		"ok": {
+			sessions: entity.Sessions{
+				{
+					ID: "current", UserID: 1, UserAgent: "agent-a", IP: "192.0.2.1",
+					Created: now, LastSeen: now, RefreshFamily: "family-a",
+				},
+				{
+					ID: "other", UserID: 1, UserAgent: "agent-b", IP: "192.0.2.2",
+					Created: now.Add(-time.Hour), LastSeen: now.Add(-time.Minute), RefreshFamily: "family-b",
+				},
+			},
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/list_session/ok_rsp.json.golden",
+			},
+		},
+		"empty": {
+			sessions: entity.Sessions{},
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/list_session/empty_rsp.json.golden",
+			},
+		},
+		"internalServerError": {
+			err: errors.New("error from mock"),
+			want: want{
+				status:  http.StatusInternalServerError,
+				rspFile: "testdata/list_session/status500_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/me/sessions", nil)
+			r = r.WithContext(auth.SetSessionID(r.Context(), "current"))
+
+			// モック準備
+			moq := &ListSessionsServiceMock{}
+			moq.ListSessionsFunc = func(ctx context.Context) (entity.Sessions, error) {
+				return tt.sessions, tt.err
+			}
+
+			sut := ListSession{Service: moq}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+type ListTag struct {
+	Service ListTagsService
+}
+
+type tag struct {
+	ID   entity.TagID `json:"id"`
+	Name string       `json:"name"`
+}
+
+func (lt *ListTag) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tags, err := lt.Service.ListTags(ctx)
+	if err != nil {
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, newTags(tags), http.StatusOK)
+}
+
+// newTags はentity.Tags型の値をレスポンス用のスライスに変換する
+// タグが存在しない場合もnullではなく空配列とする
+func newTags(tags entity.Tags) []tag {
+	rsp := make([]tag, 0, len(tags))
+	for _, t := range tags {
+		rsp = append(rsp, tag{ID: t.ID, Name: t.Name})
+	}
+	return rsp
+}
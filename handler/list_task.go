@@ -1,9 +1,16 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
 )
 
 type ListTask struct {
@@ -11,27 +18,156 @@ type ListTask struct {
 }
 
 type task struct {
-	ID     entity.TaskID     `json:"id"`
-	Title  string            `json:"title"`
-	Status entity.TaskStatus `json:"status"`
+	ID          entity.TaskID       `json:"id"`
+	ProjectID   *entity.ProjectID   `json:"project_id,omitempty"`
+	ParentID    *entity.TaskID      `json:"parent_id,omitempty"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Status      entity.TaskStatus   `json:"status"`
+	Priority    entity.TaskPriority `json:"priority"`
+	DueAt       *time.Time          `json:"due_at,omitempty"`
+	Recurrence  string              `json:"recurrence,omitempty"` // RRULE形式の繰り返し規則
+	Blocked     bool                `json:"blocked"`              // 未完了のタスクに依存している場合はtrue
+	Tags        []tag               `json:"tags"`
+	Completion  *int                `json:"completion,omitempty"` // 子タスクの完了率(%)
+	Children    []task              `json:"children,omitempty"`
+	DeletedAt   *time.Time          `json:"deleted_at,omitempty"` // ゴミ箱へ移動した日時
 }
 
 func (lt *ListTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	tasks, err := lt.Service.ListTasks(ctx)
+	f, err := parseTaskFilter(r.URL.Query())
 	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	tasks, next, err := lt.Service.ListTasks(ctx, f)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidCursor) {
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+			return
+		}
 		// DB操作が失敗した場合
 		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
 		return
 	}
 
-	rsp := make([]task, 0, len(tasks))
+	respondTasks(ctx, w, tasks, next)
+}
+
+// respondTasks はタスク一覧と次ページ取得用のカーソルをレスポンスとして書き込む
+func respondTasks(ctx context.Context, w http.ResponseWriter, tasks entity.Tasks, next string) {
+	rsp := struct {
+		Tasks      []task `json:"tasks"`
+		NextCursor string `json:"next_cursor,omitempty"`
+	}{
+		Tasks:      make([]task, 0, len(tasks)),
+		NextCursor: next,
+	}
 	for _, t := range tasks {
-		rsp = append(rsp, task{
-			ID:     t.ID,
-			Title:  t.Title,
-			Status: t.Status,
-		})
+		rsp.Tasks = append(rsp.Tasks, newTask(t))
 	}
 	RespondJSON(ctx, w, rsp, http.StatusOK)
 }
+
+// newTask は*entity.Task型の値をレスポンス用の構造体に変換する
+func newTask(t *entity.Task) task {
+	rsp := task{
+		ID:          t.ID,
+		ProjectID:   t.ProjectID,
+		ParentID:    t.ParentID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		DueAt:       t.DueAt,
+		Blocked:     t.Blocked,
+		Tags:        newTags(t.Tags),
+		DeletedAt:   t.DeletedAt,
+	}
+	if t.Recurrence != nil {
+		rsp.Recurrence = t.Recurrence.String()
+	}
+	if t.Progress != nil {
+		c := t.Progress.Percent()
+		rsp.Completion = &c
+	}
+	for _, c := range t.Children {
+		rsp.Children = append(rsp.Children, newTask(c))
+	}
+	return rsp
+}
+
+// parseTaskFilter はクエリパラメータを解析し、タスク一覧の絞り込み条件を生成する
+func parseTaskFilter(q url.Values) (*entity.TaskFilter, error) {
+	f := &entity.TaskFilter{
+		TitlePrefix: q.Get("title_prefix"),
+		Sort:        entity.TaskSortKey(q.Get("sort")),
+		Cursor:      q.Get("cursor"),
+		Tags:        q["tag"],
+	}
+	for _, s := range q["status"] {
+		st := entity.TaskStatus(s)
+		if !st.Valid() {
+			return nil, fmt.Errorf("invalid status %q", s)
+		}
+		f.Statuses = append(f.Statuses, st)
+	}
+	if f.Sort != "" && !f.Sort.Valid() {
+		return nil, fmt.Errorf("invalid sort %q", f.Sort)
+	}
+	for key, dst := range map[string]*time.Time{
+		"created_from":  &f.CreatedFrom,
+		"created_to":    &f.CreatedTo,
+		"modified_from": &f.ModifiedFrom,
+		"modified_to":   &f.ModifiedTo,
+		"due_before":    &f.DueBefore,
+	} {
+		v := q.Get(key)
+		if v == "" {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		*dst = at
+	}
+	switch mode := q.Get("tag_mode"); mode {
+	case "", "any":
+	case "all":
+		f.TagMatchAll = true
+	default:
+		return nil, fmt.Errorf("invalid tag_mode %q: must be any or all", mode)
+	}
+	if v := q.Get("overdue"); v != "" {
+		overdue, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid overdue: %w", err)
+		}
+		f.Overdue = overdue
+	}
+	if v := q.Get("tree"); v != "" {
+		tree, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tree: %w", err)
+		}
+		f.Tree = tree
+	}
+	if v := q.Get("include_archived"); v != "" {
+		include, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include_archived: %w", err)
+		}
+		f.IncludeArchived = include
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > entity.MaxTaskListLimit {
+			return nil, fmt.Errorf("invalid limit %q: must be between 1 and %d", v, entity.MaxTaskListLimit)
+		}
+		f.Limit = limit
+	}
+	return f, nil
+}
@@ -2,36 +2,57 @@ package handler
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/ac0mz/go_todo_app/clock"
 	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
 	"github.com/ac0mz/go_todo_app/testutil"
 )
 
 func TestListTask(t *testing.T) {
+	due := clock.FixedClocker{}.Now()
+	type moq struct {
+		tasks []*entity.Task
+		next  string
+		err   error
+	}
 	type want struct {
 		status  int
 		rspFile string
 	}
 	tests := map[string]struct {
-		tasks []*entity.Task
+		query string
+		moq   moq
 		want  want
 	}{
 		"ok": {
-			tasks: []*entity.Task{
-				{
-					ID:     1,
-					Title:  "test1",
-					Status: entity.TaskStatusTodo,
-				},
-				{
-					ID:     2,
-					Title:  "test2",
-					Status: entity.TaskStatusDone,
+			query: "?status=todo&status=done&sort=-created&limit=2&overdue=true&due_before=2022-08-24T00:00:00Z&tag=backend&tag=urgent-customer&tag_mode=all",
+			moq: moq{
+				tasks: []*entity.Task{
+					{
+						ID:       1,
+						Title:    "test1",
+						Status:   entity.TaskStatusTodo,
+						Priority: entity.TaskPriorityNormal,
+					},
+					{
+						ID:          2,
+						Title:       "test2",
+						Description: "## detail",
+						Status:      entity.TaskStatusDone,
+						Priority:    entity.TaskPriorityUrgent,
+						DueAt:       &due,
+						Tags: entity.Tags{
+							{ID: 1, Name: "backend"},
+							{ID: 2, Name: "urgent-customer"},
+						},
+					},
 				},
+				next: "next_from_moq",
 			},
 			want: want{
 				status:  http.StatusOK,
@@ -39,12 +60,29 @@ func TestListTask(t *testing.T) {
 			},
 		},
 		"empty": {
-			tasks: []*entity.Task{},
+			moq: moq{tasks: []*entity.Task{}},
 			want: want{
 				status:  http.StatusOK,
 				rspFile: "testdata/list_task/empty_rsp.json.golden",
 			},
 		},
+		"badRequest": {
+			query: "?status=archived",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/list_task/bad_req_rsp.json.golden",
+			},
+		},
+		"badCursor": {
+			query: "?sort=-created&cursor=abc",
+			moq: moq{
+				err: fmt.Errorf("failed to list: %w: issued for sort %q", store.ErrInvalidCursor, "created"),
+			},
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/list_task/bad_cursor_rsp.json.golden",
+			},
+		},
 	}
 
 	for n, tt := range tests {
@@ -52,17 +90,12 @@ func TestListTask(t *testing.T) {
 		t.Run(n, func(t *testing.T) {
 			t.Parallel()
 			w := httptest.NewRecorder()
-			r := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+			r := httptest.NewRequest(http.MethodGet, "/tasks"+tt.query, nil)
 
 			// モック準備
 			moq := &ListTasksServiceMock{}
-			moq.ListTasksFunc = func(ctx context.Context) (entity.Tasks, error) {
-				// 正常系の戻り値
-				if tt.tasks != nil {
-					return tt.tasks, nil
-				}
-				// 異常系の戻り値
-				return nil, errors.New("error from mock")
+			moq.ListTasksFunc = func(ctx context.Context, f *entity.TaskFilter) (entity.Tasks, string, error) {
+				return tt.moq.tasks, tt.moq.next, tt.moq.err
 			}
 
 			sut := ListTask{Service: moq}
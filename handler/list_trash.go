@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"net/http"
+)
+
+type ListTrash struct {
+	Service ListTrashService
+}
+
+func (lt *ListTrash) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tasks, err := lt.Service.ListTrash(ctx)
+	if err != nil {
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	respondTasks(ctx, w, tasks, "")
+}
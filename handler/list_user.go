@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// ListUser は管理者がユーザを検索し、一覧を取得するハンドラ
+type ListUser struct {
+	Service ListUsersService
+}
+
+// adminUser は管理者に返却するユーザ情報
+// パスワードのハッシュ値とTOTPのシークレットは含めない
+type adminUser struct {
+	ID                    entity.UserID `json:"id"`
+	Name                  string        `json:"name"`
+	Email                 *string       `json:"email,omitempty"`
+	Role                  entity.Role   `json:"role"`
+	DisplayName           string        `json:"display_name"`
+	TimeZone              string        `json:"time_zone"`
+	Locale                string        `json:"locale"`
+	TOTPEnabled           bool          `json:"totp_enabled"`
+	PasswordResetRequired bool          `json:"password_reset_required"`
+	Disabled              bool          `json:"disabled"`
+	DisabledAt            *time.Time    `json:"disabled_at,omitempty"`
+	Created               time.Time     `json:"created"`
+	Modified              time.Time     `json:"modified"`
+}
+
+func newAdminUser(u *entity.User) adminUser {
+	return adminUser{
+		ID:                    u.ID,
+		Name:                  u.Name,
+		Email:                 u.Email,
+		Role:                  u.Role,
+		DisplayName:           u.DisplayName,
+		TimeZone:              u.TimeZone,
+		Locale:                u.Locale,
+		TOTPEnabled:           u.TOTPEnabled,
+		PasswordResetRequired: u.PasswordResetRequired,
+		Disabled:              u.Disabled(),
+		DisabledAt:            u.DisabledAt,
+		Created:               u.Created,
+		Modified:              u.Modified,
+	}
+}
+
+func (lu *ListUser) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	f, err := parseUserFilter(r.URL.Query())
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	users, next, err := lu.Service.ListUsers(ctx, f)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidCursor) {
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+			return
+		}
+		// DB操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+
+	rsp := struct {
+		Users      []adminUser `json:"users"`
+		NextCursor string      `json:"next_cursor,omitempty"`
+	}{
+		Users:      make([]adminUser, 0, len(users)),
+		NextCursor: next,
+	}
+	for _, u := range users {
+		rsp.Users = append(rsp.Users, newAdminUser(u))
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
+
+// parseUserFilter はクエリパラメータを解析し、ユーザ一覧の絞り込み条件を生成する
+func parseUserFilter(q url.Values) (*entity.UserFilter, error) {
+	f := &entity.UserFilter{
+		Query:  q.Get("q"),
+		Cursor: q.Get("cursor"),
+	}
+	if v := q.Get("role"); v != "" {
+		role, err := entity.ParseRole(v)
+		if err != nil {
+			return nil, err
+		}
+		f.Role = role
+	}
+	if v := q.Get("disabled"); v != "" {
+		disabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid disabled: %w", err)
+		}
+		f.Disabled = &disabled
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > entity.MaxUserListLimit {
+			return nil, fmt.Errorf("invalid limit %q: must be between 1 and %d", v, entity.MaxUserListLimit)
+		}
+		f.Limit = limit
+	}
+	return f, nil
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListUser(t *testing.T) {
+	now := clock.FixedClocker{}.Now()
+	email := "alice@example.com"
+	type moq struct {
+		users entity.Users
+		next  string
+		err   error
+	}
+	type want struct {
+		status  int
+		rspFile string
+		filter  *entity.UserFilter
+	}
+	disabled := true
+	tests := map[string]struct {
+		query string
+		moq   moq
+		want  want
+	}{
+		"ok": {
+			query: "?q=ali&role=user&disabled=true&limit=2",
+			moq: moq{
+				users: entity.Users{
+					{
+						ID: 1, Name: "alice", Password: "hashed", Role: entity.RoleUser, Email: &email,
+						DisplayName: "Alice", TimeZone: "Asia/Tokyo", Locale: "ja-JP",
+						DisabledAt: &now, Created: now, Modified: now,
+					},
+					{
+						ID: 2, Name: "alicia", Password: "hashed", Role: entity.RoleUser,
+						TimeZone: "UTC", Locale: "en-US", TOTPEnabled: true, PasswordResetRequired: true,
+						Created: now, Modified: now,
+					},
+				},
+				next: "next_from_moq",
+			},
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/list_user/ok_rsp.json.golden",
+				filter:  &entity.UserFilter{Query: "ali", Role: entity.RoleUser, Disabled: &disabled, Limit: 2},
+			},
+		},
+		"empty": {
+			moq: moq{users: entity.Users{}},
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/list_user/empty_rsp.json.golden",
+				filter:  &entity.UserFilter{},
+			},
+		},
+		"badRequest": {
+			query: "?limit=101",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/list_user/bad_req_rsp.json.golden",
+			},
+		},
+		"badCursor": {
+			query: "?cursor=abc",
+			moq: moq{
+				err: fmt.Errorf("failed to list users: %w", store.ErrInvalidCursor),
+			},
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/list_user/bad_cursor_rsp.json.golden",
+				filter:  &entity.UserFilter{Cursor: "abc"},
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/admin/users"+tt.query, nil)
+
+			// モック準備
+			moq := &ListUsersServiceMock{}
+			moq.ListUsersFunc = func(ctx context.Context, f *entity.UserFilter) (entity.Users, string, error) {
+				if d := cmp.Diff(f, tt.want.filter); d != "" {
+					t.Errorf("unexpected filter: (-got +want)\n%s", d)
+				}
+				return tt.moq.users, tt.moq.next, tt.moq.err
+			}
+
+			sut := ListUser{Service: moq}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
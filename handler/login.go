@@ -2,8 +2,14 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/service"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -17,6 +23,8 @@ func (l *Login) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var body struct {
 		UserName string `json:"user_name" validate:"required"`
 		Password string `json:"password" validate:"required"`
+		// パスワードの再設定が必要なユーザの場合に指定する新しいパスワード
+		NewPassword string `json:"new_password"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
@@ -29,16 +37,57 @@ func (l *Login) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// ログイン
-	jwt, err := l.Service.Login(ctx, body.UserName, body.Password)
+	result, err := l.Service.Login(ctx, body.UserName, body.Password, body.NewPassword, clientIP(r))
 	if err != nil {
-		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		var blocked *service.LoginBlockedError
+		switch {
+		case errors.As(err, &blocked):
+			// ログイン試行の制限中の場合
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+			RespondJSON(ctx, w, &ErrResponse{Message: "too many login attempts"}, http.StatusTooManyRequests)
+		case errors.Is(err, service.ErrInvalidCredentials):
+			// ユーザ名の存在有無を推測させないため、誤りの内容は返却しない
+			RespondJSON(ctx, w, &ErrResponse{Message: service.ErrInvalidCredentials.Error()}, http.StatusUnauthorized)
+		case errors.Is(err, service.ErrPasswordResetRequired):
+			// 新しいパスワードを指定して再度ログインさせる
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusForbidden)
+		case errors.Is(err, auth.ErrUserDisabled):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusForbidden)
+		case errors.Is(err, service.ErrWeakPassword):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		default:
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	if result.Challenge != "" {
+		// 二要素認証が有効な場合、チャレンジとコードをPOST /login/2faへ送信させる
+		rsp := struct {
+			TwoFactorRequired bool   `json:"two_factor_required"`
+			Challenge         string `json:"challenge"`
+		}{TwoFactorRequired: true, Challenge: result.Challenge}
+		RespondJSON(ctx, w, rsp, http.StatusOK)
 		return
 	}
+	RespondJSON(ctx, w, newTokenPair(result.Tokens), http.StatusOK)
+}
 
-	rsp := struct {
-		AccessToken string `json:"access_token"`
-	}{
-		AccessToken: jwt,
+type tokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// newTokenPair は*auth.TokenPair型の値をレスポンス用の構造体に変換する
+func newTokenPair(t *auth.TokenPair) tokenPair {
+	return tokenPair{AccessToken: t.AccessToken, RefreshToken: t.RefreshToken}
+}
+
+// clientIP はリクエスト送信元のIPアドレスを返却する
+// 詐称可能なX-Forwarded-For等のヘッダーは参照しない
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
 	}
-	RespondJSON(ctx, w, &rsp, http.StatusOK)
+	return host
 }
@@ -7,19 +7,23 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/service"
 	"github.com/ac0mz/go_todo_app/testutil"
 	"github.com/go-playground/validator/v10"
 )
 
 func TestLogin_ServeHTTP(t *testing.T) {
 	type moq struct {
-		token string
-		err   error
+		result *service.LoginResult
+		err    error
 	}
 	type want struct {
-		status  int
-		rspFile string
+		status     int
+		rspFile    string
+		retryAfter string
 	}
 
 	tests := map[string]struct {
@@ -30,13 +34,25 @@ func TestLogin_ServeHTTP(t *testing.T) {
 		"ok": {
 			reqFile: "testdata/login/status200_req.json.golden",
 			moq: moq{
-				token: "from_moq",
+				result: &service.LoginResult{
+					Tokens: &auth.TokenPair{AccessToken: "from_moq", RefreshToken: "refresh_from_moq"},
+				},
 			},
 			want: want{
 				status:  http.StatusOK,
 				rspFile: "testdata/login/status200_rsp.json.golden",
 			},
 		},
+		"twoFactorRequired": {
+			reqFile: "testdata/login/status200_req.json.golden",
+			moq: moq{
+				result: &service.LoginResult{Challenge: "challenge_from_moq"},
+			},
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/login/status200_2fa_rsp.json.golden",
+			},
+		},
 		"badRequest": {
 			reqFile: "testdata/login/status400_req.json.golden",
 			want: want{
@@ -44,6 +60,47 @@ func TestLogin_ServeHTTP(t *testing.T) {
 				rspFile: "testdata/login/status400_rsp.json.golden",
 			},
 		},
+		"invalidCredentials": {
+			reqFile: "testdata/login/status200_req.json.golden",
+			moq: moq{
+				err: service.ErrInvalidCredentials,
+			},
+			want: want{
+				status:  http.StatusUnauthorized,
+				rspFile: "testdata/login/status401_rsp.json.golden",
+			},
+		},
+		"tooManyRequests": {
+			reqFile: "testdata/login/status200_req.json.golden",
+			moq: moq{
+				err: &service.LoginBlockedError{RetryAfter: 1500 * time.Millisecond},
+			},
+			want: want{
+				status:     http.StatusTooManyRequests,
+				rspFile:    "testdata/login/status429_rsp.json.golden",
+				retryAfter: "2",
+			},
+		},
+		"passwordResetRequired": {
+			reqFile: "testdata/login/status200_req.json.golden",
+			moq: moq{
+				err: service.ErrPasswordResetRequired,
+			},
+			want: want{
+				status:  http.StatusForbidden,
+				rspFile: "testdata/login/status403_rsp.json.golden",
+			},
+		},
+		"userDisabled": {
+			reqFile: "testdata/login/status200_req.json.golden",
+			moq: moq{
+				err: auth.ErrUserDisabled,
+			},
+			want: want{
+				status:  http.StatusForbidden,
+				rspFile: "testdata/login/status403_disabled_rsp.json.golden",
+			},
+		},
 		"internalServerError": {
 			reqFile: "testdata/login/status200_req.json.golden",
 			moq: moq{
@@ -62,8 +119,12 @@ func TestLogin_ServeHTTP(t *testing.T) {
 
 			// モック設定
 			moq := &LoginServiceMock{}
-			moq.LoginFunc = func(ctx context.Context, name string, password string) (string, error) {
-				return tt.moq.token, tt.moq.err
+			moq.LoginFunc = func(ctx context.Context, name, password, newPassword, ip string) (*service.LoginResult, error) {
+				// httptest.NewRequestの送信元アドレスは192.0.2.1:1234
+				if ip != "192.0.2.1" {
+					t.Errorf("want client ip %q, but got %q", "192.0.2.1", ip)
+				}
+				return tt.moq.result, tt.moq.err
 			}
 
 			w := httptest.NewRecorder()
@@ -80,6 +141,9 @@ func TestLogin_ServeHTTP(t *testing.T) {
 			// 実行と検証
 			sut.ServeHTTP(w, r)
 			rsp := w.Result()
+			if got := rsp.Header.Get("Retry-After"); got != tt.want.retryAfter {
+				t.Errorf("want Retry-After %q, but got %q", tt.want.retryAfter, got)
+			}
 			testutil.AssertResponse(t, rsp, tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
 		})
 	}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/go-playground/validator/v10"
+)
+
+// LoginTwoFactor はログイン時に発行したチャレンジと二要素認証のコードを検証し、トークンを発行するハンドラ
+type LoginTwoFactor struct {
+	Service   LoginTwoFactorService
+	Validator *validator.Validate
+}
+
+func (lt *LoginTwoFactor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Challenge string `json:"challenge" validate:"required"`
+		// TOTPのコード、またはリカバリーコード
+		Code string `json:"code" validate:"required"`
+		// パスワードの再設定が必要なユーザの場合に指定する新しいパスワード
+		NewPassword string `json:"new_password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := lt.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := lt.Service.LoginTwoFactor(ctx, b.Challenge, b.Code, b.NewPassword, clientIP(r))
+	if err != nil {
+		var blocked *service.LoginBlockedError
+		switch {
+		case errors.As(err, &blocked):
+			// ログイン試行の制限中の場合
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+			RespondJSON(ctx, w, &ErrResponse{Message: "too many login attempts"}, http.StatusTooManyRequests)
+		case errors.Is(err, service.ErrInvalidChallenge), errors.Is(err, service.ErrInvalidTwoFactorCode):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusUnauthorized)
+		case errors.Is(err, service.ErrPasswordResetRequired):
+			// チャレンジは消費していないため、新しいパスワードを指定して再度送信させる
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusForbidden)
+		case errors.Is(err, auth.ErrUserDisabled):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusForbidden)
+		case errors.Is(err, service.ErrWeakPassword):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		default:
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	RespondJSON(ctx, w, newTokenPair(tokens), http.StatusOK)
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestLoginTwoFactor(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		err     error
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/login_two_factor/ok_req.json.golden",
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/login_two_factor/ok_rsp.json.golden",
+			},
+		},
+		"badRequest": {
+			reqFile: "testdata/login_two_factor/bad_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/login_two_factor/bad_rsp.json.golden",
+			},
+		},
+		"invalidCode": {
+			reqFile: "testdata/login_two_factor/ok_req.json.golden",
+			err:     service.ErrInvalidTwoFactorCode,
+			want: want{
+				status:  http.StatusUnauthorized,
+				rspFile: "testdata/login_two_factor/invalid_code_rsp.json.golden",
+			},
+		},
+		"invalidChallenge": {
+			reqFile: "testdata/login_two_factor/ok_req.json.golden",
+			err:     service.ErrInvalidChallenge,
+			want: want{
+				status:  http.StatusUnauthorized,
+				rspFile: "testdata/login_two_factor/invalid_challenge_rsp.json.golden",
+			},
+		},
+		"resetRequired": {
+			reqFile: "testdata/login_two_factor/ok_req.json.golden",
+			err:     service.ErrPasswordResetRequired,
+			want: want{
+				status:  http.StatusForbidden,
+				rspFile: "testdata/login_two_factor/reset_required_rsp.json.golden",
+			},
+		},
+		"weakPassword": {
+			reqFile: "testdata/login_two_factor/ok_req.json.golden",
+			err:     fmt.Errorf("%w: too common", service.ErrWeakPassword),
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/login_two_factor/weak_rsp.json.golden",
+			},
+		},
+		"blocked": {
+			reqFile: "testdata/login_two_factor/ok_req.json.golden",
+			err:     &service.LoginBlockedError{RetryAfter: 90 * time.Second},
+			want: want{
+				status:  http.StatusTooManyRequests,
+				rspFile: "testdata/login_two_factor/blocked_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/login/2fa",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+
+			// モック準備
+			moq := &LoginTwoFactorServiceMock{}
+			moq.LoginTwoFactorFunc = func(ctx context.Context, challenge, code, newPassword, ip string) (*auth.TokenPair, error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &auth.TokenPair{AccessToken: "from_moq", RefreshToken: "refresh_from_moq"}, nil
+			}
+
+			sut := LoginTwoFactor{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			if tt.want.status == http.StatusTooManyRequests && res.Header.Get("Retry-After") != "90" {
+				t.Errorf("want Retry-After %q, but got %q", "90", res.Header.Get("Retry-After"))
+			}
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
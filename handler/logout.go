@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"net/http"
+)
+
+type Logout struct {
+	Service LogoutService
+	All     bool // trueの場合はログインユーザのすべてのセッションを失効させる
+}
+
+func (l *Logout) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := l.Service.Logout(ctx, l.All); err != nil {
+		// Redis操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	rsp := struct {
+		Message string `json:"message"`
+	}{Message: "logged out"}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestLogout(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		all  bool
+		err  error
+		want want
+	}{
+		"ok": {
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/logout/ok_rsp.json.golden",
+			},
+		},
+		"all": {
+			all: true,
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/logout/ok_rsp.json.golden",
+			},
+		},
+		"internalServerError": {
+			err: errors.New("error from mock"),
+			want: want{
+				status:  http.StatusInternalServerError,
+				rspFile: "testdata/logout/status500_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/logout", nil)
+
+			// モック準備
+			moq := &LogoutServiceMock{}
+			moq.LogoutFunc = func(ctx context.Context, all bool) error {
+				if all != tt.all {
+					t.Errorf("want all = %v, but got %v", tt.all, all)
+				}
+				return tt.err
+			}
+
+			sut := Logout{Service: moq, All: tt.all}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
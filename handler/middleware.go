@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 
 	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
 )
 
 // AuthMiddleware はcontext.Context型の値にユーザ情報を埋め込むミドルウェア
@@ -14,6 +18,11 @@ func AuthMiddleware(j *auth.JWTer) func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// ユーザIDおよびロール権限をcontext.Context型の値に設定した*http.Request型の値を取得
 			req, err := j.FillContext(r)
+			if errors.Is(err, auth.ErrUserDisabled) {
+				// 無効化済のユーザは、有効なトークンを保持していても拒否する
+				RespondJSON(r.Context(), w, ErrResponse{Message: auth.ErrUserDisabled.Error()}, http.StatusForbidden)
+				return
+			}
 			if err != nil {
 				RespondJSON(r.Context(), w, ErrResponse{
 					Message: "not find auth info",
@@ -21,11 +30,27 @@ func AuthMiddleware(j *auth.JWTer) func(next http.Handler) http.Handler {
 				}, http.StatusUnauthorized)
 				return
 			}
+			// セッションの最終アクセス日時を更新する。パーソナルアクセストークンにはセッションIDが設定されていない
+			// 記録に失敗しても認証自体は成功しているため、リクエストは継続する
+			if sid, ok := auth.GetSessionID(req.Context()); ok {
+				if err := j.TouchSession(req.Context(), sid); err != nil {
+					log.Printf("failed to touch session: %v", err)
+				}
+			}
 			next.ServeHTTP(w, req)
 		})
 	}
 }
 
+// ClientMiddleware はcontext.Context型の値にリクエスト元のUser-AgentとIPアドレスを埋め込むミドルウェア
+// トークンの発行時にセッション情報として記録される
+func ClientMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := auth.SetClient(r.Context(), r.UserAgent(), clientIP(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // AdminMiddleware はロール権限を確認するミドルウェア
 // context.Context型の値にユーザ情報が埋め込まれていることが前提で呼び出される想定
 func AdminMiddleware(next http.Handler) http.Handler {
@@ -40,3 +65,55 @@ func AdminMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// RequirePermission はログインユーザのロールに指定した操作が許可されていることを確認するミドルウェアを返却する
+// context.Context型の値にユーザ情報が埋め込まれていることが前提で呼び出される想定
+func RequirePermission(p entity.Permission) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !auth.HasPermission(r.Context(), p) {
+				RespondJSON(r.Context(), w, ErrResponse{
+					Message: "permission denied",
+					Details: []string{"required: " + string(p)},
+				}, http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireScopes はトークンに指定したスコープがすべて許可されていることを確認するミドルウェアを返却する
+// AuthMiddlewareの後に適用することが前提で呼び出される想定
+func RequireScopes(scopes ...entity.Scope) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return &scopedHandler{scopes: scopes, next: next}
+	}
+}
+
+// scopedHandler はRequireScopesで必要なスコープを宣言したハンドラ
+type scopedHandler struct {
+	scopes entity.Scopes
+	next   http.Handler
+}
+
+// RequiredScopes はハンドラの呼び出しに必要なスコープを返却する
+// ルーティングの設定漏れを検出するため、テストからの参照に使用する
+func (s *scopedHandler) RequiredScopes() entity.Scopes {
+	return s.scopes
+}
+
+func (s *scopedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	granted, ok := auth.GetScopes(r.Context())
+	if !ok || !granted.Contains(s.scopes...) {
+		// RFC 6750に従い、不足しているスコープをレスポンスヘッダーで通知する
+		w.Header().Set("WWW-Authenticate",
+			fmt.Sprintf(`Bearer error="insufficient_scope", scope=%q`, s.scopes.String()))
+		RespondJSON(r.Context(), w, ErrResponse{
+			Message: "insufficient scope",
+			Details: []string{"required: " + s.scopes.String()},
+		}, http.StatusForbidden)
+		return
+	}
+	s.next.ServeHTTP(w, r)
+}
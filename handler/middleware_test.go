@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestRequireScopes(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		scopes entity.Scopes // nilの場合はcontext.Contextにスコープを設定しない
+		want   want
+	}{
+		"ok": {
+			scopes: entity.Scopes{entity.ScopeTasksRead, entity.ScopeTasksWrite},
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/require_scopes/ok_rsp.json.golden",
+			},
+		},
+		"readOnly": {
+			scopes: entity.Scopes{entity.ScopeTasksRead},
+			want: want{
+				status:  http.StatusForbidden,
+				rspFile: "testdata/require_scopes/forbidden_rsp.json.golden",
+			},
+		},
+		"noScopes": {
+			want: want{
+				status:  http.StatusForbidden,
+				rspFile: "testdata/require_scopes/forbidden_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/tasks", nil)
+			if tt.scopes != nil {
+				r = r.WithContext(auth.SetScopes(context.Background(), tt.scopes))
+			}
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				RespondJSON(r.Context(), w, ErrResponse{Message: "ok"}, http.StatusOK)
+			})
+			sut := RequireScopes(entity.ScopeTasksWrite)(next)
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			if tt.want.status == http.StatusForbidden && res.Header.Get("WWW-Authenticate") == "" {
+				t.Error("want WWW-Authenticate header")
+			}
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
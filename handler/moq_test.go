@@ -5,8 +5,13 @@ package handler
 
 import (
 	"context"
+	"github.com/ac0mz/go_todo_app/auth"
 	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"io"
 	"sync"
+	"time"
 )
 
 // Ensure, that ListTasksServiceMock does implement ListTasksService.
@@ -19,7 +24,7 @@ var _ ListTasksService = &ListTasksServiceMock{}
 //
 //		// make and configure a mocked ListTasksService
 //		mockedListTasksService := &ListTasksServiceMock{
-//			ListTasksFunc: func(ctx context.Context) (entity.Tasks, error) {
+//			ListTasksFunc: func(ctx context.Context, f *entity.TaskFilter) (entity.Tasks, string, error) {
 //				panic("mock out the ListTasks method")
 //			},
 //		}
@@ -30,7 +35,7 @@ var _ ListTasksService = &ListTasksServiceMock{}
 //	}
 type ListTasksServiceMock struct {
 	// ListTasksFunc mocks the ListTasks method.
-	ListTasksFunc func(ctx context.Context) (entity.Tasks, error)
+	ListTasksFunc func(ctx context.Context, f *entity.TaskFilter) (entity.Tasks, string, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -38,25 +43,29 @@ type ListTasksServiceMock struct {
 		ListTasks []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// F is the f argument value.
+			F *entity.TaskFilter
 		}
 	}
 	lockListTasks sync.RWMutex
 }
 
 // ListTasks calls ListTasksFunc.
-func (mock *ListTasksServiceMock) ListTasks(ctx context.Context) (entity.Tasks, error) {
+func (mock *ListTasksServiceMock) ListTasks(ctx context.Context, f *entity.TaskFilter) (entity.Tasks, string, error) {
 	if mock.ListTasksFunc == nil {
 		panic("ListTasksServiceMock.ListTasksFunc: method is nil but ListTasksService.ListTasks was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
+		F   *entity.TaskFilter
 	}{
 		Ctx: ctx,
+		F:   f,
 	}
 	mock.lockListTasks.Lock()
 	mock.calls.ListTasks = append(mock.calls.ListTasks, callInfo)
 	mock.lockListTasks.Unlock()
-	return mock.ListTasksFunc(ctx)
+	return mock.ListTasksFunc(ctx, f)
 }
 
 // ListTasksCalls gets all the calls that were made to ListTasks.
@@ -65,9 +74,11 @@ func (mock *ListTasksServiceMock) ListTasks(ctx context.Context) (entity.Tasks,
 //	len(mockedListTasksService.ListTasksCalls())
 func (mock *ListTasksServiceMock) ListTasksCalls() []struct {
 	Ctx context.Context
+	F   *entity.TaskFilter
 } {
 	var calls []struct {
 		Ctx context.Context
+		F   *entity.TaskFilter
 	}
 	mock.lockListTasks.RLock()
 	calls = mock.calls.ListTasks
@@ -85,7 +96,7 @@ var _ AddTaskService = &AddTaskServiceMock{}
 //
 //		// make and configure a mocked AddTaskService
 //		mockedAddTaskService := &AddTaskServiceMock{
-//			AddTaskFunc: func(ctx context.Context, title string) (*entity.Task, error) {
+//			AddTaskFunc: func(ctx context.Context, t *entity.Task) error {
 //				panic("mock out the AddTask method")
 //			},
 //		}
@@ -96,7 +107,7 @@ var _ AddTaskService = &AddTaskServiceMock{}
 //	}
 type AddTaskServiceMock struct {
 	// AddTaskFunc mocks the AddTask method.
-	AddTaskFunc func(ctx context.Context, title string) (*entity.Task, error)
+	AddTaskFunc func(ctx context.Context, t *entity.Task) error
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -104,29 +115,29 @@ type AddTaskServiceMock struct {
 		AddTask []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Title is the title argument value.
-			Title string
+			// T is the t argument value.
+			T *entity.Task
 		}
 	}
 	lockAddTask sync.RWMutex
 }
 
 // AddTask calls AddTaskFunc.
-func (mock *AddTaskServiceMock) AddTask(ctx context.Context, title string) (*entity.Task, error) {
+func (mock *AddTaskServiceMock) AddTask(ctx context.Context, t *entity.Task) error {
 	if mock.AddTaskFunc == nil {
 		panic("AddTaskServiceMock.AddTaskFunc: method is nil but AddTaskService.AddTask was just called")
 	}
 	callInfo := struct {
-		Ctx   context.Context
-		Title string
+		Ctx context.Context
+		T   *entity.Task
 	}{
-		Ctx:   ctx,
-		Title: title,
+		Ctx: ctx,
+		T:   t,
 	}
 	mock.lockAddTask.Lock()
 	mock.calls.AddTask = append(mock.calls.AddTask, callInfo)
 	mock.lockAddTask.Unlock()
-	return mock.AddTaskFunc(ctx, title)
+	return mock.AddTaskFunc(ctx, t)
 }
 
 // AddTaskCalls gets all the calls that were made to AddTask.
@@ -134,177 +145,3879 @@ func (mock *AddTaskServiceMock) AddTask(ctx context.Context, title string) (*ent
 //
 //	len(mockedAddTaskService.AddTaskCalls())
 func (mock *AddTaskServiceMock) AddTaskCalls() []struct {
+	Ctx context.Context
+	T   *entity.Task
+} {
+	var calls []struct {
+		Ctx context.Context
+		T   *entity.Task
+	}
+	mock.lockAddTask.RLock()
+	calls = mock.calls.AddTask
+	mock.lockAddTask.RUnlock()
+	return calls
+}
+
+// Ensure, that GetTaskServiceMock does implement GetTaskService.
+// If this is not the case, regenerate this file with moq.
+var _ GetTaskService = &GetTaskServiceMock{}
+
+// GetTaskServiceMock is a mock implementation of GetTaskService.
+//
+//	func TestSomethingThatUsesGetTaskService(t *testing.T) {
+//
+//		// make and configure a mocked GetTaskService
+//		mockedGetTaskService := &GetTaskServiceMock{
+//			GetTaskFunc: func(ctx context.Context, id entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the GetTask method")
+//			},
+//		}
+//
+//		// use mockedGetTaskService in code that requires GetTaskService
+//		// and then make assertions.
+//
+//	}
+type GetTaskServiceMock struct {
+	// GetTaskFunc mocks the GetTask method.
+	GetTaskFunc func(ctx context.Context, id entity.TaskID) (*entity.Task, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetTask holds details about calls to the GetTask method.
+		GetTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+	}
+	lockGetTask sync.RWMutex
+}
+
+// GetTask calls GetTaskFunc.
+func (mock *GetTaskServiceMock) GetTask(ctx context.Context, id entity.TaskID) (*entity.Task, error) {
+	if mock.GetTaskFunc == nil {
+		panic("GetTaskServiceMock.GetTaskFunc: method is nil but GetTaskService.GetTask was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetTask.Lock()
+	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
+	mock.lockGetTask.Unlock()
+	return mock.GetTaskFunc(ctx, id)
+}
+
+// GetTaskCalls gets all the calls that were made to GetTask.
+// Check the length with:
+//
+//	len(mockedGetTaskService.GetTaskCalls())
+func (mock *GetTaskServiceMock) GetTaskCalls() []struct {
+	Ctx context.Context
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.TaskID
+	}
+	mock.lockGetTask.RLock()
+	calls = mock.calls.GetTask
+	mock.lockGetTask.RUnlock()
+	return calls
+}
+
+// Ensure, that UpdateTaskServiceMock does implement UpdateTaskService.
+// If this is not the case, regenerate this file with moq.
+var _ UpdateTaskService = &UpdateTaskServiceMock{}
+
+// UpdateTaskServiceMock is a mock implementation of UpdateTaskService.
+//
+//	func TestSomethingThatUsesUpdateTaskService(t *testing.T) {
+//
+//		// make and configure a mocked UpdateTaskService
+//		mockedUpdateTaskService := &UpdateTaskServiceMock{
+//			UpdateTaskFunc: func(ctx context.Context, id entity.TaskID, title string) (*entity.Task, error) {
+//				panic("mock out the UpdateTask method")
+//			},
+//		}
+//
+//		// use mockedUpdateTaskService in code that requires UpdateTaskService
+//		// and then make assertions.
+//
+//	}
+type UpdateTaskServiceMock struct {
+	// UpdateTaskFunc mocks the UpdateTask method.
+	UpdateTaskFunc func(ctx context.Context, id entity.TaskID, title string) (*entity.Task, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// UpdateTask holds details about calls to the UpdateTask method.
+		UpdateTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TaskID
+			// Title is the title argument value.
+			Title string
+		}
+	}
+	lockUpdateTask sync.RWMutex
+}
+
+// UpdateTask calls UpdateTaskFunc.
+func (mock *UpdateTaskServiceMock) UpdateTask(ctx context.Context, id entity.TaskID, title string) (*entity.Task, error) {
+	if mock.UpdateTaskFunc == nil {
+		panic("UpdateTaskServiceMock.UpdateTaskFunc: method is nil but UpdateTaskService.UpdateTask was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ID    entity.TaskID
+		Title string
+	}{
+		Ctx:   ctx,
+		ID:    id,
+		Title: title,
+	}
+	mock.lockUpdateTask.Lock()
+	mock.calls.UpdateTask = append(mock.calls.UpdateTask, callInfo)
+	mock.lockUpdateTask.Unlock()
+	return mock.UpdateTaskFunc(ctx, id, title)
+}
+
+// UpdateTaskCalls gets all the calls that were made to UpdateTask.
+// Check the length with:
+//
+//	len(mockedUpdateTaskService.UpdateTaskCalls())
+func (mock *UpdateTaskServiceMock) UpdateTaskCalls() []struct {
 	Ctx   context.Context
+	ID    entity.TaskID
 	Title string
 } {
 	var calls []struct {
 		Ctx   context.Context
+		ID    entity.TaskID
 		Title string
 	}
-	mock.lockAddTask.RLock()
-	calls = mock.calls.AddTask
-	mock.lockAddTask.RUnlock()
+	mock.lockUpdateTask.RLock()
+	calls = mock.calls.UpdateTask
+	mock.lockUpdateTask.RUnlock()
 	return calls
 }
 
-// Ensure, that RegisterUserServiceMock does implement RegisterUserService.
+// Ensure, that DeleteTaskServiceMock does implement DeleteTaskService.
 // If this is not the case, regenerate this file with moq.
-var _ RegisterUserService = &RegisterUserServiceMock{}
+var _ DeleteTaskService = &DeleteTaskServiceMock{}
 
-// RegisterUserServiceMock is a mock implementation of RegisterUserService.
+// DeleteTaskServiceMock is a mock implementation of DeleteTaskService.
 //
-//	func TestSomethingThatUsesRegisterUserService(t *testing.T) {
+//	func TestSomethingThatUsesDeleteTaskService(t *testing.T) {
 //
-//		// make and configure a mocked RegisterUserService
-//		mockedRegisterUserService := &RegisterUserServiceMock{
-//			RegisterUserFunc: func(ctx context.Context, name string, password string, role string) (*entity.User, error) {
-//				panic("mock out the RegisterUser method")
+//		// make and configure a mocked DeleteTaskService
+//		mockedDeleteTaskService := &DeleteTaskServiceMock{
+//			DeleteTaskFunc: func(ctx context.Context, id entity.TaskID) error {
+//				panic("mock out the DeleteTask method")
 //			},
 //		}
 //
-//		// use mockedRegisterUserService in code that requires RegisterUserService
+//		// use mockedDeleteTaskService in code that requires DeleteTaskService
 //		// and then make assertions.
 //
 //	}
-type RegisterUserServiceMock struct {
-	// RegisterUserFunc mocks the RegisterUser method.
-	RegisterUserFunc func(ctx context.Context, name string, password string, role string) (*entity.User, error)
+type DeleteTaskServiceMock struct {
+	// DeleteTaskFunc mocks the DeleteTask method.
+	DeleteTaskFunc func(ctx context.Context, id entity.TaskID) error
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// RegisterUser holds details about calls to the RegisterUser method.
-		RegisterUser []struct {
+		// DeleteTask holds details about calls to the DeleteTask method.
+		DeleteTask []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Name is the name argument value.
-			Name string
-			// Password is the password argument value.
-			Password string
-			// Role is the role argument value.
-			Role string
+			// ID is the id argument value.
+			ID entity.TaskID
 		}
 	}
-	lockRegisterUser sync.RWMutex
+	lockDeleteTask sync.RWMutex
 }
 
-// RegisterUser calls RegisterUserFunc.
-func (mock *RegisterUserServiceMock) RegisterUser(ctx context.Context, name string, password string, role string) (*entity.User, error) {
-	if mock.RegisterUserFunc == nil {
-		panic("RegisterUserServiceMock.RegisterUserFunc: method is nil but RegisterUserService.RegisterUser was just called")
+// DeleteTask calls DeleteTaskFunc.
+func (mock *DeleteTaskServiceMock) DeleteTask(ctx context.Context, id entity.TaskID) error {
+	if mock.DeleteTaskFunc == nil {
+		panic("DeleteTaskServiceMock.DeleteTaskFunc: method is nil but DeleteTaskService.DeleteTask was just called")
 	}
 	callInfo := struct {
-		Ctx      context.Context
-		Name     string
-		Password string
-		Role     string
+		Ctx context.Context
+		ID  entity.TaskID
 	}{
-		Ctx:      ctx,
-		Name:     name,
-		Password: password,
-		Role:     role,
+		Ctx: ctx,
+		ID:  id,
 	}
-	mock.lockRegisterUser.Lock()
-	mock.calls.RegisterUser = append(mock.calls.RegisterUser, callInfo)
-	mock.lockRegisterUser.Unlock()
-	return mock.RegisterUserFunc(ctx, name, password, role)
+	mock.lockDeleteTask.Lock()
+	mock.calls.DeleteTask = append(mock.calls.DeleteTask, callInfo)
+	mock.lockDeleteTask.Unlock()
+	return mock.DeleteTaskFunc(ctx, id)
 }
 
-// RegisterUserCalls gets all the calls that were made to RegisterUser.
+// DeleteTaskCalls gets all the calls that were made to DeleteTask.
 // Check the length with:
 //
-//	len(mockedRegisterUserService.RegisterUserCalls())
-func (mock *RegisterUserServiceMock) RegisterUserCalls() []struct {
-	Ctx      context.Context
-	Name     string
-	Password string
-	Role     string
+//	len(mockedDeleteTaskService.DeleteTaskCalls())
+func (mock *DeleteTaskServiceMock) DeleteTaskCalls() []struct {
+	Ctx context.Context
+	ID  entity.TaskID
 } {
 	var calls []struct {
-		Ctx      context.Context
-		Name     string
-		Password string
-		Role     string
+		Ctx context.Context
+		ID  entity.TaskID
 	}
-	mock.lockRegisterUser.RLock()
-	calls = mock.calls.RegisterUser
-	mock.lockRegisterUser.RUnlock()
+	mock.lockDeleteTask.RLock()
+	calls = mock.calls.DeleteTask
+	mock.lockDeleteTask.RUnlock()
 	return calls
 }
 
-// Ensure, that LoginServiceMock does implement LoginService.
+// Ensure, that TransitionTaskServiceMock does implement TransitionTaskService.
 // If this is not the case, regenerate this file with moq.
-var _ LoginService = &LoginServiceMock{}
+var _ TransitionTaskService = &TransitionTaskServiceMock{}
 
-// LoginServiceMock is a mock implementation of LoginService.
+// TransitionTaskServiceMock is a mock implementation of TransitionTaskService.
 //
-//	func TestSomethingThatUsesLoginService(t *testing.T) {
+//	func TestSomethingThatUsesTransitionTaskService(t *testing.T) {
 //
-//		// make and configure a mocked LoginService
-//		mockedLoginService := &LoginServiceMock{
-//			LoginFunc: func(ctx context.Context, name string, password string) (string, error) {
-//				panic("mock out the Login method")
+//		// make and configure a mocked TransitionTaskService
+//		mockedTransitionTaskService := &TransitionTaskServiceMock{
+//			TransitionTaskFunc: func(ctx context.Context, id entity.TaskID, to entity.TaskStatus, force bool) (*entity.Task, error) {
+//				panic("mock out the TransitionTask method")
 //			},
 //		}
 //
-//		// use mockedLoginService in code that requires LoginService
+//		// use mockedTransitionTaskService in code that requires TransitionTaskService
 //		// and then make assertions.
 //
 //	}
-type LoginServiceMock struct {
-	// LoginFunc mocks the Login method.
-	LoginFunc func(ctx context.Context, name string, password string) (string, error)
+type TransitionTaskServiceMock struct {
+	// TransitionTaskFunc mocks the TransitionTask method.
+	TransitionTaskFunc func(ctx context.Context, id entity.TaskID, to entity.TaskStatus, force bool) (*entity.Task, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// Login holds details about calls to the Login method.
-		Login []struct {
+		// TransitionTask holds details about calls to the TransitionTask method.
+		TransitionTask []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Name is the name argument value.
-			Name string
-			// Password is the password argument value.
-			Password string
+			// ID is the id argument value.
+			ID entity.TaskID
+			// To is the to argument value.
+			To entity.TaskStatus
+			// Force is the force argument value.
+			Force bool
 		}
 	}
-	lockLogin sync.RWMutex
+	lockTransitionTask sync.RWMutex
 }
 
-// Login calls LoginFunc.
-func (mock *LoginServiceMock) Login(ctx context.Context, name string, password string) (string, error) {
-	if mock.LoginFunc == nil {
-		panic("LoginServiceMock.LoginFunc: method is nil but LoginService.Login was just called")
+// TransitionTask calls TransitionTaskFunc.
+func (mock *TransitionTaskServiceMock) TransitionTask(ctx context.Context, id entity.TaskID, to entity.TaskStatus, force bool) (*entity.Task, error) {
+	if mock.TransitionTaskFunc == nil {
+		panic("TransitionTaskServiceMock.TransitionTaskFunc: method is nil but TransitionTaskService.TransitionTask was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ID    entity.TaskID
+		To    entity.TaskStatus
+		Force bool
+	}{
+		Ctx:   ctx,
+		ID:    id,
+		To:    to,
+		Force: force,
+	}
+	mock.lockTransitionTask.Lock()
+	mock.calls.TransitionTask = append(mock.calls.TransitionTask, callInfo)
+	mock.lockTransitionTask.Unlock()
+	return mock.TransitionTaskFunc(ctx, id, to, force)
+}
+
+// TransitionTaskCalls gets all the calls that were made to TransitionTask.
+// Check the length with:
+//
+//	len(mockedTransitionTaskService.TransitionTaskCalls())
+func (mock *TransitionTaskServiceMock) TransitionTaskCalls() []struct {
+	Ctx   context.Context
+	ID    entity.TaskID
+	To    entity.TaskStatus
+	Force bool
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ID    entity.TaskID
+		To    entity.TaskStatus
+		Force bool
+	}
+	mock.lockTransitionTask.RLock()
+	calls = mock.calls.TransitionTask
+	mock.lockTransitionTask.RUnlock()
+	return calls
+}
+
+// Ensure, that ListChildTasksServiceMock does implement ListChildTasksService.
+// If this is not the case, regenerate this file with moq.
+var _ ListChildTasksService = &ListChildTasksServiceMock{}
+
+// ListChildTasksServiceMock is a mock implementation of ListChildTasksService.
+//
+//	func TestSomethingThatUsesListChildTasksService(t *testing.T) {
+//
+//		// make and configure a mocked ListChildTasksService
+//		mockedListChildTasksService := &ListChildTasksServiceMock{
+//			ListChildTasksFunc: func(ctx context.Context, id entity.TaskID) (entity.Tasks, error) {
+//				panic("mock out the ListChildTasks method")
+//			},
+//		}
+//
+//		// use mockedListChildTasksService in code that requires ListChildTasksService
+//		// and then make assertions.
+//
+//	}
+type ListChildTasksServiceMock struct {
+	// ListChildTasksFunc mocks the ListChildTasks method.
+	ListChildTasksFunc func(ctx context.Context, id entity.TaskID) (entity.Tasks, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListChildTasks holds details about calls to the ListChildTasks method.
+		ListChildTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+	}
+	lockListChildTasks sync.RWMutex
+}
+
+// ListChildTasks calls ListChildTasksFunc.
+func (mock *ListChildTasksServiceMock) ListChildTasks(ctx context.Context, id entity.TaskID) (entity.Tasks, error) {
+	if mock.ListChildTasksFunc == nil {
+		panic("ListChildTasksServiceMock.ListChildTasksFunc: method is nil but ListChildTasksService.ListChildTasks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockListChildTasks.Lock()
+	mock.calls.ListChildTasks = append(mock.calls.ListChildTasks, callInfo)
+	mock.lockListChildTasks.Unlock()
+	return mock.ListChildTasksFunc(ctx, id)
+}
+
+// ListChildTasksCalls gets all the calls that were made to ListChildTasks.
+// Check the length with:
+//
+//	len(mockedListChildTasksService.ListChildTasksCalls())
+func (mock *ListChildTasksServiceMock) ListChildTasksCalls() []struct {
+	Ctx context.Context
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.TaskID
+	}
+	mock.lockListChildTasks.RLock()
+	calls = mock.calls.ListChildTasks
+	mock.lockListChildTasks.RUnlock()
+	return calls
+}
+
+// Ensure, that MoveTaskServiceMock does implement MoveTaskService.
+// If this is not the case, regenerate this file with moq.
+var _ MoveTaskService = &MoveTaskServiceMock{}
+
+// MoveTaskServiceMock is a mock implementation of MoveTaskService.
+//
+//	func TestSomethingThatUsesMoveTaskService(t *testing.T) {
+//
+//		// make and configure a mocked MoveTaskService
+//		mockedMoveTaskService := &MoveTaskServiceMock{
+//			MoveTaskFunc: func(ctx context.Context, id entity.TaskID, parentID *entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the MoveTask method")
+//			},
+//		}
+//
+//		// use mockedMoveTaskService in code that requires MoveTaskService
+//		// and then make assertions.
+//
+//	}
+type MoveTaskServiceMock struct {
+	// MoveTaskFunc mocks the MoveTask method.
+	MoveTaskFunc func(ctx context.Context, id entity.TaskID, parentID *entity.TaskID) (*entity.Task, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// MoveTask holds details about calls to the MoveTask method.
+		MoveTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TaskID
+			// ParentID is the parentID argument value.
+			ParentID *entity.TaskID
+		}
+	}
+	lockMoveTask sync.RWMutex
+}
+
+// MoveTask calls MoveTaskFunc.
+func (mock *MoveTaskServiceMock) MoveTask(ctx context.Context, id entity.TaskID, parentID *entity.TaskID) (*entity.Task, error) {
+	if mock.MoveTaskFunc == nil {
+		panic("MoveTaskServiceMock.MoveTaskFunc: method is nil but MoveTaskService.MoveTask was just called")
 	}
 	callInfo := struct {
 		Ctx      context.Context
-		Name     string
-		Password string
+		ID       entity.TaskID
+		ParentID *entity.TaskID
 	}{
 		Ctx:      ctx,
-		Name:     name,
-		Password: password,
+		ID:       id,
+		ParentID: parentID,
 	}
-	mock.lockLogin.Lock()
-	mock.calls.Login = append(mock.calls.Login, callInfo)
-	mock.lockLogin.Unlock()
-	return mock.LoginFunc(ctx, name, password)
+	mock.lockMoveTask.Lock()
+	mock.calls.MoveTask = append(mock.calls.MoveTask, callInfo)
+	mock.lockMoveTask.Unlock()
+	return mock.MoveTaskFunc(ctx, id, parentID)
 }
 
-// LoginCalls gets all the calls that were made to Login.
+// MoveTaskCalls gets all the calls that were made to MoveTask.
 // Check the length with:
 //
-//	len(mockedLoginService.LoginCalls())
-func (mock *LoginServiceMock) LoginCalls() []struct {
+//	len(mockedMoveTaskService.MoveTaskCalls())
+func (mock *MoveTaskServiceMock) MoveTaskCalls() []struct {
 	Ctx      context.Context
-	Name     string
-	Password string
+	ID       entity.TaskID
+	ParentID *entity.TaskID
 } {
 	var calls []struct {
 		Ctx      context.Context
-		Name     string
-		Password string
+		ID       entity.TaskID
+		ParentID *entity.TaskID
 	}
-	mock.lockLogin.RLock()
-	calls = mock.calls.Login
-	mock.lockLogin.RUnlock()
+	mock.lockMoveTask.RLock()
+	calls = mock.calls.MoveTask
+	mock.lockMoveTask.RUnlock()
+	return calls
+}
+
+// Ensure, that AddTaskDependencyServiceMock does implement AddTaskDependencyService.
+// If this is not the case, regenerate this file with moq.
+var _ AddTaskDependencyService = &AddTaskDependencyServiceMock{}
+
+// AddTaskDependencyServiceMock is a mock implementation of AddTaskDependencyService.
+//
+//	func TestSomethingThatUsesAddTaskDependencyService(t *testing.T) {
+//
+//		// make and configure a mocked AddTaskDependencyService
+//		mockedAddTaskDependencyService := &AddTaskDependencyServiceMock{
+//			AddTaskDependencyFunc: func(ctx context.Context, blockerID entity.TaskID, blockedID entity.TaskID) error {
+//				panic("mock out the AddTaskDependency method")
+//			},
+//		}
+//
+//		// use mockedAddTaskDependencyService in code that requires AddTaskDependencyService
+//		// and then make assertions.
+//
+//	}
+type AddTaskDependencyServiceMock struct {
+	// AddTaskDependencyFunc mocks the AddTaskDependency method.
+	AddTaskDependencyFunc func(ctx context.Context, blockerID entity.TaskID, blockedID entity.TaskID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddTaskDependency holds details about calls to the AddTaskDependency method.
+		AddTaskDependency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BlockerID is the blockerID argument value.
+			BlockerID entity.TaskID
+			// BlockedID is the blockedID argument value.
+			BlockedID entity.TaskID
+		}
+	}
+	lockAddTaskDependency sync.RWMutex
+}
+
+// AddTaskDependency calls AddTaskDependencyFunc.
+func (mock *AddTaskDependencyServiceMock) AddTaskDependency(ctx context.Context, blockerID entity.TaskID, blockedID entity.TaskID) error {
+	if mock.AddTaskDependencyFunc == nil {
+		panic("AddTaskDependencyServiceMock.AddTaskDependencyFunc: method is nil but AddTaskDependencyService.AddTaskDependency was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		BlockerID entity.TaskID
+		BlockedID entity.TaskID
+	}{
+		Ctx:       ctx,
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}
+	mock.lockAddTaskDependency.Lock()
+	mock.calls.AddTaskDependency = append(mock.calls.AddTaskDependency, callInfo)
+	mock.lockAddTaskDependency.Unlock()
+	return mock.AddTaskDependencyFunc(ctx, blockerID, blockedID)
+}
+
+// AddTaskDependencyCalls gets all the calls that were made to AddTaskDependency.
+// Check the length with:
+//
+//	len(mockedAddTaskDependencyService.AddTaskDependencyCalls())
+func (mock *AddTaskDependencyServiceMock) AddTaskDependencyCalls() []struct {
+	Ctx       context.Context
+	BlockerID entity.TaskID
+	BlockedID entity.TaskID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		BlockerID entity.TaskID
+		BlockedID entity.TaskID
+	}
+	mock.lockAddTaskDependency.RLock()
+	calls = mock.calls.AddTaskDependency
+	mock.lockAddTaskDependency.RUnlock()
+	return calls
+}
+
+// Ensure, that DeleteTaskDependencyServiceMock does implement DeleteTaskDependencyService.
+// If this is not the case, regenerate this file with moq.
+var _ DeleteTaskDependencyService = &DeleteTaskDependencyServiceMock{}
+
+// DeleteTaskDependencyServiceMock is a mock implementation of DeleteTaskDependencyService.
+//
+//	func TestSomethingThatUsesDeleteTaskDependencyService(t *testing.T) {
+//
+//		// make and configure a mocked DeleteTaskDependencyService
+//		mockedDeleteTaskDependencyService := &DeleteTaskDependencyServiceMock{
+//			DeleteTaskDependencyFunc: func(ctx context.Context, blockerID entity.TaskID, blockedID entity.TaskID) error {
+//				panic("mock out the DeleteTaskDependency method")
+//			},
+//		}
+//
+//		// use mockedDeleteTaskDependencyService in code that requires DeleteTaskDependencyService
+//		// and then make assertions.
+//
+//	}
+type DeleteTaskDependencyServiceMock struct {
+	// DeleteTaskDependencyFunc mocks the DeleteTaskDependency method.
+	DeleteTaskDependencyFunc func(ctx context.Context, blockerID entity.TaskID, blockedID entity.TaskID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteTaskDependency holds details about calls to the DeleteTaskDependency method.
+		DeleteTaskDependency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BlockerID is the blockerID argument value.
+			BlockerID entity.TaskID
+			// BlockedID is the blockedID argument value.
+			BlockedID entity.TaskID
+		}
+	}
+	lockDeleteTaskDependency sync.RWMutex
+}
+
+// DeleteTaskDependency calls DeleteTaskDependencyFunc.
+func (mock *DeleteTaskDependencyServiceMock) DeleteTaskDependency(ctx context.Context, blockerID entity.TaskID, blockedID entity.TaskID) error {
+	if mock.DeleteTaskDependencyFunc == nil {
+		panic("DeleteTaskDependencyServiceMock.DeleteTaskDependencyFunc: method is nil but DeleteTaskDependencyService.DeleteTaskDependency was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		BlockerID entity.TaskID
+		BlockedID entity.TaskID
+	}{
+		Ctx:       ctx,
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}
+	mock.lockDeleteTaskDependency.Lock()
+	mock.calls.DeleteTaskDependency = append(mock.calls.DeleteTaskDependency, callInfo)
+	mock.lockDeleteTaskDependency.Unlock()
+	return mock.DeleteTaskDependencyFunc(ctx, blockerID, blockedID)
+}
+
+// DeleteTaskDependencyCalls gets all the calls that were made to DeleteTaskDependency.
+// Check the length with:
+//
+//	len(mockedDeleteTaskDependencyService.DeleteTaskDependencyCalls())
+func (mock *DeleteTaskDependencyServiceMock) DeleteTaskDependencyCalls() []struct {
+	Ctx       context.Context
+	BlockerID entity.TaskID
+	BlockedID entity.TaskID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		BlockerID entity.TaskID
+		BlockedID entity.TaskID
+	}
+	mock.lockDeleteTaskDependency.RLock()
+	calls = mock.calls.DeleteTaskDependency
+	mock.lockDeleteTaskDependency.RUnlock()
+	return calls
+}
+
+// Ensure, that ListTrashServiceMock does implement ListTrashService.
+// If this is not the case, regenerate this file with moq.
+var _ ListTrashService = &ListTrashServiceMock{}
+
+// ListTrashServiceMock is a mock implementation of ListTrashService.
+//
+//	func TestSomethingThatUsesListTrashService(t *testing.T) {
+//
+//		// make and configure a mocked ListTrashService
+//		mockedListTrashService := &ListTrashServiceMock{
+//			ListTrashFunc: func(ctx context.Context) (entity.Tasks, error) {
+//				panic("mock out the ListTrash method")
+//			},
+//		}
+//
+//		// use mockedListTrashService in code that requires ListTrashService
+//		// and then make assertions.
+//
+//	}
+type ListTrashServiceMock struct {
+	// ListTrashFunc mocks the ListTrash method.
+	ListTrashFunc func(ctx context.Context) (entity.Tasks, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListTrash holds details about calls to the ListTrash method.
+		ListTrash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockListTrash sync.RWMutex
+}
+
+// ListTrash calls ListTrashFunc.
+func (mock *ListTrashServiceMock) ListTrash(ctx context.Context) (entity.Tasks, error) {
+	if mock.ListTrashFunc == nil {
+		panic("ListTrashServiceMock.ListTrashFunc: method is nil but ListTrashService.ListTrash was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListTrash.Lock()
+	mock.calls.ListTrash = append(mock.calls.ListTrash, callInfo)
+	mock.lockListTrash.Unlock()
+	return mock.ListTrashFunc(ctx)
+}
+
+// ListTrashCalls gets all the calls that were made to ListTrash.
+// Check the length with:
+//
+//	len(mockedListTrashService.ListTrashCalls())
+func (mock *ListTrashServiceMock) ListTrashCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListTrash.RLock()
+	calls = mock.calls.ListTrash
+	mock.lockListTrash.RUnlock()
+	return calls
+}
+
+// Ensure, that RestoreTaskServiceMock does implement RestoreTaskService.
+// If this is not the case, regenerate this file with moq.
+var _ RestoreTaskService = &RestoreTaskServiceMock{}
+
+// RestoreTaskServiceMock is a mock implementation of RestoreTaskService.
+//
+//	func TestSomethingThatUsesRestoreTaskService(t *testing.T) {
+//
+//		// make and configure a mocked RestoreTaskService
+//		mockedRestoreTaskService := &RestoreTaskServiceMock{
+//			RestoreTaskFunc: func(ctx context.Context, id entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the RestoreTask method")
+//			},
+//		}
+//
+//		// use mockedRestoreTaskService in code that requires RestoreTaskService
+//		// and then make assertions.
+//
+//	}
+type RestoreTaskServiceMock struct {
+	// RestoreTaskFunc mocks the RestoreTask method.
+	RestoreTaskFunc func(ctx context.Context, id entity.TaskID) (*entity.Task, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RestoreTask holds details about calls to the RestoreTask method.
+		RestoreTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+	}
+	lockRestoreTask sync.RWMutex
+}
+
+// RestoreTask calls RestoreTaskFunc.
+func (mock *RestoreTaskServiceMock) RestoreTask(ctx context.Context, id entity.TaskID) (*entity.Task, error) {
+	if mock.RestoreTaskFunc == nil {
+		panic("RestoreTaskServiceMock.RestoreTaskFunc: method is nil but RestoreTaskService.RestoreTask was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockRestoreTask.Lock()
+	mock.calls.RestoreTask = append(mock.calls.RestoreTask, callInfo)
+	mock.lockRestoreTask.Unlock()
+	return mock.RestoreTaskFunc(ctx, id)
+}
+
+// RestoreTaskCalls gets all the calls that were made to RestoreTask.
+// Check the length with:
+//
+//	len(mockedRestoreTaskService.RestoreTaskCalls())
+func (mock *RestoreTaskServiceMock) RestoreTaskCalls() []struct {
+	Ctx context.Context
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.TaskID
+	}
+	mock.lockRestoreTask.RLock()
+	calls = mock.calls.RestoreTask
+	mock.lockRestoreTask.RUnlock()
+	return calls
+}
+
+// Ensure, that EmptyTrashServiceMock does implement EmptyTrashService.
+// If this is not the case, regenerate this file with moq.
+var _ EmptyTrashService = &EmptyTrashServiceMock{}
+
+// EmptyTrashServiceMock is a mock implementation of EmptyTrashService.
+//
+//	func TestSomethingThatUsesEmptyTrashService(t *testing.T) {
+//
+//		// make and configure a mocked EmptyTrashService
+//		mockedEmptyTrashService := &EmptyTrashServiceMock{
+//			EmptyTrashFunc: func(ctx context.Context) (int64, error) {
+//				panic("mock out the EmptyTrash method")
+//			},
+//		}
+//
+//		// use mockedEmptyTrashService in code that requires EmptyTrashService
+//		// and then make assertions.
+//
+//	}
+type EmptyTrashServiceMock struct {
+	// EmptyTrashFunc mocks the EmptyTrash method.
+	EmptyTrashFunc func(ctx context.Context) (int64, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// EmptyTrash holds details about calls to the EmptyTrash method.
+		EmptyTrash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockEmptyTrash sync.RWMutex
+}
+
+// EmptyTrash calls EmptyTrashFunc.
+func (mock *EmptyTrashServiceMock) EmptyTrash(ctx context.Context) (int64, error) {
+	if mock.EmptyTrashFunc == nil {
+		panic("EmptyTrashServiceMock.EmptyTrashFunc: method is nil but EmptyTrashService.EmptyTrash was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockEmptyTrash.Lock()
+	mock.calls.EmptyTrash = append(mock.calls.EmptyTrash, callInfo)
+	mock.lockEmptyTrash.Unlock()
+	return mock.EmptyTrashFunc(ctx)
+}
+
+// EmptyTrashCalls gets all the calls that were made to EmptyTrash.
+// Check the length with:
+//
+//	len(mockedEmptyTrashService.EmptyTrashCalls())
+func (mock *EmptyTrashServiceMock) EmptyTrashCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockEmptyTrash.RLock()
+	calls = mock.calls.EmptyTrash
+	mock.lockEmptyTrash.RUnlock()
+	return calls
+}
+
+// Ensure, that AddTagServiceMock does implement AddTagService.
+// If this is not the case, regenerate this file with moq.
+var _ AddTagService = &AddTagServiceMock{}
+
+// AddTagServiceMock is a mock implementation of AddTagService.
+//
+//	func TestSomethingThatUsesAddTagService(t *testing.T) {
+//
+//		// make and configure a mocked AddTagService
+//		mockedAddTagService := &AddTagServiceMock{
+//			AddTagFunc: func(ctx context.Context, name string) (*entity.Tag, error) {
+//				panic("mock out the AddTag method")
+//			},
+//		}
+//
+//		// use mockedAddTagService in code that requires AddTagService
+//		// and then make assertions.
+//
+//	}
+type AddTagServiceMock struct {
+	// AddTagFunc mocks the AddTag method.
+	AddTagFunc func(ctx context.Context, name string) (*entity.Tag, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddTag holds details about calls to the AddTag method.
+		AddTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+	}
+	lockAddTag sync.RWMutex
+}
+
+// AddTag calls AddTagFunc.
+func (mock *AddTagServiceMock) AddTag(ctx context.Context, name string) (*entity.Tag, error) {
+	if mock.AddTagFunc == nil {
+		panic("AddTagServiceMock.AddTagFunc: method is nil but AddTagService.AddTag was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockAddTag.Lock()
+	mock.calls.AddTag = append(mock.calls.AddTag, callInfo)
+	mock.lockAddTag.Unlock()
+	return mock.AddTagFunc(ctx, name)
+}
+
+// AddTagCalls gets all the calls that were made to AddTag.
+// Check the length with:
+//
+//	len(mockedAddTagService.AddTagCalls())
+func (mock *AddTagServiceMock) AddTagCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockAddTag.RLock()
+	calls = mock.calls.AddTag
+	mock.lockAddTag.RUnlock()
+	return calls
+}
+
+// Ensure, that ListTagsServiceMock does implement ListTagsService.
+// If this is not the case, regenerate this file with moq.
+var _ ListTagsService = &ListTagsServiceMock{}
+
+// ListTagsServiceMock is a mock implementation of ListTagsService.
+//
+//	func TestSomethingThatUsesListTagsService(t *testing.T) {
+//
+//		// make and configure a mocked ListTagsService
+//		mockedListTagsService := &ListTagsServiceMock{
+//			ListTagsFunc: func(ctx context.Context) (entity.Tags, error) {
+//				panic("mock out the ListTags method")
+//			},
+//		}
+//
+//		// use mockedListTagsService in code that requires ListTagsService
+//		// and then make assertions.
+//
+//	}
+type ListTagsServiceMock struct {
+	// ListTagsFunc mocks the ListTags method.
+	ListTagsFunc func(ctx context.Context) (entity.Tags, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListTags holds details about calls to the ListTags method.
+		ListTags []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockListTags sync.RWMutex
+}
+
+// ListTags calls ListTagsFunc.
+func (mock *ListTagsServiceMock) ListTags(ctx context.Context) (entity.Tags, error) {
+	if mock.ListTagsFunc == nil {
+		panic("ListTagsServiceMock.ListTagsFunc: method is nil but ListTagsService.ListTags was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListTags.Lock()
+	mock.calls.ListTags = append(mock.calls.ListTags, callInfo)
+	mock.lockListTags.Unlock()
+	return mock.ListTagsFunc(ctx)
+}
+
+// ListTagsCalls gets all the calls that were made to ListTags.
+// Check the length with:
+//
+//	len(mockedListTagsService.ListTagsCalls())
+func (mock *ListTagsServiceMock) ListTagsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListTags.RLock()
+	calls = mock.calls.ListTags
+	mock.lockListTags.RUnlock()
+	return calls
+}
+
+// Ensure, that UpdateTagServiceMock does implement UpdateTagService.
+// If this is not the case, regenerate this file with moq.
+var _ UpdateTagService = &UpdateTagServiceMock{}
+
+// UpdateTagServiceMock is a mock implementation of UpdateTagService.
+//
+//	func TestSomethingThatUsesUpdateTagService(t *testing.T) {
+//
+//		// make and configure a mocked UpdateTagService
+//		mockedUpdateTagService := &UpdateTagServiceMock{
+//			UpdateTagFunc: func(ctx context.Context, id entity.TagID, name string) (*entity.Tag, error) {
+//				panic("mock out the UpdateTag method")
+//			},
+//		}
+//
+//		// use mockedUpdateTagService in code that requires UpdateTagService
+//		// and then make assertions.
+//
+//	}
+type UpdateTagServiceMock struct {
+	// UpdateTagFunc mocks the UpdateTag method.
+	UpdateTagFunc func(ctx context.Context, id entity.TagID, name string) (*entity.Tag, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// UpdateTag holds details about calls to the UpdateTag method.
+		UpdateTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TagID
+			// Name is the name argument value.
+			Name string
+		}
+	}
+	lockUpdateTag sync.RWMutex
+}
+
+// UpdateTag calls UpdateTagFunc.
+func (mock *UpdateTagServiceMock) UpdateTag(ctx context.Context, id entity.TagID, name string) (*entity.Tag, error) {
+	if mock.UpdateTagFunc == nil {
+		panic("UpdateTagServiceMock.UpdateTagFunc: method is nil but UpdateTagService.UpdateTag was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		ID   entity.TagID
+		Name string
+	}{
+		Ctx:  ctx,
+		ID:   id,
+		Name: name,
+	}
+	mock.lockUpdateTag.Lock()
+	mock.calls.UpdateTag = append(mock.calls.UpdateTag, callInfo)
+	mock.lockUpdateTag.Unlock()
+	return mock.UpdateTagFunc(ctx, id, name)
+}
+
+// UpdateTagCalls gets all the calls that were made to UpdateTag.
+// Check the length with:
+//
+//	len(mockedUpdateTagService.UpdateTagCalls())
+func (mock *UpdateTagServiceMock) UpdateTagCalls() []struct {
+	Ctx  context.Context
+	ID   entity.TagID
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		ID   entity.TagID
+		Name string
+	}
+	mock.lockUpdateTag.RLock()
+	calls = mock.calls.UpdateTag
+	mock.lockUpdateTag.RUnlock()
+	return calls
+}
+
+// Ensure, that DeleteTagServiceMock does implement DeleteTagService.
+// If this is not the case, regenerate this file with moq.
+var _ DeleteTagService = &DeleteTagServiceMock{}
+
+// DeleteTagServiceMock is a mock implementation of DeleteTagService.
+//
+//	func TestSomethingThatUsesDeleteTagService(t *testing.T) {
+//
+//		// make and configure a mocked DeleteTagService
+//		mockedDeleteTagService := &DeleteTagServiceMock{
+//			DeleteTagFunc: func(ctx context.Context, id entity.TagID) error {
+//				panic("mock out the DeleteTag method")
+//			},
+//		}
+//
+//		// use mockedDeleteTagService in code that requires DeleteTagService
+//		// and then make assertions.
+//
+//	}
+type DeleteTagServiceMock struct {
+	// DeleteTagFunc mocks the DeleteTag method.
+	DeleteTagFunc func(ctx context.Context, id entity.TagID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteTag holds details about calls to the DeleteTag method.
+		DeleteTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TagID
+		}
+	}
+	lockDeleteTag sync.RWMutex
+}
+
+// DeleteTag calls DeleteTagFunc.
+func (mock *DeleteTagServiceMock) DeleteTag(ctx context.Context, id entity.TagID) error {
+	if mock.DeleteTagFunc == nil {
+		panic("DeleteTagServiceMock.DeleteTagFunc: method is nil but DeleteTagService.DeleteTag was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.TagID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteTag.Lock()
+	mock.calls.DeleteTag = append(mock.calls.DeleteTag, callInfo)
+	mock.lockDeleteTag.Unlock()
+	return mock.DeleteTagFunc(ctx, id)
+}
+
+// DeleteTagCalls gets all the calls that were made to DeleteTag.
+// Check the length with:
+//
+//	len(mockedDeleteTagService.DeleteTagCalls())
+func (mock *DeleteTagServiceMock) DeleteTagCalls() []struct {
+	Ctx context.Context
+	ID  entity.TagID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.TagID
+	}
+	mock.lockDeleteTag.RLock()
+	calls = mock.calls.DeleteTag
+	mock.lockDeleteTag.RUnlock()
+	return calls
+}
+
+// Ensure, that AttachTagServiceMock does implement AttachTagService.
+// If this is not the case, regenerate this file with moq.
+var _ AttachTagService = &AttachTagServiceMock{}
+
+// AttachTagServiceMock is a mock implementation of AttachTagService.
+//
+//	func TestSomethingThatUsesAttachTagService(t *testing.T) {
+//
+//		// make and configure a mocked AttachTagService
+//		mockedAttachTagService := &AttachTagServiceMock{
+//			AttachTagFunc: func(ctx context.Context, taskID entity.TaskID, tagID entity.TagID) error {
+//				panic("mock out the AttachTag method")
+//			},
+//		}
+//
+//		// use mockedAttachTagService in code that requires AttachTagService
+//		// and then make assertions.
+//
+//	}
+type AttachTagServiceMock struct {
+	// AttachTagFunc mocks the AttachTag method.
+	AttachTagFunc func(ctx context.Context, taskID entity.TaskID, tagID entity.TagID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AttachTag holds details about calls to the AttachTag method.
+		AttachTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TaskID is the taskID argument value.
+			TaskID entity.TaskID
+			// TagID is the tagID argument value.
+			TagID entity.TagID
+		}
+	}
+	lockAttachTag sync.RWMutex
+}
+
+// AttachTag calls AttachTagFunc.
+func (mock *AttachTagServiceMock) AttachTag(ctx context.Context, taskID entity.TaskID, tagID entity.TagID) error {
+	if mock.AttachTagFunc == nil {
+		panic("AttachTagServiceMock.AttachTagFunc: method is nil but AttachTagService.AttachTag was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		TaskID entity.TaskID
+		TagID  entity.TagID
+	}{
+		Ctx:    ctx,
+		TaskID: taskID,
+		TagID:  tagID,
+	}
+	mock.lockAttachTag.Lock()
+	mock.calls.AttachTag = append(mock.calls.AttachTag, callInfo)
+	mock.lockAttachTag.Unlock()
+	return mock.AttachTagFunc(ctx, taskID, tagID)
+}
+
+// AttachTagCalls gets all the calls that were made to AttachTag.
+// Check the length with:
+//
+//	len(mockedAttachTagService.AttachTagCalls())
+func (mock *AttachTagServiceMock) AttachTagCalls() []struct {
+	Ctx    context.Context
+	TaskID entity.TaskID
+	TagID  entity.TagID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		TaskID entity.TaskID
+		TagID  entity.TagID
+	}
+	mock.lockAttachTag.RLock()
+	calls = mock.calls.AttachTag
+	mock.lockAttachTag.RUnlock()
+	return calls
+}
+
+// Ensure, that DetachTagServiceMock does implement DetachTagService.
+// If this is not the case, regenerate this file with moq.
+var _ DetachTagService = &DetachTagServiceMock{}
+
+// DetachTagServiceMock is a mock implementation of DetachTagService.
+//
+//	func TestSomethingThatUsesDetachTagService(t *testing.T) {
+//
+//		// make and configure a mocked DetachTagService
+//		mockedDetachTagService := &DetachTagServiceMock{
+//			DetachTagFunc: func(ctx context.Context, taskID entity.TaskID, tagID entity.TagID) error {
+//				panic("mock out the DetachTag method")
+//			},
+//		}
+//
+//		// use mockedDetachTagService in code that requires DetachTagService
+//		// and then make assertions.
+//
+//	}
+type DetachTagServiceMock struct {
+	// DetachTagFunc mocks the DetachTag method.
+	DetachTagFunc func(ctx context.Context, taskID entity.TaskID, tagID entity.TagID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DetachTag holds details about calls to the DetachTag method.
+		DetachTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TaskID is the taskID argument value.
+			TaskID entity.TaskID
+			// TagID is the tagID argument value.
+			TagID entity.TagID
+		}
+	}
+	lockDetachTag sync.RWMutex
+}
+
+// DetachTag calls DetachTagFunc.
+func (mock *DetachTagServiceMock) DetachTag(ctx context.Context, taskID entity.TaskID, tagID entity.TagID) error {
+	if mock.DetachTagFunc == nil {
+		panic("DetachTagServiceMock.DetachTagFunc: method is nil but DetachTagService.DetachTag was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		TaskID entity.TaskID
+		TagID  entity.TagID
+	}{
+		Ctx:    ctx,
+		TaskID: taskID,
+		TagID:  tagID,
+	}
+	mock.lockDetachTag.Lock()
+	mock.calls.DetachTag = append(mock.calls.DetachTag, callInfo)
+	mock.lockDetachTag.Unlock()
+	return mock.DetachTagFunc(ctx, taskID, tagID)
+}
+
+// DetachTagCalls gets all the calls that were made to DetachTag.
+// Check the length with:
+//
+//	len(mockedDetachTagService.DetachTagCalls())
+func (mock *DetachTagServiceMock) DetachTagCalls() []struct {
+	Ctx    context.Context
+	TaskID entity.TaskID
+	TagID  entity.TagID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		TaskID entity.TaskID
+		TagID  entity.TagID
+	}
+	mock.lockDetachTag.RLock()
+	calls = mock.calls.DetachTag
+	mock.lockDetachTag.RUnlock()
+	return calls
+}
+
+// Ensure, that AddProjectServiceMock does implement AddProjectService.
+// If this is not the case, regenerate this file with moq.
+var _ AddProjectService = &AddProjectServiceMock{}
+
+// AddProjectServiceMock is a mock implementation of AddProjectService.
+//
+//	func TestSomethingThatUsesAddProjectService(t *testing.T) {
+//
+//		// make and configure a mocked AddProjectService
+//		mockedAddProjectService := &AddProjectServiceMock{
+//			AddProjectFunc: func(ctx context.Context, name string) (*entity.Project, error) {
+//				panic("mock out the AddProject method")
+//			},
+//		}
+//
+//		// use mockedAddProjectService in code that requires AddProjectService
+//		// and then make assertions.
+//
+//	}
+type AddProjectServiceMock struct {
+	// AddProjectFunc mocks the AddProject method.
+	AddProjectFunc func(ctx context.Context, name string) (*entity.Project, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddProject holds details about calls to the AddProject method.
+		AddProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+	}
+	lockAddProject sync.RWMutex
+}
+
+// AddProject calls AddProjectFunc.
+func (mock *AddProjectServiceMock) AddProject(ctx context.Context, name string) (*entity.Project, error) {
+	if mock.AddProjectFunc == nil {
+		panic("AddProjectServiceMock.AddProjectFunc: method is nil but AddProjectService.AddProject was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockAddProject.Lock()
+	mock.calls.AddProject = append(mock.calls.AddProject, callInfo)
+	mock.lockAddProject.Unlock()
+	return mock.AddProjectFunc(ctx, name)
+}
+
+// AddProjectCalls gets all the calls that were made to AddProject.
+// Check the length with:
+//
+//	len(mockedAddProjectService.AddProjectCalls())
+func (mock *AddProjectServiceMock) AddProjectCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockAddProject.RLock()
+	calls = mock.calls.AddProject
+	mock.lockAddProject.RUnlock()
+	return calls
+}
+
+// Ensure, that ListProjectsServiceMock does implement ListProjectsService.
+// If this is not the case, regenerate this file with moq.
+var _ ListProjectsService = &ListProjectsServiceMock{}
+
+// ListProjectsServiceMock is a mock implementation of ListProjectsService.
+//
+//	func TestSomethingThatUsesListProjectsService(t *testing.T) {
+//
+//		// make and configure a mocked ListProjectsService
+//		mockedListProjectsService := &ListProjectsServiceMock{
+//			ListProjectsFunc: func(ctx context.Context, includeArchived bool) (entity.Projects, error) {
+//				panic("mock out the ListProjects method")
+//			},
+//		}
+//
+//		// use mockedListProjectsService in code that requires ListProjectsService
+//		// and then make assertions.
+//
+//	}
+type ListProjectsServiceMock struct {
+	// ListProjectsFunc mocks the ListProjects method.
+	ListProjectsFunc func(ctx context.Context, includeArchived bool) (entity.Projects, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListProjects holds details about calls to the ListProjects method.
+		ListProjects []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// IncludeArchived is the includeArchived argument value.
+			IncludeArchived bool
+		}
+	}
+	lockListProjects sync.RWMutex
+}
+
+// ListProjects calls ListProjectsFunc.
+func (mock *ListProjectsServiceMock) ListProjects(ctx context.Context, includeArchived bool) (entity.Projects, error) {
+	if mock.ListProjectsFunc == nil {
+		panic("ListProjectsServiceMock.ListProjectsFunc: method is nil but ListProjectsService.ListProjects was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		IncludeArchived bool
+	}{
+		Ctx:             ctx,
+		IncludeArchived: includeArchived,
+	}
+	mock.lockListProjects.Lock()
+	mock.calls.ListProjects = append(mock.calls.ListProjects, callInfo)
+	mock.lockListProjects.Unlock()
+	return mock.ListProjectsFunc(ctx, includeArchived)
+}
+
+// ListProjectsCalls gets all the calls that were made to ListProjects.
+// Check the length with:
+//
+//	len(mockedListProjectsService.ListProjectsCalls())
+func (mock *ListProjectsServiceMock) ListProjectsCalls() []struct {
+	Ctx             context.Context
+	IncludeArchived bool
+} {
+	var calls []struct {
+		Ctx             context.Context
+		IncludeArchived bool
+	}
+	mock.lockListProjects.RLock()
+	calls = mock.calls.ListProjects
+	mock.lockListProjects.RUnlock()
+	return calls
+}
+
+// Ensure, that GetProjectServiceMock does implement GetProjectService.
+// If this is not the case, regenerate this file with moq.
+var _ GetProjectService = &GetProjectServiceMock{}
+
+// GetProjectServiceMock is a mock implementation of GetProjectService.
+//
+//	func TestSomethingThatUsesGetProjectService(t *testing.T) {
+//
+//		// make and configure a mocked GetProjectService
+//		mockedGetProjectService := &GetProjectServiceMock{
+//			GetProjectFunc: func(ctx context.Context, id entity.ProjectID) (*entity.Project, error) {
+//				panic("mock out the GetProject method")
+//			},
+//		}
+//
+//		// use mockedGetProjectService in code that requires GetProjectService
+//		// and then make assertions.
+//
+//	}
+type GetProjectServiceMock struct {
+	// GetProjectFunc mocks the GetProject method.
+	GetProjectFunc func(ctx context.Context, id entity.ProjectID) (*entity.Project, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetProject holds details about calls to the GetProject method.
+		GetProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.ProjectID
+		}
+	}
+	lockGetProject sync.RWMutex
+}
+
+// GetProject calls GetProjectFunc.
+func (mock *GetProjectServiceMock) GetProject(ctx context.Context, id entity.ProjectID) (*entity.Project, error) {
+	if mock.GetProjectFunc == nil {
+		panic("GetProjectServiceMock.GetProjectFunc: method is nil but GetProjectService.GetProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.ProjectID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetProject.Lock()
+	mock.calls.GetProject = append(mock.calls.GetProject, callInfo)
+	mock.lockGetProject.Unlock()
+	return mock.GetProjectFunc(ctx, id)
+}
+
+// GetProjectCalls gets all the calls that were made to GetProject.
+// Check the length with:
+//
+//	len(mockedGetProjectService.GetProjectCalls())
+func (mock *GetProjectServiceMock) GetProjectCalls() []struct {
+	Ctx context.Context
+	ID  entity.ProjectID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.ProjectID
+	}
+	mock.lockGetProject.RLock()
+	calls = mock.calls.GetProject
+	mock.lockGetProject.RUnlock()
+	return calls
+}
+
+// Ensure, that UpdateProjectServiceMock does implement UpdateProjectService.
+// If this is not the case, regenerate this file with moq.
+var _ UpdateProjectService = &UpdateProjectServiceMock{}
+
+// UpdateProjectServiceMock is a mock implementation of UpdateProjectService.
+//
+//	func TestSomethingThatUsesUpdateProjectService(t *testing.T) {
+//
+//		// make and configure a mocked UpdateProjectService
+//		mockedUpdateProjectService := &UpdateProjectServiceMock{
+//			UpdateProjectFunc: func(ctx context.Context, id entity.ProjectID, name string) (*entity.Project, error) {
+//				panic("mock out the UpdateProject method")
+//			},
+//		}
+//
+//		// use mockedUpdateProjectService in code that requires UpdateProjectService
+//		// and then make assertions.
+//
+//	}
+type UpdateProjectServiceMock struct {
+	// UpdateProjectFunc mocks the UpdateProject method.
+	UpdateProjectFunc func(ctx context.Context, id entity.ProjectID, name string) (*entity.Project, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// UpdateProject holds details about calls to the UpdateProject method.
+		UpdateProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.ProjectID
+			// Name is the name argument value.
+			Name string
+		}
+	}
+	lockUpdateProject sync.RWMutex
+}
+
+// UpdateProject calls UpdateProjectFunc.
+func (mock *UpdateProjectServiceMock) UpdateProject(ctx context.Context, id entity.ProjectID, name string) (*entity.Project, error) {
+	if mock.UpdateProjectFunc == nil {
+		panic("UpdateProjectServiceMock.UpdateProjectFunc: method is nil but UpdateProjectService.UpdateProject was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		ID   entity.ProjectID
+		Name string
+	}{
+		Ctx:  ctx,
+		ID:   id,
+		Name: name,
+	}
+	mock.lockUpdateProject.Lock()
+	mock.calls.UpdateProject = append(mock.calls.UpdateProject, callInfo)
+	mock.lockUpdateProject.Unlock()
+	return mock.UpdateProjectFunc(ctx, id, name)
+}
+
+// UpdateProjectCalls gets all the calls that were made to UpdateProject.
+// Check the length with:
+//
+//	len(mockedUpdateProjectService.UpdateProjectCalls())
+func (mock *UpdateProjectServiceMock) UpdateProjectCalls() []struct {
+	Ctx  context.Context
+	ID   entity.ProjectID
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		ID   entity.ProjectID
+		Name string
+	}
+	mock.lockUpdateProject.RLock()
+	calls = mock.calls.UpdateProject
+	mock.lockUpdateProject.RUnlock()
+	return calls
+}
+
+// Ensure, that ArchiveProjectServiceMock does implement ArchiveProjectService.
+// If this is not the case, regenerate this file with moq.
+var _ ArchiveProjectService = &ArchiveProjectServiceMock{}
+
+// ArchiveProjectServiceMock is a mock implementation of ArchiveProjectService.
+//
+//	func TestSomethingThatUsesArchiveProjectService(t *testing.T) {
+//
+//		// make and configure a mocked ArchiveProjectService
+//		mockedArchiveProjectService := &ArchiveProjectServiceMock{
+//			ArchiveProjectFunc: func(ctx context.Context, id entity.ProjectID, archived bool) (*entity.Project, error) {
+//				panic("mock out the ArchiveProject method")
+//			},
+//		}
+//
+//		// use mockedArchiveProjectService in code that requires ArchiveProjectService
+//		// and then make assertions.
+//
+//	}
+type ArchiveProjectServiceMock struct {
+	// ArchiveProjectFunc mocks the ArchiveProject method.
+	ArchiveProjectFunc func(ctx context.Context, id entity.ProjectID, archived bool) (*entity.Project, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ArchiveProject holds details about calls to the ArchiveProject method.
+		ArchiveProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.ProjectID
+			// Archived is the archived argument value.
+			Archived bool
+		}
+	}
+	lockArchiveProject sync.RWMutex
+}
+
+// ArchiveProject calls ArchiveProjectFunc.
+func (mock *ArchiveProjectServiceMock) ArchiveProject(ctx context.Context, id entity.ProjectID, archived bool) (*entity.Project, error) {
+	if mock.ArchiveProjectFunc == nil {
+		panic("ArchiveProjectServiceMock.ArchiveProjectFunc: method is nil but ArchiveProjectService.ArchiveProject was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ID       entity.ProjectID
+		Archived bool
+	}{
+		Ctx:      ctx,
+		ID:       id,
+		Archived: archived,
+	}
+	mock.lockArchiveProject.Lock()
+	mock.calls.ArchiveProject = append(mock.calls.ArchiveProject, callInfo)
+	mock.lockArchiveProject.Unlock()
+	return mock.ArchiveProjectFunc(ctx, id, archived)
+}
+
+// ArchiveProjectCalls gets all the calls that were made to ArchiveProject.
+// Check the length with:
+//
+//	len(mockedArchiveProjectService.ArchiveProjectCalls())
+func (mock *ArchiveProjectServiceMock) ArchiveProjectCalls() []struct {
+	Ctx      context.Context
+	ID       entity.ProjectID
+	Archived bool
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ID       entity.ProjectID
+		Archived bool
+	}
+	mock.lockArchiveProject.RLock()
+	calls = mock.calls.ArchiveProject
+	mock.lockArchiveProject.RUnlock()
+	return calls
+}
+
+// Ensure, that DeleteProjectServiceMock does implement DeleteProjectService.
+// If this is not the case, regenerate this file with moq.
+var _ DeleteProjectService = &DeleteProjectServiceMock{}
+
+// DeleteProjectServiceMock is a mock implementation of DeleteProjectService.
+//
+//	func TestSomethingThatUsesDeleteProjectService(t *testing.T) {
+//
+//		// make and configure a mocked DeleteProjectService
+//		mockedDeleteProjectService := &DeleteProjectServiceMock{
+//			DeleteProjectFunc: func(ctx context.Context, id entity.ProjectID) error {
+//				panic("mock out the DeleteProject method")
+//			},
+//		}
+//
+//		// use mockedDeleteProjectService in code that requires DeleteProjectService
+//		// and then make assertions.
+//
+//	}
+type DeleteProjectServiceMock struct {
+	// DeleteProjectFunc mocks the DeleteProject method.
+	DeleteProjectFunc func(ctx context.Context, id entity.ProjectID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteProject holds details about calls to the DeleteProject method.
+		DeleteProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.ProjectID
+		}
+	}
+	lockDeleteProject sync.RWMutex
+}
+
+// DeleteProject calls DeleteProjectFunc.
+func (mock *DeleteProjectServiceMock) DeleteProject(ctx context.Context, id entity.ProjectID) error {
+	if mock.DeleteProjectFunc == nil {
+		panic("DeleteProjectServiceMock.DeleteProjectFunc: method is nil but DeleteProjectService.DeleteProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.ProjectID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteProject.Lock()
+	mock.calls.DeleteProject = append(mock.calls.DeleteProject, callInfo)
+	mock.lockDeleteProject.Unlock()
+	return mock.DeleteProjectFunc(ctx, id)
+}
+
+// DeleteProjectCalls gets all the calls that were made to DeleteProject.
+// Check the length with:
+//
+//	len(mockedDeleteProjectService.DeleteProjectCalls())
+func (mock *DeleteProjectServiceMock) DeleteProjectCalls() []struct {
+	Ctx context.Context
+	ID  entity.ProjectID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.ProjectID
+	}
+	mock.lockDeleteProject.RLock()
+	calls = mock.calls.DeleteProject
+	mock.lockDeleteProject.RUnlock()
+	return calls
+}
+
+// Ensure, that ListProjectTasksServiceMock does implement ListProjectTasksService.
+// If this is not the case, regenerate this file with moq.
+var _ ListProjectTasksService = &ListProjectTasksServiceMock{}
+
+// ListProjectTasksServiceMock is a mock implementation of ListProjectTasksService.
+//
+//	func TestSomethingThatUsesListProjectTasksService(t *testing.T) {
+//
+//		// make and configure a mocked ListProjectTasksService
+//		mockedListProjectTasksService := &ListProjectTasksServiceMock{
+//			ListProjectTasksFunc: func(ctx context.Context, id entity.ProjectID, f *entity.TaskFilter) (entity.Tasks, string, error) {
+//				panic("mock out the ListProjectTasks method")
+//			},
+//		}
+//
+//		// use mockedListProjectTasksService in code that requires ListProjectTasksService
+//		// and then make assertions.
+//
+//	}
+type ListProjectTasksServiceMock struct {
+	// ListProjectTasksFunc mocks the ListProjectTasks method.
+	ListProjectTasksFunc func(ctx context.Context, id entity.ProjectID, f *entity.TaskFilter) (entity.Tasks, string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListProjectTasks holds details about calls to the ListProjectTasks method.
+		ListProjectTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.ProjectID
+			// F is the f argument value.
+			F *entity.TaskFilter
+		}
+	}
+	lockListProjectTasks sync.RWMutex
+}
+
+// ListProjectTasks calls ListProjectTasksFunc.
+func (mock *ListProjectTasksServiceMock) ListProjectTasks(ctx context.Context, id entity.ProjectID, f *entity.TaskFilter) (entity.Tasks, string, error) {
+	if mock.ListProjectTasksFunc == nil {
+		panic("ListProjectTasksServiceMock.ListProjectTasksFunc: method is nil but ListProjectTasksService.ListProjectTasks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.ProjectID
+		F   *entity.TaskFilter
+	}{
+		Ctx: ctx,
+		ID:  id,
+		F:   f,
+	}
+	mock.lockListProjectTasks.Lock()
+	mock.calls.ListProjectTasks = append(mock.calls.ListProjectTasks, callInfo)
+	mock.lockListProjectTasks.Unlock()
+	return mock.ListProjectTasksFunc(ctx, id, f)
+}
+
+// ListProjectTasksCalls gets all the calls that were made to ListProjectTasks.
+// Check the length with:
+//
+//	len(mockedListProjectTasksService.ListProjectTasksCalls())
+func (mock *ListProjectTasksServiceMock) ListProjectTasksCalls() []struct {
+	Ctx context.Context
+	ID  entity.ProjectID
+	F   *entity.TaskFilter
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.ProjectID
+		F   *entity.TaskFilter
+	}
+	mock.lockListProjectTasks.RLock()
+	calls = mock.calls.ListProjectTasks
+	mock.lockListProjectTasks.RUnlock()
+	return calls
+}
+
+// Ensure, that RegisterUserServiceMock does implement RegisterUserService.
+// If this is not the case, regenerate this file with moq.
+var _ RegisterUserService = &RegisterUserServiceMock{}
+
+// RegisterUserServiceMock is a mock implementation of RegisterUserService.
+//
+//	func TestSomethingThatUsesRegisterUserService(t *testing.T) {
+//
+//		// make and configure a mocked RegisterUserService
+//		mockedRegisterUserService := &RegisterUserServiceMock{
+//			RegisterUserFunc: func(ctx context.Context, name string, password string, email string) (*entity.User, error) {
+//				panic("mock out the RegisterUser method")
+//			},
+//		}
+//
+//		// use mockedRegisterUserService in code that requires RegisterUserService
+//		// and then make assertions.
+//
+//	}
+type RegisterUserServiceMock struct {
+	// RegisterUserFunc mocks the RegisterUser method.
+	RegisterUserFunc func(ctx context.Context, name string, password string, email string) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RegisterUser holds details about calls to the RegisterUser method.
+		RegisterUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Password is the password argument value.
+			Password string
+			// Email is the email argument value.
+			Email string
+		}
+	}
+	lockRegisterUser sync.RWMutex
+}
+
+// RegisterUser calls RegisterUserFunc.
+func (mock *RegisterUserServiceMock) RegisterUser(ctx context.Context, name string, password string, email string) (*entity.User, error) {
+	if mock.RegisterUserFunc == nil {
+		panic("RegisterUserServiceMock.RegisterUserFunc: method is nil but RegisterUserService.RegisterUser was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Name     string
+		Password string
+		Email    string
+	}{
+		Ctx:      ctx,
+		Name:     name,
+		Password: password,
+		Email:    email,
+	}
+	mock.lockRegisterUser.Lock()
+	mock.calls.RegisterUser = append(mock.calls.RegisterUser, callInfo)
+	mock.lockRegisterUser.Unlock()
+	return mock.RegisterUserFunc(ctx, name, password, email)
+}
+
+// RegisterUserCalls gets all the calls that were made to RegisterUser.
+// Check the length with:
+//
+//	len(mockedRegisterUserService.RegisterUserCalls())
+func (mock *RegisterUserServiceMock) RegisterUserCalls() []struct {
+	Ctx      context.Context
+	Name     string
+	Password string
+	Email    string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Name     string
+		Password string
+		Email    string
+	}
+	mock.lockRegisterUser.RLock()
+	calls = mock.calls.RegisterUser
+	mock.lockRegisterUser.RUnlock()
+	return calls
+}
+
+// Ensure, that UpdateUserRoleServiceMock does implement UpdateUserRoleService.
+// If this is not the case, regenerate this file with moq.
+var _ UpdateUserRoleService = &UpdateUserRoleServiceMock{}
+
+// UpdateUserRoleServiceMock is a mock implementation of UpdateUserRoleService.
+//
+//	func TestSomethingThatUsesUpdateUserRoleService(t *testing.T) {
+//
+//		// make and configure a mocked UpdateUserRoleService
+//		mockedUpdateUserRoleService := &UpdateUserRoleServiceMock{
+//			UpdateUserRoleFunc: func(ctx context.Context, id entity.UserID, role entity.Role) (*entity.User, error) {
+//				panic("mock out the UpdateUserRole method")
+//			},
+//		}
+//
+//		// use mockedUpdateUserRoleService in code that requires UpdateUserRoleService
+//		// and then make assertions.
+//
+//	}
+type UpdateUserRoleServiceMock struct {
+	// UpdateUserRoleFunc mocks the UpdateUserRole method.
+	UpdateUserRoleFunc func(ctx context.Context, id entity.UserID, role entity.Role) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// UpdateUserRole holds details about calls to the UpdateUserRole method.
+		UpdateUserRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.UserID
+			// Role is the role argument value.
+			Role entity.Role
+		}
+	}
+	lockUpdateUserRole sync.RWMutex
+}
+
+// UpdateUserRole calls UpdateUserRoleFunc.
+func (mock *UpdateUserRoleServiceMock) UpdateUserRole(ctx context.Context, id entity.UserID, role entity.Role) (*entity.User, error) {
+	if mock.UpdateUserRoleFunc == nil {
+		panic("UpdateUserRoleServiceMock.UpdateUserRoleFunc: method is nil but UpdateUserRoleService.UpdateUserRole was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		ID   entity.UserID
+		Role entity.Role
+	}{
+		Ctx:  ctx,
+		ID:   id,
+		Role: role,
+	}
+	mock.lockUpdateUserRole.Lock()
+	mock.calls.UpdateUserRole = append(mock.calls.UpdateUserRole, callInfo)
+	mock.lockUpdateUserRole.Unlock()
+	return mock.UpdateUserRoleFunc(ctx, id, role)
+}
+
+// UpdateUserRoleCalls gets all the calls that were made to UpdateUserRole.
+// Check the length with:
+//
+//	len(mockedUpdateUserRoleService.UpdateUserRoleCalls())
+func (mock *UpdateUserRoleServiceMock) UpdateUserRoleCalls() []struct {
+	Ctx  context.Context
+	ID   entity.UserID
+	Role entity.Role
+} {
+	var calls []struct {
+		Ctx  context.Context
+		ID   entity.UserID
+		Role entity.Role
+	}
+	mock.lockUpdateUserRole.RLock()
+	calls = mock.calls.UpdateUserRole
+	mock.lockUpdateUserRole.RUnlock()
+	return calls
+}
+
+// Ensure, that ListUsersServiceMock does implement ListUsersService.
+// If this is not the case, regenerate this file with moq.
+var _ ListUsersService = &ListUsersServiceMock{}
+
+// ListUsersServiceMock is a mock implementation of ListUsersService.
+//
+//	func TestSomethingThatUsesListUsersService(t *testing.T) {
+//
+//		// make and configure a mocked ListUsersService
+//		mockedListUsersService := &ListUsersServiceMock{
+//			ListUsersFunc: func(ctx context.Context, f *entity.UserFilter) (entity.Users, string, error) {
+//				panic("mock out the ListUsers method")
+//			},
+//		}
+//
+//		// use mockedListUsersService in code that requires ListUsersService
+//		// and then make assertions.
+//
+//	}
+type ListUsersServiceMock struct {
+	// ListUsersFunc mocks the ListUsers method.
+	ListUsersFunc func(ctx context.Context, f *entity.UserFilter) (entity.Users, string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListUsers holds details about calls to the ListUsers method.
+		ListUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// F is the f argument value.
+			F *entity.UserFilter
+		}
+	}
+	lockListUsers sync.RWMutex
+}
+
+// ListUsers calls ListUsersFunc.
+func (mock *ListUsersServiceMock) ListUsers(ctx context.Context, f *entity.UserFilter) (entity.Users, string, error) {
+	if mock.ListUsersFunc == nil {
+		panic("ListUsersServiceMock.ListUsersFunc: method is nil but ListUsersService.ListUsers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		F   *entity.UserFilter
+	}{
+		Ctx: ctx,
+		F:   f,
+	}
+	mock.lockListUsers.Lock()
+	mock.calls.ListUsers = append(mock.calls.ListUsers, callInfo)
+	mock.lockListUsers.Unlock()
+	return mock.ListUsersFunc(ctx, f)
+}
+
+// ListUsersCalls gets all the calls that were made to ListUsers.
+// Check the length with:
+//
+//	len(mockedListUsersService.ListUsersCalls())
+func (mock *ListUsersServiceMock) ListUsersCalls() []struct {
+	Ctx context.Context
+	F   *entity.UserFilter
+} {
+	var calls []struct {
+		Ctx context.Context
+		F   *entity.UserFilter
+	}
+	mock.lockListUsers.RLock()
+	calls = mock.calls.ListUsers
+	mock.lockListUsers.RUnlock()
+	return calls
+}
+
+// Ensure, that GetUserServiceMock does implement GetUserService.
+// If this is not the case, regenerate this file with moq.
+var _ GetUserService = &GetUserServiceMock{}
+
+// GetUserServiceMock is a mock implementation of GetUserService.
+//
+//	func TestSomethingThatUsesGetUserService(t *testing.T) {
+//
+//		// make and configure a mocked GetUserService
+//		mockedGetUserService := &GetUserServiceMock{
+//			GetUserFunc: func(ctx context.Context, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUser method")
+//			},
+//		}
+//
+//		// use mockedGetUserService in code that requires GetUserService
+//		// and then make assertions.
+//
+//	}
+type GetUserServiceMock struct {
+	// GetUserFunc mocks the GetUser method.
+	GetUserFunc func(ctx context.Context, id entity.UserID) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUser holds details about calls to the GetUser method.
+		GetUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+	}
+	lockGetUser sync.RWMutex
+}
+
+// GetUser calls GetUserFunc.
+func (mock *GetUserServiceMock) GetUser(ctx context.Context, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserFunc == nil {
+		panic("GetUserServiceMock.GetUserFunc: method is nil but GetUserService.GetUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetUser.Lock()
+	mock.calls.GetUser = append(mock.calls.GetUser, callInfo)
+	mock.lockGetUser.Unlock()
+	return mock.GetUserFunc(ctx, id)
+}
+
+// GetUserCalls gets all the calls that were made to GetUser.
+// Check the length with:
+//
+//	len(mockedGetUserService.GetUserCalls())
+func (mock *GetUserServiceMock) GetUserCalls() []struct {
+	Ctx context.Context
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.UserID
+	}
+	mock.lockGetUser.RLock()
+	calls = mock.calls.GetUser
+	mock.lockGetUser.RUnlock()
+	return calls
+}
+
+// Ensure, that SetUserDisabledServiceMock does implement SetUserDisabledService.
+// If this is not the case, regenerate this file with moq.
+var _ SetUserDisabledService = &SetUserDisabledServiceMock{}
+
+// SetUserDisabledServiceMock is a mock implementation of SetUserDisabledService.
+//
+//	func TestSomethingThatUsesSetUserDisabledService(t *testing.T) {
+//
+//		// make and configure a mocked SetUserDisabledService
+//		mockedSetUserDisabledService := &SetUserDisabledServiceMock{
+//			SetUserDisabledFunc: func(ctx context.Context, id entity.UserID, disabled bool) (*entity.User, error) {
+//				panic("mock out the SetUserDisabled method")
+//			},
+//		}
+//
+//		// use mockedSetUserDisabledService in code that requires SetUserDisabledService
+//		// and then make assertions.
+//
+//	}
+type SetUserDisabledServiceMock struct {
+	// SetUserDisabledFunc mocks the SetUserDisabled method.
+	SetUserDisabledFunc func(ctx context.Context, id entity.UserID, disabled bool) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// SetUserDisabled holds details about calls to the SetUserDisabled method.
+		SetUserDisabled []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.UserID
+			// Disabled is the disabled argument value.
+			Disabled bool
+		}
+	}
+	lockSetUserDisabled sync.RWMutex
+}
+
+// SetUserDisabled calls SetUserDisabledFunc.
+func (mock *SetUserDisabledServiceMock) SetUserDisabled(ctx context.Context, id entity.UserID, disabled bool) (*entity.User, error) {
+	if mock.SetUserDisabledFunc == nil {
+		panic("SetUserDisabledServiceMock.SetUserDisabledFunc: method is nil but SetUserDisabledService.SetUserDisabled was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ID       entity.UserID
+		Disabled bool
+	}{
+		Ctx:      ctx,
+		ID:       id,
+		Disabled: disabled,
+	}
+	mock.lockSetUserDisabled.Lock()
+	mock.calls.SetUserDisabled = append(mock.calls.SetUserDisabled, callInfo)
+	mock.lockSetUserDisabled.Unlock()
+	return mock.SetUserDisabledFunc(ctx, id, disabled)
+}
+
+// SetUserDisabledCalls gets all the calls that were made to SetUserDisabled.
+// Check the length with:
+//
+//	len(mockedSetUserDisabledService.SetUserDisabledCalls())
+func (mock *SetUserDisabledServiceMock) SetUserDisabledCalls() []struct {
+	Ctx      context.Context
+	ID       entity.UserID
+	Disabled bool
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ID       entity.UserID
+		Disabled bool
+	}
+	mock.lockSetUserDisabled.RLock()
+	calls = mock.calls.SetUserDisabled
+	mock.lockSetUserDisabled.RUnlock()
+	return calls
+}
+
+// Ensure, that ForceLogoutServiceMock does implement ForceLogoutService.
+// If this is not the case, regenerate this file with moq.
+var _ ForceLogoutService = &ForceLogoutServiceMock{}
+
+// ForceLogoutServiceMock is a mock implementation of ForceLogoutService.
+//
+//	func TestSomethingThatUsesForceLogoutService(t *testing.T) {
+//
+//		// make and configure a mocked ForceLogoutService
+//		mockedForceLogoutService := &ForceLogoutServiceMock{
+//			ForceLogoutFunc: func(ctx context.Context, id entity.UserID) error {
+//				panic("mock out the ForceLogout method")
+//			},
+//		}
+//
+//		// use mockedForceLogoutService in code that requires ForceLogoutService
+//		// and then make assertions.
+//
+//	}
+type ForceLogoutServiceMock struct {
+	// ForceLogoutFunc mocks the ForceLogout method.
+	ForceLogoutFunc func(ctx context.Context, id entity.UserID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ForceLogout holds details about calls to the ForceLogout method.
+		ForceLogout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+	}
+	lockForceLogout sync.RWMutex
+}
+
+// ForceLogout calls ForceLogoutFunc.
+func (mock *ForceLogoutServiceMock) ForceLogout(ctx context.Context, id entity.UserID) error {
+	if mock.ForceLogoutFunc == nil {
+		panic("ForceLogoutServiceMock.ForceLogoutFunc: method is nil but ForceLogoutService.ForceLogout was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockForceLogout.Lock()
+	mock.calls.ForceLogout = append(mock.calls.ForceLogout, callInfo)
+	mock.lockForceLogout.Unlock()
+	return mock.ForceLogoutFunc(ctx, id)
+}
+
+// ForceLogoutCalls gets all the calls that were made to ForceLogout.
+// Check the length with:
+//
+//	len(mockedForceLogoutService.ForceLogoutCalls())
+func (mock *ForceLogoutServiceMock) ForceLogoutCalls() []struct {
+	Ctx context.Context
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.UserID
+	}
+	mock.lockForceLogout.RLock()
+	calls = mock.calls.ForceLogout
+	mock.lockForceLogout.RUnlock()
+	return calls
+}
+
+// Ensure, that ChangePasswordServiceMock does implement ChangePasswordService.
+// If this is not the case, regenerate this file with moq.
+var _ ChangePasswordService = &ChangePasswordServiceMock{}
+
+// ChangePasswordServiceMock is a mock implementation of ChangePasswordService.
+//
+//	func TestSomethingThatUsesChangePasswordService(t *testing.T) {
+//
+//		// make and configure a mocked ChangePasswordService
+//		mockedChangePasswordService := &ChangePasswordServiceMock{
+//			ChangePasswordFunc: func(ctx context.Context, current string, password string) (*auth.TokenPair, error) {
+//				panic("mock out the ChangePassword method")
+//			},
+//		}
+//
+//		// use mockedChangePasswordService in code that requires ChangePasswordService
+//		// and then make assertions.
+//
+//	}
+type ChangePasswordServiceMock struct {
+	// ChangePasswordFunc mocks the ChangePassword method.
+	ChangePasswordFunc func(ctx context.Context, current string, password string) (*auth.TokenPair, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ChangePassword holds details about calls to the ChangePassword method.
+		ChangePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Current is the current argument value.
+			Current string
+			// Password is the password argument value.
+			Password string
+		}
+	}
+	lockChangePassword sync.RWMutex
+}
+
+// ChangePassword calls ChangePasswordFunc.
+func (mock *ChangePasswordServiceMock) ChangePassword(ctx context.Context, current string, password string) (*auth.TokenPair, error) {
+	if mock.ChangePasswordFunc == nil {
+		panic("ChangePasswordServiceMock.ChangePasswordFunc: method is nil but ChangePasswordService.ChangePassword was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Current  string
+		Password string
+	}{
+		Ctx:      ctx,
+		Current:  current,
+		Password: password,
+	}
+	mock.lockChangePassword.Lock()
+	mock.calls.ChangePassword = append(mock.calls.ChangePassword, callInfo)
+	mock.lockChangePassword.Unlock()
+	return mock.ChangePasswordFunc(ctx, current, password)
+}
+
+// ChangePasswordCalls gets all the calls that were made to ChangePassword.
+// Check the length with:
+//
+//	len(mockedChangePasswordService.ChangePasswordCalls())
+func (mock *ChangePasswordServiceMock) ChangePasswordCalls() []struct {
+	Ctx      context.Context
+	Current  string
+	Password string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Current  string
+		Password string
+	}
+	mock.lockChangePassword.RLock()
+	calls = mock.calls.ChangePassword
+	mock.lockChangePassword.RUnlock()
+	return calls
+}
+
+// Ensure, that RequirePasswordResetServiceMock does implement RequirePasswordResetService.
+// If this is not the case, regenerate this file with moq.
+var _ RequirePasswordResetService = &RequirePasswordResetServiceMock{}
+
+// RequirePasswordResetServiceMock is a mock implementation of RequirePasswordResetService.
+//
+//	func TestSomethingThatUsesRequirePasswordResetService(t *testing.T) {
+//
+//		// make and configure a mocked RequirePasswordResetService
+//		mockedRequirePasswordResetService := &RequirePasswordResetServiceMock{
+//			RequirePasswordResetFunc: func(ctx context.Context, id entity.UserID) error {
+//				panic("mock out the RequirePasswordReset method")
+//			},
+//		}
+//
+//		// use mockedRequirePasswordResetService in code that requires RequirePasswordResetService
+//		// and then make assertions.
+//
+//	}
+type RequirePasswordResetServiceMock struct {
+	// RequirePasswordResetFunc mocks the RequirePasswordReset method.
+	RequirePasswordResetFunc func(ctx context.Context, id entity.UserID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RequirePasswordReset holds details about calls to the RequirePasswordReset method.
+		RequirePasswordReset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+	}
+	lockRequirePasswordReset sync.RWMutex
+}
+
+// RequirePasswordReset calls RequirePasswordResetFunc.
+func (mock *RequirePasswordResetServiceMock) RequirePasswordReset(ctx context.Context, id entity.UserID) error {
+	if mock.RequirePasswordResetFunc == nil {
+		panic("RequirePasswordResetServiceMock.RequirePasswordResetFunc: method is nil but RequirePasswordResetService.RequirePasswordReset was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockRequirePasswordReset.Lock()
+	mock.calls.RequirePasswordReset = append(mock.calls.RequirePasswordReset, callInfo)
+	mock.lockRequirePasswordReset.Unlock()
+	return mock.RequirePasswordResetFunc(ctx, id)
+}
+
+// RequirePasswordResetCalls gets all the calls that were made to RequirePasswordReset.
+// Check the length with:
+//
+//	len(mockedRequirePasswordResetService.RequirePasswordResetCalls())
+func (mock *RequirePasswordResetServiceMock) RequirePasswordResetCalls() []struct {
+	Ctx context.Context
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.UserID
+	}
+	mock.lockRequirePasswordReset.RLock()
+	calls = mock.calls.RequirePasswordReset
+	mock.lockRequirePasswordReset.RUnlock()
+	return calls
+}
+
+// Ensure, that ForgotPasswordServiceMock does implement ForgotPasswordService.
+// If this is not the case, regenerate this file with moq.
+var _ ForgotPasswordService = &ForgotPasswordServiceMock{}
+
+// ForgotPasswordServiceMock is a mock implementation of ForgotPasswordService.
+//
+//	func TestSomethingThatUsesForgotPasswordService(t *testing.T) {
+//
+//		// make and configure a mocked ForgotPasswordService
+//		mockedForgotPasswordService := &ForgotPasswordServiceMock{
+//			ForgotPasswordFunc: func(ctx context.Context, email string) error {
+//				panic("mock out the ForgotPassword method")
+//			},
+//		}
+//
+//		// use mockedForgotPasswordService in code that requires ForgotPasswordService
+//		// and then make assertions.
+//
+//	}
+type ForgotPasswordServiceMock struct {
+	// ForgotPasswordFunc mocks the ForgotPassword method.
+	ForgotPasswordFunc func(ctx context.Context, email string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ForgotPassword holds details about calls to the ForgotPassword method.
+		ForgotPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+		}
+	}
+	lockForgotPassword sync.RWMutex
+}
+
+// ForgotPassword calls ForgotPasswordFunc.
+func (mock *ForgotPasswordServiceMock) ForgotPassword(ctx context.Context, email string) error {
+	if mock.ForgotPasswordFunc == nil {
+		panic("ForgotPasswordServiceMock.ForgotPasswordFunc: method is nil but ForgotPasswordService.ForgotPassword was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Email string
+	}{
+		Ctx:   ctx,
+		Email: email,
+	}
+	mock.lockForgotPassword.Lock()
+	mock.calls.ForgotPassword = append(mock.calls.ForgotPassword, callInfo)
+	mock.lockForgotPassword.Unlock()
+	return mock.ForgotPasswordFunc(ctx, email)
+}
+
+// ForgotPasswordCalls gets all the calls that were made to ForgotPassword.
+// Check the length with:
+//
+//	len(mockedForgotPasswordService.ForgotPasswordCalls())
+func (mock *ForgotPasswordServiceMock) ForgotPasswordCalls() []struct {
+	Ctx   context.Context
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Email string
+	}
+	mock.lockForgotPassword.RLock()
+	calls = mock.calls.ForgotPassword
+	mock.lockForgotPassword.RUnlock()
+	return calls
+}
+
+// Ensure, that ResetPasswordServiceMock does implement ResetPasswordService.
+// If this is not the case, regenerate this file with moq.
+var _ ResetPasswordService = &ResetPasswordServiceMock{}
+
+// ResetPasswordServiceMock is a mock implementation of ResetPasswordService.
+//
+//	func TestSomethingThatUsesResetPasswordService(t *testing.T) {
+//
+//		// make and configure a mocked ResetPasswordService
+//		mockedResetPasswordService := &ResetPasswordServiceMock{
+//			ResetPasswordFunc: func(ctx context.Context, token string, password string) error {
+//				panic("mock out the ResetPassword method")
+//			},
+//		}
+//
+//		// use mockedResetPasswordService in code that requires ResetPasswordService
+//		// and then make assertions.
+//
+//	}
+type ResetPasswordServiceMock struct {
+	// ResetPasswordFunc mocks the ResetPassword method.
+	ResetPasswordFunc func(ctx context.Context, token string, password string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ResetPassword holds details about calls to the ResetPassword method.
+		ResetPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+			// Password is the password argument value.
+			Password string
+		}
+	}
+	lockResetPassword sync.RWMutex
+}
+
+// ResetPassword calls ResetPasswordFunc.
+func (mock *ResetPasswordServiceMock) ResetPassword(ctx context.Context, token string, password string) error {
+	if mock.ResetPasswordFunc == nil {
+		panic("ResetPasswordServiceMock.ResetPasswordFunc: method is nil but ResetPasswordService.ResetPassword was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Token    string
+		Password string
+	}{
+		Ctx:      ctx,
+		Token:    token,
+		Password: password,
+	}
+	mock.lockResetPassword.Lock()
+	mock.calls.ResetPassword = append(mock.calls.ResetPassword, callInfo)
+	mock.lockResetPassword.Unlock()
+	return mock.ResetPasswordFunc(ctx, token, password)
+}
+
+// ResetPasswordCalls gets all the calls that were made to ResetPassword.
+// Check the length with:
+//
+//	len(mockedResetPasswordService.ResetPasswordCalls())
+func (mock *ResetPasswordServiceMock) ResetPasswordCalls() []struct {
+	Ctx      context.Context
+	Token    string
+	Password string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Token    string
+		Password string
+	}
+	mock.lockResetPassword.RLock()
+	calls = mock.calls.ResetPassword
+	mock.lockResetPassword.RUnlock()
+	return calls
+}
+
+// Ensure, that SetupTwoFactorServiceMock does implement SetupTwoFactorService.
+// If this is not the case, regenerate this file with moq.
+var _ SetupTwoFactorService = &SetupTwoFactorServiceMock{}
+
+// SetupTwoFactorServiceMock is a mock implementation of SetupTwoFactorService.
+//
+//	func TestSomethingThatUsesSetupTwoFactorService(t *testing.T) {
+//
+//		// make and configure a mocked SetupTwoFactorService
+//		mockedSetupTwoFactorService := &SetupTwoFactorServiceMock{
+//			SetupTwoFactorFunc: func(ctx context.Context) (string, string, error) {
+//				panic("mock out the SetupTwoFactor method")
+//			},
+//		}
+//
+//		// use mockedSetupTwoFactorService in code that requires SetupTwoFactorService
+//		// and then make assertions.
+//
+//	}
+type SetupTwoFactorServiceMock struct {
+	// SetupTwoFactorFunc mocks the SetupTwoFactor method.
+	SetupTwoFactorFunc func(ctx context.Context) (string, string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// SetupTwoFactor holds details about calls to the SetupTwoFactor method.
+		SetupTwoFactor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockSetupTwoFactor sync.RWMutex
+}
+
+// SetupTwoFactor calls SetupTwoFactorFunc.
+func (mock *SetupTwoFactorServiceMock) SetupTwoFactor(ctx context.Context) (string, string, error) {
+	if mock.SetupTwoFactorFunc == nil {
+		panic("SetupTwoFactorServiceMock.SetupTwoFactorFunc: method is nil but SetupTwoFactorService.SetupTwoFactor was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockSetupTwoFactor.Lock()
+	mock.calls.SetupTwoFactor = append(mock.calls.SetupTwoFactor, callInfo)
+	mock.lockSetupTwoFactor.Unlock()
+	return mock.SetupTwoFactorFunc(ctx)
+}
+
+// SetupTwoFactorCalls gets all the calls that were made to SetupTwoFactor.
+// Check the length with:
+//
+//	len(mockedSetupTwoFactorService.SetupTwoFactorCalls())
+func (mock *SetupTwoFactorServiceMock) SetupTwoFactorCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockSetupTwoFactor.RLock()
+	calls = mock.calls.SetupTwoFactor
+	mock.lockSetupTwoFactor.RUnlock()
+	return calls
+}
+
+// Ensure, that ConfirmTwoFactorServiceMock does implement ConfirmTwoFactorService.
+// If this is not the case, regenerate this file with moq.
+var _ ConfirmTwoFactorService = &ConfirmTwoFactorServiceMock{}
+
+// ConfirmTwoFactorServiceMock is a mock implementation of ConfirmTwoFactorService.
+//
+//	func TestSomethingThatUsesConfirmTwoFactorService(t *testing.T) {
+//
+//		// make and configure a mocked ConfirmTwoFactorService
+//		mockedConfirmTwoFactorService := &ConfirmTwoFactorServiceMock{
+//			ConfirmTwoFactorFunc: func(ctx context.Context, code string) ([]string, error) {
+//				panic("mock out the ConfirmTwoFactor method")
+//			},
+//		}
+//
+//		// use mockedConfirmTwoFactorService in code that requires ConfirmTwoFactorService
+//		// and then make assertions.
+//
+//	}
+type ConfirmTwoFactorServiceMock struct {
+	// ConfirmTwoFactorFunc mocks the ConfirmTwoFactor method.
+	ConfirmTwoFactorFunc func(ctx context.Context, code string) ([]string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ConfirmTwoFactor holds details about calls to the ConfirmTwoFactor method.
+		ConfirmTwoFactor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+		}
+	}
+	lockConfirmTwoFactor sync.RWMutex
+}
+
+// ConfirmTwoFactor calls ConfirmTwoFactorFunc.
+func (mock *ConfirmTwoFactorServiceMock) ConfirmTwoFactor(ctx context.Context, code string) ([]string, error) {
+	if mock.ConfirmTwoFactorFunc == nil {
+		panic("ConfirmTwoFactorServiceMock.ConfirmTwoFactorFunc: method is nil but ConfirmTwoFactorService.ConfirmTwoFactor was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Code string
+	}{
+		Ctx:  ctx,
+		Code: code,
+	}
+	mock.lockConfirmTwoFactor.Lock()
+	mock.calls.ConfirmTwoFactor = append(mock.calls.ConfirmTwoFactor, callInfo)
+	mock.lockConfirmTwoFactor.Unlock()
+	return mock.ConfirmTwoFactorFunc(ctx, code)
+}
+
+// ConfirmTwoFactorCalls gets all the calls that were made to ConfirmTwoFactor.
+// Check the length with:
+//
+//	len(mockedConfirmTwoFactorService.ConfirmTwoFactorCalls())
+func (mock *ConfirmTwoFactorServiceMock) ConfirmTwoFactorCalls() []struct {
+	Ctx  context.Context
+	Code string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Code string
+	}
+	mock.lockConfirmTwoFactor.RLock()
+	calls = mock.calls.ConfirmTwoFactor
+	mock.lockConfirmTwoFactor.RUnlock()
+	return calls
+}
+
+// Ensure, that GetProfileServiceMock does implement GetProfileService.
+// If this is not the case, regenerate this file with moq.
+var _ GetProfileService = &GetProfileServiceMock{}
+
+// GetProfileServiceMock is a mock implementation of GetProfileService.
+//
+//	func TestSomethingThatUsesGetProfileService(t *testing.T) {
+//
+//		// make and configure a mocked GetProfileService
+//		mockedGetProfileService := &GetProfileServiceMock{
+//			GetProfileFunc: func(ctx context.Context) (*entity.User, error) {
+//				panic("mock out the GetProfile method")
+//			},
+//		}
+//
+//		// use mockedGetProfileService in code that requires GetProfileService
+//		// and then make assertions.
+//
+//	}
+type GetProfileServiceMock struct {
+	// GetProfileFunc mocks the GetProfile method.
+	GetProfileFunc func(ctx context.Context) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetProfile holds details about calls to the GetProfile method.
+		GetProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockGetProfile sync.RWMutex
+}
+
+// GetProfile calls GetProfileFunc.
+func (mock *GetProfileServiceMock) GetProfile(ctx context.Context) (*entity.User, error) {
+	if mock.GetProfileFunc == nil {
+		panic("GetProfileServiceMock.GetProfileFunc: method is nil but GetProfileService.GetProfile was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetProfile.Lock()
+	mock.calls.GetProfile = append(mock.calls.GetProfile, callInfo)
+	mock.lockGetProfile.Unlock()
+	return mock.GetProfileFunc(ctx)
+}
+
+// GetProfileCalls gets all the calls that were made to GetProfile.
+// Check the length with:
+//
+//	len(mockedGetProfileService.GetProfileCalls())
+func (mock *GetProfileServiceMock) GetProfileCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetProfile.RLock()
+	calls = mock.calls.GetProfile
+	mock.lockGetProfile.RUnlock()
+	return calls
+}
+
+// Ensure, that UpdateProfileServiceMock does implement UpdateProfileService.
+// If this is not the case, regenerate this file with moq.
+var _ UpdateProfileService = &UpdateProfileServiceMock{}
+
+// UpdateProfileServiceMock is a mock implementation of UpdateProfileService.
+//
+//	func TestSomethingThatUsesUpdateProfileService(t *testing.T) {
+//
+//		// make and configure a mocked UpdateProfileService
+//		mockedUpdateProfileService := &UpdateProfileServiceMock{
+//			UpdateProfileFunc: func(ctx context.Context, displayName *string, timeZone *string, locale *string) (*entity.User, error) {
+//				panic("mock out the UpdateProfile method")
+//			},
+//		}
+//
+//		// use mockedUpdateProfileService in code that requires UpdateProfileService
+//		// and then make assertions.
+//
+//	}
+type UpdateProfileServiceMock struct {
+	// UpdateProfileFunc mocks the UpdateProfile method.
+	UpdateProfileFunc func(ctx context.Context, displayName *string, timeZone *string, locale *string) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// UpdateProfile holds details about calls to the UpdateProfile method.
+		UpdateProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DisplayName is the displayName argument value.
+			DisplayName *string
+			// TimeZone is the timeZone argument value.
+			TimeZone *string
+			// Locale is the locale argument value.
+			Locale *string
+		}
+	}
+	lockUpdateProfile sync.RWMutex
+}
+
+// UpdateProfile calls UpdateProfileFunc.
+func (mock *UpdateProfileServiceMock) UpdateProfile(ctx context.Context, displayName *string, timeZone *string, locale *string) (*entity.User, error) {
+	if mock.UpdateProfileFunc == nil {
+		panic("UpdateProfileServiceMock.UpdateProfileFunc: method is nil but UpdateProfileService.UpdateProfile was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		DisplayName *string
+		TimeZone    *string
+		Locale      *string
+	}{
+		Ctx:         ctx,
+		DisplayName: displayName,
+		TimeZone:    timeZone,
+		Locale:      locale,
+	}
+	mock.lockUpdateProfile.Lock()
+	mock.calls.UpdateProfile = append(mock.calls.UpdateProfile, callInfo)
+	mock.lockUpdateProfile.Unlock()
+	return mock.UpdateProfileFunc(ctx, displayName, timeZone, locale)
+}
+
+// UpdateProfileCalls gets all the calls that were made to UpdateProfile.
+// Check the length with:
+//
+//	len(mockedUpdateProfileService.UpdateProfileCalls())
+func (mock *UpdateProfileServiceMock) UpdateProfileCalls() []struct {
+	Ctx         context.Context
+	DisplayName *string
+	TimeZone    *string
+	Locale      *string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		DisplayName *string
+		TimeZone    *string
+		Locale      *string
+	}
+	mock.lockUpdateProfile.RLock()
+	calls = mock.calls.UpdateProfile
+	mock.lockUpdateProfile.RUnlock()
+	return calls
+}
+
+// Ensure, that DeleteAccountServiceMock does implement DeleteAccountService.
+// If this is not the case, regenerate this file with moq.
+var _ DeleteAccountService = &DeleteAccountServiceMock{}
+
+// DeleteAccountServiceMock is a mock implementation of DeleteAccountService.
+//
+//	func TestSomethingThatUsesDeleteAccountService(t *testing.T) {
+//
+//		// make and configure a mocked DeleteAccountService
+//		mockedDeleteAccountService := &DeleteAccountServiceMock{
+//			DeleteAccountFunc: func(ctx context.Context, password string) error {
+//				panic("mock out the DeleteAccount method")
+//			},
+//		}
+//
+//		// use mockedDeleteAccountService in code that requires DeleteAccountService
+//		// and then make assertions.
+//
+//	}
+type DeleteAccountServiceMock struct {
+	// DeleteAccountFunc mocks the DeleteAccount method.
+	DeleteAccountFunc func(ctx context.Context, password string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteAccount holds details about calls to the DeleteAccount method.
+		DeleteAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Password is the password argument value.
+			Password string
+		}
+	}
+	lockDeleteAccount sync.RWMutex
+}
+
+// DeleteAccount calls DeleteAccountFunc.
+func (mock *DeleteAccountServiceMock) DeleteAccount(ctx context.Context, password string) error {
+	if mock.DeleteAccountFunc == nil {
+		panic("DeleteAccountServiceMock.DeleteAccountFunc: method is nil but DeleteAccountService.DeleteAccount was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Password string
+	}{
+		Ctx:      ctx,
+		Password: password,
+	}
+	mock.lockDeleteAccount.Lock()
+	mock.calls.DeleteAccount = append(mock.calls.DeleteAccount, callInfo)
+	mock.lockDeleteAccount.Unlock()
+	return mock.DeleteAccountFunc(ctx, password)
+}
+
+// DeleteAccountCalls gets all the calls that were made to DeleteAccount.
+// Check the length with:
+//
+//	len(mockedDeleteAccountService.DeleteAccountCalls())
+func (mock *DeleteAccountServiceMock) DeleteAccountCalls() []struct {
+	Ctx      context.Context
+	Password string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Password string
+	}
+	mock.lockDeleteAccount.RLock()
+	calls = mock.calls.DeleteAccount
+	mock.lockDeleteAccount.RUnlock()
+	return calls
+}
+
+// Ensure, that ExportAccountServiceMock does implement ExportAccountService.
+// If this is not the case, regenerate this file with moq.
+var _ ExportAccountService = &ExportAccountServiceMock{}
+
+// ExportAccountServiceMock is a mock implementation of ExportAccountService.
+//
+//	func TestSomethingThatUsesExportAccountService(t *testing.T) {
+//
+//		// make and configure a mocked ExportAccountService
+//		mockedExportAccountService := &ExportAccountServiceMock{
+//			ExportAccountFunc: func(ctx context.Context, w io.Writer) error {
+//				panic("mock out the ExportAccount method")
+//			},
+//		}
+//
+//		// use mockedExportAccountService in code that requires ExportAccountService
+//		// and then make assertions.
+//
+//	}
+type ExportAccountServiceMock struct {
+	// ExportAccountFunc mocks the ExportAccount method.
+	ExportAccountFunc func(ctx context.Context, w io.Writer) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ExportAccount holds details about calls to the ExportAccount method.
+		ExportAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// W is the w argument value.
+			W io.Writer
+		}
+	}
+	lockExportAccount sync.RWMutex
+}
+
+// ExportAccount calls ExportAccountFunc.
+func (mock *ExportAccountServiceMock) ExportAccount(ctx context.Context, w io.Writer) error {
+	if mock.ExportAccountFunc == nil {
+		panic("ExportAccountServiceMock.ExportAccountFunc: method is nil but ExportAccountService.ExportAccount was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		W   io.Writer
+	}{
+		Ctx: ctx,
+		W:   w,
+	}
+	mock.lockExportAccount.Lock()
+	mock.calls.ExportAccount = append(mock.calls.ExportAccount, callInfo)
+	mock.lockExportAccount.Unlock()
+	return mock.ExportAccountFunc(ctx, w)
+}
+
+// ExportAccountCalls gets all the calls that were made to ExportAccount.
+// Check the length with:
+//
+//	len(mockedExportAccountService.ExportAccountCalls())
+func (mock *ExportAccountServiceMock) ExportAccountCalls() []struct {
+	Ctx context.Context
+	W   io.Writer
+} {
+	var calls []struct {
+		Ctx context.Context
+		W   io.Writer
+	}
+	mock.lockExportAccount.RLock()
+	calls = mock.calls.ExportAccount
+	mock.lockExportAccount.RUnlock()
+	return calls
+}
+
+// Ensure, that LoginServiceMock does implement LoginService.
+// If this is not the case, regenerate this file with moq.
+var _ LoginService = &LoginServiceMock{}
+
+// LoginServiceMock is a mock implementation of LoginService.
+//
+//	func TestSomethingThatUsesLoginService(t *testing.T) {
+//
+//		// make and configure a mocked LoginService
+//		mockedLoginService := &LoginServiceMock{
+//			LoginFunc: func(ctx context.Context, name string, password string, newPassword string, ip string) (*service.LoginResult, error) {
+//				panic("mock out the Login method")
+//			},
+//		}
+//
+//		// use mockedLoginService in code that requires LoginService
+//		// and then make assertions.
+//
+//	}
+type LoginServiceMock struct {
+	// LoginFunc mocks the Login method.
+	LoginFunc func(ctx context.Context, name string, password string, newPassword string, ip string) (*service.LoginResult, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Login holds details about calls to the Login method.
+		Login []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Password is the password argument value.
+			Password string
+			// NewPassword is the newPassword argument value.
+			NewPassword string
+			// IP is the ip argument value.
+			IP string
+		}
+	}
+	lockLogin sync.RWMutex
+}
+
+// Login calls LoginFunc.
+func (mock *LoginServiceMock) Login(ctx context.Context, name string, password string, newPassword string, ip string) (*service.LoginResult, error) {
+	if mock.LoginFunc == nil {
+		panic("LoginServiceMock.LoginFunc: method is nil but LoginService.Login was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Name        string
+		Password    string
+		NewPassword string
+		IP          string
+	}{
+		Ctx:         ctx,
+		Name:        name,
+		Password:    password,
+		NewPassword: newPassword,
+		IP:          ip,
+	}
+	mock.lockLogin.Lock()
+	mock.calls.Login = append(mock.calls.Login, callInfo)
+	mock.lockLogin.Unlock()
+	return mock.LoginFunc(ctx, name, password, newPassword, ip)
+}
+
+// LoginCalls gets all the calls that were made to Login.
+// Check the length with:
+//
+//	len(mockedLoginService.LoginCalls())
+func (mock *LoginServiceMock) LoginCalls() []struct {
+	Ctx         context.Context
+	Name        string
+	Password    string
+	NewPassword string
+	IP          string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Name        string
+		Password    string
+		NewPassword string
+		IP          string
+	}
+	mock.lockLogin.RLock()
+	calls = mock.calls.Login
+	mock.lockLogin.RUnlock()
+	return calls
+}
+
+// Ensure, that LoginTwoFactorServiceMock does implement LoginTwoFactorService.
+// If this is not the case, regenerate this file with moq.
+var _ LoginTwoFactorService = &LoginTwoFactorServiceMock{}
+
+// LoginTwoFactorServiceMock is a mock implementation of LoginTwoFactorService.
+//
+//	func TestSomethingThatUsesLoginTwoFactorService(t *testing.T) {
+//
+//		// make and configure a mocked LoginTwoFactorService
+//		mockedLoginTwoFactorService := &LoginTwoFactorServiceMock{
+//			LoginTwoFactorFunc: func(ctx context.Context, challenge string, code string, newPassword string, ip string) (*auth.TokenPair, error) {
+//				panic("mock out the LoginTwoFactor method")
+//			},
+//		}
+//
+//		// use mockedLoginTwoFactorService in code that requires LoginTwoFactorService
+//		// and then make assertions.
+//
+//	}
+type LoginTwoFactorServiceMock struct {
+	// LoginTwoFactorFunc mocks the LoginTwoFactor method.
+	LoginTwoFactorFunc func(ctx context.Context, challenge string, code string, newPassword string, ip string) (*auth.TokenPair, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// LoginTwoFactor holds details about calls to the LoginTwoFactor method.
+		LoginTwoFactor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Challenge is the challenge argument value.
+			Challenge string
+			// Code is the code argument value.
+			Code string
+			// NewPassword is the newPassword argument value.
+			NewPassword string
+			// IP is the ip argument value.
+			IP string
+		}
+	}
+	lockLoginTwoFactor sync.RWMutex
+}
+
+// LoginTwoFactor calls LoginTwoFactorFunc.
+func (mock *LoginTwoFactorServiceMock) LoginTwoFactor(ctx context.Context, challenge string, code string, newPassword string, ip string) (*auth.TokenPair, error) {
+	if mock.LoginTwoFactorFunc == nil {
+		panic("LoginTwoFactorServiceMock.LoginTwoFactorFunc: method is nil but LoginTwoFactorService.LoginTwoFactor was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Challenge   string
+		Code        string
+		NewPassword string
+		IP          string
+	}{
+		Ctx:         ctx,
+		Challenge:   challenge,
+		Code:        code,
+		NewPassword: newPassword,
+		IP:          ip,
+	}
+	mock.lockLoginTwoFactor.Lock()
+	mock.calls.LoginTwoFactor = append(mock.calls.LoginTwoFactor, callInfo)
+	mock.lockLoginTwoFactor.Unlock()
+	return mock.LoginTwoFactorFunc(ctx, challenge, code, newPassword, ip)
+}
+
+// LoginTwoFactorCalls gets all the calls that were made to LoginTwoFactor.
+// Check the length with:
+//
+//	len(mockedLoginTwoFactorService.LoginTwoFactorCalls())
+func (mock *LoginTwoFactorServiceMock) LoginTwoFactorCalls() []struct {
+	Ctx         context.Context
+	Challenge   string
+	Code        string
+	NewPassword string
+	IP          string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Challenge   string
+		Code        string
+		NewPassword string
+		IP          string
+	}
+	mock.lockLoginTwoFactor.RLock()
+	calls = mock.calls.LoginTwoFactor
+	mock.lockLoginTwoFactor.RUnlock()
+	return calls
+}
+
+// Ensure, that StartOIDCLoginServiceMock does implement StartOIDCLoginService.
+// If this is not the case, regenerate this file with moq.
+var _ StartOIDCLoginService = &StartOIDCLoginServiceMock{}
+
+// StartOIDCLoginServiceMock is a mock implementation of StartOIDCLoginService.
+//
+//	func TestSomethingThatUsesStartOIDCLoginService(t *testing.T) {
+//
+//		// make and configure a mocked StartOIDCLoginService
+//		mockedStartOIDCLoginService := &StartOIDCLoginServiceMock{
+//			StartOIDCLoginFunc: func(ctx context.Context) (string, string, error) {
+//				panic("mock out the StartOIDCLogin method")
+//			},
+//		}
+//
+//		// use mockedStartOIDCLoginService in code that requires StartOIDCLoginService
+//		// and then make assertions.
+//
+//	}
+type StartOIDCLoginServiceMock struct {
+	// StartOIDCLoginFunc mocks the StartOIDCLogin method.
+	StartOIDCLoginFunc func(ctx context.Context) (string, string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// StartOIDCLogin holds details about calls to the StartOIDCLogin method.
+		StartOIDCLogin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockStartOIDCLogin sync.RWMutex
+}
+
+// StartOIDCLogin calls StartOIDCLoginFunc.
+func (mock *StartOIDCLoginServiceMock) StartOIDCLogin(ctx context.Context) (string, string, error) {
+	if mock.StartOIDCLoginFunc == nil {
+		panic("StartOIDCLoginServiceMock.StartOIDCLoginFunc: method is nil but StartOIDCLoginService.StartOIDCLogin was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockStartOIDCLogin.Lock()
+	mock.calls.StartOIDCLogin = append(mock.calls.StartOIDCLogin, callInfo)
+	mock.lockStartOIDCLogin.Unlock()
+	return mock.StartOIDCLoginFunc(ctx)
+}
+
+// StartOIDCLoginCalls gets all the calls that were made to StartOIDCLogin.
+// Check the length with:
+//
+//	len(mockedStartOIDCLoginService.StartOIDCLoginCalls())
+func (mock *StartOIDCLoginServiceMock) StartOIDCLoginCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockStartOIDCLogin.RLock()
+	calls = mock.calls.StartOIDCLogin
+	mock.lockStartOIDCLogin.RUnlock()
+	return calls
+}
+
+// Ensure, that OIDCLoginServiceMock does implement OIDCLoginService.
+// If this is not the case, regenerate this file with moq.
+var _ OIDCLoginService = &OIDCLoginServiceMock{}
+
+// OIDCLoginServiceMock is a mock implementation of OIDCLoginService.
+//
+//	func TestSomethingThatUsesOIDCLoginService(t *testing.T) {
+//
+//		// make and configure a mocked OIDCLoginService
+//		mockedOIDCLoginService := &OIDCLoginServiceMock{
+//			OIDCLoginFunc: func(ctx context.Context, state string, code string) (*auth.TokenPair, error) {
+//				panic("mock out the OIDCLogin method")
+//			},
+//		}
+//
+//		// use mockedOIDCLoginService in code that requires OIDCLoginService
+//		// and then make assertions.
+//
+//	}
+type OIDCLoginServiceMock struct {
+	// OIDCLoginFunc mocks the OIDCLogin method.
+	OIDCLoginFunc func(ctx context.Context, state string, code string) (*auth.TokenPair, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// OIDCLogin holds details about calls to the OIDCLogin method.
+		OIDCLogin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// State is the state argument value.
+			State string
+			// Code is the code argument value.
+			Code string
+		}
+	}
+	lockOIDCLogin sync.RWMutex
+}
+
+// OIDCLogin calls OIDCLoginFunc.
+func (mock *OIDCLoginServiceMock) OIDCLogin(ctx context.Context, state string, code string) (*auth.TokenPair, error) {
+	if mock.OIDCLoginFunc == nil {
+		panic("OIDCLoginServiceMock.OIDCLoginFunc: method is nil but OIDCLoginService.OIDCLogin was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		State string
+		Code  string
+	}{
+		Ctx:   ctx,
+		State: state,
+		Code:  code,
+	}
+	mock.lockOIDCLogin.Lock()
+	mock.calls.OIDCLogin = append(mock.calls.OIDCLogin, callInfo)
+	mock.lockOIDCLogin.Unlock()
+	return mock.OIDCLoginFunc(ctx, state, code)
+}
+
+// OIDCLoginCalls gets all the calls that were made to OIDCLogin.
+// Check the length with:
+//
+//	len(mockedOIDCLoginService.OIDCLoginCalls())
+func (mock *OIDCLoginServiceMock) OIDCLoginCalls() []struct {
+	Ctx   context.Context
+	State string
+	Code  string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		State string
+		Code  string
+	}
+	mock.lockOIDCLogin.RLock()
+	calls = mock.calls.OIDCLogin
+	mock.lockOIDCLogin.RUnlock()
+	return calls
+}
+
+// Ensure, that RefreshTokenServiceMock does implement RefreshTokenService.
+// If this is not the case, regenerate this file with moq.
+var _ RefreshTokenService = &RefreshTokenServiceMock{}
+
+// RefreshTokenServiceMock is a mock implementation of RefreshTokenService.
+//
+//	func TestSomethingThatUsesRefreshTokenService(t *testing.T) {
+//
+//		// make and configure a mocked RefreshTokenService
+//		mockedRefreshTokenService := &RefreshTokenServiceMock{
+//			RefreshTokenFunc: func(ctx context.Context, token string) (*auth.TokenPair, error) {
+//				panic("mock out the RefreshToken method")
+//			},
+//		}
+//
+//		// use mockedRefreshTokenService in code that requires RefreshTokenService
+//		// and then make assertions.
+//
+//	}
+type RefreshTokenServiceMock struct {
+	// RefreshTokenFunc mocks the RefreshToken method.
+	RefreshTokenFunc func(ctx context.Context, token string) (*auth.TokenPair, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RefreshToken holds details about calls to the RefreshToken method.
+		RefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+	}
+	lockRefreshToken sync.RWMutex
+}
+
+// RefreshToken calls RefreshTokenFunc.
+func (mock *RefreshTokenServiceMock) RefreshToken(ctx context.Context, token string) (*auth.TokenPair, error) {
+	if mock.RefreshTokenFunc == nil {
+		panic("RefreshTokenServiceMock.RefreshTokenFunc: method is nil but RefreshTokenService.RefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockRefreshToken.Lock()
+	mock.calls.RefreshToken = append(mock.calls.RefreshToken, callInfo)
+	mock.lockRefreshToken.Unlock()
+	return mock.RefreshTokenFunc(ctx, token)
+}
+
+// RefreshTokenCalls gets all the calls that were made to RefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenService.RefreshTokenCalls())
+func (mock *RefreshTokenServiceMock) RefreshTokenCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockRefreshToken.RLock()
+	calls = mock.calls.RefreshToken
+	mock.lockRefreshToken.RUnlock()
+	return calls
+}
+
+// Ensure, that LogoutServiceMock does implement LogoutService.
+// If this is not the case, regenerate this file with moq.
+var _ LogoutService = &LogoutServiceMock{}
+
+// LogoutServiceMock is a mock implementation of LogoutService.
+//
+//	func TestSomethingThatUsesLogoutService(t *testing.T) {
+//
+//		// make and configure a mocked LogoutService
+//		mockedLogoutService := &LogoutServiceMock{
+//			LogoutFunc: func(ctx context.Context, all bool) error {
+//				panic("mock out the Logout method")
+//			},
+//		}
+//
+//		// use mockedLogoutService in code that requires LogoutService
+//		// and then make assertions.
+//
+//	}
+type LogoutServiceMock struct {
+	// LogoutFunc mocks the Logout method.
+	LogoutFunc func(ctx context.Context, all bool) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Logout holds details about calls to the Logout method.
+		Logout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// All is the all argument value.
+			All bool
+		}
+	}
+	lockLogout sync.RWMutex
+}
+
+// Logout calls LogoutFunc.
+func (mock *LogoutServiceMock) Logout(ctx context.Context, all bool) error {
+	if mock.LogoutFunc == nil {
+		panic("LogoutServiceMock.LogoutFunc: method is nil but LogoutService.Logout was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		All bool
+	}{
+		Ctx: ctx,
+		All: all,
+	}
+	mock.lockLogout.Lock()
+	mock.calls.Logout = append(mock.calls.Logout, callInfo)
+	mock.lockLogout.Unlock()
+	return mock.LogoutFunc(ctx, all)
+}
+
+// LogoutCalls gets all the calls that were made to Logout.
+// Check the length with:
+//
+//	len(mockedLogoutService.LogoutCalls())
+func (mock *LogoutServiceMock) LogoutCalls() []struct {
+	Ctx context.Context
+	All bool
+} {
+	var calls []struct {
+		Ctx context.Context
+		All bool
+	}
+	mock.lockLogout.RLock()
+	calls = mock.calls.Logout
+	mock.lockLogout.RUnlock()
+	return calls
+}
+
+// Ensure, that ListSessionsServiceMock does implement ListSessionsService.
+// If this is not the case, regenerate this file with moq.
+var _ ListSessionsService = &ListSessionsServiceMock{}
+
+// ListSessionsServiceMock is a mock implementation of ListSessionsService.
+//
+//	func TestSomethingThatUsesListSessionsService(t *testing.T) {
+//
+//		// make and configure a mocked ListSessionsService
+//		mockedListSessionsService := &ListSessionsServiceMock{
+//			ListSessionsFunc: func(ctx context.Context) (entity.Sessions, error) {
+//				panic("mock out the ListSessions method")
+//			},
+//		}
+//
+//		// use mockedListSessionsService in code that requires ListSessionsService
+//		// and then make assertions.
+//
+//	}
+type ListSessionsServiceMock struct {
+	// ListSessionsFunc mocks the ListSessions method.
+	ListSessionsFunc func(ctx context.Context) (entity.Sessions, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListSessions holds details about calls to the ListSessions method.
+		ListSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockListSessions sync.RWMutex
+}
+
+// ListSessions calls ListSessionsFunc.
+func (mock *ListSessionsServiceMock) ListSessions(ctx context.Context) (entity.Sessions, error) {
+	if mock.ListSessionsFunc == nil {
+		panic("ListSessionsServiceMock.ListSessionsFunc: method is nil but ListSessionsService.ListSessions was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListSessions.Lock()
+	mock.calls.ListSessions = append(mock.calls.ListSessions, callInfo)
+	mock.lockListSessions.Unlock()
+	return mock.ListSessionsFunc(ctx)
+}
+
+// ListSessionsCalls gets all the calls that were made to ListSessions.
+// Check the length with:
+//
+//	len(mockedListSessionsService.ListSessionsCalls())
+func (mock *ListSessionsServiceMock) ListSessionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListSessions.RLock()
+	calls = mock.calls.ListSessions
+	mock.lockListSessions.RUnlock()
+	return calls
+}
+
+// Ensure, that DeleteSessionServiceMock does implement DeleteSessionService.
+// If this is not the case, regenerate this file with moq.
+var _ DeleteSessionService = &DeleteSessionServiceMock{}
+
+// DeleteSessionServiceMock is a mock implementation of DeleteSessionService.
+//
+//	func TestSomethingThatUsesDeleteSessionService(t *testing.T) {
+//
+//		// make and configure a mocked DeleteSessionService
+//		mockedDeleteSessionService := &DeleteSessionServiceMock{
+//			DeleteSessionFunc: func(ctx context.Context, sid string) error {
+//				panic("mock out the DeleteSession method")
+//			},
+//		}
+//
+//		// use mockedDeleteSessionService in code that requires DeleteSessionService
+//		// and then make assertions.
+//
+//	}
+type DeleteSessionServiceMock struct {
+	// DeleteSessionFunc mocks the DeleteSession method.
+	DeleteSessionFunc func(ctx context.Context, sid string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteSession holds details about calls to the DeleteSession method.
+		DeleteSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Sid is the sid argument value.
+			Sid string
+		}
+	}
+	lockDeleteSession sync.RWMutex
+}
+
+// DeleteSession calls DeleteSessionFunc.
+func (mock *DeleteSessionServiceMock) DeleteSession(ctx context.Context, sid string) error {
+	if mock.DeleteSessionFunc == nil {
+		panic("DeleteSessionServiceMock.DeleteSessionFunc: method is nil but DeleteSessionService.DeleteSession was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Sid string
+	}{
+		Ctx: ctx,
+		Sid: sid,
+	}
+	mock.lockDeleteSession.Lock()
+	mock.calls.DeleteSession = append(mock.calls.DeleteSession, callInfo)
+	mock.lockDeleteSession.Unlock()
+	return mock.DeleteSessionFunc(ctx, sid)
+}
+
+// DeleteSessionCalls gets all the calls that were made to DeleteSession.
+// Check the length with:
+//
+//	len(mockedDeleteSessionService.DeleteSessionCalls())
+func (mock *DeleteSessionServiceMock) DeleteSessionCalls() []struct {
+	Ctx context.Context
+	Sid string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Sid string
+	}
+	mock.lockDeleteSession.RLock()
+	calls = mock.calls.DeleteSession
+	mock.lockDeleteSession.RUnlock()
+	return calls
+}
+
+// Ensure, that JWKSServiceMock does implement JWKSService.
+// If this is not the case, regenerate this file with moq.
+var _ JWKSService = &JWKSServiceMock{}
+
+// JWKSServiceMock is a mock implementation of JWKSService.
+//
+//	func TestSomethingThatUsesJWKSService(t *testing.T) {
+//
+//		// make and configure a mocked JWKSService
+//		mockedJWKSService := &JWKSServiceMock{
+//			PublicKeysFunc: func(ctx context.Context) (jwk.Set, error) {
+//				panic("mock out the PublicKeys method")
+//			},
+//		}
+//
+//		// use mockedJWKSService in code that requires JWKSService
+//		// and then make assertions.
+//
+//	}
+type JWKSServiceMock struct {
+	// PublicKeysFunc mocks the PublicKeys method.
+	PublicKeysFunc func(ctx context.Context) (jwk.Set, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// PublicKeys holds details about calls to the PublicKeys method.
+		PublicKeys []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockPublicKeys sync.RWMutex
+}
+
+// PublicKeys calls PublicKeysFunc.
+func (mock *JWKSServiceMock) PublicKeys(ctx context.Context) (jwk.Set, error) {
+	if mock.PublicKeysFunc == nil {
+		panic("JWKSServiceMock.PublicKeysFunc: method is nil but JWKSService.PublicKeys was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPublicKeys.Lock()
+	mock.calls.PublicKeys = append(mock.calls.PublicKeys, callInfo)
+	mock.lockPublicKeys.Unlock()
+	return mock.PublicKeysFunc(ctx)
+}
+
+// PublicKeysCalls gets all the calls that were made to PublicKeys.
+// Check the length with:
+//
+//	len(mockedJWKSService.PublicKeysCalls())
+func (mock *JWKSServiceMock) PublicKeysCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockPublicKeys.RLock()
+	calls = mock.calls.PublicKeys
+	mock.lockPublicKeys.RUnlock()
+	return calls
+}
+
+// Ensure, that AddPersonalAccessTokenServiceMock does implement AddPersonalAccessTokenService.
+// If this is not the case, regenerate this file with moq.
+var _ AddPersonalAccessTokenService = &AddPersonalAccessTokenServiceMock{}
+
+// AddPersonalAccessTokenServiceMock is a mock implementation of AddPersonalAccessTokenService.
+//
+//	func TestSomethingThatUsesAddPersonalAccessTokenService(t *testing.T) {
+//
+//		// make and configure a mocked AddPersonalAccessTokenService
+//		mockedAddPersonalAccessTokenService := &AddPersonalAccessTokenServiceMock{
+//			AddPersonalAccessTokenFunc: func(ctx context.Context, name string, scopes entity.Scopes, expiresAt *time.Time) (*entity.PersonalAccessToken, string, error) {
+//				panic("mock out the AddPersonalAccessToken method")
+//			},
+//		}
+//
+//		// use mockedAddPersonalAccessTokenService in code that requires AddPersonalAccessTokenService
+//		// and then make assertions.
+//
+//	}
+type AddPersonalAccessTokenServiceMock struct {
+	// AddPersonalAccessTokenFunc mocks the AddPersonalAccessToken method.
+	AddPersonalAccessTokenFunc func(ctx context.Context, name string, scopes entity.Scopes, expiresAt *time.Time) (*entity.PersonalAccessToken, string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddPersonalAccessToken holds details about calls to the AddPersonalAccessToken method.
+		AddPersonalAccessToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Scopes is the scopes argument value.
+			Scopes entity.Scopes
+			// ExpiresAt is the expiresAt argument value.
+			ExpiresAt *time.Time
+		}
+	}
+	lockAddPersonalAccessToken sync.RWMutex
+}
+
+// AddPersonalAccessToken calls AddPersonalAccessTokenFunc.
+func (mock *AddPersonalAccessTokenServiceMock) AddPersonalAccessToken(ctx context.Context, name string, scopes entity.Scopes, expiresAt *time.Time) (*entity.PersonalAccessToken, string, error) {
+	if mock.AddPersonalAccessTokenFunc == nil {
+		panic("AddPersonalAccessTokenServiceMock.AddPersonalAccessTokenFunc: method is nil but AddPersonalAccessTokenService.AddPersonalAccessToken was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Name      string
+		Scopes    entity.Scopes
+		ExpiresAt *time.Time
+	}{
+		Ctx:       ctx,
+		Name:      name,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+	mock.lockAddPersonalAccessToken.Lock()
+	mock.calls.AddPersonalAccessToken = append(mock.calls.AddPersonalAccessToken, callInfo)
+	mock.lockAddPersonalAccessToken.Unlock()
+	return mock.AddPersonalAccessTokenFunc(ctx, name, scopes, expiresAt)
+}
+
+// AddPersonalAccessTokenCalls gets all the calls that were made to AddPersonalAccessToken.
+// Check the length with:
+//
+//	len(mockedAddPersonalAccessTokenService.AddPersonalAccessTokenCalls())
+func (mock *AddPersonalAccessTokenServiceMock) AddPersonalAccessTokenCalls() []struct {
+	Ctx       context.Context
+	Name      string
+	Scopes    entity.Scopes
+	ExpiresAt *time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Name      string
+		Scopes    entity.Scopes
+		ExpiresAt *time.Time
+	}
+	mock.lockAddPersonalAccessToken.RLock()
+	calls = mock.calls.AddPersonalAccessToken
+	mock.lockAddPersonalAccessToken.RUnlock()
+	return calls
+}
+
+// Ensure, that ListPersonalAccessTokensServiceMock does implement ListPersonalAccessTokensService.
+// If this is not the case, regenerate this file with moq.
+var _ ListPersonalAccessTokensService = &ListPersonalAccessTokensServiceMock{}
+
+// ListPersonalAccessTokensServiceMock is a mock implementation of ListPersonalAccessTokensService.
+//
+//	func TestSomethingThatUsesListPersonalAccessTokensService(t *testing.T) {
+//
+//		// make and configure a mocked ListPersonalAccessTokensService
+//		mockedListPersonalAccessTokensService := &ListPersonalAccessTokensServiceMock{
+//			ListPersonalAccessTokensFunc: func(ctx context.Context) (entity.PersonalAccessTokens, error) {
+//				panic("mock out the ListPersonalAccessTokens method")
+//			},
+//		}
+//
+//		// use mockedListPersonalAccessTokensService in code that requires ListPersonalAccessTokensService
+//		// and then make assertions.
+//
+//	}
+type ListPersonalAccessTokensServiceMock struct {
+	// ListPersonalAccessTokensFunc mocks the ListPersonalAccessTokens method.
+	ListPersonalAccessTokensFunc func(ctx context.Context) (entity.PersonalAccessTokens, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListPersonalAccessTokens holds details about calls to the ListPersonalAccessTokens method.
+		ListPersonalAccessTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockListPersonalAccessTokens sync.RWMutex
+}
+
+// ListPersonalAccessTokens calls ListPersonalAccessTokensFunc.
+func (mock *ListPersonalAccessTokensServiceMock) ListPersonalAccessTokens(ctx context.Context) (entity.PersonalAccessTokens, error) {
+	if mock.ListPersonalAccessTokensFunc == nil {
+		panic("ListPersonalAccessTokensServiceMock.ListPersonalAccessTokensFunc: method is nil but ListPersonalAccessTokensService.ListPersonalAccessTokens was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListPersonalAccessTokens.Lock()
+	mock.calls.ListPersonalAccessTokens = append(mock.calls.ListPersonalAccessTokens, callInfo)
+	mock.lockListPersonalAccessTokens.Unlock()
+	return mock.ListPersonalAccessTokensFunc(ctx)
+}
+
+// ListPersonalAccessTokensCalls gets all the calls that were made to ListPersonalAccessTokens.
+// Check the length with:
+//
+//	len(mockedListPersonalAccessTokensService.ListPersonalAccessTokensCalls())
+func (mock *ListPersonalAccessTokensServiceMock) ListPersonalAccessTokensCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListPersonalAccessTokens.RLock()
+	calls = mock.calls.ListPersonalAccessTokens
+	mock.lockListPersonalAccessTokens.RUnlock()
+	return calls
+}
+
+// Ensure, that DeletePersonalAccessTokenServiceMock does implement DeletePersonalAccessTokenService.
+// If this is not the case, regenerate this file with moq.
+var _ DeletePersonalAccessTokenService = &DeletePersonalAccessTokenServiceMock{}
+
+// DeletePersonalAccessTokenServiceMock is a mock implementation of DeletePersonalAccessTokenService.
+//
+//	func TestSomethingThatUsesDeletePersonalAccessTokenService(t *testing.T) {
+//
+//		// make and configure a mocked DeletePersonalAccessTokenService
+//		mockedDeletePersonalAccessTokenService := &DeletePersonalAccessTokenServiceMock{
+//			DeletePersonalAccessTokenFunc: func(ctx context.Context, id entity.PersonalAccessTokenID) error {
+//				panic("mock out the DeletePersonalAccessToken method")
+//			},
+//		}
+//
+//		// use mockedDeletePersonalAccessTokenService in code that requires DeletePersonalAccessTokenService
+//		// and then make assertions.
+//
+//	}
+type DeletePersonalAccessTokenServiceMock struct {
+	// DeletePersonalAccessTokenFunc mocks the DeletePersonalAccessToken method.
+	DeletePersonalAccessTokenFunc func(ctx context.Context, id entity.PersonalAccessTokenID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeletePersonalAccessToken holds details about calls to the DeletePersonalAccessToken method.
+		DeletePersonalAccessToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.PersonalAccessTokenID
+		}
+	}
+	lockDeletePersonalAccessToken sync.RWMutex
+}
+
+// DeletePersonalAccessToken calls DeletePersonalAccessTokenFunc.
+func (mock *DeletePersonalAccessTokenServiceMock) DeletePersonalAccessToken(ctx context.Context, id entity.PersonalAccessTokenID) error {
+	if mock.DeletePersonalAccessTokenFunc == nil {
+		panic("DeletePersonalAccessTokenServiceMock.DeletePersonalAccessTokenFunc: method is nil but DeletePersonalAccessTokenService.DeletePersonalAccessToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.PersonalAccessTokenID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeletePersonalAccessToken.Lock()
+	mock.calls.DeletePersonalAccessToken = append(mock.calls.DeletePersonalAccessToken, callInfo)
+	mock.lockDeletePersonalAccessToken.Unlock()
+	return mock.DeletePersonalAccessTokenFunc(ctx, id)
+}
+
+// DeletePersonalAccessTokenCalls gets all the calls that were made to DeletePersonalAccessToken.
+// Check the length with:
+//
+//	len(mockedDeletePersonalAccessTokenService.DeletePersonalAccessTokenCalls())
+func (mock *DeletePersonalAccessTokenServiceMock) DeletePersonalAccessTokenCalls() []struct {
+	Ctx context.Context
+	ID  entity.PersonalAccessTokenID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.PersonalAccessTokenID
+	}
+	mock.lockDeletePersonalAccessToken.RLock()
+	calls = mock.calls.DeletePersonalAccessToken
+	mock.lockDeletePersonalAccessToken.RUnlock()
 	return calls
 }
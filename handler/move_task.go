@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type MoveTask struct {
+	Service MoveTaskService
+}
+
+func (mt *MoveTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := taskIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	var b struct {
+		ParentID *entity.TaskID `json:"parent_id"` // nullの場合は親タスクを持たないタスクとする
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+
+	t, err := mt.Service.MoveTask(ctx, id, b.ParentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+		case errors.Is(err, store.ErrTaskCycle), errors.Is(err, store.ErrTaskDepthExceeded):
+			// 親子関係が循環する場合、または階層数の上限を超える場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusConflict)
+		default:
+			// DB操作が失敗した場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	RespondJSON(ctx, w, newTask(t), http.StatusOK)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/oidc"
+	"github.com/ac0mz/go_todo_app/service"
+)
+
+type OIDCLogin struct {
+	Service OIDCLoginService
+}
+
+func (ol *OIDCLogin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+	if e := q.Get("error"); e != "" {
+		// ユーザが認可を拒否した場合等、IdPがエラーを返却した場合
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: oidc.ErrAuthentication.Error(),
+			Details: []string{e, q.Get("error_description")},
+		}, http.StatusUnauthorized)
+		return
+	}
+	state, code := q.Get("state"), q.Get("code")
+	if state == "" || code == "" {
+		RespondJSON(ctx, w, &ErrResponse{Message: "state and code are required"}, http.StatusBadRequest)
+		return
+	}
+	// 認可リクエストを開始したブラウザからのコールバックであることを確認する
+	c, err := r.Cookie(oidcStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(c.Value), []byte(state)) != 1 {
+		RespondJSON(ctx, w, &ErrResponse{Message: service.ErrInvalidOIDCState.Error()}, http.StatusBadRequest)
+		return
+	}
+	// stateは1回のみ使用できるため、結果によらずクッキーを削除する
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Path:     oidcCookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	tokens, err := ol.Service.OIDCLogin(ctx, state, code)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidOIDCState):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		case errors.Is(err, oidc.ErrAuthentication):
+			// IDトークンの検証結果の詳細は返却しない
+			RespondJSON(ctx, w, &ErrResponse{Message: oidc.ErrAuthentication.Error()}, http.StatusUnauthorized)
+		case errors.Is(err, auth.ErrUserDisabled):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusForbidden)
+		default:
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	RespondJSON(ctx, w, newTokenPair(tokens), http.StatusOK)
+}
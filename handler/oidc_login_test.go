@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/oidc"
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		query  string
+		cookie string // 空文字の場合はクッキーを送信しない
+		err    error
+		want   want
+	}{
+		"ok": {
+			query:  "state=abc&code=xyz",
+			cookie: "abc",
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/oidc_login/ok_rsp.json.golden",
+			},
+		},
+		"idpError": {
+			query: "error=access_denied&error_description=denied+by+user&state=abc",
+			want: want{
+				status:  http.StatusUnauthorized,
+				rspFile: "testdata/oidc_login/idp_error_rsp.json.golden",
+			},
+		},
+		"missingCode": {
+			query:  "state=abc",
+			cookie: "abc",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/oidc_login/bad_req_rsp.json.golden",
+			},
+		},
+		"cookieMismatch": {
+			query:  "state=abc&code=xyz",
+			cookie: "other",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/oidc_login/invalid_state_rsp.json.golden",
+			},
+		},
+		"invalidState": {
+			query:  "state=abc&code=xyz",
+			cookie: "abc",
+			err:    service.ErrInvalidOIDCState,
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/oidc_login/invalid_state_rsp.json.golden",
+			},
+		},
+		"authenticationFailed": {
+			query:  "state=abc&code=xyz",
+			cookie: "abc",
+			err:    fmt.Errorf("failed to exchange code: %w: nonce mismatch", oidc.ErrAuthentication),
+			want: want{
+				status:  http.StatusUnauthorized,
+				rspFile: "testdata/oidc_login/unauthorized_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/auth/oidc/callback?"+tt.query, nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: oidcStateCookie, Value: tt.cookie})
+			}
+
+			// モック準備
+			moq := &OIDCLoginServiceMock{}
+			moq.OIDCLoginFunc = func(ctx context.Context, state, code string) (*auth.TokenPair, error) {
+				if state != "abc" || code != "xyz" {
+					t.Errorf("unexpected state %q and code %q", state, code)
+				}
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &auth.TokenPair{AccessToken: "from_moq", RefreshToken: "refresh_from_moq"}, nil
+			}
+
+			sut := OIDCLogin{Service: moq}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
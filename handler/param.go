@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/go-chi/chi/v5"
+)
+
+// taskIDParam はURLパスパラメータ{id}からタスクIDを取得する
+func taskIDParam(r *http.Request) (entity.TaskID, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid task id: %w", err)
+	}
+	return entity.TaskID(id), nil
+}
+
+// blockerIDParam はURLパスパラメータ{blocker_id}から依存先のタスクIDを取得する
+func blockerIDParam(r *http.Request) (entity.TaskID, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "blocker_id"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid blocker task id: %w", err)
+	}
+	return entity.TaskID(id), nil
+}
+
+// tagIDParam はURLパスパラメータkeyからタグIDを取得する
+func tagIDParam(r *http.Request, key string) (entity.TagID, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, key), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tag id: %w", err)
+	}
+	return entity.TagID(id), nil
+}
+
+// projectIDParam はURLパスパラメータ{id}からプロジェクトIDを取得する
+func projectIDParam(r *http.Request) (entity.ProjectID, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid project id: %w", err)
+	}
+	return entity.ProjectID(id), nil
+}
+
+// patIDParam はURLパスパラメータ{id}からパーソナルアクセストークンIDを取得する
+func patIDParam(r *http.Request) (entity.PersonalAccessTokenID, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid personal access token id: %w", err)
+	}
+	return entity.PersonalAccessTokenID(id), nil
+}
+
+// userIDParam はURLパスパラメータ{id}からユーザIDを取得する
+func userIDParam(r *http.Request) (entity.UserID, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user id: %w", err)
+	}
+	return entity.UserID(id), nil
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/go-playground/validator/v10"
+)
+
+type RefreshToken struct {
+	Service   RefreshTokenService
+	Validator *validator.Validate
+}
+
+func (rt *RefreshToken) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		RefreshToken string `json:"refresh_token" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	// 必須チェック
+	if err := rt.Validator.Struct(body); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := rt.Service.RefreshToken(ctx, body.RefreshToken)
+	if err != nil {
+		// 失効済、再使用、またはユーザが存在しない場合は再ログインを要求する
+		if errors.Is(err, auth.ErrInvalidRefreshToken) ||
+			errors.Is(err, auth.ErrRefreshTokenReused) ||
+			errors.Is(err, store.ErrNotFound) {
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, auth.ErrUserDisabled) {
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusForbidden)
+			return
+		}
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, newTokenPair(tokens), http.StatusOK)
+}
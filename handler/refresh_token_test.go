@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestRefreshToken(t *testing.T) {
+	t.Parallel()
+	type moq struct {
+		tokens *auth.TokenPair
+		err    error
+	}
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		moq     moq
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/refresh_token/ok_req.json.golden",
+			moq: moq{
+				tokens: &auth.TokenPair{AccessToken: "from_moq", RefreshToken: "rotated_from_moq"},
+			},
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/refresh_token/ok_rsp.json.golden",
+			},
+		},
+		"badRequest": {
+			reqFile: "testdata/refresh_token/bad_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/refresh_token/bad_req_rsp.json.golden",
+			},
+		},
+		"reused": {
+			reqFile: "testdata/refresh_token/ok_req.json.golden",
+			moq: moq{
+				err: fmt.Errorf("failed to rotate refresh token: %w", auth.ErrRefreshTokenReused),
+			},
+			want: want{
+				status:  http.StatusUnauthorized,
+				rspFile: "testdata/refresh_token/reused_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/token/refresh",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+
+			// モック準備
+			moq := &RefreshTokenServiceMock{}
+			moq.RefreshTokenFunc = func(ctx context.Context, token string) (*auth.TokenPair, error) {
+				return tt.moq.tokens, tt.moq.err
+			}
+
+			sut := RefreshToken{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
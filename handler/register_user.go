@@ -2,9 +2,12 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/service"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -20,16 +23,33 @@ func (ru RegisterUser) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var b struct {
 		Name     string `json:"name" validate:"required"`
 		Password string `json:"password" validate:"required"`
-		Role     string `json:"role" validate:"required"`
+		Role     string `json:"role"` // 既定のロール以外は指定不可
+		Email    string `json:"email" validate:"omitempty,email,max=254"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
 		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
 		return
 	}
+	if err := ru.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	if b.Role != "" && entity.Role(b.Role) != entity.DefaultRole {
+		// ロールの変更は管理者のみに許可する
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: fmt.Sprintf("role %q cannot be assigned on registration", b.Role),
+		}, http.StatusForbidden)
+		return
+	}
 
 	// DB登録
-	u, err := ru.Service.RegisterUser(ctx, b.Name, b.Password, b.Role)
+	u, err := ru.Service.RegisterUser(ctx, b.Name, b.Password, b.Email)
 	if err != nil {
+		if errors.Is(err, service.ErrWeakPassword) {
+			// パスワードポリシーを満たさない場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+			return
+		}
 		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
 		return
 	}
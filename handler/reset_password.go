@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/go-playground/validator/v10"
+)
+
+// ResetPassword はパスワード再設定トークンを使用してパスワードを再設定するハンドラ
+type ResetPassword struct {
+	Service   ResetPasswordService
+	Validator *validator.Validate
+}
+
+func (rp *ResetPassword) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Token       string `json:"token" validate:"required"`
+		NewPassword string `json:"new_password" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := rp.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	if err := rp.Service.ResetPassword(ctx, b.Token, b.NewPassword); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidResetToken), errors.Is(err, service.ErrWeakPassword):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		default:
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	rsp := struct {
+		Message string `json:"message"`
+	}{Message: "password has been reset"}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestResetPassword(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		err  error
+		want want
+	}{
+		"ok": {
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/reset_password/ok_rsp.json.golden",
+			},
+		},
+		"invalidToken": {
+			err: service.ErrInvalidResetToken,
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/reset_password/invalid_token_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/password/reset",
+				bytes.NewReader(testutil.LoadFile(t, "testdata/reset_password/ok_req.json.golden")),
+			)
+
+			// モック準備
+			moq := &ResetPasswordServiceMock{}
+			moq.ResetPasswordFunc = func(ctx context.Context, token, password string) error {
+				return tt.err
+			}
+
+			sut := ResetPassword{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestRestoreTask(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		task *entity.Task
+		err  error
+		want want
+	}{
+		"ok": {
+			task: &entity.Task{
+				ID:       1,
+				Title:    "restored",
+				Status:   entity.TaskStatusTodo,
+				Priority: entity.TaskPriorityNormal,
+			},
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/restore_task/ok_rsp.json.golden",
+			},
+		},
+		"notFound": {
+			err: fmt.Errorf("failed to restore: task 1: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/restore_task/not_found_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/tasks/1/restore", nil)
+			r = testutil.WithURLParams(r, map[string]string{"id": "1"})
+
+			// モック準備
+			moq := &RestoreTaskServiceMock{}
+			moq.RestoreTaskFunc = func(ctx context.Context, id entity.TaskID) (*entity.Task, error) {
+				return tt.task, tt.err
+			}
+
+			sut := RestoreTask{Service: moq}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
@@ -2,25 +2,230 @@ package handler
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/ac0mz/go_todo_app/auth"
 	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 )
 
 // 以下インターフェースの実装はserviceパッケージ配下に格納
 
-//go:generate go run github.com/matryer/moq -out moq_test.go . ListTasksService AddTaskService RegisterUserService LoginService
+//go:generate go run github.com/matryer/moq -out moq_test.go . ListTasksService AddTaskService GetTaskService UpdateTaskService DeleteTaskService TransitionTaskService ListChildTasksService MoveTaskService AddTaskDependencyService DeleteTaskDependencyService ListTrashService RestoreTaskService EmptyTrashService AddTagService ListTagsService UpdateTagService DeleteTagService AttachTagService DetachTagService AddProjectService ListProjectsService GetProjectService UpdateProjectService ArchiveProjectService DeleteProjectService ListProjectTasksService RegisterUserService UpdateUserRoleService ListUsersService GetUserService SetUserDisabledService ForceLogoutService ChangePasswordService RequirePasswordResetService ForgotPasswordService ResetPasswordService SetupTwoFactorService ConfirmTwoFactorService GetProfileService UpdateProfileService DeleteAccountService ExportAccountService LoginService LoginTwoFactorService StartOIDCLoginService OIDCLoginService RefreshTokenService LogoutService ListSessionsService DeleteSessionService JWKSService AddPersonalAccessTokenService ListPersonalAccessTokensService DeletePersonalAccessTokenService
 type ListTasksService interface {
-	ListTasks(ctx context.Context) (entity.Tasks, error)
+	ListTasks(ctx context.Context, f *entity.TaskFilter) (entity.Tasks, string, error)
 }
 
 type AddTaskService interface {
-	AddTask(ctx context.Context, title string) (*entity.Task, error)
+	AddTask(ctx context.Context, t *entity.Task) error
+}
+
+type GetTaskService interface {
+	GetTask(ctx context.Context, id entity.TaskID) (*entity.Task, error)
+}
+
+type UpdateTaskService interface {
+	UpdateTask(ctx context.Context, id entity.TaskID, title string) (*entity.Task, error)
+}
+
+type DeleteTaskService interface {
+	DeleteTask(ctx context.Context, id entity.TaskID) error
+}
+
+type TransitionTaskService interface {
+	TransitionTask(ctx context.Context, id entity.TaskID, to entity.TaskStatus, force bool) (*entity.Task, error)
+}
+
+type ListChildTasksService interface {
+	ListChildTasks(ctx context.Context, id entity.TaskID) (entity.Tasks, error)
+}
+
+type MoveTaskService interface {
+	MoveTask(ctx context.Context, id entity.TaskID, parentID *entity.TaskID) (*entity.Task, error)
+}
+
+type AddTaskDependencyService interface {
+	AddTaskDependency(ctx context.Context, blockerID, blockedID entity.TaskID) error
+}
+
+type DeleteTaskDependencyService interface {
+	DeleteTaskDependency(ctx context.Context, blockerID, blockedID entity.TaskID) error
+}
+
+type ListTrashService interface {
+	ListTrash(ctx context.Context) (entity.Tasks, error)
+}
+
+type RestoreTaskService interface {
+	RestoreTask(ctx context.Context, id entity.TaskID) (*entity.Task, error)
+}
+
+type EmptyTrashService interface {
+	EmptyTrash(ctx context.Context) (int64, error)
+}
+
+type AddTagService interface {
+	AddTag(ctx context.Context, name string) (*entity.Tag, error)
+}
+
+type ListTagsService interface {
+	ListTags(ctx context.Context) (entity.Tags, error)
+}
+
+type UpdateTagService interface {
+	UpdateTag(ctx context.Context, id entity.TagID, name string) (*entity.Tag, error)
+}
+
+type DeleteTagService interface {
+	DeleteTag(ctx context.Context, id entity.TagID) error
+}
+
+type AttachTagService interface {
+	AttachTag(ctx context.Context, taskID entity.TaskID, tagID entity.TagID) error
+}
+
+type DetachTagService interface {
+	DetachTag(ctx context.Context, taskID entity.TaskID, tagID entity.TagID) error
+}
+
+type AddProjectService interface {
+	AddProject(ctx context.Context, name string) (*entity.Project, error)
+}
+
+type ListProjectsService interface {
+	ListProjects(ctx context.Context, includeArchived bool) (entity.Projects, error)
+}
+
+type GetProjectService interface {
+	GetProject(ctx context.Context, id entity.ProjectID) (*entity.Project, error)
+}
+
+type UpdateProjectService interface {
+	UpdateProject(ctx context.Context, id entity.ProjectID, name string) (*entity.Project, error)
+}
+
+type ArchiveProjectService interface {
+	ArchiveProject(ctx context.Context, id entity.ProjectID, archived bool) (*entity.Project, error)
+}
+
+type DeleteProjectService interface {
+	DeleteProject(ctx context.Context, id entity.ProjectID) error
+}
+
+type ListProjectTasksService interface {
+	ListProjectTasks(ctx context.Context, id entity.ProjectID, f *entity.TaskFilter) (entity.Tasks, string, error)
 }
 
 type RegisterUserService interface {
-	RegisterUser(ctx context.Context, name, password, role string) (*entity.User, error)
+	RegisterUser(ctx context.Context, name, password, email string) (*entity.User, error)
+}
+
+type UpdateUserRoleService interface {
+	UpdateUserRole(ctx context.Context, id entity.UserID, role entity.Role) (*entity.User, error)
+}
+
+type ListUsersService interface {
+	ListUsers(ctx context.Context, f *entity.UserFilter) (entity.Users, string, error)
+}
+
+type GetUserService interface {
+	GetUser(ctx context.Context, id entity.UserID) (*entity.User, error)
+}
+
+type SetUserDisabledService interface {
+	SetUserDisabled(ctx context.Context, id entity.UserID, disabled bool) (*entity.User, error)
+}
+
+type ForceLogoutService interface {
+	ForceLogout(ctx context.Context, id entity.UserID) error
+}
+
+type ChangePasswordService interface {
+	ChangePassword(ctx context.Context, current, password string) (*auth.TokenPair, error)
+}
+
+type RequirePasswordResetService interface {
+	RequirePasswordReset(ctx context.Context, id entity.UserID) error
+}
+
+type ForgotPasswordService interface {
+	ForgotPassword(ctx context.Context, email string) error
+}
+
+type ResetPasswordService interface {
+	ResetPassword(ctx context.Context, token, password string) error
+}
+
+type SetupTwoFactorService interface {
+	SetupTwoFactor(ctx context.Context) (string, string, error)
+}
+
+type ConfirmTwoFactorService interface {
+	ConfirmTwoFactor(ctx context.Context, code string) ([]string, error)
+}
+
+type GetProfileService interface {
+	GetProfile(ctx context.Context) (*entity.User, error)
+}
+
+type UpdateProfileService interface {
+	UpdateProfile(ctx context.Context, displayName, timeZone, locale *string) (*entity.User, error)
+}
+
+type DeleteAccountService interface {
+	DeleteAccount(ctx context.Context, password string) error
+}
+
+type ExportAccountService interface {
+	ExportAccount(ctx context.Context, w io.Writer) error
 }
 
 type LoginService interface {
-	Login(ctx context.Context, name, password string) (string, error)
+	Login(ctx context.Context, name, password, newPassword, ip string) (*service.LoginResult, error)
+}
+
+type LoginTwoFactorService interface {
+	LoginTwoFactor(ctx context.Context, challenge, code, newPassword, ip string) (*auth.TokenPair, error)
+}
+
+type StartOIDCLoginService interface {
+	StartOIDCLogin(ctx context.Context) (string, string, error)
+}
+
+type OIDCLoginService interface {
+	OIDCLogin(ctx context.Context, state, code string) (*auth.TokenPair, error)
+}
+
+type RefreshTokenService interface {
+	RefreshToken(ctx context.Context, token string) (*auth.TokenPair, error)
+}
+
+type LogoutService interface {
+	Logout(ctx context.Context, all bool) error
+}
+
+type ListSessionsService interface {
+	ListSessions(ctx context.Context) (entity.Sessions, error)
+}
+
+type DeleteSessionService interface {
+	DeleteSession(ctx context.Context, sid string) error
+}
+
+type JWKSService interface {
+	PublicKeys(ctx context.Context) (jwk.Set, error)
+}
+
+type AddPersonalAccessTokenService interface {
+	AddPersonalAccessToken(ctx context.Context, name string, scopes entity.Scopes, expiresAt *time.Time) (*entity.PersonalAccessToken, string, error)
+}
+
+type ListPersonalAccessTokensService interface {
+	ListPersonalAccessTokens(ctx context.Context) (entity.PersonalAccessTokens, error)
+}
+
+type DeletePersonalAccessTokenService interface {
+	DeletePersonalAccessToken(ctx context.Context, id entity.PersonalAccessTokenID) error
 }
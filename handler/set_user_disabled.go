@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// SetUserDisabled は管理者がユーザを無効化または有効化するハンドラ
+type SetUserDisabled struct {
+	Service  SetUserDisabledService
+	Disabled bool // trueの場合はユーザを無効化し、falseの場合は有効化する
+}
+
+func (sd *SetUserDisabled) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := userIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	u, err := sd.Service.SetUserDisabled(ctx, id, sd.Disabled)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+		case errors.Is(err, service.ErrOwnUserDisable):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		default:
+			// DBまたはRedis操作が失敗した場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	RespondJSON(ctx, w, newAdminUser(u), http.StatusOK)
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestSetUserDisabled(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		disabled bool
+		id       string
+		err      error
+		want     want
+	}{
+		"disable": {
+			disabled: true,
+			id:       "2",
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/set_user_disabled/disable_rsp.json.golden",
+			},
+		},
+		"enable": {
+			id: "2",
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/set_user_disabled/enable_rsp.json.golden",
+			},
+		},
+		"invalidID": {
+			disabled: true,
+			id:       "abc",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/set_user_disabled/bad_id_rsp.json.golden",
+			},
+		},
+		"ownAccount": {
+			disabled: true,
+			id:       "1",
+			err:      fmt.Errorf("user 1: %w", service.ErrOwnUserDisable),
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/set_user_disabled/own_account_rsp.json.golden",
+			},
+		},
+		"notFound": {
+			disabled: true,
+			id:       "3",
+			err:      fmt.Errorf("failed to get user: user 3: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/set_user_disabled/not_found_rsp.json.golden",
+			},
+		},
+		"internalServerError": {
+			disabled: true,
+			id:       "2",
+			err:      errors.New("error from mock"),
+			want: want{
+				status:  http.StatusInternalServerError,
+				rspFile: "testdata/set_user_disabled/status500_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/admin/users/"+tt.id+"/disable", nil)
+			r = testutil.WithURLParams(r, map[string]string{"id": tt.id})
+
+			// モック準備
+			moq := &SetUserDisabledServiceMock{}
+			moq.SetUserDisabledFunc = func(ctx context.Context, id entity.UserID, disabled bool) (*entity.User, error) {
+				if disabled != tt.disabled {
+					t.Errorf("want disabled %v, but got %v", tt.disabled, disabled)
+				}
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				now := clock.FixedClocker{}.Now()
+				u := &entity.User{
+					ID: id, Name: "john", Password: "hashed", Role: entity.RoleUser,
+					TimeZone: "UTC", Locale: "en-US", Created: now, Modified: now,
+				}
+				if disabled {
+					u.DisabledAt = &now
+				}
+				return u, nil
+			}
+
+			sut := SetUserDisabled{Service: moq, Disabled: tt.disabled}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
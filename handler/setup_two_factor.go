@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/service"
+)
+
+// SetupTwoFactor はログインユーザの二要素認証のシークレットを生成するハンドラ
+type SetupTwoFactor struct {
+	Service SetupTwoFactorService
+}
+
+func (st *SetupTwoFactor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	secret, uri, err := st.Service.SetupTwoFactor(ctx)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTwoFactorAlreadyEnabled):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusConflict)
+		default:
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	rsp := struct {
+		Secret     string `json:"secret"`
+		OTPAuthURI string `json:"otpauth_uri"`
+	}{Secret: secret, OTPAuthURI: uri}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
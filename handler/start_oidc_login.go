@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+)
+
+// oidcStateCookie は認可リクエストを開始したブラウザとコールバックを対応付けるクッキーの名前
+// 他者が開始した認可リクエストのコールバックを踏ませるログインCSRFを防ぐ
+const oidcStateCookie = "oidc_state"
+
+// oidcCookiePath はOpenID ConnectのAPIにのみクッキーを送信させるパス
+const oidcCookiePath = "/auth/oidc"
+
+type StartOIDCLogin struct {
+	Service StartOIDCLoginService
+}
+
+func (so *StartOIDCLogin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authURL, state, err := so.Service.StartOIDCLogin(ctx)
+	if err != nil {
+		// IdPのメタデータの取得、またはRedis操作が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     oidcCookiePath,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		// IdPからのリダイレクト(トップレベルのGET)でクッキーを送信させるため、StrictではなくLaxとする
+		SameSite: http.SameSiteLaxMode,
+	})
+	// ユーザをIdPの認可エンドポイントへリダイレクトさせる
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestStartOIDCLogin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/auth/oidc/start", nil)
+
+		moq := &StartOIDCLoginServiceMock{}
+		moq.StartOIDCLoginFunc = func(ctx context.Context) (string, string, error) {
+			return "https://idp.example.com/authorize?state=abc", "abc", nil
+		}
+		sut := StartOIDCLogin{Service: moq}
+		sut.ServeHTTP(w, r)
+
+		res := w.Result()
+		t.Cleanup(func() { _ = res.Body.Close() })
+		if res.StatusCode != http.StatusFound {
+			t.Fatalf("want status %d, but got %d", http.StatusFound, res.StatusCode)
+		}
+		if got := res.Header.Get("Location"); got != "https://idp.example.com/authorize?state=abc" {
+			t.Errorf("unexpected location %q", got)
+		}
+		// コールバックでstateを照合するためのクッキーを設定する
+		cs := res.Cookies()
+		if len(cs) != 1 || cs[0].Name != oidcStateCookie || cs[0].Value != "abc" ||
+			cs[0].Path != oidcCookiePath || !cs[0].HttpOnly {
+			t.Errorf("unexpected cookies: %+v", cs)
+		}
+	})
+	t.Run("internalServerError", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/auth/oidc/start", nil)
+
+		moq := &StartOIDCLoginServiceMock{}
+		moq.StartOIDCLoginFunc = func(ctx context.Context) (string, string, error) {
+			return "", "", errors.New("error from mock")
+		}
+		sut := StartOIDCLogin{Service: moq}
+		sut.ServeHTTP(w, r)
+
+		testutil.AssertResponse(t,
+			w.Result(), http.StatusInternalServerError, testutil.LoadFile(t, "testdata/start_oidc_login/status500_rsp.json.golden"),
+		)
+	})
+}
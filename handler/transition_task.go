@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/go-playground/validator/v10"
+)
+
+type TransitionTask struct {
+	Service   TransitionTaskService
+	Validator *validator.Validate
+}
+
+func (tt *TransitionTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := taskIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	var b struct {
+		Status entity.TaskStatus `json:"status" validate:"required,oneof=todo doing done"`
+		Force  bool              `json:"force"` // 未完了の子タスクが存在しても完了させる場合はtrue
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := tt.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	t, err := tt.Service.TransitionTask(ctx, id, b.Status, b.Force)
+	if err != nil {
+		var terr *entity.TransitionError
+		var oerr *entity.OpenChildrenError
+		var berr *entity.BlockedError
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+		case errors.As(err, &terr):
+			// 遷移表で許可されていない遷移の場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusConflict)
+		case errors.As(err, &oerr):
+			// 未完了の子タスクが存在する場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusConflict)
+		case errors.As(err, &berr):
+			// 未完了のタスクに依存している場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusConflict)
+		default:
+			// DB操作が失敗した場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	RespondJSON(ctx, w, newTask(t), http.StatusOK)
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestTransitionTask(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		from    entity.TaskStatus
+		open    int // 未完了の子タスクの件数
+		blocked []entity.TaskID
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/transition_task/ok_req.json.golden",
+			from:    entity.TaskStatusTodo,
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/transition_task/ok_rsp.json.golden",
+			},
+		},
+		"badRequest": {
+			reqFile: "testdata/transition_task/bad_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/transition_task/bad_req_rsp.json.golden",
+			},
+		},
+		"conflict": {
+			reqFile: "testdata/transition_task/ok_req.json.golden",
+			from:    entity.TaskStatusDone,
+			want: want{
+				status:  http.StatusConflict,
+				rspFile: "testdata/transition_task/conflict_rsp.json.golden",
+			},
+		},
+		"blocked": {
+			reqFile: "testdata/transition_task/ok_req.json.golden",
+			from:    entity.TaskStatusTodo,
+			blocked: []entity.TaskID{2, 3},
+			want: want{
+				status:  http.StatusConflict,
+				rspFile: "testdata/transition_task/blocked_rsp.json.golden",
+			},
+		},
+		"openChildren": {
+			reqFile: "testdata/transition_task/done_req.json.golden",
+			from:    entity.TaskStatusDoing,
+			open:    2,
+			want: want{
+				status:  http.StatusConflict,
+				rspFile: "testdata/transition_task/open_children_rsp.json.golden",
+			},
+		},
+		"forced": {
+			reqFile: "testdata/transition_task/force_done_req.json.golden",
+			from:    entity.TaskStatusDoing,
+			open:    2,
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/transition_task/force_done_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/tasks/1/transition",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+			r = testutil.WithURLParams(r, map[string]string{"id": "1"})
+
+			// モック準備
+			moq := &TransitionTaskServiceMock{}
+			moq.TransitionTaskFunc = func(
+				ctx context.Context, id entity.TaskID, to entity.TaskStatus, force bool,
+			) (*entity.Task, error) {
+				if to == entity.TaskStatusDoing && len(tt.blocked) > 0 {
+					return nil, &entity.BlockedError{TaskID: id, Blockers: tt.blocked}
+				}
+				if to == entity.TaskStatusDone && !force && tt.open > 0 {
+					return nil, &entity.OpenChildrenError{TaskID: id, Open: tt.open}
+				}
+				t := &entity.Task{ID: id, Title: "test1", Status: tt.from, Priority: entity.TaskPriorityNormal}
+				if err := t.Transition(to, false); err != nil {
+					return nil, err
+				}
+				return t, nil
+			}
+
+			sut := TransitionTask{
+				Service:   moq,
+				Validator: validator.New(),
+			}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
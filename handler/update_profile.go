@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UpdateProfile はログインユーザの表示名、タイムゾーン、ロケールを更新するハンドラ
+type UpdateProfile struct {
+	Service   UpdateProfileService
+	Validator *validator.Validate
+}
+
+func (up *UpdateProfile) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	// 省略した項目は更新しない
+	var b struct {
+		DisplayName *string `json:"display_name" validate:"omitempty,max=64"`
+		TimeZone    *string `json:"time_zone" validate:"omitempty,timezone"`
+		Locale      *string `json:"locale" validate:"omitempty,bcp47_language_tag,max=35"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := up.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	u, err := up.Service.UpdateProfile(ctx, b.DisplayName, b.TimeZone, b.Locale)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, newProfile(u), http.StatusOK)
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestUpdateProfile(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/update_profile/ok_req.json.golden",
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/update_profile/ok_rsp.json.golden",
+			},
+		},
+		"badTimeZone": {
+			reqFile: "testdata/update_profile/bad_time_zone_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/update_profile/bad_time_zone_rsp.json.golden",
+			},
+		},
+		"badLocale": {
+			reqFile: "testdata/update_profile/bad_locale_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/update_profile/bad_locale_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPatch,
+				"/me",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+
+			// モック準備
+			moq := &UpdateProfileServiceMock{}
+			moq.UpdateProfileFunc = func(ctx context.Context, displayName, timeZone, locale *string) (*entity.User, error) {
+				email := "john@example.com"
+				c := clock.FixedClocker{}
+				return &entity.User{
+					ID: 1, Name: "john", Password: "hashed_password", Email: &email, Role: entity.RoleUser,
+					DisplayName: *displayName, TimeZone: *timeZone, Locale: *locale,
+					Created: c.Now(), Modified: c.Now(),
+				}, nil
+			}
+
+			sut := UpdateProfile{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
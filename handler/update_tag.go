@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/go-playground/validator/v10"
+)
+
+type UpdateTag struct {
+	Service   UpdateTagService
+	Validator *validator.Validate
+}
+
+func (ut *UpdateTag) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := tagIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	var b struct {
+		Name string `json:"name" validate:"required,max=64"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := ut.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	t, err := ut.Service.UpdateTag(ctx, id, b.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+		case errors.Is(err, store.ErrAlreadyEntry):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusConflict)
+		default:
+			// DB操作が失敗した場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	RespondJSON(ctx, w, tag{ID: t.ID, Name: t.Name}, http.StatusOK)
+}
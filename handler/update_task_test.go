@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestUpdateTask(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		err     error
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/update_task/ok_req.json.golden",
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/update_task/ok_rsp.json.golden",
+			},
+		},
+		"badRequest": {
+			reqFile: "testdata/update_task/bad_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/update_task/bad_req_rsp.json.golden",
+			},
+		},
+		"notFound": {
+			reqFile: "testdata/update_task/ok_req.json.golden",
+			err:     fmt.Errorf("failed to get: task 1: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/update_task/not_found_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPut,
+				"/tasks/1",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+			r = testutil.WithURLParams(r, map[string]string{"id": "1"})
+
+			// モック準備
+			moq := &UpdateTaskServiceMock{}
+			moq.UpdateTaskFunc = func(ctx context.Context, id entity.TaskID, title string) (*entity.Task, error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &entity.Task{ID: id, Title: title, Status: entity.TaskStatusTodo, Priority: entity.TaskPriorityNormal}, nil
+			}
+
+			sut := UpdateTask{
+				Service:   moq,
+				Validator: validator.New(),
+			}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/go-playground/validator/v10"
+)
+
+// UpdateUserRole は管理者がユーザのロールを変更するハンドラ
+type UpdateUserRole struct {
+	Service   UpdateUserRoleService
+	Validator *validator.Validate
+}
+
+// user はレスポンスとして返却するユーザ情報
+// パスワードのハッシュ値は含めない
+type user struct {
+	ID       entity.UserID `json:"id"`
+	Name     string        `json:"name"`
+	Role     entity.Role   `json:"role"`
+	Created  time.Time     `json:"created"`
+	Modified time.Time     `json:"modified"`
+}
+
+func newUser(u *entity.User) user {
+	return user{
+		ID:       u.ID,
+		Name:     u.Name,
+		Role:     u.Role,
+		Created:  u.Created,
+		Modified: u.Modified,
+	}
+}
+
+func (uu *UpdateUserRole) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := userIDParam(r)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	var b struct {
+		Role string `json:"role" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		// リクエスト情報の解析が失敗した場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+	if err := uu.Validator.Struct(b); err != nil {
+		// バリデーションエラーの場合
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+	role, err := entity.ParseRole(b.Role)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	u, err := uu.Service.UpdateUserRole(ctx, id, role)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusNotFound)
+		case errors.Is(err, service.ErrOwnRoleChange):
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusBadRequest)
+		default:
+			// DB操作が失敗した場合
+			RespondJSON(ctx, w, &ErrResponse{Message: err.Error()}, http.StatusInternalServerError)
+		}
+		return
+	}
+	RespondJSON(ctx, w, newUser(u), http.StatusOK)
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestUpdateUserRole(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		err     error
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/update_user_role/ok_req.json.golden",
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/update_user_role/ok_rsp.json.golden",
+			},
+		},
+		"unknownRole": {
+			reqFile: "testdata/update_user_role/bad_role_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/update_user_role/bad_role_rsp.json.golden",
+			},
+		},
+		"ownRole": {
+			reqFile: "testdata/update_user_role/ok_req.json.golden",
+			err:     fmt.Errorf("user 1: %w", service.ErrOwnRoleChange),
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/update_user_role/own_role_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPut,
+				"/admin/users/2/role",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+			r = testutil.WithURLParams(r, map[string]string{"id": "2"})
+
+			// モック準備
+			moq := &UpdateUserRoleServiceMock{}
+			moq.UpdateUserRoleFunc = func(ctx context.Context, id entity.UserID, role entity.Role) (*entity.User, error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				now := clock.FixedClocker{}.Now()
+				return &entity.User{ID: id, Name: "john", Password: "hashed", Role: role, Created: now, Modified: now}, nil
+			}
+
+			sut := UpdateUserRole{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+
+			res := w.Result()
+			testutil.AssertResponse(t,
+				res, tt.want.status, testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
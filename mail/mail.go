@@ -0,0 +1,130 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/smtp"
+	"strings"
+	"sync"
+
+	"github.com/ac0mz/go_todo_app/config"
+)
+
+// Message は送信するメールを表す
+type Message struct {
+	To      string
+	Subject string
+	Body    string // プレーンテキストの本文
+}
+
+// Mailer はメールの送信を扱う
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New は設定に応じたMailerを生成する
+// SMTPサーバが未指定の場合はメールを送信せずに破棄するため、開発環境での使用を想定する
+func New(cfg *config.Config) Mailer {
+	if cfg.SMTPHost == "" {
+		log.Printf("SMTP server is not configured, mails will not be delivered")
+		return Discard{}
+	}
+	s := &SMTP{
+		Addr: fmt.Sprintf("%s:%d", cfg.SMTPHost, cfg.SMTPPort),
+		From: cfg.MailFrom,
+	}
+	if cfg.SMTPUser != "" {
+		s.Auth = smtp.PlainAuth("", cfg.SMTPUser, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+	return s
+}
+
+// SMTP はSMTPサーバを経由してメールを送信する
+type SMTP struct {
+	Addr string    // SMTPサーバの"ホスト:ポート"
+	From string    // 送信元アドレス
+	Auth smtp.Auth // nilの場合は認証しない
+}
+
+// Send はメールを送信する
+// net/smtpはcontext.Contextによる中断に対応していないため、ctxは送信前の中断確認のみに使用する
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if strings.ContainsAny(msg.To+msg.Subject, "\r\n") {
+		// ヘッダーインジェクションを防ぐため、改行を含む宛先や件名は送信しない
+		return fmt.Errorf("invalid header value in message to %q", msg.To)
+	}
+	body := strings.Join([]string{
+		"From: " + s.From,
+		"To: " + msg.To,
+		"Subject: " + msg.Subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		msg.Body,
+	}, "\r\n")
+	if err := smtp.SendMail(s.Addr, s.Auth, s.From, []string{msg.To}, []byte(body)); err != nil {
+		return fmt.Errorf("failed to send mail to %q: %w", msg.To, err)
+	}
+	return nil
+}
+
+// Discard はメールを送信せずに破棄する
+// SMTPサーバを設定していない開発環境で使用し、本文にはトークン等の秘匿情報を含みうるため記録しない
+type Discard struct{}
+
+// Send は宛先と件名のみをログに出力し、メールを破棄する
+func (Discard) Send(ctx context.Context, msg Message) error {
+	log.Printf("mail to %q (subject %q) was discarded", msg.To, msg.Subject)
+	return nil
+}
+
+// Async はメールの送信をバックグラウンドで実施する
+// 送信の所要時間や失敗をリクエスト元に伝えないため、送信の失敗はログへの出力のみとする
+type Async struct {
+	Mailer Mailer
+	wg     sync.WaitGroup
+}
+
+// Send は送信を開始し、完了を待たずに返却する
+// リクエストの完了後も送信を継続するため、ctxは送信の中断には使用しない
+func (a *Async) Send(ctx context.Context, msg Message) error {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		if err := a.Mailer.Send(context.Background(), msg); err != nil {
+			log.Printf("failed to send mail: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Wait は開始済の送信がすべて完了するまで待機する
+func (a *Async) Wait() {
+	a.wg.Wait()
+}
+
+// Memory は送信したメールをメモリ上に保持する
+// テストで使用する
+type Memory struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// Send はメールを保持する
+func (m *Memory) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages は保持しているメールを送信順に返却する
+func (m *Memory) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
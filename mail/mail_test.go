@@ -0,0 +1,83 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/config"
+)
+
+func TestMemory_Send(t *testing.T) {
+	t.Parallel()
+
+	sut := &Memory{}
+	want := []Message{
+		{To: "a@example.com", Subject: "first", Body: "body"},
+		{To: "b@example.com", Subject: "second", Body: "body"},
+	}
+	for _, m := range want {
+		if err := sut.Send(context.Background(), m); err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+	}
+	got := sut.Messages()
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("want %v, but got %v", want, got)
+	}
+}
+
+func TestSMTP_Send_headerInjection(t *testing.T) {
+	t.Parallel()
+
+	// 接続前に拒否されるため、SMTPサーバは不要
+	sut := &SMTP{Addr: "127.0.0.1:0", From: "no-reply@example.com"}
+	msg := Message{To: "a@example.com\r\nBcc: victim@example.com", Subject: "reset"}
+	if err := sut.Send(context.Background(), msg); err == nil {
+		t.Error("want error, but got nil")
+	}
+}
+
+// failing は常に送信に失敗するMailer
+type failing struct{}
+
+func (failing) Send(ctx context.Context, msg Message) error {
+	return errors.New("connection refused")
+}
+
+func TestAsync_Send(t *testing.T) {
+	t.Parallel()
+
+	mem := &Memory{}
+	sut := &Async{Mailer: mem}
+	// リクエストの完了後も送信を継続する
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	msg := Message{To: "a@example.com", Subject: "reset", Body: "body"}
+	if err := sut.Send(ctx, msg); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	sut.Wait()
+	if got := mem.Messages(); len(got) != 1 || got[0] != msg {
+		t.Errorf("want %v, but got %v", []Message{msg}, got)
+	}
+
+	// 送信の失敗は呼び出し元に返却しない
+	failed := &Async{Mailer: failing{}}
+	if err := failed.Send(context.Background(), msg); err != nil {
+		t.Errorf("want no error, but got %v", err)
+	}
+	failed.Wait()
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	// SMTPサーバが未指定の場合はメールを保持しない
+	if _, ok := New(&config.Config{}).(Discard); !ok {
+		t.Error("want Discard mailer without SMTP server")
+	}
+	if _, ok := New(&config.Config{SMTPHost: "smtp.example.com", SMTPPort: 587}).(*SMTP); !ok {
+		t.Error("want SMTP mailer with SMTP server")
+	}
+}
@@ -6,8 +6,14 @@ import (
 	"log"
 	"net"
 	"os"
+	// タイムゾーンの検証にOSのタイムゾーンデータベースを必要としないよう、バイナリに埋め込む
+	_ "time/tzdata"
 
+	"github.com/ac0mz/go_todo_app/clock"
 	"github.com/ac0mz/go_todo_app/config"
+	"github.com/ac0mz/go_todo_app/service"
+	"github.com/ac0mz/go_todo_app/store"
+	"golang.org/x/sync/errgroup"
 )
 
 // run はHTTPサーバを起動する関数
@@ -25,16 +31,39 @@ func run(ctx context.Context) error {
 	url := fmt.Sprintf("http://%s", l.Addr().String())
 	log.Printf("start with: %v", url)
 
-	// handlerをルーティングするmuxの生成
-	mux, cleanup, err := NewMux(ctx, cfg)
+	// DBの接続プールはHTTPサーバとゴミ箱の定期削除処理で共有する
+	db, cleanup, err := store.New(ctx, cfg)
 	if err != nil {
 		return err
 	}
 	defer cleanup()
 
+	// handlerをルーティングするmuxの生成
+	mux, err := NewMux(ctx, cfg, db)
+	if err != nil {
+		return err
+	}
+
+	// ゴミ箱の定期削除処理の生成
+	purger := &service.PurgeTrash{
+		DB:        db,
+		Repo:      &store.Repository{Clocker: clock.RealClocker{}},
+		Clocker:   clock.RealClocker{},
+		Retention: cfg.TrashRetention,
+		Interval:  cfg.TrashPurgeInterval,
+	}
+
 	// HTTPサーバの生成と起動
+	// 定期削除処理はHTTPサーバと同一のコンテキストで起動し、HTTPサーバの終了に併せて停止する
 	s := NewServer(l, mux)
-	return s.Run(ctx)
+	eg, ctx := errgroup.WithContext(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	eg.Go(func() error { return purger.Run(ctx) })
+	eg.Go(func() error {
+		defer cancel()
+		return s.Run(ctx)
+	})
+	return eg.Wait()
 }
 
 func main() {
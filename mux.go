@@ -7,15 +7,22 @@ import (
 	"github.com/ac0mz/go_todo_app/auth"
 	"github.com/ac0mz/go_todo_app/clock"
 	"github.com/ac0mz/go_todo_app/config"
+	"github.com/ac0mz/go_todo_app/entity"
 	"github.com/ac0mz/go_todo_app/handler"
+	"github.com/ac0mz/go_todo_app/mail"
+	"github.com/ac0mz/go_todo_app/oidc"
 	"github.com/ac0mz/go_todo_app/service"
 	"github.com/ac0mz/go_todo_app/store"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
+	"github.com/jmoiron/sqlx"
 )
 
-func NewMux(ctx context.Context, cfg *config.Config) (http.Handler, func(), error) {
+// NewMux はハンドラをルーティングしたmuxを生成する
+// dbはゴミ箱の定期削除処理等と共有するため、呼び出し元で生成および解放する
+func NewMux(ctx context.Context, cfg *config.Config, db *sqlx.DB) (http.Handler, error) {
 	mux := chi.NewRouter()
+	mux.Use(handler.ClientMiddleware)
 
 	// ヘルスチェックAPI
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -24,38 +31,148 @@ func NewMux(ctx context.Context, cfg *config.Config) (http.Handler, func(), erro
 		_, _ = w.Write([]byte(`{"status": "ok"}`))
 	})
 
-	db, cleanup, err := store.New(ctx, cfg)
-	if err != nil {
-		return nil, cleanup, err
-	}
 	clocker := clock.RealClocker{}
 	r := store.Repository{Clocker: clocker}
 	v := validator.New()
+	// 認証が必要なAPIは、ルートごとにトークンへ許可されている必要のあるスコープを宣言する
+	readTasks := handler.RequireScopes(entity.ScopeTasksRead)
+	writeTasks := handler.RequireScopes(entity.ScopeTasksWrite)
+	readTags := handler.RequireScopes(entity.ScopeTagsRead)
+	writeTags := handler.RequireScopes(entity.ScopeTagsWrite)
+	readProjects := handler.RequireScopes(entity.ScopeProjectsRead)
+	writeProjects := handler.RequireScopes(entity.ScopeProjectsWrite)
+	account := handler.RequireScopes(entity.ScopeAccount)
+	policy := service.PasswordPolicy{
+		MinLength:  cfg.PasswordMinLength,
+		MaxLength:  cfg.PasswordMaxLength,
+		MinClasses: cfg.PasswordMinClasses,
+	}
 
 	// -- auth --------------------------------
 	redisCli, err := store.NewKVS(ctx, cfg)
 	if err != nil {
-		return nil, cleanup, err
+		return nil, err
 	}
-	jwter, err := auth.NewJWTer(redisCli, clocker)
+	jwter, err := auth.NewJWTerWithOptions(redisCli, clocker, auth.Options{
+		KeyDir:               cfg.JWTKeyDir,
+		SigningKeyID:         cfg.JWTSigningKeyID,
+		Algorithm:            cfg.JWTAlgorithm,
+		Issuer:               cfg.JWTIssuer,
+		Audience:             cfg.JWTAudience,
+		Lifetime:             cfg.JWTLifetime,
+		RefreshTokenTTL:      cfg.RefreshTokenTTL,
+		SessionTouchInterval: cfg.SessionTouchInterval,
+	})
 	if err != nil {
-		return nil, cleanup, err
+		return nil, err
+	}
+	jwter.PATs = &service.FindPersonalAccessToken{DB: db, Repo: &r}
+	jwks := &handler.JWKS{Service: jwter}
+	// トークン検証用の公開鍵一覧取得API
+	mux.Get("/.well-known/jwks.json", jwks.ServeHTTP)
+	// ユーザ名およびIPアドレスごとのログイン試行の制限
+	throttle := &service.LoginThrottle{
+		Store:           redisCli,
+		Window:          cfg.LoginFailureWindow,
+		BackoffAfter:    cfg.LoginBackoffAfter,
+		BackoffBase:     cfg.LoginBackoffBase,
+		BackoffMax:      cfg.LoginBackoffMax,
+		UserLockout:     cfg.LoginUserLockout,
+		IPLockout:       cfg.LoginIPLockout,
+		LockoutDuration: cfg.LoginLockoutDuration,
 	}
 	l := &handler.Login{
-		Service:   &service.Login{DB: db, Repo: &r, TokenGenerator: jwter},
+		Service: &service.Login{
+			DB: db, Repo: &r, TokenGenerator: jwter, Throttle: throttle, Policy: policy,
+			Challenges: redisCli, ChallengeTTL: cfg.LoginChallengeTTL,
+		},
 		Validator: v,
 	}
 	// 一般権限認証認可API
 	mux.Post("/login", l.ServeHTTP)
+	l2fa := &handler.LoginTwoFactor{
+		Service: &service.LoginTwoFactor{
+			DB: db, Repo: &r, Challenges: redisCli, TokenGenerator: jwter,
+			Clocker: clocker, Policy: policy, MaxFailures: cfg.LoginChallengeMaxFailures, Throttle: throttle,
+		},
+		Validator: v,
+	}
+	// 二要素認証のコードによるログインAPI
+	mux.Post("/login/2fa", l2fa.ServeHTTP)
+	rft := &handler.RefreshToken{
+		Service:   &service.RefreshToken{DB: db, Repo: &r, TokenRefresher: jwter},
+		Validator: v,
+	}
+	// アクセストークン再発行API
+	mux.Post("/token/refresh", rft.ServeHTTP)
+	// OpenID Connectによるログイン(IdPの発行者が設定されている場合のみ有効)
+	if provider := oidc.New(cfg, clocker); provider != nil {
+		so := &handler.StartOIDCLogin{
+			Service: &service.StartOIDCLogin{Provider: provider, States: redisCli, TTL: cfg.OIDCStateTTL},
+		}
+		ol := &handler.OIDCLogin{
+			Service: &service.OIDCLogin{
+				DB: db, Repo: &r, Provider: provider, States: redisCli, TokenGenerator: jwter,
+			},
+		}
+		// IdPの認可エンドポイントへのリダイレクトAPI
+		mux.Get("/auth/oidc/start", so.ServeHTTP)
+		// IdPからのコールバックによるログインAPI
+		mux.Get("/auth/oidc/callback", ol.ServeHTTP)
+	}
+	lo := &handler.Logout{
+		Service: &service.Logout{Revoker: jwter, Sessions: jwter},
+	}
+	loa := &handler.Logout{
+		Service: &service.Logout{Revoker: jwter, Sessions: jwter},
+		All:     true,
+	}
+	mux.Group(func(r chi.Router) {
+		r.Use(handler.AuthMiddleware(jwter), account)
+		// ログアウトAPI(リクエストに使用したトークンのみ失効)
+		r.Post("/logout", lo.ServeHTTP)
+		// 全セッションのログアウトAPI
+		r.Post("/logout/all", loa.ServeHTTP)
+	})
 
+	uur := &handler.UpdateUserRole{
+		Service:   &service.UpdateUserRole{DB: db, Repo: &r, Revoker: jwter},
+		Validator: v,
+	}
+	rpr := &handler.RequirePasswordReset{
+		Service: &service.RequirePasswordReset{DB: db, Repo: &r, Revoker: jwter},
+	}
+	lu := &handler.ListUser{
+		Service: &service.ListUser{DB: db, Repo: &r},
+	}
+	gu := &handler.GetUser{
+		Service: &service.GetUser{DB: db, Repo: &r},
+	}
+	du := &handler.SetUserDisabled{
+		Service:  &service.SetUserDisabled{DB: db, Repo: &r, Marker: jwter, Clocker: clocker},
+		Disabled: true,
+	}
+	eu := &handler.SetUserDisabled{
+		Service: &service.SetUserDisabled{DB: db, Repo: &r, Marker: jwter, Clocker: clocker},
+	}
+	fl := &handler.ForceLogout{
+		Service: &service.ForceLogout{DB: db, Repo: &r, Revoker: jwter},
+	}
 	mux.Route("/admin", func(r chi.Router) {
-		r.Use(handler.AuthMiddleware(jwter), handler.AdminMiddleware)
-		// 管理者権限認証認可API
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json; charset=utf-8")
-			// 静的解析エラー回避用に戻り値を明示的に破棄
-			_, _ = w.Write([]byte(`{"message": "admin only}"`))
-		})
+		r.Use(handler.AuthMiddleware(jwter), account, handler.AdminMiddleware)
+		// ユーザの検索・一覧取得API
+		r.With(handler.RequirePermission(entity.PermissionManageUsers)).Get("/users", lu.ServeHTTP)
+		// ユーザの取得API
+		r.With(handler.RequirePermission(entity.PermissionManageUsers)).Get("/users/{id}", gu.ServeHTTP)
+		// ユーザの無効化・有効化API
+		r.With(handler.RequirePermission(entity.PermissionManageUsers)).Post("/users/{id}/disable", du.ServeHTTP)
+		r.With(handler.RequirePermission(entity.PermissionManageUsers)).Post("/users/{id}/enable", eu.ServeHTTP)
+		// ユーザの全セッションの強制ログアウトAPI
+		r.With(handler.RequirePermission(entity.PermissionManageUsers)).Post("/users/{id}/logout", fl.ServeHTTP)
+		// ユーザのロール変更API
+		r.With(handler.RequirePermission(entity.PermissionAssignRoles)).Put("/users/{id}/role", uur.ServeHTTP)
+		// 次回ログイン時のパスワード再設定の強制API
+		r.With(handler.RequirePermission(entity.PermissionManageUsers)).Post("/users/{id}/password/reset", rpr.ServeHTTP)
 	})
 
 	// -- tasks --------------------------------
@@ -66,22 +183,256 @@ func NewMux(ctx context.Context, cfg *config.Config) (http.Handler, func(), erro
 	lt := &handler.ListTask{
 		Service: &service.ListTask{DB: db, Repo: &r},
 	}
+	gt := &handler.GetTask{
+		Service: &service.GetTask{DB: db, Repo: &r},
+	}
+	ut := &handler.UpdateTask{
+		Service:   &service.UpdateTask{DB: db, Repo: &r},
+		Validator: v,
+	}
+	dt := &handler.DeleteTask{
+		Service: &service.DeleteTask{DB: db, Repo: &r},
+	}
+	tt := &handler.TransitionTask{
+		Service:   &service.TransitionTask{DB: db, Repo: &r, Clocker: clocker},
+		Validator: v,
+	}
+	lct := &handler.ListChildTask{
+		Service: &service.ListChildTask{DB: db, Repo: &r},
+	}
+	mt := &handler.MoveTask{
+		Service: &service.MoveTask{DB: db, Repo: &r},
+	}
+	atd := &handler.AddTaskDependency{
+		Service: &service.AddTaskDependency{DB: db, Repo: &r},
+	}
+	dtd := &handler.DeleteTaskDependency{
+		Service: &service.DeleteTaskDependency{DB: db, Repo: &r},
+	}
+	rt := &handler.RestoreTask{
+		Service: &service.RestoreTask{DB: db, Repo: &r},
+	}
+	att := &handler.AttachTag{
+		Service: &service.AttachTag{DB: db, Repo: &r},
+	}
+	dtt := &handler.DetachTag{
+		Service: &service.DetachTag{DB: db, Repo: &r},
+	}
 	mux.Route("/tasks", func(r chi.Router) {
 		// ログインしている場合のみ/tasksエンドポイントへのアクセスを許可する
 		r.Use(handler.AuthMiddleware(jwter))
 		// タスク個別登録API
-		r.Post("/", at.ServeHTTP)
+		r.With(writeTasks).Post("/", at.ServeHTTP)
 		// タスク一覧取得API
-		r.Get("/", lt.ServeHTTP)
+		r.With(readTasks).Get("/", lt.ServeHTTP)
+		// タスク個別取得API
+		r.With(readTasks).Get("/{id}", gt.ServeHTTP)
+		// タスク個別更新API
+		r.With(writeTasks).Put("/{id}", ut.ServeHTTP)
+		r.With(writeTasks).Patch("/{id}", ut.ServeHTTP)
+		// タスク個別削除API
+		r.With(writeTasks).Delete("/{id}", dt.ServeHTTP)
+		// タスクステータス遷移API
+		r.With(writeTasks).Post("/{id}/transition", tt.ServeHTTP)
+		// 子タスク一覧取得API
+		r.With(readTasks).Get("/{id}/children", lct.ServeHTTP)
+		// 親タスク変更API
+		r.With(writeTasks).Put("/{id}/parent", mt.ServeHTTP)
+		// タスク間の依存関係登録・削除API
+		r.With(writeTasks).Put("/{id}/blockers/{blocker_id}", atd.ServeHTTP)
+		r.With(writeTasks).Delete("/{id}/blockers/{blocker_id}", dtd.ServeHTTP)
+		// ゴミ箱のタスク復元API
+		r.With(writeTasks).Post("/{id}/restore", rt.ServeHTTP)
+		// タスクへのタグ付与・解除API
+		r.With(writeTasks).Put("/{id}/tags/{tag_id}", att.ServeHTTP)
+		r.With(writeTasks).Delete("/{id}/tags/{tag_id}", dtt.ServeHTTP)
+	})
+
+	// -- trash --------------------------------
+	ltr := &handler.ListTrash{
+		Service: &service.ListTrash{DB: db, Repo: &r},
+	}
+	etr := &handler.EmptyTrash{
+		Service: &service.EmptyTrash{DB: db, Repo: &r},
+	}
+	mux.Route("/trash", func(r chi.Router) {
+		r.Use(handler.AuthMiddleware(jwter))
+		// ゴミ箱のタスク一覧取得API
+		r.With(readTasks).Get("/", ltr.ServeHTTP)
+		// ゴミ箱を空にするAPI
+		r.With(writeTasks).Delete("/", etr.ServeHTTP)
+	})
+
+	// -- tags --------------------------------
+	ag := &handler.AddTag{
+		Service:   &service.AddTag{DB: db, Repo: &r},
+		Validator: v,
+	}
+	lg := &handler.ListTag{
+		Service: &service.ListTag{DB: db, Repo: &r},
+	}
+	ug := &handler.UpdateTag{
+		Service:   &service.UpdateTag{DB: db, Repo: &r},
+		Validator: v,
+	}
+	dg := &handler.DeleteTag{
+		Service: &service.DeleteTag{DB: db, Repo: &r},
+	}
+	mux.Route("/tags", func(r chi.Router) {
+		r.Use(handler.AuthMiddleware(jwter))
+		// タグ個別登録API
+		r.With(writeTags).Post("/", ag.ServeHTTP)
+		// タグ一覧取得API
+		r.With(readTags).Get("/", lg.ServeHTTP)
+		// タグ個別更新API
+		r.With(writeTags).Put("/{id}", ug.ServeHTTP)
+		r.With(writeTags).Patch("/{id}", ug.ServeHTTP)
+		// タグ個別削除API
+		r.With(writeTags).Delete("/{id}", dg.ServeHTTP)
+	})
+
+	// -- projects --------------------------------
+	ap := &handler.AddProject{
+		Service:   &service.AddProject{DB: db, Repo: &r},
+		Validator: v,
+	}
+	lp := &handler.ListProject{
+		Service: &service.ListProject{DB: db, Repo: &r},
+	}
+	gp := &handler.GetProject{
+		Service: &service.GetProject{DB: db, Repo: &r},
+	}
+	up := &handler.UpdateProject{
+		Service:   &service.UpdateProject{DB: db, Repo: &r},
+		Validator: v,
+	}
+	dp := &handler.DeleteProject{
+		Service: &service.DeleteProject{DB: db, Repo: &r},
+	}
+	arp := &handler.ArchiveProject{
+		Service:  &service.ArchiveProject{DB: db, Repo: &r},
+		Archived: true,
+	}
+	uap := &handler.ArchiveProject{
+		Service:  &service.ArchiveProject{DB: db, Repo: &r},
+		Archived: false,
+	}
+	lpt := &handler.ListProjectTask{
+		Service: &service.ListProjectTask{DB: db, Repo: &r},
+	}
+	mux.Route("/projects", func(r chi.Router) {
+		r.Use(handler.AuthMiddleware(jwter))
+		// プロジェクト個別登録API
+		r.With(writeProjects).Post("/", ap.ServeHTTP)
+		// プロジェクト一覧取得API
+		r.With(readProjects).Get("/", lp.ServeHTTP)
+		// プロジェクト個別取得API
+		r.With(readProjects).Get("/{id}", gp.ServeHTTP)
+		// プロジェクト個別更新API
+		r.With(writeProjects).Put("/{id}", up.ServeHTTP)
+		r.With(writeProjects).Patch("/{id}", up.ServeHTTP)
+		// プロジェクト個別削除API
+		r.With(writeProjects).Delete("/{id}", dp.ServeHTTP)
+		// プロジェクトのアーカイブ・アーカイブ解除API
+		r.With(writeProjects).Post("/{id}/archive", arp.ServeHTTP)
+		r.With(writeProjects).Post("/{id}/unarchive", uap.ServeHTTP)
+		// プロジェクト所属タスク一覧取得API
+		r.With(readProjects, readTasks).Get("/{id}/tasks", lpt.ServeHTTP)
+	})
+
+	// -- me --------------------------------
+	apt := &handler.AddPersonalAccessToken{
+		Service:   &service.AddPersonalAccessToken{DB: db, Repo: &r, Clocker: clocker},
+		Validator: v,
+	}
+	lpat := &handler.ListPersonalAccessToken{
+		Service: &service.ListPersonalAccessToken{DB: db, Repo: &r},
+	}
+	dpat := &handler.DeletePersonalAccessToken{
+		Service: &service.DeletePersonalAccessToken{DB: db, Repo: &r},
+	}
+	cpw := &handler.ChangePassword{
+		Service: &service.ChangePassword{
+			DB: db, Repo: &r, Policy: policy, Revoker: jwter, TokenGenerator: jwter,
+			Clocker: clocker, ReauthMaxAge: cfg.ReauthMaxAge,
+		},
+		Validator: v,
+	}
+	s2fa := &handler.SetupTwoFactor{
+		Service: &service.SetupTwoFactor{DB: db, Repo: &r, Issuer: cfg.TOTPIssuer},
+	}
+	c2fa := &handler.ConfirmTwoFactor{
+		Service:   &service.ConfirmTwoFactor{DB: db, Repo: &r, Clocker: clocker},
+		Validator: v,
+	}
+	gpf := &handler.GetProfile{
+		Service: &service.GetProfile{DB: db, Repo: &r},
+	}
+	upf := &handler.UpdateProfile{
+		Service:   &service.UpdateProfile{DB: db, Repo: &r},
+		Validator: v,
+	}
+	dac := &handler.DeleteAccount{
+		Service: &service.DeleteAccount{
+			DB: db, Repo: &r, Revoker: jwter, Clocker: clocker, ReauthMaxAge: cfg.ReauthMaxAge,
+		},
+		Validator: v,
+	}
+	eac := &handler.ExportAccount{
+		Service: &service.ExportAccount{DB: db, Repo: &r},
+	}
+	lss := &handler.ListSession{
+		Service: &service.ListSession{Sessions: jwter},
+	}
+	dss := &handler.DeleteSession{
+		Service: &service.DeleteSession{Sessions: jwter},
+	}
+	mux.Route("/me", func(r chi.Router) {
+		r.Use(handler.AuthMiddleware(jwter), account)
+		// ログインユーザの情報取得・更新・アカウント削除API
+		r.Get("/", gpf.ServeHTTP)
+		r.Patch("/", upf.ServeHTTP)
+		r.Delete("/", dac.ServeHTTP)
+		// ログインユーザのデータのエクスポートAPI
+		r.Get("/export", eac.ServeHTTP)
+		// パーソナルアクセストークン発行・一覧取得・失効API
+		r.Post("/tokens", apt.ServeHTTP)
+		r.Get("/tokens", lpat.ServeHTTP)
+		r.Delete("/tokens/{id}", dpat.ServeHTTP)
+		// ログイン中のセッション一覧取得・端末ごとのセッション失効API
+		r.Get("/sessions", lss.ServeHTTP)
+		r.Delete("/sessions/{id}", dss.ServeHTTP)
+		// パスワード変更API
+		r.Put("/password", cpw.ServeHTTP)
+		// 二要素認証の設定・有効化API
+		r.Post("/2fa/setup", s2fa.ServeHTTP)
+		r.Post("/2fa/confirm", c2fa.ServeHTTP)
 	})
 
 	// -- users --------------------------------
 	ru := &handler.RegisterUser{
-		Service:   &service.RegisterUser{DB: db, Repo: &r},
+		Service:   &service.RegisterUser{DB: db, Repo: &r, Policy: policy},
 		Validator: v,
 	}
 	// ユーザ個別登録API
 	mux.Post("/register", ru.ServeHTTP)
+	fpw := &handler.ForgotPassword{
+		Service: &service.ForgotPassword{
+			DB: db, Repo: &r, Tokens: redisCli, Mailer: &mail.Async{Mailer: mail.New(cfg)},
+			TTL: cfg.PasswordResetTTL, ResetURL: cfg.PasswordResetURL,
+		},
+		Validator: v,
+	}
+	rspw := &handler.ResetPassword{
+		Service: &service.ResetPassword{
+			DB: db, Repo: &r, Tokens: redisCli, Policy: policy, Revoker: jwter,
+		},
+		Validator: v,
+	}
+	// パスワード再設定トークンの送信API
+	mux.Post("/password/forgot", fpw.ServeHTTP)
+	// パスワード再設定API
+	mux.Post("/password/reset", rspw.ServeHTTP)
 
-	return mux, cleanup, nil
+	return mux, nil
 }
@@ -9,6 +9,9 @@ import (
 	"testing"
 
 	"github.com/ac0mz/go_todo_app/config"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/go-chi/chi/v5"
 )
 
 func Test_NewMux(t *testing.T) {
@@ -25,8 +28,13 @@ func Test_NewMux(t *testing.T) {
 		cfg.DBPort = 3306
 		cfg.RedisPort = 6379
 	}
+	db, cleanup, err := store.New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(cleanup)
 	// ハンドラのルータ（コントローラ）であるmuxを生成
-	mux, _, err := NewMux(ctx, cfg)
+	mux, err := NewMux(ctx, cfg, db)
 	if err != nil {
 		t.Fatalf("failed to create mux: %v", err)
 	}
@@ -50,3 +58,73 @@ func Test_NewMux(t *testing.T) {
 		t.Errorf("want %q, but got %q", want, got)
 	}
 }
+
+// Test_NewMux_scopes 認証不要なAPIを除くすべてのルートで、必要なスコープが宣言されていることを確認する
+func Test_NewMux_scopes(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	if _, defined := os.LookupEnv("CI"); defined {
+		cfg.DBPort = 3306
+		cfg.RedisPort = 6379
+	}
+	db, cleanup, err := store.New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(cleanup)
+	mux, err := NewMux(ctx, cfg, db)
+	if err != nil {
+		t.Fatalf("failed to create mux: %v", err)
+	}
+
+	// 認証不要なAPI
+	public := map[string]bool{
+		"GET /.well-known/jwks.json": true,
+		"POST /login":                true,
+		"POST /login/2fa":            true,
+		"POST /token/refresh":        true,
+		"GET /auth/oidc/start":       true,
+		"GET /auth/oidc/callback":    true,
+		"POST /register":             true,
+		"POST /password/forgot":      true,
+		"POST /password/reset":       true,
+	}
+	// ミドルウェアを適用したハンドラから、RequireScopesで宣言されたスコープを収集する
+	type scopeDeclarer interface {
+		RequiredScopes() entity.Scopes
+	}
+	probe := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	routes, ok := mux.(chi.Routes)
+	if !ok {
+		t.Fatalf("want chi.Routes, but got %T", mux)
+	}
+	err = chi.Walk(routes, func(
+		method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler,
+	) error {
+		// メソッドを限定しないヘルスチェックAPIは対象外とする
+		if route == "/health" {
+			return nil
+		}
+		key := method + " " + route
+		var scopes entity.Scopes
+		for _, mw := range middlewares {
+			if d, ok := mw(probe).(scopeDeclarer); ok {
+				scopes = append(scopes, d.RequiredScopes()...)
+			}
+		}
+		switch {
+		case public[key] && len(scopes) > 0:
+			t.Errorf("%s: public route must not declare scopes, but got %v", key, scopes)
+		case !public[key] && len(scopes) == 0:
+			t.Errorf("%s: route must declare required scopes", key)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %v", err)
+	}
+}
@@ -0,0 +1,314 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/config"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+// ErrAuthentication はIdPでの認証、またはIDトークンの検証に失敗したことを表す
+var ErrAuthentication = errors.New("oidc authentication failed")
+
+// AcceptableSkew はIDトークンの有効期限等の検証で許容するIdPとの時刻のずれ
+const AcceptableSkew = time.Minute
+
+// Identity はIDトークンから取得したIdP上のユーザ情報
+type Identity struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+	// AuthTime はIdPでユーザが認証した日時(auth_timeクレームがない場合はIDトークンの発行日時)
+	AuthTime time.Time
+}
+
+// AuthRequest は認可リクエストごとに生成し、コールバックでの検証まで保持する値
+// Stateはリクエストとコールバックの対応付け、NonceはIDトークンの再送防止、VerifierはPKCEに使用する
+type AuthRequest struct {
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// NewAuthRequest は推測困難な値で認可リクエストを生成する
+func NewAuthRequest() (*AuthRequest, error) {
+	vs := make([]string, 3)
+	for i := range vs {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to generate auth request: %w", err)
+		}
+		vs[i] = base64.RawURLEncoding.EncodeToString(b)
+	}
+	return &AuthRequest{State: vs[0], Nonce: vs[1], Verifier: vs[2]}, nil
+}
+
+// Challenge はPKCEのcode_challengeをS256方式で算出する(RFC 7636)
+func (r *AuthRequest) Challenge() string {
+	sum := sha256.Sum256([]byte(r.Verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// metadata はIdPのディスカバリで取得するメタデータのうち、認可コードフローで使用する項目
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider はOpenID Connectの認可コードフローでIdPと通信するクライアント
+// メタデータと公開鍵は初回の使用時に取得してキャッシュする
+type Provider struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string // 空文字の場合は公開クライアントとしてトークンエンドポイントで認証しない
+	RedirectURL  string
+	Scopes       []string
+	Client       *http.Client
+	Clocker      clock.Clocker
+
+	mu   sync.Mutex
+	meta *metadata
+	keys jwk.Set
+}
+
+// New は設定に応じたProviderを生成する
+// IdPの発行者が未指定の場合はnilを返却する
+func New(cfg *config.Config, clocker clock.Clocker) *Provider {
+	if cfg.OIDCIssuer == "" {
+		return nil
+	}
+	return &Provider{
+		Issuer:       cfg.OIDCIssuer,
+		ClientID:     cfg.OIDCClientID,
+		ClientSecret: cfg.OIDCClientSecret,
+		RedirectURL:  cfg.OIDCRedirectURL,
+		Scopes:       cfg.OIDCScopes,
+		Client:       &http.Client{Timeout: 10 * time.Second},
+		Clocker:      clocker,
+	}
+}
+
+// AuthCodeURL はユーザをリダイレクトさせるIdPの認可エンドポイントのURLを返却する
+func (p *Provider) AuthCodeURL(ctx context.Context, req *AuthRequest) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization_endpoint %q: %w", meta.AuthorizationEndpoint, err)
+	}
+	// 認可エンドポイントに設定済のクエリパラメータは保持する
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.ClientID)
+	q.Set("redirect_uri", p.RedirectURL)
+	q.Set("scope", strings.Join(p.Scopes, " "))
+	q.Set("state", req.State)
+	q.Set("nonce", req.Nonce)
+	q.Set("code_challenge", req.Challenge())
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// tokenResponse はトークンエンドポイントのレスポンスのうち、使用する項目
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange は認可コードをトークンエンドポイントでIDトークンと交換し、検証したIDトークンのユーザ情報を返却する
+// IdPがコードを拒否した場合、またはIDトークンが不正な場合はErrAuthenticationをラップしたエラーを返却する
+func (p *Provider) Exchange(ctx context.Context, code string, req *AuthRequest) (*Identity, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.RedirectURL},
+		"client_id":     {p.ClientID},
+		"code_verifier": {req.Verifier},
+	}
+	hr, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	hr.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	hr.Header.Set("Accept", "application/json")
+	if p.ClientSecret != "" {
+		// client_secret_basicでは、クライアントIDとシークレットをURLエンコードした上で設定する(RFC 6749 2.3.1)
+		hr.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+	}
+	res, err := p.Client.Do(hr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request token endpoint: %w", err)
+	}
+	defer res.Body.Close()
+
+	var tr tokenResponse
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(&tr); err != nil {
+		return nil, fmt.Errorf("failed to decode token response (status %d): %w", res.StatusCode, err)
+	}
+	if res.StatusCode != http.StatusOK || tr.Error != "" {
+		return nil, fmt.Errorf("%w: token endpoint returned %d %s: %s",
+			ErrAuthentication, res.StatusCode, tr.Error, tr.ErrorDescription)
+	}
+	if tr.IDToken == "" {
+		return nil, fmt.Errorf("%w: id_token not found in token response", ErrAuthentication)
+	}
+	return p.verify(ctx, meta, tr.IDToken, req.Nonce)
+}
+
+// verify はIDトークンの署名をIdPの公開鍵で検証し、クレームを検証する
+// 署名を検証できない場合は、IdPの鍵のローテーションを考慮して公開鍵を再取得した上で1度だけ再検証する
+func (p *Provider) verify(ctx context.Context, meta *metadata, raw, nonce string) (*Identity, error) {
+	keys, err := p.jwks(ctx, meta, false)
+	if err != nil {
+		return nil, err
+	}
+	token, err := parse(raw, keys)
+	if err != nil {
+		if keys, err = p.jwks(ctx, meta, true); err != nil {
+			return nil, err
+		}
+		if token, err = parse(raw, keys); err != nil {
+			return nil, fmt.Errorf("%w: failed to verify id_token: %v", ErrAuthentication, err)
+		}
+	}
+
+	if err := jwt.Validate(token,
+		jwt.WithClock(p.Clocker),
+		jwt.WithAcceptableSkew(AcceptableSkew),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(p.ClientID),
+		jwt.WithRequiredClaim(jwt.ExpirationKey),
+		jwt.WithRequiredClaim(jwt.IssuedAtKey),
+		jwt.WithRequiredClaim(jwt.SubjectKey),
+	); err != nil {
+		return nil, fmt.Errorf("%w: invalid id_token: %v", ErrAuthentication, err)
+	}
+	got, _ := stringClaim(token, "nonce")
+	if subtle.ConstantTimeCompare([]byte(got), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrAuthentication)
+	}
+	// 複数の受信者を含む場合、自クライアントに発行されたトークンであることをazpで確認する
+	if len(token.Audience()) > 1 {
+		if azp, _ := stringClaim(token, "azp"); azp != p.ClientID {
+			return nil, fmt.Errorf("%w: azp %q mismatch", ErrAuthentication, azp)
+		}
+	}
+
+	id := &Identity{Issuer: token.Issuer(), Subject: token.Subject()}
+	id.Email, _ = stringClaim(token, "email")
+	id.Name, _ = stringClaim(token, "name")
+	id.PreferredUsername, _ = stringClaim(token, "preferred_username")
+	if v, ok := token.Get("email_verified"); ok {
+		id.EmailVerified, _ = v.(bool)
+	}
+	id.AuthTime = token.IssuedAt()
+	if v, ok := token.Get("auth_time"); ok {
+		// 数値のクレームはfloat64として復元される
+		if sec, ok := v.(float64); ok {
+			id.AuthTime = time.Unix(int64(sec), 0).UTC()
+		}
+	}
+	return id, nil
+}
+
+// parse はIDトークンの署名を検証する
+// IdPの公開鍵にalgが設定されていない場合は、鍵の種類からアルゴリズムを推定する
+func parse(raw string, keys jwk.Set) (jwt.Token, error) {
+	return jwt.Parse([]byte(raw),
+		jwt.WithKeySet(keys, jws.WithInferAlgorithmFromKey(true)),
+		// 時刻情報(*Provider.Clocker)をベースに検証するため、ここでの検証は無視する
+		jwt.WithValidate(false),
+	)
+}
+
+// stringClaim はトークンから文字列のクレームを取得する
+func stringClaim(token jwt.Token, name string) (string, bool) {
+	v, ok := token.Get(name)
+	if !ok {
+		return "", false
+	}
+	s, ok := v.(string)
+	return s, ok
+}
+
+// discover は発行者のURLからIdPのメタデータを取得する(OpenID Connect Discovery 1.0)
+// 取得に成功したメタデータはキャッシュし、以降は再取得しない
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.meta != nil {
+		return p.meta, nil
+	}
+
+	u := strings.TrimSuffix(p.Issuer, "/") + "/.well-known/openid-configuration"
+	hr, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := p.Client.Do(hr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover %q: %w", u, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to discover %q: status %d", u, res.StatusCode)
+	}
+	meta := &metadata{}
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(meta); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata from %q: %w", u, err)
+	}
+	// なりすましを防ぐため、メタデータの発行者は設定した発行者と完全に一致する必要がある
+	if meta.Issuer != p.Issuer {
+		return nil, fmt.Errorf("issuer mismatch: want %q, but got %q", p.Issuer, meta.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("incomplete metadata from %q", u)
+	}
+	p.meta = meta
+	return meta, nil
+}
+
+// jwks はIdPの公開鍵を返却する
+// forceがtrueの場合はキャッシュを使用せずに再取得する
+func (p *Provider) jwks(ctx context.Context, meta *metadata, force bool) (jwk.Set, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keys != nil && !force {
+		return p.keys, nil
+	}
+	keys, err := jwk.Fetch(ctx, meta.JWKSURI, jwk.WithHTTPClient(p.Client))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks from %q: %w", meta.JWKSURI, err)
+	}
+	p.keys = keys
+	return keys, nil
+}
@@ -0,0 +1,197 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/config"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+const testClientID = "todo-app"
+
+// newTestProvider はFakeIdPを発行者とするProviderを生成する
+func newTestProvider(t *testing.T) (*Provider, *testutil.FakeIdP) {
+	t.Helper()
+
+	idp := testutil.NewFakeIdP(t, testClientID, clock.FixedClocker{}.Now())
+	return &Provider{
+		Issuer:      idp.Issuer(),
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost/auth/oidc/callback",
+		Scopes:      []string{"openid", "email", "profile"},
+		Client:      idp.Server.Client(),
+		Clocker:     clock.FixedClocker{},
+	}, idp
+}
+
+func TestAuthRequest_Challenge(t *testing.T) {
+	t.Parallel()
+
+	// RFC 7636 Appendix Bの例
+	r := &AuthRequest{Verifier: "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"}
+	want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	if got := r.Challenge(); got != want {
+		t.Errorf("want %q, but got %q", want, got)
+	}
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sut, idp := newTestProvider(t)
+	req, err := NewAuthRequest()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+
+	got, err := sut.AuthCodeURL(ctx, req)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base := idp.Issuer() + "/authorize"; u.Scheme+"://"+u.Host+u.Path != base {
+		t.Errorf("want endpoint %q, but got %q", base, got)
+	}
+	want := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {testClientID},
+		"redirect_uri":          {"http://localhost/auth/oidc/callback"},
+		"scope":                 {"openid email profile"},
+		"state":                 {req.State},
+		"nonce":                 {req.Nonce},
+		"code_challenge":        {req.Challenge()},
+		"code_challenge_method": {"S256"},
+	}
+	if d := cmp.Diff(u.Query(), want); d != "" {
+		t.Errorf("differs: (-got +want)\n%s", d)
+	}
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		claims map[string]any
+		// tamper は認可リクエストをIdPへの送信後、コードの交換前に改変する
+		tamper  func(r *AuthRequest)
+		rotate  bool
+		want    *Identity
+		wantErr error
+	}{
+		"ok": {
+			claims: map[string]any{
+				"email": "alice@example.com", "email_verified": true,
+				"name": "Alice", "preferred_username": "alice",
+			},
+			want: &Identity{
+				Subject: "fake-subject", Email: "alice@example.com", EmailVerified: true,
+				Name: "Alice", PreferredUsername: "alice", AuthTime: clock.FixedClocker{}.Now(),
+			},
+		},
+		// IdPのセッションによりユーザの操作なしで認証された場合
+		"authTime": {
+			claims: map[string]any{"auth_time": clock.FixedClocker{}.Now().Add(-time.Hour).Unix()},
+			want:   &Identity{Subject: "fake-subject", AuthTime: clock.FixedClocker{}.Now().Add(-time.Hour)},
+		},
+		"keyRotated": {
+			rotate: true,
+			want:   &Identity{Subject: "fake-subject", AuthTime: clock.FixedClocker{}.Now()},
+		},
+		"wrongVerifier": {
+			tamper:  func(r *AuthRequest) { r.Verifier = "wrong-verifier-wrong-verifier-wrong-verifier" },
+			wantErr: ErrAuthentication,
+		},
+		"wrongNonce": {
+			tamper:  func(r *AuthRequest) { r.Nonce = "wrong-nonce" },
+			wantErr: ErrAuthentication,
+		},
+		"wrongAudience": {
+			claims:  map[string]any{"aud": []string{"other-app"}},
+			wantErr: ErrAuthentication,
+		},
+		"wrongIssuer": {
+			claims:  map[string]any{"iss": "https://evil.example.com"},
+			wantErr: ErrAuthentication,
+		},
+		"expired": {
+			claims:  map[string]any{"exp": clock.FixedClocker{}.Now().Add(-time.Hour)},
+			wantErr: ErrAuthentication,
+		},
+		"unauthorizedParty": {
+			claims:  map[string]any{"aud": []string{testClientID, "other-app"}, "azp": "other-app"},
+			wantErr: ErrAuthentication,
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			sut, idp := newTestProvider(t)
+			for k, v := range tt.claims {
+				idp.Claims[k] = v
+			}
+			req, err := NewAuthRequest()
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			authURL, err := sut.AuthCodeURL(ctx, req)
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			code := idp.Authorize(t, authURL)
+			if tt.rotate {
+				// キャッシュ済の公開鍵に存在しないkidで署名されたIDトークンを発行させる
+				if _, err := sut.jwks(ctx, sut.meta, false); err != nil {
+					t.Fatalf("want no error, but got %v", err)
+				}
+				idp.RotateKey(t, "fake-idp-2")
+			}
+			if tt.tamper != nil {
+				tt.tamper(req)
+			}
+
+			got, err := sut.Exchange(ctx, code, req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want error %v, but got %v", tt.wantErr, err)
+			}
+			if tt.want != nil {
+				tt.want.Issuer = idp.Issuer()
+			}
+			if d := cmp.Diff(got, tt.want); d != "" {
+				t.Errorf("differs: (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func TestProvider_discover_issuerMismatch(t *testing.T) {
+	t.Parallel()
+
+	sut, idp := newTestProvider(t)
+	// メタデータの発行者と一致しない発行者を設定した場合は、IdPのエンドポイントを使用しない
+	sut.Issuer = idp.Issuer() + "/"
+	if _, err := sut.AuthCodeURL(context.Background(), &AuthRequest{}); err == nil {
+		t.Error("want error, but got nil")
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	if got := New(&config.Config{}, clock.FixedClocker{}); got != nil {
+		t.Errorf("want nil for unconfigured issuer, but got %+v", got)
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// ErrInvalidExpiration は有効期限に現在日時以前の日時が指定された場合のエラー
+var ErrInvalidExpiration = errors.New("expires_at must be in the future")
+
+type AddPersonalAccessToken struct {
+	DB      store.Execer
+	Repo    PersonalAccessTokenAdder
+	Clocker clock.Clocker
+}
+
+// AddPersonalAccessToken はログインユーザのパーソナルアクセストークンを発行する
+// トークン文字列は当該戻り値でのみ返却し、DBにはハッシュ値のみを登録する
+// handler/service.goの実装
+func (a *AddPersonalAccessToken) AddPersonalAccessToken(
+	ctx context.Context, name string, scopes entity.Scopes, expiresAt *time.Time,
+) (*entity.PersonalAccessToken, string, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, "", fmt.Errorf("user_id not found")
+	}
+	// 発行時点で失効済みとなるトークンは発行しない
+	if expiresAt != nil && !expiresAt.After(a.Clocker.Now()) {
+		return nil, "", ErrInvalidExpiration
+	}
+	token, hash, err := auth.NewPersonalAccessToken()
+	if err != nil {
+		return nil, "", err
+	}
+	t := &entity.PersonalAccessToken{
+		UserID:    uid,
+		Name:      name,
+		TokenHash: hash,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+	if err := a.Repo.AddPersonalAccessToken(ctx, a.DB, t); err != nil {
+		return nil, "", fmt.Errorf("failed to register: %w", err)
+	}
+	return t, token, nil
+}
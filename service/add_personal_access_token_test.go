@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+func TestAddPersonalAccessToken(t *testing.T) {
+	t.Parallel()
+
+	now := clock.FixedClocker{}.Now()
+	past, future := now.Add(-time.Second), now.Add(time.Hour)
+	tests := map[string]struct {
+		expiresAt *time.Time
+		wantErr   error
+	}{
+		"noExpiry": {},
+		"future":   {expiresAt: &future},
+		"now":      {expiresAt: &now, wantErr: ErrInvalidExpiration},
+		"past":     {expiresAt: &past, wantErr: ErrInvalidExpiration},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := auth.SetUserID(context.Background(), 1)
+			repo := &PersonalAccessTokenAdderMock{}
+			repo.AddPersonalAccessTokenFunc = func(ctx context.Context, db store.Execer, pat *entity.PersonalAccessToken) error {
+				return nil
+			}
+
+			sut := &AddPersonalAccessToken{Repo: repo, Clocker: clock.FixedClocker{}}
+			got, token, err := sut.AddPersonalAccessToken(ctx, "ci", entity.Scopes{entity.ScopeTasksRead}, tt.expiresAt)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("want %v, but got %v", tt.wantErr, err)
+				}
+				if len(repo.AddPersonalAccessTokenCalls()) != 0 {
+					t.Error("want token not to be registered")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if token == "" || got.UserID != 1 {
+				t.Errorf("want token for user 1, but got %q for user %d", token, got.UserID)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type AddProject struct {
+	DB   store.Execer
+	Repo ProjectAdder
+}
+
+// AddProject はログインユーザのプロジェクトを登録する
+// handler/service.goの実装
+func (a *AddProject) AddProject(ctx context.Context, name string) (*entity.Project, error) {
+	id, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	p := &entity.Project{UserID: id, Name: name}
+	if err := a.Repo.AddProject(ctx, a.DB, p); err != nil {
+		return nil, fmt.Errorf("failed to register: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type AddTag struct {
+	DB   store.Execer
+	Repo TagAdder
+}
+
+// AddTag はログインユーザのタグを登録する
+// handler/service.goの実装
+func (a *AddTag) AddTag(ctx context.Context, name string) (*entity.Tag, error) {
+	id, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	t := &entity.Tag{UserID: id, Name: name}
+	if err := a.Repo.AddTag(ctx, a.DB, t); err != nil {
+		return nil, fmt.Errorf("failed to register: %w", err)
+	}
+	return t, nil
+}
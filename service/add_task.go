@@ -10,24 +10,38 @@ import (
 )
 
 type AddTask struct {
-	DB   store.Execer
+	DB   store.ExecQueryer
 	Repo TaskAdder
 }
 
-// AddTask はhandler/service.goの実装
-func (a *AddTask) AddTask(ctx context.Context, title string) (*entity.Task, error) {
+// AddTask はリクエストされた内容のタスクをログインユーザのタスクとして登録する
+// ステータスは常に未着手とし、優先度が未指定の場合は通常とする
+// handler/service.goの実装
+func (a *AddTask) AddTask(ctx context.Context, t *entity.Task) error {
 	id, ok := auth.GetUserID(ctx)
 	if !ok {
-		return nil, fmt.Errorf("user_id not found")
+		return fmt.Errorf("user_id not found")
 	}
-	t := &entity.Task{
-		UserID: id,
-		Title:  title,
-		Status: entity.TaskStatusTodo,
+	if t.ProjectID != nil {
+		// 他ユーザのプロジェクトにタスクを登録しないよう、所有者を確認する
+		if _, err := a.Repo.GetProject(ctx, a.DB, id, *t.ProjectID); err != nil {
+			return fmt.Errorf("failed to get project: %w", err)
+		}
+	}
+	if t.ParentID != nil {
+		// 新規のタスクは循環し得ないため、親タスクの所有者と階層数のみを検証する
+		if err := a.Repo.ValidateTaskParent(ctx, a.DB, id, 0, *t.ParentID); err != nil {
+			return fmt.Errorf("failed to validate parent: %w", err)
+		}
+	}
+	t.UserID = id
+	t.Status = entity.TaskStatusTodo
+	if t.Priority == "" {
+		t.Priority = entity.TaskPriorityNormal
 	}
 	err := a.Repo.AddTask(ctx, a.DB, t)
 	if err != nil {
-		return nil, fmt.Errorf("failed to register: %w", err)
+		return fmt.Errorf("failed to register: %w", err)
 	}
-	return t, nil
+	return nil
 }
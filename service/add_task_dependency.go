@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type AddTaskDependency struct {
+	DB   store.TxBeginner
+	Repo TaskDependencyAdder
+}
+
+// AddTaskDependency はblockerIDのタスクが完了するまでblockedIDのタスクに着手できない依存関係を登録する
+// 依存関係が循環する場合は*entity.DependencyCycleErrorを返却する
+// 登録済の場合も成功として扱い、PUTリクエストとして冪等とする
+// handler/service.goの実装
+func (a *AddTaskDependency) AddTaskDependency(ctx context.Context, blockerID, blockedID entity.TaskID) error {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+
+	tx, err := a.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Commit済の場合、Rollbackはsql.ErrTxDoneを返すのみのため戻り値は破棄する
+	defer func() { _ = tx.Rollback() }()
+
+	ds, err := a.Repo.ListTaskDependencies(ctx, tx, uid)
+	if err != nil {
+		return fmt.Errorf("failed to list dependencies: %w", err)
+	}
+	// 追加する依存関係の逆方向に到達できる場合、追加により循環が発生する
+	if path := ds.PathFrom(blockedID, blockerID); path != nil {
+		return &entity.DependencyCycleError{Path: append([]entity.TaskID{blockerID}, path...)}
+	}
+	if err := a.Repo.AddTaskDependency(ctx, tx, uid, blockerID, blockedID); err != nil && !errors.Is(err, store.ErrAlreadyEntry) {
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type ArchiveProject struct {
+	DB   store.ExecQueryer
+	Repo ProjectArchiver
+}
+
+// ArchiveProject は一意のユーザに紐付いたプロジェクトをアーカイブ、またはアーカイブを解除する
+// アーカイブ済のプロジェクトに属するタスクは、タスク一覧の既定の取得結果から除外される
+// handler/service.goの実装
+func (a *ArchiveProject) ArchiveProject(ctx context.Context, id entity.ProjectID, archived bool) (*entity.Project, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	if err := a.Repo.ArchiveProject(ctx, a.DB, uid, id, archived); err != nil {
+		return nil, fmt.Errorf("failed to archive: %w", err)
+	}
+	p, err := a.Repo.GetProject(ctx, a.DB, uid, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type AttachTag struct {
+	DB   store.Execer
+	Repo TagAttacher
+}
+
+// AttachTag はログインユーザのタスクにタグを付与する
+// 付与済の場合も成功として扱い、PUTリクエストとして冪等とする
+// handler/service.goの実装
+func (a *AttachTag) AttachTag(ctx context.Context, taskID entity.TaskID, tagID entity.TagID) error {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	if err := a.Repo.AttachTag(ctx, a.DB, uid, taskID, tagID); err != nil && !errors.Is(err, store.ErrAlreadyEntry) {
+		return fmt.Errorf("failed to attach: %w", err)
+	}
+	return nil
+}
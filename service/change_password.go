@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type ChangePassword struct {
+	DB             store.ExecQueryer
+	Repo           UserPasswordUpdater
+	Policy         PasswordPolicy
+	Revoker        TokenRevoker
+	TokenGenerator TokenGenerator
+	Clocker        clock.Clocker
+	ReauthMaxAge   time.Duration // パスワードを持たないユーザに要求するIdPでの認証からの経過期間の上限
+}
+
+// ChangePassword は現在のパスワードを検証した上で、ログインユーザのパスワードを変更する
+// パスワードを持たないユーザの場合は、現在のパスワードに代えて直近のIdPでの認証を要求した上でパスワードを設定する
+// 他のセッションを失効させるため発行済のトークンはパーソナルアクセストークンを含めすべて失効させ、
+// リクエスト元には新たなトークンを発行する
+// handler/service.goの実装
+func (c *ChangePassword) ChangePassword(ctx context.Context, current, password string) (*auth.TokenPair, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	u, err := c.Repo.GetUserByID(ctx, c.DB, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := reauthenticate(ctx, u, current, c.Clocker.Now(), c.ReauthMaxAge); err != nil {
+		return nil, err
+	}
+	if err := validateNewPassword(c.Policy, u.Name, current, password); err != nil {
+		return nil, err
+	}
+	pw, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	u.Password = pw
+	u.PasswordResetRequired = false
+	if err := c.Repo.UpdatePassword(ctx, c.DB, u); err != nil {
+		return nil, fmt.Errorf("failed to update password: %w", err)
+	}
+	if err := c.Repo.DeletePersonalAccessTokens(ctx, c.DB, uid); err != nil {
+		return nil, fmt.Errorf("failed to delete personal access tokens: %w", err)
+	}
+
+	if err := c.Revoker.RevokeAllTokens(ctx, uid); err != nil {
+		return nil, fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	refresh, err := c.TokenGenerator.GenerateRefreshToken(ctx, *u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	// アクセストークンのセッションをリフレッシュトークンに関連付け、セッション単位で失効できるようにする
+	jwt, err := c.TokenGenerator.GenerateToken(auth.SetRefreshToken(ctx, refresh), *u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT: %w", err)
+	}
+	return &auth.TokenPair{AccessToken: string(jwt), RefreshToken: refresh}, nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestChangePassword(t *testing.T) {
+	t.Parallel()
+
+	pw, err := bcrypt.GenerateFromPassword([]byte("Current-pass1"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := clock.FixedClocker{}.Now()
+	tests := map[string]struct {
+		hash     string // 空の場合はOpenID Connectで登録したパスワードを持たないユーザ
+		current  string
+		password string
+		authTime time.Time // ゼロ値の場合はトークンに認証日時を持たない
+		wantErr  error
+	}{
+		"ok":            {hash: string(pw), current: "Current-pass1", password: "N3w-passphrase"},
+		"wrongCurrent":  {hash: string(pw), current: "wrong", password: "N3w-passphrase", wantErr: ErrInvalidCredentials},
+		"weak":          {hash: string(pw), current: "Current-pass1", password: "short", wantErr: ErrWeakPassword},
+		"sameAsCurrent": {hash: string(pw), current: "Current-pass1", password: "Current-pass1", wantErr: ErrWeakPassword},
+		"recentLogin":   {password: "N3w-passphrase", authTime: now.Add(-time.Minute)},
+		"staleLogin": {
+			password: "N3w-passphrase", authTime: now.Add(-time.Hour), wantErr: ErrReauthenticationRequired,
+		},
+		"noAuthTime": {password: "N3w-passphrase", wantErr: ErrReauthenticationRequired},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := auth.SetUserID(context.Background(), 1)
+			if !tt.authTime.IsZero() {
+				ctx = auth.SetAuthTime(ctx, tt.authTime)
+			}
+			repo := &UserPasswordUpdaterMock{}
+			repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+				return &entity.User{ID: id, Name: "john", Password: tt.hash, PasswordResetRequired: true}, nil
+			}
+			repo.UpdatePasswordFunc = func(ctx context.Context, db store.Execer, u *entity.User) error {
+				if u.PasswordResetRequired || u.ComparePassword(tt.password) != nil {
+					t.Errorf("want new password stored and reset flag cleared, but got %+v", u)
+				}
+				return nil
+			}
+			repo.DeletePersonalAccessTokensFunc = func(ctx context.Context, db store.Execer, uid entity.UserID) error {
+				return nil
+			}
+			revoker := &TokenRevokerMock{}
+			revoker.RevokeAllTokensFunc = func(ctx context.Context, uid entity.UserID) error {
+				return nil
+			}
+			tg := &TokenGeneratorMock{}
+			tg.GenerateTokenFunc = func(ctx context.Context, u entity.User) ([]byte, error) {
+				return []byte("access"), nil
+			}
+			tg.GenerateRefreshTokenFunc = func(ctx context.Context, u entity.User) (string, error) {
+				return "refresh", nil
+			}
+
+			sut := &ChangePassword{
+				Repo: repo, Policy: PasswordPolicy{MinLength: 10, MinClasses: 3}, Revoker: revoker, TokenGenerator: tg,
+				Clocker: clock.FixedClocker{}, ReauthMaxAge: 5 * time.Minute,
+			}
+			got, err := sut.ChangePassword(ctx, tt.current, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want %v, but got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				if n := len(repo.UpdatePasswordCalls()) + len(repo.DeletePersonalAccessTokensCalls()) +
+					len(revoker.RevokeAllTokensCalls()); n != 0 {
+					t.Errorf("want nothing changed, but got %d calls", n)
+				}
+				return
+			}
+			if n := len(revoker.RevokeAllTokensCalls()); n != 1 {
+				t.Errorf("want other sessions revoked, but got %d calls", n)
+			}
+			if n := len(repo.DeletePersonalAccessTokensCalls()); n != 1 {
+				t.Errorf("want personal access tokens revoked, but got %d calls", n)
+			}
+			if got.AccessToken != "access" || got.RefreshToken != "refresh" {
+				t.Errorf("unexpected tokens: %+v", got)
+			}
+		})
+	}
+}
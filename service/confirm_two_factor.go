@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// RecoveryCodeCount は二要素認証の有効化時に発行するリカバリーコードの数
+const RecoveryCodeCount = 10
+
+var (
+	// ErrTwoFactorNotSetUp は二要素認証のシークレットが生成されていないことを表す
+	ErrTwoFactorNotSetUp = errors.New("two-factor authentication is not set up")
+	// ErrInvalidTwoFactorCode はTOTPのコードまたはリカバリーコードが誤っていることを表す
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor authentication code")
+)
+
+type ConfirmTwoFactor struct {
+	DB      store.TxBeginner
+	Repo    TwoFactorConfirmer
+	Clocker clock.Clocker
+}
+
+// ConfirmTwoFactor はSetupTwoFactorで生成したシークレットに対するTOTPのコードを確認し、二要素認証を有効化する
+// 有効化と同時にリカバリーコードを発行し、平文のコードを返却する(保存するのはハッシュ値のみ)
+// handler/service.goの実装
+func (ct *ConfirmTwoFactor) ConfirmTwoFactor(ctx context.Context, code string) ([]string, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	tx, err := ct.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Commit済の場合、Rollbackはsql.ErrTxDoneを返すのみのため戻り値は破棄する
+	defer func() { _ = tx.Rollback() }()
+
+	u, err := ct.Repo.GetUserByID(ctx, tx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if u.TOTPEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if u.TOTPSecret == nil {
+		return nil, ErrTwoFactorNotSetUp
+	}
+	if _, ok := auth.VerifyTOTP(*u.TOTPSecret, code, ct.Clocker.Now()); !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+	if err := ct.Repo.EnableTOTP(ctx, tx, uid, *u.TOTPSecret); err != nil {
+		// 確認中にシークレットが再生成された場合
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrInvalidTwoFactorCode
+		}
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		if codes[i], err = newRecoveryCode(); err != nil {
+			return nil, err
+		}
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	if err := ct.Repo.ReplaceRecoveryCodes(ctx, tx, uid, hashes); err != nil {
+		return nil, fmt.Errorf("failed to save recovery codes: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return codes, nil
+}
+
+// newRecoveryCode は"XXXX-XXXX-XXXX-XXXX"形式(80bit)のリカバリーコードを生成する
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+	s := base32.StdEncoding.EncodeToString(b)
+	return s[0:4] + "-" + s[4:8] + "-" + s[8:12] + "-" + s[12:16], nil
+}
+
+// hashRecoveryCode はリカバリーコードのSHA-256ハッシュ値を16進数文字列で返却する
+// 入力の揺れを許容するため、区切り文字と空白を除去し、英字を大文字に揃えてからハッシュ化する
+func hashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestConfirmTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(clock.FixedClocker{}.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		user    entity.User
+		code    string
+		wantErr error
+	}{
+		"ok":             {user: entity.User{TOTPSecret: &secret}, code: code},
+		"wrongCode":      {user: entity.User{TOTPSecret: &secret}, code: "000000", wantErr: ErrInvalidTwoFactorCode},
+		"notSetUp":       {user: entity.User{}, code: code, wantErr: ErrTwoFactorNotSetUp},
+		"alreadyEnabled": {user: entity.User{TOTPSecret: &secret, TOTPEnabled: true}, code: code, wantErr: ErrTwoFactorAlreadyEnabled},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := auth.SetUserID(context.Background(), 1)
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			mock.ExpectBegin()
+			if tt.wantErr == nil {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			var saved []string
+			repo := &TwoFactorConfirmerMock{}
+			repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+				u := tt.user
+				u.ID = id
+				return &u, nil
+			}
+			repo.EnableTOTPFunc = func(ctx context.Context, db store.Execer, id entity.UserID, s string) error {
+				return nil
+			}
+			repo.ReplaceRecoveryCodesFunc = func(ctx context.Context, db store.Execer, id entity.UserID, hashes []string) error {
+				saved = hashes
+				return nil
+			}
+
+			sut := &ConfirmTwoFactor{DB: sqlx.NewDb(db, "mysql"), Repo: repo, Clocker: clock.FixedClocker{}}
+			got, err := sut.ConfirmTwoFactor(ctx, tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want %v, but got %v", tt.wantErr, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+			if tt.wantErr != nil {
+				if n := len(repo.EnableTOTPCalls()); n != 0 {
+					t.Errorf("want not enabled, but got %d calls", n)
+				}
+				return
+			}
+			// 平文のリカバリーコードは返却のみとし、保存するのはハッシュ値のみとする
+			if len(got) != RecoveryCodeCount || len(saved) != RecoveryCodeCount {
+				t.Fatalf("want %d recovery codes, but got %d returned and %d saved", RecoveryCodeCount, len(got), len(saved))
+			}
+			for i, c := range got {
+				if saved[i] != hashRecoveryCode(c) {
+					t.Errorf("want hash of %q saved, but got %q", c, saved[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type DeleteAccount struct {
+	DB           store.TxBeginner
+	Repo         AccountDeleter
+	Revoker      TokenRevoker
+	Clocker      clock.Clocker
+	ReauthMaxAge time.Duration // パスワードを持たないユーザに要求するIdPでの認証からの経過期間の上限
+}
+
+// DeleteAccount はパスワードを再確認した上で、ログインユーザと、ユーザが作成したすべてのデータを削除する
+// パスワードを持たないユーザの場合は、パスワードに代えて直近のIdPでの認証を要求する
+// 匿名化ではなく物理削除とし、削除後は発行済のトークンをすべて失効させる
+// handler/service.goの実装
+func (d *DeleteAccount) DeleteAccount(ctx context.Context, password string) error {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+
+	tx, err := d.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Commit済の場合、Rollbackはsql.ErrTxDoneを返すのみのため戻り値は破棄する
+	defer func() { _ = tx.Rollback() }()
+
+	u, err := d.Repo.GetUserByID(ctx, tx, uid)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := reauthenticate(ctx, u, password, d.Clocker.Now(), d.ReauthMaxAge); err != nil {
+		return err
+	}
+	if err := d.Repo.DeleteUser(ctx, tx, uid); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	if err := d.Revoker.RevokeAllTokens(ctx, uid); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestDeleteAccount(t *testing.T) {
+	t.Parallel()
+
+	pw, err := bcrypt.GenerateFromPassword([]byte("correct"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := clock.FixedClocker{}.Now()
+	tests := map[string]struct {
+		hash     string // 空の場合はOpenID Connectで登録したパスワードを持たないユーザ
+		password string
+		authTime time.Time // ゼロ値の場合はトークンに認証日時を持たない
+		wantErr  error
+	}{
+		"ok":            {hash: string(pw), password: "correct"},
+		"wrongPassword": {hash: string(pw), password: "wrong", wantErr: ErrInvalidCredentials},
+		"recentLogin":   {authTime: now.Add(-time.Minute)},
+		"staleLogin":    {authTime: now.Add(-time.Hour), wantErr: ErrReauthenticationRequired},
+		"noAuthTime":    {wantErr: ErrReauthenticationRequired},
+		// パスワードを持つユーザはIdPでの認証日時によらずパスワードを要求する
+		"passwordOverAuthTime": {
+			hash: string(pw), password: "wrong", authTime: now, wantErr: ErrInvalidCredentials,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := auth.SetUserID(context.Background(), 1)
+			if !tt.authTime.IsZero() {
+				ctx = auth.SetAuthTime(ctx, tt.authTime)
+			}
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			mock.ExpectBegin()
+			if tt.wantErr == nil {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			repo := &AccountDeleterMock{}
+			repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+				return &entity.User{ID: id, Name: "john", Password: tt.hash}, nil
+			}
+			repo.DeleteUserFunc = func(ctx context.Context, db store.Execer, id entity.UserID) error {
+				return nil
+			}
+			revoker := &TokenRevokerMock{}
+			revoker.RevokeAllTokensFunc = func(ctx context.Context, uid entity.UserID) error {
+				return nil
+			}
+
+			sut := &DeleteAccount{
+				DB: sqlx.NewDb(db, "mysql"), Repo: repo, Revoker: revoker,
+				Clocker: clock.FixedClocker{}, ReauthMaxAge: 5 * time.Minute,
+			}
+			err = sut.DeleteAccount(ctx, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want %v, but got %v", tt.wantErr, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+			want := 1
+			if tt.wantErr != nil {
+				want = 0
+			}
+			if n := len(repo.DeleteUserCalls()) + len(revoker.RevokeAllTokensCalls()); n != 2*want {
+				t.Errorf("want user deleted and tokens revoked %d times, but got %d calls", want, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type DeletePersonalAccessToken struct {
+	DB   store.Execer
+	Repo PersonalAccessTokenDeleter
+}
+
+// DeletePersonalAccessToken はログインユーザが発行したパーソナルアクセストークンを失効させる
+// handler/service.goの実装
+func (d *DeletePersonalAccessToken) DeletePersonalAccessToken(ctx context.Context, id entity.PersonalAccessTokenID) error {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	if err := d.Repo.DeletePersonalAccessToken(ctx, d.DB, uid, id); err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type DeleteProject struct {
+	DB   store.Execer
+	Repo ProjectDeleter
+}
+
+// DeleteProject は一意のユーザに紐付いたプロジェクトを1件削除する
+// 所属していたタスクは削除せず、プロジェクト未所属とする
+// handler/service.goの実装
+func (d *DeleteProject) DeleteProject(ctx context.Context, id entity.ProjectID) error {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	if err := d.Repo.DeleteProject(ctx, d.DB, uid, id); err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+)
+
+type DeleteSession struct {
+	Sessions SessionRevoker
+}
+
+// DeleteSession はログインユーザのセッションを削除し、当該セッションのアクセストークンとリフレッシュトークンを失効させる
+// handler/service.goの実装
+func (d *DeleteSession) DeleteSession(ctx context.Context, sid string) error {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	if err := d.Sessions.RevokeSession(ctx, uid, sid); err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type DeleteTag struct {
+	DB   store.Execer
+	Repo TagDeleter
+}
+
+// DeleteTag は一意のユーザに紐付いたタグを1件削除する
+// handler/service.goの実装
+func (d *DeleteTag) DeleteTag(ctx context.Context, id entity.TagID) error {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	if err := d.Repo.DeleteTag(ctx, d.DB, uid, id); err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	return nil
+}
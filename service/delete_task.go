@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type DeleteTask struct {
+	DB   store.ExecQueryer
+	Repo TaskDeleter
+}
+
+// DeleteTask は一意のユーザに紐付いたタスクを1件、子孫タスクと併せてゴミ箱へ移動する
+// handler/service.goの実装
+func (d *DeleteTask) DeleteTask(ctx context.Context, id entity.TaskID) error {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	if err := d.Repo.DeleteTask(ctx, d.DB, uid, id); err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	return nil
+}
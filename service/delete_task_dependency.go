@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type DeleteTaskDependency struct {
+	DB   store.Execer
+	Repo TaskDependencyDeleter
+}
+
+// DeleteTaskDependency はログインユーザのタスク間の依存関係を削除する
+// handler/service.goの実装
+func (d *DeleteTaskDependency) DeleteTaskDependency(ctx context.Context, blockerID, blockedID entity.TaskID) error {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	if err := d.Repo.DeleteTaskDependency(ctx, d.DB, uid, blockerID, blockedID); err != nil {
+		return fmt.Errorf("failed to delete dependency: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type DetachTag struct {
+	DB   store.Execer
+	Repo TagDetacher
+}
+
+// DetachTag はログインユーザのタスクからタグを外す
+// handler/service.goの実装
+func (d *DetachTag) DetachTag(ctx context.Context, taskID entity.TaskID, tagID entity.TagID) error {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	if err := d.Repo.DetachTag(ctx, d.DB, uid, taskID, tagID); err != nil {
+		return fmt.Errorf("failed to detach: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type EmptyTrash struct {
+	DB   store.Execer
+	Repo TrashEmptier
+}
+
+// EmptyTrash はログインユーザのゴミ箱にあるタスクをすべて完全に削除し、削除した件数を返却する
+// handler/service.goの実装
+func (e *EmptyTrash) EmptyTrash(ctx context.Context) (int64, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return 0, fmt.Errorf("user_id not found")
+	}
+	n, err := e.Repo.EmptyTrash(ctx, e.DB, uid)
+	if err != nil {
+		return 0, fmt.Errorf("failed to empty trash: %w", err)
+	}
+	return n, nil
+}
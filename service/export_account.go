@@ -0,0 +1,90 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// exportBatchSize はエクスポート時に1回のSQLで取得するタスクの件数
+const exportBatchSize = 500
+
+type ExportAccount struct {
+	DB   store.Queryer
+	Repo AccountExporter
+}
+
+// ExportAccount はログインユーザのプロフィールと、ゴミ箱を含むすべてのタスクをJSONとしてZIP形式でwへ書き込む
+// ZIPにはprofile.jsonとtasks.json(タスクの配列)を格納し、タスクは一定件数ごとに取得して逐次書き込む
+// handler/service.goの実装
+func (e *ExportAccount) ExportAccount(ctx context.Context, w io.Writer) error {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	u, err := e.Repo.GetUserByID(ctx, e.DB, uid)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	zw := zip.NewWriter(w)
+	f, err := zw.Create("profile.json")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(u); err != nil {
+		return fmt.Errorf("failed to encode profile: %w", err)
+	}
+
+	if f, err = zw.Create("tasks.json"); err != nil {
+		return err
+	}
+	if err := e.writeTasks(ctx, f, uid); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// writeTasks はユーザのすべてのタスクをJSONの配列としてwへ書き込む
+func (e *ExportAccount) writeTasks(ctx context.Context, w io.Writer, uid entity.UserID) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	var after entity.TaskID
+	for first := true; ; {
+		tasks, err := e.Repo.ExportTasks(ctx, e.DB, uid, after, exportBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to export tasks: %w", err)
+		}
+		for _, t := range tasks {
+			b, err := json.Marshal(t)
+			if err != nil {
+				return fmt.Errorf("failed to encode task %d: %w", t.ID, err)
+			}
+			sep := ",\n"
+			if first {
+				sep, first = "\n", false
+			}
+			if _, err := io.WriteString(w, sep); err != nil {
+				return err
+			}
+			if _, err := w.Write(b); err != nil {
+				return err
+			}
+		}
+		if len(tasks) < exportBatchSize {
+			break
+		}
+		after = tasks[len(tasks)-1].ID
+	}
+	_, err := io.WriteString(w, "\n]\n")
+	return err
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+func TestExportAccount(t *testing.T) {
+	t.Parallel()
+
+	ctx := auth.SetUserID(context.Background(), 1)
+	// バッチの境界をまたぐ件数のタスクを用意する
+	all := make(entity.Tasks, exportBatchSize+2)
+	for i := range all {
+		all[i] = &entity.Task{ID: entity.TaskID(i + 1), UserID: 1, Title: "task", Tags: entity.Tags{}}
+	}
+	repo := &AccountExporterMock{}
+	repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+		return &entity.User{ID: id, Name: "john", Password: "hashed_password", TimeZone: "Asia/Tokyo", Locale: "ja"}, nil
+	}
+	repo.ExportTasksFunc = func(
+		ctx context.Context, db store.Queryer, uid entity.UserID, afterID entity.TaskID, limit int,
+	) (entity.Tasks, error) {
+		start := int(afterID)
+		end := start + limit
+		if end > len(all) {
+			end = len(all)
+		}
+		return all[start:end], nil
+	}
+
+	var buf bytes.Buffer
+	sut := &ExportAccount{Repo: repo}
+	if err := sut.ExportAccount(ctx, &buf); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if n := len(repo.ExportTasksCalls()); n != 2 {
+		t.Errorf("want tasks fetched in 2 batches, but got %d", n)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("want valid zip, but got %v", err)
+	}
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = b
+	}
+
+	var profile map[string]any
+	if err := json.Unmarshal(files["profile.json"], &profile); err != nil {
+		t.Fatalf("want profile.json, but got %v", err)
+	}
+	if profile["name"] != "john" || profile["time_zone"] != "Asia/Tokyo" {
+		t.Errorf("unexpected profile: %v", profile)
+	}
+	if strings.Contains(string(files["profile.json"]), "hashed_password") {
+		t.Errorf("want password excluded, but got %s", files["profile.json"])
+	}
+	var tasks entity.Tasks
+	if err := json.Unmarshal(files["tasks.json"], &tasks); err != nil {
+		t.Fatalf("want tasks.json, but got %v", err)
+	}
+	if len(tasks) != len(all) || tasks[len(tasks)-1].ID != all[len(all)-1].ID {
+		t.Errorf("want %d tasks, but got %d", len(all), len(tasks))
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type FindPersonalAccessToken struct {
+	DB   store.Queryer
+	Repo PersonalAccessTokenGetter
+}
+
+// FindPersonalAccessToken はハッシュ値に一致するパーソナルアクセストークンを取得する
+// 所有者が管理者により無効化されている場合はauth.ErrUserDisabledを返却する
+// KVSの無効化フラグは反映に失敗する可能性があるため、DBの状態でも判定する
+// auth.PersonalAccessTokenFinderの実装
+func (f *FindPersonalAccessToken) FindPersonalAccessToken(ctx context.Context, hash string) (*entity.PersonalAccessToken, error) {
+	t, err := f.Repo.GetPersonalAccessTokenByHash(ctx, f.DB, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find personal access token: %w", err)
+	}
+	u, err := f.Repo.GetUserByID(ctx, f.DB, t.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner of personal access token: %w", err)
+	}
+	if u.Disabled() {
+		return nil, fmt.Errorf("user %d: %w", u.ID, auth.ErrUserDisabled)
+	}
+	return t, nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+func TestFindPersonalAccessToken(t *testing.T) {
+	t.Parallel()
+
+	now := clock.FixedClocker{}.Now()
+	tests := map[string]struct {
+		hash     string
+		disabled bool // 所有者がDBで無効化済か
+		wantErr  error
+	}{
+		"ok":       {hash: "hash"},
+		"notFound": {hash: "unknown", wantErr: store.ErrNotFound},
+		// KVSへの反映に失敗した場合も、DBで無効化済の所有者のトークンは拒否する
+		"disabledOwner": {hash: "hash", disabled: true, wantErr: auth.ErrUserDisabled},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &PersonalAccessTokenGetterMock{}
+			repo.GetPersonalAccessTokenByHashFunc = func(ctx context.Context, db store.Queryer, hash string) (*entity.PersonalAccessToken, error) {
+				if hash != "hash" {
+					return nil, store.ErrNotFound
+				}
+				return &entity.PersonalAccessToken{ID: 1, UserID: 10, TokenHash: hash}, nil
+			}
+			repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+				u := &entity.User{ID: id}
+				if tt.disabled {
+					u.DisabledAt = &now
+				}
+				return u, nil
+			}
+
+			sut := &FindPersonalAccessToken{Repo: repo}
+			got, err := sut.FindPersonalAccessToken(context.Background(), tt.hash)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want %v, but got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && got.UserID != 10 {
+				t.Errorf("want token of user 10, but got %+v", got)
+			}
+		})
+	}
+}
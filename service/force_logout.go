@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type ForceLogout struct {
+	DB      store.Queryer
+	Repo    UserByIDGetter
+	Revoker TokenRevoker
+}
+
+// ForceLogout は指定したユーザに発行済のすべてのトークンを失効させる
+// ユーザが存在しない場合はstore.ErrNotFoundを返却する
+// 管理者権限の確認は、呼び出し元のミドルウェアで実施する
+// handler/service.goの実装
+func (f *ForceLogout) ForceLogout(ctx context.Context, id entity.UserID) error {
+	if _, err := f.Repo.GetUserByID(ctx, f.DB, id); err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := f.Revoker.RevokeAllTokens(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke tokens of user %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/mail"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type ForgotPassword struct {
+	DB     store.Queryer
+	Repo   UserByEmailGetter
+	Tokens PasswordResetTokenStore
+	Mailer mail.Mailer
+	TTL    time.Duration // パスワード再設定トークンの有効期間
+	// ResetURL はメール本文に記載するパスワード再設定画面のURL
+	// "{token}"をパスワード再設定トークンに置換する
+	ResetURL string
+}
+
+// ForgotPassword はメールアドレスに一致するユーザへ、パスワード再設定トークンを記載したメールを送信する
+// アカウントの存在有無を推測させないため、一致するユーザが存在しない場合もエラーとしない
+// handler/service.goの実装
+func (f *ForgotPassword) ForgotPassword(ctx context.Context, email string) error {
+	u, err := f.Repo.GetUserByEmail(ctx, f.DB, email)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	token, hash, err := newPasswordResetToken()
+	if err != nil {
+		return err
+	}
+	// トークンはハッシュ値のみを保存し、平文はメールでのみ伝達する
+	if err := f.Tokens.SavePasswordResetToken(ctx, hash, u.ID, f.TTL); err != nil {
+		return fmt.Errorf("failed to save password reset token: %w", err)
+	}
+	msg := mail.Message{
+		To:      email,
+		Subject: "Password reset",
+		Body: fmt.Sprintf("Hello %s,\n\n"+
+			"A password reset was requested for your account. "+
+			"Use the following link within %s to set a new password:\n\n%s\n\n"+
+			"If you did not request this, you can ignore this email.\n",
+			u.Name, f.TTL, strings.ReplaceAll(f.ResetURL, "{token}", token)),
+	}
+	if err := f.Mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send password reset mail: %w", err)
+	}
+	return nil
+}
+
+// newPasswordResetToken はパスワード再設定トークンと、保存用のハッシュ値を生成する
+func newPasswordResetToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashPasswordResetToken(token), nil
+}
+
+// hashPasswordResetToken はパスワード再設定トークンのSHA-256ハッシュ値を16進数文字列で返却する
+func hashPasswordResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
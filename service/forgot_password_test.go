@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/mail"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+func TestForgotPassword(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := &UserByEmailGetterMock{}
+	repo.GetUserByEmailFunc = func(ctx context.Context, db store.Queryer, email string) (*entity.User, error) {
+		if email != "john@example.com" {
+			return nil, store.ErrNotFound
+		}
+		return &entity.User{ID: 1, Name: "john", Email: &email}, nil
+	}
+	saved := map[string]entity.UserID{}
+	tokens := &PasswordResetTokenStoreMock{}
+	tokens.SavePasswordResetTokenFunc = func(ctx context.Context, hash string, uid entity.UserID, ttl time.Duration) error {
+		saved[hash] = uid
+		return nil
+	}
+	mailer := &mail.Memory{}
+	sut := &ForgotPassword{
+		Repo: repo, Tokens: tokens, Mailer: mailer,
+		TTL: 30 * time.Minute, ResetURL: "https://example.com/reset?token={token}",
+	}
+
+	// 存在しないアカウントの場合もエラーとしない
+	if err := sut.ForgotPassword(ctx, "unknown@example.com"); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if n := len(mailer.Messages()); n != 0 {
+		t.Fatalf("want no mail for unknown account, but got %d", n)
+	}
+
+	if err := sut.ForgotPassword(ctx, "john@example.com"); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	msgs := mailer.Messages()
+	if len(msgs) != 1 || msgs[0].To != "john@example.com" {
+		t.Fatalf("want 1 mail to john, but got %v", msgs)
+	}
+	_, rest, ok := strings.Cut(msgs[0].Body, "https://example.com/reset?token=")
+	if !ok {
+		t.Fatalf("want reset url in body, but got %q", msgs[0].Body)
+	}
+	token := strings.Fields(rest)[0]
+	// 平文のトークンではなくハッシュ値を保存する
+	if saved[hashPasswordResetToken(token)] != 1 || saved[token] != 0 {
+		t.Errorf("want hashed token saved, but got %v", saved)
+	}
+}
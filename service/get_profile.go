@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type GetProfile struct {
+	DB   store.Queryer
+	Repo UserByIDGetter
+}
+
+// GetProfile はログインユーザの情報を取得する
+// handler/service.goの実装
+func (g *GetProfile) GetProfile(ctx context.Context) (*entity.User, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	u, err := g.Repo.GetUserByID(ctx, g.DB, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return u, nil
+}
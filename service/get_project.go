@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type GetProject struct {
+	DB   store.Queryer
+	Repo ProjectGetter
+}
+
+// GetProject は一意のユーザに紐付いたプロジェクトを1件取得する
+// handler/service.goの実装
+func (g *GetProject) GetProject(ctx context.Context, id entity.ProjectID) (*entity.Project, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	p, err := g.Repo.GetProject(ctx, g.DB, uid, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get: %w", err)
+	}
+	return p, nil
+}
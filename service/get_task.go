@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type GetTask struct {
+	DB   store.Queryer
+	Repo TaskGetter
+}
+
+// GetTask は一意のユーザに紐付いたタスクを1件取得する
+// handler/service.goの実装
+func (g *GetTask) GetTask(ctx context.Context, id entity.TaskID) (*entity.Task, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	t, err := g.Repo.GetTask(ctx, g.DB, uid, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get: %w", err)
+	}
+	return t, nil
+}
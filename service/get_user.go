@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type GetUser struct {
+	DB   store.Queryer
+	Repo UserByIDGetter
+}
+
+// GetUser は指定したユーザを取得する
+// 管理者権限の確認は、呼び出し元のミドルウェアで実施する
+// handler/service.goの実装
+func (g *GetUser) GetUser(ctx context.Context, id entity.UserID) (*entity.User, error) {
+	u, err := g.Repo.GetUserByID(ctx, g.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return u, nil
+}
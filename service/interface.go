@@ -2,30 +2,292 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/oidc"
 	"github.com/ac0mz/go_todo_app/store"
 )
 
 // 以下インターフェースはstore/task.goに実装する
 
-//go:generate go run github.com/matryer/moq -out moq_test.go . TaskLister TaskAdder UserRegister UserGetter TokenGenerator
+//go:generate go run github.com/matryer/moq -out moq_test.go . TaskLister TaskAdder TaskGetter TaskUpdater TaskDeleter TaskTransitioner TaskChildLister TaskMover TaskDependencyAdder TaskDependencyDeleter TrashLister TaskRestorer TrashEmptier TrashPurger TagAdder TagLister TagUpdater TagDeleter TagAttacher TagDetacher ProjectAdder ProjectLister ProjectGetter ProjectUpdater ProjectArchiver ProjectDeleter ProjectTaskLister UserRegister UserAuthenticator UserPasswordUpdater PasswordResetRequirer UserByEmailGetter UserByIDGetter UserLister UserRoleUpdater UserDisabler ProfileUpdater AccountDeleter AccountExporter TwoFactorSetupper TwoFactorConfirmer RecoveryCodeUser LoginAttemptStore PasswordResetTokenStore LoginChallengeStore OIDCUserProvisioner OIDCProvider OIDCStateStore TokenGenerator TokenRefresher TokenRevoker UserDisabledMarker SessionLister SessionRevoker PersonalAccessTokenAdder PersonalAccessTokenLister PersonalAccessTokenGetter PersonalAccessTokenDeleter
 type TaskLister interface {
-	ListTasks(ctx context.Context, db store.Queryer, id entity.UserID) (entity.Tasks, error)
+	ListTasks(ctx context.Context, db store.Queryer, id entity.UserID, f *entity.TaskFilter) (entity.Tasks, string, error)
 }
 
 type TaskAdder interface {
+	GetProject(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error)
+	ValidateTaskParent(ctx context.Context, db store.Queryer, uid entity.UserID, id, parentID entity.TaskID) error
 	AddTask(ctx context.Context, db store.Execer, t *entity.Task) error
 }
 
+type TaskGetter interface {
+	GetTask(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error)
+}
+
+type TaskUpdater interface {
+	GetTask(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error)
+	UpdateTask(ctx context.Context, db store.Execer, t *entity.Task) error
+}
+
+type TaskDeleter interface {
+	DeleteTask(ctx context.Context, db store.ExecQueryer, uid entity.UserID, id entity.TaskID) error
+}
+
+type TaskTransitioner interface {
+	GetTaskForUpdate(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error)
+	UpdateTaskStatus(ctx context.Context, db store.Execer, t *entity.Task) error
+	AddTaskTransition(ctx context.Context, db store.Execer, tt *entity.TaskTransition) error
+	GetTaskProgress(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (entity.TaskProgress, error)
+	ListOpenBlockers(ctx context.Context, db store.Queryer, id entity.TaskID) ([]entity.TaskID, error)
+	AddNextOccurrence(ctx context.Context, db store.Execer, sourceID entity.TaskID, t *entity.Task) error
+}
+
+type TaskChildLister interface {
+	GetTask(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error)
+	ListChildTasks(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (entity.Tasks, error)
+}
+
+type TaskMover interface {
+	GetTaskForUpdate(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error)
+	ValidateTaskParent(ctx context.Context, db store.Queryer, uid entity.UserID, id, parentID entity.TaskID) error
+	UpdateTaskParent(ctx context.Context, db store.Execer, t *entity.Task) error
+}
+
+type TaskDependencyAdder interface {
+	ListTaskDependencies(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.TaskDependencies, error)
+	AddTaskDependency(ctx context.Context, db store.Execer, uid entity.UserID, blockerID, blockedID entity.TaskID) error
+}
+
+type TaskDependencyDeleter interface {
+	DeleteTaskDependency(ctx context.Context, db store.Execer, uid entity.UserID, blockerID, blockedID entity.TaskID) error
+}
+
+type TrashLister interface {
+	ListTrashedTasks(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.Tasks, error)
+}
+
+type TaskRestorer interface {
+	RestoreTask(ctx context.Context, db store.ExecQueryer, uid entity.UserID, id entity.TaskID) error
+	GetTask(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error)
+}
+
+type TrashEmptier interface {
+	EmptyTrash(ctx context.Context, db store.Execer, uid entity.UserID) (int64, error)
+}
+
+type TrashPurger interface {
+	PurgeTrash(ctx context.Context, db store.Execer, before time.Time) (int64, error)
+}
+
+type TagAdder interface {
+	AddTag(ctx context.Context, db store.Execer, t *entity.Tag) error
+}
+
+type TagLister interface {
+	ListTags(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.Tags, error)
+}
+
+type TagUpdater interface {
+	UpdateTag(ctx context.Context, db store.Execer, t *entity.Tag) error
+}
+
+type TagDeleter interface {
+	DeleteTag(ctx context.Context, db store.Execer, uid entity.UserID, id entity.TagID) error
+}
+
+type TagAttacher interface {
+	AttachTag(ctx context.Context, db store.Execer, uid entity.UserID, taskID entity.TaskID, tagID entity.TagID) error
+}
+
+type TagDetacher interface {
+	DetachTag(ctx context.Context, db store.Execer, uid entity.UserID, taskID entity.TaskID, tagID entity.TagID) error
+}
+
+type ProjectAdder interface {
+	AddProject(ctx context.Context, db store.Execer, p *entity.Project) error
+}
+
+type ProjectLister interface {
+	ListProjects(ctx context.Context, db store.Queryer, uid entity.UserID, includeArchived bool) (entity.Projects, error)
+}
+
+type ProjectGetter interface {
+	GetProject(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error)
+}
+
+type ProjectUpdater interface {
+	GetProject(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error)
+	UpdateProject(ctx context.Context, db store.Execer, p *entity.Project) error
+}
+
+type ProjectArchiver interface {
+	GetProject(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error)
+	ArchiveProject(ctx context.Context, db store.Execer, uid entity.UserID, id entity.ProjectID, archived bool) error
+}
+
+type ProjectDeleter interface {
+	DeleteProject(ctx context.Context, db store.Execer, uid entity.UserID, id entity.ProjectID) error
+}
+
+type ProjectTaskLister interface {
+	GetProject(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error)
+	ListTasks(ctx context.Context, db store.Queryer, id entity.UserID, f *entity.TaskFilter) (entity.Tasks, string, error)
+}
+
 type UserRegister interface {
 	RegisterUser(ctx context.Context, db store.Execer, u *entity.User) error
 }
 
-type UserGetter interface {
+type UserAuthenticator interface {
 	GetUser(ctx context.Context, db store.Queryer, name string) (*entity.User, error)
+	UpdatePassword(ctx context.Context, db store.Execer, u *entity.User) error
+}
+
+type UserPasswordUpdater interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	UpdatePassword(ctx context.Context, db store.Execer, u *entity.User) error
+	DeletePersonalAccessTokens(ctx context.Context, db store.Execer, uid entity.UserID) error
+}
+
+type UserByEmailGetter interface {
+	GetUserByEmail(ctx context.Context, db store.Queryer, email string) (*entity.User, error)
+}
+
+type PasswordResetRequirer interface {
+	RequirePasswordReset(ctx context.Context, db store.Execer, id entity.UserID) error
+	DeletePersonalAccessTokens(ctx context.Context, db store.Execer, uid entity.UserID) error
+}
+
+type UserByIDGetter interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+}
+
+type ProfileUpdater interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	UpdateProfile(ctx context.Context, db store.Execer, u *entity.User) error
+}
+
+type AccountDeleter interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	DeleteUser(ctx context.Context, db store.Execer, id entity.UserID) error
+}
+
+type AccountExporter interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	ExportTasks(ctx context.Context, db store.Queryer, uid entity.UserID, afterID entity.TaskID, limit int) (entity.Tasks, error)
+}
+
+type UserLister interface {
+	ListUsers(ctx context.Context, db store.Queryer, f *entity.UserFilter) (entity.Users, string, error)
+}
+
+type UserRoleUpdater interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	UpdateUserRole(ctx context.Context, db store.Execer, u *entity.User) error
+}
+
+type UserDisabler interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	UpdateUserDisabled(ctx context.Context, db store.Execer, u *entity.User) error
+}
+
+type TwoFactorSetupper interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	SaveTOTPSecret(ctx context.Context, db store.Execer, id entity.UserID, secret string) error
+}
+
+type TwoFactorConfirmer interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	EnableTOTP(ctx context.Context, db store.Execer, id entity.UserID, secret string) error
+	ReplaceRecoveryCodes(ctx context.Context, db store.Execer, id entity.UserID, hashes []string) error
+}
+
+type RecoveryCodeUser interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	UseRecoveryCode(ctx context.Context, db store.Execer, id entity.UserID, hash string) error
+	UpdatePassword(ctx context.Context, db store.Execer, u *entity.User) error
+}
+
+type LoginAttemptStore interface {
+	IncrLoginFailures(ctx context.Context, key string, window time.Duration) (int64, error)
+	ResetLoginFailures(ctx context.Context, key string) error
+	LockLogin(ctx context.Context, key string, d time.Duration) error
+	LoginLockTTL(ctx context.Context, key string) (time.Duration, error)
+}
+
+type PasswordResetTokenStore interface {
+	SavePasswordResetToken(ctx context.Context, hash string, userID entity.UserID, ttl time.Duration) error
+	LoadPasswordResetToken(ctx context.Context, hash string) (entity.UserID, error)
+	ConsumePasswordResetToken(ctx context.Context, hash string) (entity.UserID, error)
+}
+
+type LoginChallengeStore interface {
+	SaveLoginChallenge(ctx context.Context, hash string, userID entity.UserID, ttl time.Duration) error
+	LoadLoginChallenge(ctx context.Context, hash string) (entity.UserID, error)
+	IncrLoginChallengeFailures(ctx context.Context, hash string) (int64, error)
+	ConsumeLoginChallenge(ctx context.Context, hash string) error
+	UseTOTPStep(ctx context.Context, userID entity.UserID, step int64, ttl time.Duration) (bool, error)
+}
+
+type OIDCUserProvisioner interface {
+	GetUserByIdentity(ctx context.Context, db store.Queryer, issuer, subject string) (*entity.User, error)
+	RegisterUser(ctx context.Context, db store.Execer, u *entity.User) error
+	AddUserIdentity(ctx context.Context, db store.Execer, i *entity.UserIdentity) error
+}
+
+type OIDCProvider interface {
+	AuthCodeURL(ctx context.Context, req *oidc.AuthRequest) (string, error)
+	Exchange(ctx context.Context, code string, req *oidc.AuthRequest) (*oidc.Identity, error)
+}
+
+type OIDCStateStore interface {
+	SaveOIDCState(ctx context.Context, hash, nonce, verifier string, ttl time.Duration) error
+	ConsumeOIDCState(ctx context.Context, hash string) (string, string, error)
 }
 
 type TokenGenerator interface {
 	GenerateToken(ctx context.Context, u entity.User) ([]byte, error)
+	GenerateRefreshToken(ctx context.Context, u entity.User) (string, error)
+}
+
+type TokenRefresher interface {
+	RotateRefreshToken(ctx context.Context, token string) (string, entity.UserID, error)
+	GenerateToken(ctx context.Context, u entity.User) ([]byte, error)
+}
+
+type TokenRevoker interface {
+	RevokeToken(ctx context.Context, jti string) error
+	RevokeAllTokens(ctx context.Context, uid entity.UserID) error
+}
+
+type UserDisabledMarker interface {
+	DisableUser(ctx context.Context, uid entity.UserID) error
+	EnableUser(ctx context.Context, uid entity.UserID) error
+}
+
+type SessionLister interface {
+	ListSessions(ctx context.Context, uid entity.UserID) (entity.Sessions, error)
+}
+
+type SessionRevoker interface {
+	RevokeSession(ctx context.Context, uid entity.UserID, sid string) error
+}
+
+type PersonalAccessTokenAdder interface {
+	AddPersonalAccessToken(ctx context.Context, db store.Execer, t *entity.PersonalAccessToken) error
+}
+
+type PersonalAccessTokenLister interface {
+	ListPersonalAccessTokens(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.PersonalAccessTokens, error)
+}
+
+type PersonalAccessTokenGetter interface {
+	GetPersonalAccessTokenByHash(ctx context.Context, db store.Queryer, hash string) (*entity.PersonalAccessToken, error)
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+}
+
+type PersonalAccessTokenDeleter interface {
+	DeletePersonalAccessToken(ctx context.Context, db store.Execer, uid entity.UserID, id entity.PersonalAccessTokenID) error
 }
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type ListChildTask struct {
+	DB   store.Queryer
+	Repo TaskChildLister
+}
+
+// ListChildTasks は一意のユーザに紐付いたタスクの直下の子タスク一覧を取得する
+// handler/service.goの実装
+func (l *ListChildTask) ListChildTasks(ctx context.Context, id entity.TaskID) (entity.Tasks, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	// 他ユーザのタスクは空の一覧ではなく404とするため、先に存在を確認する
+	if _, err := l.Repo.GetTask(ctx, l.DB, uid, id); err != nil {
+		return nil, fmt.Errorf("failed to get: %w", err)
+	}
+	ts, err := l.Repo.ListChildTasks(ctx, l.DB, uid, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list children: %w", err)
+	}
+	return ts, nil
+}
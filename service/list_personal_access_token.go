@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type ListPersonalAccessToken struct {
+	DB   store.Queryer
+	Repo PersonalAccessTokenLister
+}
+
+// ListPersonalAccessTokens はログインユーザが発行したパーソナルアクセストークンの一覧を取得する
+// handler/service.goの実装
+func (l *ListPersonalAccessToken) ListPersonalAccessTokens(ctx context.Context) (entity.PersonalAccessTokens, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	ts, err := l.Repo.ListPersonalAccessTokens(ctx, l.DB, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list: %w", err)
+	}
+	return ts, nil
+}
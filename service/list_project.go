@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type ListProject struct {
+	DB   store.Queryer
+	Repo ProjectLister
+}
+
+// ListProjects は一意のユーザに紐付いたプロジェクト一覧のみを取得する
+// handler/service.goの実装
+func (l *ListProject) ListProjects(ctx context.Context, includeArchived bool) (entity.Projects, error) {
+	id, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	ps, err := l.Repo.ListProjects(ctx, l.DB, id, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list: %w", err)
+	}
+	return ps, nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type ListProjectTask struct {
+	DB   store.Queryer
+	Repo ProjectTaskLister
+}
+
+// ListProjectTasks はプロジェクトに属するタスク一覧を取得する
+// タスクの取得はListTasksと同じリポジトリの処理を経由し、ユーザの所有確認を一箇所に留める
+// handler/service.goの実装
+func (l *ListProjectTask) ListProjectTasks(
+	ctx context.Context, id entity.ProjectID, f *entity.TaskFilter,
+) (entity.Tasks, string, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, "", fmt.Errorf("user_id not found")
+	}
+	// 他ユーザのプロジェクトは空の一覧ではなく404とするため、先に存在を確認する
+	if _, err := l.Repo.GetProject(ctx, l.DB, uid, id); err != nil {
+		return nil, "", fmt.Errorf("failed to get project: %w", err)
+	}
+	f.ProjectID = &id
+	ts, next, err := l.Repo.ListTasks(ctx, l.DB, uid, f)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list: %w", err)
+	}
+	return ts, next, nil
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+type ListSession struct {
+	Sessions SessionLister
+}
+
+// ListSessions はログインユーザの有効なセッションの一覧を取得する
+// handler/service.goの実装
+func (l *ListSession) ListSessions(ctx context.Context) (entity.Sessions, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	ss, err := l.Sessions.ListSessions(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list: %w", err)
+	}
+	return ss, nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type ListTag struct {
+	DB   store.Queryer
+	Repo TagLister
+}
+
+// ListTags は一意のユーザに紐付いたタグ一覧のみを取得する
+// handler/service.goの実装
+func (l *ListTag) ListTags(ctx context.Context) (entity.Tags, error) {
+	id, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	tags, err := l.Repo.ListTags(ctx, l.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list: %w", err)
+	}
+	return tags, nil
+}
@@ -15,15 +15,16 @@ type ListTask struct {
 }
 
 // ListTasks は一意のユーザに紐付いたタスク一覧のみを取得する
+// 戻り値の文字列は次ページ取得用のカーソルであり、後続のページが存在しない場合は空文字となる
 // handler/service.goの実装
-func (l *ListTask) ListTasks(ctx context.Context) (entity.Tasks, error) {
+func (l *ListTask) ListTasks(ctx context.Context, f *entity.TaskFilter) (entity.Tasks, string, error) {
 	id, ok := auth.GetUserID(ctx)
 	if !ok {
-		return nil, fmt.Errorf("user_id not found")
+		return nil, "", fmt.Errorf("user_id not found")
 	}
-	ts, err := l.Repo.ListTasks(ctx, l.DB, id)
+	ts, next, err := l.Repo.ListTasks(ctx, l.DB, id, f)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list: %v", err)
+		return nil, "", fmt.Errorf("failed to list: %w", err)
 	}
-	return ts, nil
+	return ts, next, nil
 }
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type ListTrash struct {
+	DB   store.Queryer
+	Repo TrashLister
+}
+
+// ListTrash はログインユーザのゴミ箱にあるタスク一覧を取得する
+// handler/service.goの実装
+func (l *ListTrash) ListTrash(ctx context.Context) (entity.Tasks, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	ts, err := l.Repo.ListTrashedTasks(ctx, l.DB, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list: %w", err)
+	}
+	return ts, nil
+}
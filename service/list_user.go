@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type ListUser struct {
+	DB   store.Queryer
+	Repo UserLister
+}
+
+// ListUsers は条件に一致するユーザの一覧を取得する
+// 戻り値の文字列は次ページ取得用のカーソルであり、後続のページが存在しない場合は空文字となる
+// 管理者権限の確認は、呼び出し元のミドルウェアで実施する
+// handler/service.goの実装
+func (l *ListUser) ListUsers(ctx context.Context, f *entity.UserFilter) (entity.Users, string, error) {
+	us, next, err := l.Repo.ListUsers(ctx, l.DB, f)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list users: %w", err)
+	}
+	return us, next, nil
+}
@@ -2,29 +2,134 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
 	"github.com/ac0mz/go_todo_app/store"
 )
 
+// ErrInvalidCredentials はユーザ名またはパスワードが誤っていることを表す
+// ユーザ名の存在有無を推測させないため、いずれの誤りも当該エラーとする
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// ErrPasswordResetRequired はパスワードの再設定が必要なユーザが、新しいパスワードを指定せずにログインしたことを表す
+var ErrPasswordResetRequired = errors.New("password reset required")
+
+// dummyPasswordHash はユーザが存在しない場合に比較するパスワードのハッシュ値
+// ユーザの存在有無によって応答時間に差が生じないよう、存在する場合と同様にハッシュ値を比較する
+const dummyPasswordHash = "$2a$10$Rr50MRFyMLUJBMgXS0gK9eUPbQsbvXxe3vOguH.J19QVRVQrpDwCq"
+
 type Login struct {
-	DB             store.Queryer
-	Repo           UserGetter
+	DB             store.ExecQueryer
+	Repo           UserAuthenticator
 	TokenGenerator TokenGenerator
+	Throttle       *LoginThrottle // nilの場合はログイン試行を制限しない
+	Policy         PasswordPolicy
+	Challenges     LoginChallengeStore
+	ChallengeTTL   time.Duration // 二要素認証のチャレンジの有効期間
 }
 
-func (l Login) Login(ctx context.Context, name, password string) (string, error) {
+// LoginResult はログインの結果
+// 二要素認証が有効なユーザの場合はTokensをnilとし、トークンの発行に代えてチャレンジを返却する
+type LoginResult struct {
+	Tokens    *auth.TokenPair
+	Challenge string
+}
+
+// Login はユーザ名とパスワードを検証し、アクセストークンとリフレッシュトークンを発行する
+// ipはログイン試行の制限に使用するクライアントのIPアドレス
+// パスワードの再設定が必要なユーザの場合、newPasswordに指定したパスワードへ更新した上でトークンを発行する
+// 二要素認証が有効なユーザの場合、LoginTwoFactorでコードとともに使用するチャレンジを発行する
+// パスワードのみでは再設定させないため、二要素認証が有効なユーザのnewPasswordは無視し、LoginTwoFactorで受け付ける
+// 管理者により無効化されたユーザの場合はauth.ErrUserDisabledを返却する
+func (l Login) Login(ctx context.Context, name, password, newPassword, ip string) (*LoginResult, error) {
 	u, err := l.Repo.GetUser(ctx, l.DB, name)
-	if err != nil {
-		return "", fmt.Errorf("failed to get a user: %w", err)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get a user: %w", err)
+	}
+	// 表記の異なるユーザ名で制限を回避させないため、ユーザが存在する場合は登録済のユーザ名で試行を計上する
+	if u != nil {
+		name = u.Name
+	}
+	if l.Throttle != nil {
+		if err := l.Throttle.Check(ctx, name, ip); err != nil {
+			return nil, err
+		}
+	}
+	if u == nil {
+		_ = (&entity.User{Password: dummyPasswordHash}).ComparePassword(password)
+		return nil, l.fail(ctx, name, ip)
 	}
 	if err := u.ComparePassword(password); err != nil {
-		return "", fmt.Errorf("wrong password: %w", err)
+		return nil, l.fail(ctx, name, ip)
+	}
+	// 無効化の有無はパスワードの検証後に判定し、第三者にユーザの状態を推測させない
+	if u.Disabled() {
+		return nil, auth.ErrUserDisabled
+	}
+	if u.TOTPEnabled {
+		challenge, hash, err := newLoginChallenge()
+		if err != nil {
+			return nil, err
+		}
+		if err := l.Challenges.SaveLoginChallenge(ctx, hash, u.ID, l.ChallengeTTL); err != nil {
+			return nil, fmt.Errorf("failed to save login challenge: %w", err)
+		}
+		return &LoginResult{Challenge: challenge}, nil
+	}
+	if u.PasswordResetRequired {
+		if err := l.resetPassword(ctx, u, password, newPassword); err != nil {
+			return nil, err
+		}
+	}
+	// 二要素認証が有効なユーザの失敗回数は、LoginTwoFactorで第二要素の検証に成功した時点で消去する
+	if l.Throttle != nil {
+		if err := l.Throttle.Succeed(ctx, name); err != nil {
+			return nil, err
+		}
 	}
 
-	jwt, err := l.TokenGenerator.GenerateToken(ctx, *u)
+	refresh, err := l.TokenGenerator.GenerateRefreshToken(ctx, *u)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate JWT: %w", err)
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	// アクセストークンのセッションをリフレッシュトークンに関連付け、セッション単位で失効できるようにする
+	jwt, err := l.TokenGenerator.GenerateToken(auth.SetRefreshToken(ctx, refresh), *u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT: %w", err)
+	}
+	return &LoginResult{Tokens: &auth.TokenPair{AccessToken: string(jwt), RefreshToken: refresh}}, nil
+}
+
+// fail はログイン失敗を計上し、ErrInvalidCredentialsを返却する
+func (l Login) fail(ctx context.Context, name, ip string) error {
+	if l.Throttle != nil {
+		if err := l.Throttle.Fail(ctx, name, ip); err != nil {
+			return err
+		}
+	}
+	return ErrInvalidCredentials
+}
+
+// resetPassword は再設定が必要なユーザのパスワードを更新する
+func (l Login) resetPassword(ctx context.Context, u *entity.User, current, password string) error {
+	if password == "" {
+		return ErrPasswordResetRequired
+	}
+	if err := validateNewPassword(l.Policy, u.Name, current, password); err != nil {
+		return err
+	}
+	pw, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	u.Password = pw
+	u.PasswordResetRequired = false
+	if err := l.Repo.UpdatePassword(ctx, l.DB, u); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
 	}
-	return string(jwt), nil
+	return nil
 }
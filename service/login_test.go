@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newAttemptStore はRedisの代わりにマップでログイン試行を管理するモックを生成する
+func newAttemptStore() (*LoginAttemptStoreMock, map[string]time.Duration) {
+	failures := map[string]int64{}
+	locks := map[string]time.Duration{}
+	moq := &LoginAttemptStoreMock{}
+	moq.IncrLoginFailuresFunc = func(ctx context.Context, key string, window time.Duration) (int64, error) {
+		failures[key]++
+		return failures[key], nil
+	}
+	moq.ResetLoginFailuresFunc = func(ctx context.Context, key string) error {
+		delete(failures, key)
+		return nil
+	}
+	moq.LockLoginFunc = func(ctx context.Context, key string, d time.Duration) error {
+		if d > locks[key] {
+			locks[key] = d
+		}
+		return nil
+	}
+	moq.LoginLockTTLFunc = func(ctx context.Context, key string) (time.Duration, error) {
+		return locks[key], nil
+	}
+	return moq, locks
+}
+
+func TestLoginThrottle_Fail(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	moq, locks := newAttemptStore()
+	sut := &LoginThrottle{
+		Store:           moq,
+		Window:          15 * time.Minute,
+		BackoffAfter:    3,
+		BackoffBase:     time.Second,
+		BackoffMax:      5 * time.Second,
+		UserLockout:     6,
+		IPLockout:       8,
+		LockoutDuration: 15 * time.Minute,
+	}
+
+	// ユーザ名ごとの失敗回数に応じた停止期間
+	want := []time.Duration{0, 0, time.Second, 2 * time.Second, 4 * time.Second, 15 * time.Minute}
+	for i, w := range want {
+		delete(locks, "user:john")
+		if err := sut.Fail(ctx, "john", "192.0.2.1"); err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+		if got := locks["user:john"]; got != w {
+			t.Errorf("failure %d: want lock %s, but got %s", i+1, w, got)
+		}
+	}
+	noLockout := *sut
+	noLockout.UserLockout = 0
+	if d := noLockout.userLockDuration(10); d != 5*time.Second {
+		t.Errorf("want backoff capped at %s, but got %s", 5*time.Second, d)
+	}
+
+	// 異なるユーザ名への試行もIPアドレスごとに計上する
+	for _, name := range []string{"alice", "bob"} {
+		if err := sut.Fail(ctx, name, "192.0.2.1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := locks["ip:192.0.2.1"]; got != 15*time.Minute {
+		t.Errorf("want ip lock %s, but got %s", 15*time.Minute, got)
+	}
+	var blocked *LoginBlockedError
+	if err := sut.Check(ctx, "carol", "192.0.2.1"); !errors.As(err, &blocked) || blocked.RetryAfter != 15*time.Minute {
+		t.Errorf("want blocked error, but got %v", err)
+	}
+	if err := sut.Check(ctx, "carol", "192.0.2.2"); err != nil {
+		t.Errorf("want no error from other ip, but got %v", err)
+	}
+}
+
+// TestLogin_nameVariants はMySQLの照合順序により同一のユーザとなる表記の異なるユーザ名で、
+// ロックを回避できないことを確認する
+func TestLogin_nameVariants(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pw, err := bcrypt.GenerateFromPassword([]byte("correct"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &UserAuthenticatorMock{}
+	repo.GetUserFunc = func(ctx context.Context, db store.Queryer, name string) (*entity.User, error) {
+		// utf8mb4_0900_ai_ciと同様に大文字小文字を区別せずに検索する
+		if !strings.EqualFold(name, "john") {
+			return nil, store.ErrNotFound
+		}
+		return &entity.User{ID: 1, Name: "john", Password: string(pw), Role: entity.RoleUser}, nil
+	}
+	attempts, locks := newAttemptStore()
+	sut := Login{
+		Repo:     repo,
+		Throttle: &LoginThrottle{Store: attempts, UserLockout: 3, LockoutDuration: 15 * time.Minute},
+	}
+
+	for _, name := range []string{"john", "John", "JOHN"} {
+		if _, err := sut.Login(ctx, name, "wrong", "", "192.0.2.1"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("want %v, but got %v", ErrInvalidCredentials, err)
+		}
+	}
+	if got := locks["user:john"]; got != 15*time.Minute {
+		t.Errorf("want user lock %s, but got %s", 15*time.Minute, got)
+	}
+	var blocked *LoginBlockedError
+	if _, err := sut.Login(ctx, "jOhN", "correct", "", "192.0.2.1"); !errors.As(err, &blocked) {
+		t.Errorf("want *LoginBlockedError for another spelling, but got %v", err)
+	}
+	// 存在しないユーザ名も大文字小文字を区別せずに計上する
+	for _, name := range []string{"alice", "Alice"} {
+		if _, err := sut.Login(ctx, name, "wrong", "", "192.0.2.2"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("want %v, but got %v", ErrInvalidCredentials, err)
+		}
+	}
+	if n := len(attempts.IncrLoginFailuresCalls()); n != 10 {
+		t.Fatalf("want 10 counted failures, but got %d", n)
+	}
+	if calls := attempts.IncrLoginFailuresCalls(); calls[6].Key != "user:alice" || calls[8].Key != "user:alice" {
+		t.Errorf("want failures of user:alice counted, but got %q and %q", calls[6].Key, calls[8].Key)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	t.Parallel()
+
+	pw, err := bcrypt.GenerateFromPassword([]byte("correct"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		name        string
+		password    string
+		newPassword string
+		resetFlag   bool
+		totp        bool
+		disabled    bool
+		wantErr     error
+		wantUpdate  bool
+	}{
+		"ok":            {name: "john", password: "correct"},
+		"wrongPassword": {name: "john", password: "wrong", wantErr: ErrInvalidCredentials},
+		"unknownUser":   {name: "alice", password: "correct", wantErr: ErrInvalidCredentials},
+		"resetRequired": {name: "john", password: "correct", resetFlag: true, wantErr: ErrPasswordResetRequired},
+		"resetWeak":     {name: "john", password: "correct", newPassword: "password", resetFlag: true, wantErr: ErrWeakPassword},
+		"reset":         {name: "john", password: "correct", newPassword: "N3w-passphrase", resetFlag: true, wantUpdate: true},
+		"twoFactor":     {name: "john", password: "correct", totp: true},
+		"disabled":      {name: "john", password: "correct", disabled: true, wantErr: auth.ErrUserDisabled},
+		// 二要素認証が有効なユーザは、パスワードのみでは再設定させずにチャレンジを発行する
+		"twoFactorReset": {
+			name: "john", password: "correct", newPassword: "N3w-passphrase", resetFlag: true, totp: true,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := &UserAuthenticatorMock{}
+			repo.GetUserFunc = func(ctx context.Context, db store.Queryer, name string) (*entity.User, error) {
+				if name != "john" {
+					return nil, store.ErrNotFound
+				}
+				u := &entity.User{
+					ID: 1, Name: name, Password: string(pw), Role: entity.RoleUser, PasswordResetRequired: tt.resetFlag, TOTPEnabled: tt.totp,
+				}
+				if tt.disabled {
+					u.DisabledAt = &time.Time{}
+				}
+				return u, nil
+			}
+			repo.UpdatePasswordFunc = func(ctx context.Context, db store.Execer, u *entity.User) error {
+				if u.PasswordResetRequired || u.ComparePassword(tt.newPassword) != nil {
+					t.Errorf("want new password stored and reset flag cleared, but got %+v", u)
+				}
+				return nil
+			}
+			tg := &TokenGeneratorMock{}
+			tg.GenerateTokenFunc = func(ctx context.Context, u entity.User) ([]byte, error) {
+				return []byte("access"), nil
+			}
+			tg.GenerateRefreshTokenFunc = func(ctx context.Context, u entity.User) (string, error) {
+				return "refresh", nil
+			}
+			challenges := &LoginChallengeStoreMock{}
+			challenges.SaveLoginChallengeFunc = func(ctx context.Context, hash string, userID entity.UserID, ttl time.Duration) error {
+				return nil
+			}
+			attempts, _ := newAttemptStore()
+			sut := Login{
+				Repo: repo, TokenGenerator: tg, Throttle: &LoginThrottle{Store: attempts},
+				Challenges: challenges, ChallengeTTL: 5 * time.Minute,
+			}
+
+			got, err := sut.Login(ctx, tt.name, tt.password, tt.newPassword, "192.0.2.1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want %v, but got %v", tt.wantErr, err)
+			}
+			if n := len(repo.UpdatePasswordCalls()); (n == 1) != tt.wantUpdate {
+				t.Errorf("want password updated %v, but got %d calls", tt.wantUpdate, n)
+			}
+			if errors.Is(tt.wantErr, ErrInvalidCredentials) {
+				if n := len(attempts.IncrLoginFailuresCalls()); n != 2 {
+					t.Errorf("want failures counted per user and ip, but got %d calls", n)
+				}
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if tt.totp {
+				// 二要素認証が有効な場合はトークンを発行せず、ハッシュ値のみを保存したチャレンジを返却する
+				calls := challenges.SaveLoginChallengeCalls()
+				if got.Tokens != nil || got.Challenge == "" || len(tg.GenerateTokenCalls()) != 0 {
+					t.Errorf("want only challenge, but got %+v", got)
+				}
+				if len(calls) != 1 || calls[0].Hash != hashLoginChallenge(got.Challenge) || calls[0].UserID != 1 {
+					t.Errorf("want challenge hash saved, but got %+v", calls)
+				}
+			} else if got.Tokens == nil || got.Tokens.AccessToken != "access" || got.Tokens.RefreshToken != "refresh" {
+				t.Errorf("unexpected tokens: %+v", got)
+			}
+			// 二要素認証が有効な場合は第二要素の検証まで失敗回数を消去しない
+			want := 1
+			if tt.totp {
+				want = 0
+			}
+			if n := len(attempts.ResetLoginFailuresCalls()); n != want {
+				t.Errorf("want failures reset %d times, but got %d", want, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LoginThrottle はユーザ名およびIPアドレスごとのログイン失敗回数に応じて、ログインを一時停止する
+//
+// ユーザ名ごとの失敗回数がBackoffAfterに達した以降は、失敗の都度BackoffBaseを起点に倍増する期間
+// (上限BackoffMax)ログインを停止し、UserLockoutに達した場合はLockoutDurationの間アカウントをロックする
+// IPアドレスごとの失敗回数がIPLockoutに達した場合も、LockoutDurationの間当該IPアドレスからのログインを停止する
+type LoginThrottle struct {
+	Store           LoginAttemptStore
+	Window          time.Duration // 失敗回数を計上する期間
+	BackoffAfter    int64
+	BackoffBase     time.Duration
+	BackoffMax      time.Duration
+	UserLockout     int64
+	IPLockout       int64
+	LockoutDuration time.Duration
+}
+
+// LoginBlockedError はログインが一時停止中であることを表す
+type LoginBlockedError struct {
+	RetryAfter time.Duration // 停止が解除されるまでの残り期間
+}
+
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("too many login attempts, retry after %s", e.RetryAfter)
+}
+
+// userAttemptKey はユーザ名ごとの失敗回数のキーを返却する
+// users.nameの照合順序は大文字小文字を区別せず同一のユーザへログインできるため、小文字に正規化する
+func userAttemptKey(name string) string {
+	return "user:" + strings.ToLower(name)
+}
+
+func ipAttemptKey(ip string) string {
+	return "ip:" + ip
+}
+
+// Check はユーザ名またはIPアドレスのログインが停止中の場合、*LoginBlockedError型のエラーを返却する
+func (t *LoginThrottle) Check(ctx context.Context, name, ip string) error {
+	var retryAfter time.Duration
+	for _, key := range []string{userAttemptKey(name), ipAttemptKey(ip)} {
+		ttl, err := t.Store.LoginLockTTL(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to check login lock: %w", err)
+		}
+		if ttl > retryAfter {
+			retryAfter = ttl
+		}
+	}
+	if retryAfter > 0 {
+		return &LoginBlockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// Fail はログイン失敗を計上し、失敗回数に応じてログインを停止する
+func (t *LoginThrottle) Fail(ctx context.Context, name, ip string) error {
+	n, err := t.Store.IncrLoginFailures(ctx, userAttemptKey(name), t.Window)
+	if err != nil {
+		return fmt.Errorf("failed to count login failure: %w", err)
+	}
+	if d := t.userLockDuration(n); d > 0 {
+		if err := t.Store.LockLogin(ctx, userAttemptKey(name), d); err != nil {
+			return fmt.Errorf("failed to lock login: %w", err)
+		}
+	}
+
+	n, err = t.Store.IncrLoginFailures(ctx, ipAttemptKey(ip), t.Window)
+	if err != nil {
+		return fmt.Errorf("failed to count login failure: %w", err)
+	}
+	if t.IPLockout > 0 && n >= t.IPLockout {
+		if err := t.Store.LockLogin(ctx, ipAttemptKey(ip), t.LockoutDuration); err != nil {
+			return fmt.Errorf("failed to lock login: %w", err)
+		}
+	}
+	return nil
+}
+
+// Succeed はログイン成功時にユーザ名ごとの失敗回数を消去する
+// IPアドレスごとの失敗回数は、有効なアカウントでのログインによる消去を防ぐため維持する
+func (t *LoginThrottle) Succeed(ctx context.Context, name string) error {
+	if err := t.Store.ResetLoginFailures(ctx, userAttemptKey(name)); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+	return nil
+}
+
+// userLockDuration はユーザ名ごとの失敗回数nに応じたログインの停止期間を返却する
+func (t *LoginThrottle) userLockDuration(n int64) time.Duration {
+	if t.UserLockout > 0 && n >= t.UserLockout {
+		return t.LockoutDuration
+	}
+	if t.BackoffAfter <= 0 || n < t.BackoffAfter {
+		return 0
+	}
+	d := t.BackoffBase
+	for i := t.BackoffAfter; i < n && d < t.BackoffMax; i++ {
+		d *= 2
+	}
+	if d > t.BackoffMax {
+		d = t.BackoffMax
+	}
+	return d
+}
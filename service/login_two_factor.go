@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// ErrInvalidChallenge は二要素認証のチャレンジが存在しない、期限切れ、または使用済であることを表す
+var ErrInvalidChallenge = errors.New("invalid or expired login challenge")
+
+type LoginTwoFactor struct {
+	DB             store.ExecQueryer
+	Repo           RecoveryCodeUser
+	Challenges     LoginChallengeStore
+	TokenGenerator TokenGenerator
+	Clocker        clock.Clocker
+	Policy         PasswordPolicy
+	// MaxFailures はチャレンジごとに許容するコードの誤り回数(超過した場合はチャレンジを無効とする)
+	MaxFailures int64
+	// Throttle はチャレンジをまたいだユーザ名およびIPアドレスごとの試行の制限に使用し、nilの場合は制限しない
+	Throttle *LoginThrottle
+}
+
+// LoginTwoFactor はLoginで発行したチャレンジと、TOTPのコードまたはリカバリーコードを検証し、
+// アクセストークンとリフレッシュトークンを発行する
+// ipはログイン試行の制限に使用するクライアントのIPアドレスで、コードの誤りはパスワードの誤りと同様に計上する
+// パスワードの再設定が必要なユーザの場合、第二要素の検証に成功した後にnewPasswordに指定したパスワードへ更新する
+// handler/service.goの実装
+func (lt *LoginTwoFactor) LoginTwoFactor(ctx context.Context, challenge, code, newPassword, ip string) (*auth.TokenPair, error) {
+	hash := hashLoginChallenge(challenge)
+	uid, err := lt.Challenges.LoadLoginChallenge(ctx, hash)
+	if err != nil {
+		return nil, lt.challengeError(err)
+	}
+	u, err := lt.Repo.GetUserByID(ctx, lt.DB, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	// チャレンジの発行後に無効化された場合
+	if u.Disabled() {
+		return nil, auth.ErrUserDisabled
+	}
+	// チャレンジの発行後に二要素認証が無効化された場合
+	if !u.TOTPEnabled || u.TOTPSecret == nil {
+		return nil, ErrInvalidChallenge
+	}
+
+	if lt.Throttle != nil {
+		if err := lt.Throttle.Check(ctx, u.Name, ip); err != nil {
+			return nil, err
+		}
+	}
+
+	// 新しいパスワードの誤りでチャレンジを消費させないため、第二要素の検証の前に判定する
+	if u.PasswordResetRequired {
+		if err := validateResetPassword(lt.Policy, u, newPassword); err != nil {
+			return nil, err
+		}
+	}
+
+	// 並行して検証された場合も上限を超えて試行させないため、試行回数は検証の前に計上する
+	n, err := lt.Challenges.IncrLoginChallengeFailures(ctx, hash)
+	if err != nil {
+		return nil, lt.challengeError(err)
+	}
+	if n > lt.MaxFailures {
+		if err := lt.consume(ctx, hash); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidChallenge
+	}
+	ok, err := lt.verify(ctx, u, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if n >= lt.MaxFailures {
+			// 総当たりを防ぐため、誤りが上限に達したチャレンジはパスワードからやり直させる
+			if err := lt.consume(ctx, hash); err != nil {
+				return nil, err
+			}
+		}
+		if lt.Throttle != nil {
+			if err := lt.Throttle.Fail(ctx, u.Name, ip); err != nil {
+				return nil, err
+			}
+		}
+		return nil, ErrInvalidTwoFactorCode
+	}
+	// 並行して同一のチャレンジが使用された場合に備え、消費はアトミックに実施する
+	if err := lt.Challenges.ConsumeLoginChallenge(ctx, hash); err != nil {
+		return nil, lt.challengeError(err)
+	}
+	if lt.Throttle != nil {
+		if err := lt.Throttle.Succeed(ctx, u.Name); err != nil {
+			return nil, err
+		}
+	}
+	if u.PasswordResetRequired {
+		if err := lt.resetPassword(ctx, u, newPassword); err != nil {
+			return nil, err
+		}
+	}
+
+	refresh, err := lt.TokenGenerator.GenerateRefreshToken(ctx, *u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	// アクセストークンのセッションをリフレッシュトークンに関連付け、セッション単位で失効できるようにする
+	jwt, err := lt.TokenGenerator.GenerateToken(auth.SetRefreshToken(ctx, refresh), *u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT: %w", err)
+	}
+	return &auth.TokenPair{AccessToken: string(jwt), RefreshToken: refresh}, nil
+}
+
+// verify はTOTPのコード、またはリカバリーコードを検証する
+// TOTPのコードは同一の時間ステップを再使用できず、リカバリーコードは検証に成功した時点で使用済とする
+func (lt *LoginTwoFactor) verify(ctx context.Context, u *entity.User, code string) (bool, error) {
+	if len(code) == auth.TOTPDigits {
+		step, ok := auth.VerifyTOTP(*u.TOTPSecret, code, lt.Clocker.Now())
+		if !ok {
+			return false, nil
+		}
+		// 検証で許容する時間ステップの範囲を経過するまで使用済として保持する
+		ttl := time.Duration(2*auth.TOTPSkew+1) * auth.TOTPPeriod
+		fresh, err := lt.Challenges.UseTOTPStep(ctx, u.ID, step, ttl)
+		if err != nil {
+			return false, fmt.Errorf("failed to record totp usage: %w", err)
+		}
+		return fresh, nil
+	}
+	if err := lt.Repo.UseRecoveryCode(ctx, lt.DB, u.ID, hashRecoveryCode(code)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	return true, nil
+}
+
+// resetPassword は第二要素の検証後に、再設定が必要なユーザのパスワードを更新する
+func (lt *LoginTwoFactor) resetPassword(ctx context.Context, u *entity.User, password string) error {
+	pw, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	u.Password = pw
+	u.PasswordResetRequired = false
+	if err := lt.Repo.UpdatePassword(ctx, lt.DB, u); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	return nil
+}
+
+// validateResetPassword は再設定が必要なユーザが指定した新しいパスワードを検証する
+// 現在のパスワードの平文は受け取らないため、現在のパスワードとの一致はハッシュ値との比較で判定する
+func validateResetPassword(p PasswordPolicy, u *entity.User, password string) error {
+	if password == "" {
+		return ErrPasswordResetRequired
+	}
+	if err := u.ComparePassword(password); err == nil {
+		return fmt.Errorf("%w: must differ from current password", ErrWeakPassword)
+	}
+	return p.Validate(u.Name, password)
+}
+
+// consume は無効としたチャレンジを消費する
+// 並行して消費された場合も無効となったことに変わりはないため、存在しない場合はエラーとしない
+func (lt *LoginTwoFactor) consume(ctx context.Context, hash string) error {
+	if err := lt.Challenges.ConsumeLoginChallenge(ctx, hash); err != nil && !errors.Is(err, store.ErrNotFound) {
+		return fmt.Errorf("failed to consume login challenge: %w", err)
+	}
+	return nil
+}
+
+// challengeError はチャレンジが見つからない場合にErrInvalidChallengeへ変換する
+func (lt *LoginTwoFactor) challengeError(err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrInvalidChallenge
+	}
+	return fmt.Errorf("failed to load login challenge: %w", err)
+}
+
+// newLoginChallenge は二要素認証のチャレンジと、保存用のハッシュ値を生成する
+func newLoginChallenge() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate login challenge: %w", err)
+	}
+	challenge := base64.RawURLEncoding.EncodeToString(b)
+	return challenge, hashLoginChallenge(challenge), nil
+}
+
+// hashLoginChallenge は二要素認証のチャレンジのSHA-256ハッシュ値を16進数文字列で返却する
+func hashLoginChallenge(challenge string) string {
+	sum := sha256.Sum256([]byte(challenge))
+	return hex.EncodeToString(sum[:])
+}
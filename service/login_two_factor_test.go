@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// offsetClocker はclock.FixedClockerの時刻から期間dだけずらした時刻を返却するclock.Clockerの実装
+type offsetClocker time.Duration
+
+func (c offsetClocker) Now() time.Time {
+	return clock.FixedClocker{}.Now().Add(time.Duration(c))
+}
+
+// newChallengeStore はRedisの代わりにマップでチャレンジを管理するモックを生成する
+func newChallengeStore(hash string, uid entity.UserID) *LoginChallengeStoreMock {
+	challenges := map[string]entity.UserID{hash: uid}
+	failures := map[string]int64{}
+	used := map[int64]bool{}
+	moq := &LoginChallengeStoreMock{}
+	moq.LoadLoginChallengeFunc = func(ctx context.Context, hash string) (entity.UserID, error) {
+		id, ok := challenges[hash]
+		if !ok {
+			return 0, store.ErrNotFound
+		}
+		return id, nil
+	}
+	moq.IncrLoginChallengeFailuresFunc = func(ctx context.Context, hash string) (int64, error) {
+		failures[hash]++
+		return failures[hash], nil
+	}
+	moq.ConsumeLoginChallengeFunc = func(ctx context.Context, hash string) error {
+		if _, ok := challenges[hash]; !ok {
+			return store.ErrNotFound
+		}
+		delete(challenges, hash)
+		return nil
+	}
+	moq.UseTOTPStepFunc = func(ctx context.Context, userID entity.UserID, step int64, ttl time.Duration) (bool, error) {
+		fresh := !used[step]
+		used[step] = true
+		return fresh, nil
+	}
+	return moq
+}
+
+func TestLoginTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(clock.FixedClocker{}.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovery := "ABCD-EFGH-IJKL-MNOP"
+	tests := map[string]struct {
+		clocker   clock.Clocker
+		challenge string
+		code      string
+		wantErr   error
+	}{
+		"ok":               {clocker: clock.FixedClocker{}, challenge: "valid", code: code},
+		"clockSkew":        {clocker: offsetClocker(30 * time.Second), challenge: "valid", code: code},
+		"expiredCode":      {clocker: offsetClocker(90 * time.Second), challenge: "valid", code: code, wantErr: ErrInvalidTwoFactorCode},
+		"recoveryCode":     {clocker: clock.FixedClocker{}, challenge: "valid", code: "abcd efgh ijkl mnop"},
+		"wrongRecovery":    {clocker: clock.FixedClocker{}, challenge: "valid", code: "ZZZZ-ZZZZ-ZZZZ-ZZZZ", wantErr: ErrInvalidTwoFactorCode},
+		"unknownChallenge": {clocker: clock.FixedClocker{}, challenge: "unknown", code: code, wantErr: ErrInvalidChallenge},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := &RecoveryCodeUserMock{}
+			repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+				return &entity.User{ID: id, Name: "john", TOTPSecret: &secret, TOTPEnabled: true}, nil
+			}
+			repo.UseRecoveryCodeFunc = func(ctx context.Context, db store.Execer, id entity.UserID, hash string) error {
+				if hash != hashRecoveryCode(recovery) {
+					return store.ErrNotFound
+				}
+				return nil
+			}
+			challenges := newChallengeStore(hashLoginChallenge("valid"), 1)
+			tg := &TokenGeneratorMock{}
+			tg.GenerateTokenFunc = func(ctx context.Context, u entity.User) ([]byte, error) {
+				return []byte("access"), nil
+			}
+			tg.GenerateRefreshTokenFunc = func(ctx context.Context, u entity.User) (string, error) {
+				return "refresh", nil
+			}
+
+			sut := &LoginTwoFactor{
+				Repo: repo, Challenges: challenges, TokenGenerator: tg, Clocker: tt.clocker, MaxFailures: 5,
+			}
+			got, err := sut.LoginTwoFactor(ctx, tt.challenge, tt.code, "", "192.0.2.1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want %v, but got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.AccessToken != "access" || got.RefreshToken != "refresh" {
+				t.Errorf("unexpected tokens: %+v", got)
+			}
+			// チャレンジは1回のみ使用できる
+			if _, err := sut.LoginTwoFactor(ctx, tt.challenge, tt.code, "", "192.0.2.1"); !errors.Is(err, ErrInvalidChallenge) {
+				t.Errorf("want %v for reused challenge, but got %v", ErrInvalidChallenge, err)
+			}
+		})
+	}
+}
+
+func TestLoginTwoFactor_replayAndLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(clock.FixedClocker{}.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &RecoveryCodeUserMock{}
+	repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+		return &entity.User{ID: id, Name: "john", TOTPSecret: &secret, TOTPEnabled: true}, nil
+	}
+	repo.UseRecoveryCodeFunc = func(ctx context.Context, db store.Execer, id entity.UserID, hash string) error {
+		return store.ErrNotFound
+	}
+	challenges := newChallengeStore(hashLoginChallenge("first"), 1)
+	tg := &TokenGeneratorMock{}
+	tg.GenerateTokenFunc = func(ctx context.Context, u entity.User) ([]byte, error) {
+		return []byte("access"), nil
+	}
+	tg.GenerateRefreshTokenFunc = func(ctx context.Context, u entity.User) (string, error) {
+		return "refresh", nil
+	}
+	sut := &LoginTwoFactor{
+		Repo: repo, Challenges: challenges, TokenGenerator: tg, Clocker: clock.FixedClocker{}, MaxFailures: 3,
+	}
+	if _, err := sut.LoginTwoFactor(ctx, "first", code, "", "192.0.2.1"); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+
+	// 同一の時間ステップのコードは別のチャレンジでも再使用できない
+	used := challenges.UseTOTPStepFunc
+	challenges = newChallengeStore(hashLoginChallenge("second"), 1)
+	challenges.UseTOTPStepFunc = used
+	sut.Challenges = challenges
+	if _, err := sut.LoginTwoFactor(ctx, "second", code, "", "192.0.2.1"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("want %v for replayed code, but got %v", ErrInvalidTwoFactorCode, err)
+	}
+
+	// 誤りが上限に達したチャレンジは無効となる
+	for i := 0; i < 2; i++ {
+		if _, err := sut.LoginTwoFactor(ctx, "second", "000000", "", "192.0.2.1"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+			t.Fatalf("want %v, but got %v", ErrInvalidTwoFactorCode, err)
+		}
+	}
+	if _, err := sut.LoginTwoFactor(ctx, "second", code, "", "192.0.2.1"); !errors.Is(err, ErrInvalidChallenge) {
+		t.Errorf("want %v after too many failures, but got %v", ErrInvalidChallenge, err)
+	}
+}
+
+func TestLoginTwoFactor_throttle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(clock.FixedClocker{}.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &RecoveryCodeUserMock{}
+	repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+		return &entity.User{ID: id, Name: "john", TOTPSecret: &secret, TOTPEnabled: true}, nil
+	}
+	repo.UseRecoveryCodeFunc = func(ctx context.Context, db store.Execer, id entity.UserID, hash string) error {
+		return store.ErrNotFound
+	}
+	tg := &TokenGeneratorMock{}
+	tg.GenerateTokenFunc = func(ctx context.Context, u entity.User) ([]byte, error) {
+		return []byte("access"), nil
+	}
+	tg.GenerateRefreshTokenFunc = func(ctx context.Context, u entity.User) (string, error) {
+		return "refresh", nil
+	}
+	attempts, _ := newAttemptStore()
+	sut := &LoginTwoFactor{
+		Repo: repo, TokenGenerator: tg, Clocker: clock.FixedClocker{}, MaxFailures: 5,
+		Throttle: &LoginThrottle{Store: attempts, UserLockout: 3, LockoutDuration: 15 * time.Minute},
+	}
+
+	// チャレンジを発行し直しても、ユーザ名ごとの失敗回数は引き継ぐ
+	for i := 0; i < 3; i++ {
+		challenge := fmt.Sprintf("challenge-%d", i)
+		sut.Challenges = newChallengeStore(hashLoginChallenge(challenge), 1)
+		if _, err := sut.LoginTwoFactor(ctx, challenge, "000000", "", "192.0.2.1"); !errors.Is(err, ErrInvalidTwoFactorCode) {
+			t.Fatalf("want %v, but got %v", ErrInvalidTwoFactorCode, err)
+		}
+	}
+	if n := len(attempts.ResetLoginFailuresCalls()); n != 0 {
+		t.Errorf("want failures not reset, but got %d calls", n)
+	}
+	sut.Challenges = newChallengeStore(hashLoginChallenge("last"), 1)
+	var blocked *LoginBlockedError
+	if _, err := sut.LoginTwoFactor(ctx, "last", code, "", "192.0.2.1"); !errors.As(err, &blocked) {
+		t.Fatalf("want *LoginBlockedError after too many failures, but got %v", err)
+	}
+}
+
+func TestLoginTwoFactor_succeed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(clock.FixedClocker{}.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &RecoveryCodeUserMock{}
+	repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+		return &entity.User{ID: id, Name: "john", TOTPSecret: &secret, TOTPEnabled: true}, nil
+	}
+	tg := &TokenGeneratorMock{}
+	tg.GenerateTokenFunc = func(ctx context.Context, u entity.User) ([]byte, error) {
+		return []byte("access"), nil
+	}
+	tg.GenerateRefreshTokenFunc = func(ctx context.Context, u entity.User) (string, error) {
+		return "refresh", nil
+	}
+	attempts, _ := newAttemptStore()
+	sut := &LoginTwoFactor{
+		Repo: repo, Challenges: newChallengeStore(hashLoginChallenge("valid"), 1), TokenGenerator: tg,
+		Clocker: clock.FixedClocker{}, MaxFailures: 5, Throttle: &LoginThrottle{Store: attempts},
+	}
+
+	// 第二要素の検証に成功した時点でユーザ名ごとの失敗回数を消去する
+	if _, err := sut.LoginTwoFactor(ctx, "valid", code, "", "192.0.2.1"); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	calls := attempts.ResetLoginFailuresCalls()
+	if len(calls) != 1 || calls[0].Key != "user:john" {
+		t.Errorf("want failures of user:john reset, but got %+v", calls)
+	}
+}
+
+func TestLoginTwoFactor_passwordReset(t *testing.T) {
+	t.Parallel()
+
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(clock.FixedClocker{}.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pw, err := hashPassword("Current-pass1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		code        string
+		newPassword string
+		wantErr     error
+		wantUpdate  bool
+	}{
+		"ok":             {code: code, newPassword: "N3w-passphrase", wantUpdate: true},
+		"noNewPassword":  {code: code, wantErr: ErrPasswordResetRequired},
+		"weak":           {code: code, newPassword: "short", wantErr: ErrWeakPassword},
+		"sameAsCurrent":  {code: code, newPassword: "Current-pass1", wantErr: ErrWeakPassword},
+		"wrongTwoFactor": {code: "000000", newPassword: "N3w-passphrase", wantErr: ErrInvalidTwoFactorCode},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := &RecoveryCodeUserMock{}
+			repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+				return &entity.User{
+					ID: id, Name: "john", Password: pw, PasswordResetRequired: true, TOTPSecret: &secret, TOTPEnabled: true,
+				}, nil
+			}
+			repo.UpdatePasswordFunc = func(ctx context.Context, db store.Execer, u *entity.User) error {
+				if u.PasswordResetRequired || u.ComparePassword(tt.newPassword) != nil {
+					t.Errorf("want new password stored and reset flag cleared, but got %+v", u)
+				}
+				return nil
+			}
+			challenges := newChallengeStore(hashLoginChallenge("valid"), 1)
+			tg := &TokenGeneratorMock{}
+			tg.GenerateTokenFunc = func(ctx context.Context, u entity.User) ([]byte, error) {
+				return []byte("access"), nil
+			}
+			tg.GenerateRefreshTokenFunc = func(ctx context.Context, u entity.User) (string, error) {
+				return "refresh", nil
+			}
+
+			sut := &LoginTwoFactor{
+				Repo: repo, Challenges: challenges, TokenGenerator: tg, Clocker: clock.FixedClocker{},
+				Policy: PasswordPolicy{MinLength: 10, MinClasses: 3}, MaxFailures: 5,
+			}
+			_, err := sut.LoginTwoFactor(ctx, "valid", tt.code, tt.newPassword, "192.0.2.1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want %v, but got %v", tt.wantErr, err)
+			}
+			// 第二要素の検証に成功するまでパスワードを更新しない
+			if n := len(repo.UpdatePasswordCalls()); (n == 1) != tt.wantUpdate {
+				t.Errorf("want password updated %v, but got %d calls", tt.wantUpdate, n)
+			}
+			// 新しいパスワードの誤りはコードの試行として計上せず、チャレンジも消費しない
+			if errors.Is(tt.wantErr, ErrPasswordResetRequired) || errors.Is(tt.wantErr, ErrWeakPassword) {
+				if n := len(challenges.IncrLoginChallengeFailuresCalls()) + len(challenges.ConsumeLoginChallengeCalls()); n != 0 {
+					t.Errorf("want challenge untouched, but got %d calls", n)
+				}
+			}
+		})
+	}
+}
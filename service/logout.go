@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type Logout struct {
+	Revoker  TokenRevoker
+	Sessions SessionRevoker
+}
+
+// Logout はリクエストに使用したトークンのセッションを失効させる
+// セッションに関連するリフレッシュトークンも併せて失効させ、ログアウト後のトークンの再発行を防ぐ
+// allがtrueの場合は、ログインユーザに発行済のすべてのトークンを失効させる
+// handler/service.goの実装
+func (l *Logout) Logout(ctx context.Context, all bool) error {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	if all {
+		if err := l.Revoker.RevokeAllTokens(ctx, uid); err != nil {
+			return fmt.Errorf("failed to logout all sessions: %w", err)
+		}
+		return nil
+	}
+	jti, ok := auth.GetTokenID(ctx)
+	if !ok {
+		return fmt.Errorf("jti not found")
+	}
+	// sidクレーム導入前のトークンは、FillContextによりJWT IDがセッションIDとして設定される
+	sid, ok := auth.GetSessionID(ctx)
+	if !ok {
+		return fmt.Errorf("session id not found")
+	}
+	err := l.Sessions.RevokeSession(ctx, uid, sid)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return fmt.Errorf("failed to logout: %w", err)
+	}
+	// セッション情報を記録していないトークンは、アクセストークンのみを失効させる
+	if err := l.Revoker.RevokeToken(ctx, jti); err != nil {
+		return fmt.Errorf("failed to logout: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+func TestLogout(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		all        bool
+		sessionErr error
+		want       []string // 失効させた対象
+		wantErr    bool
+	}{
+		"session":      {want: []string{"session:sid"}},
+		"noSession":    {sessionErr: fmt.Errorf("session %q: %w", "sid", store.ErrNotFound), want: []string{"session:sid", "token:jti"}},
+		"sessionError": {sessionErr: errors.New("error from mock"), want: []string{"session:sid"}, wantErr: true},
+		"all":          {all: true, want: []string{"all:10"}},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			revoker := &TokenRevokerMock{}
+			revoker.RevokeTokenFunc = func(ctx context.Context, jti string) error {
+				got = append(got, "token:"+jti)
+				return nil
+			}
+			revoker.RevokeAllTokensFunc = func(ctx context.Context, uid entity.UserID) error {
+				got = append(got, fmt.Sprintf("all:%d", uid))
+				return nil
+			}
+			sessions := &SessionRevokerMock{}
+			sessions.RevokeSessionFunc = func(ctx context.Context, uid entity.UserID, sid string) error {
+				if uid != 10 {
+					t.Errorf("want user 10, but got %d", uid)
+				}
+				got = append(got, "session:"+sid)
+				return tt.sessionErr
+			}
+
+			ctx := auth.SetTokenID(auth.SetUserID(context.Background(), 10), "jti")
+			ctx = auth.SetSessionID(ctx, "sid")
+			sut := &Logout{Revoker: revoker, Sessions: sessions}
+			err := sut.Logout(ctx, tt.all)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, but got %v", tt.wantErr, err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("want revoked %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -6,8 +6,10 @@ package service
 import (
 	"context"
 	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/oidc"
 	"github.com/ac0mz/go_todo_app/store"
 	"sync"
+	"time"
 )
 
 // Ensure, that TaskListerMock does implement TaskLister.
@@ -20,7 +22,7 @@ var _ TaskLister = &TaskListerMock{}
 //
 //		// make and configure a mocked TaskLister
 //		mockedTaskLister := &TaskListerMock{
-//			ListTasksFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (entity.Tasks, error) {
+//			ListTasksFunc: func(ctx context.Context, db store.Queryer, id entity.UserID, f *entity.TaskFilter) (entity.Tasks, string, error) {
 //				panic("mock out the ListTasks method")
 //			},
 //		}
@@ -31,7 +33,7 @@ var _ TaskLister = &TaskListerMock{}
 //	}
 type TaskListerMock struct {
 	// ListTasksFunc mocks the ListTasks method.
-	ListTasksFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (entity.Tasks, error)
+	ListTasksFunc func(ctx context.Context, db store.Queryer, id entity.UserID, f *entity.TaskFilter) (entity.Tasks, string, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -43,13 +45,15 @@ type TaskListerMock struct {
 			Db store.Queryer
 			// ID is the id argument value.
 			ID entity.UserID
+			// F is the f argument value.
+			F *entity.TaskFilter
 		}
 	}
 	lockListTasks sync.RWMutex
 }
 
 // ListTasks calls ListTasksFunc.
-func (mock *TaskListerMock) ListTasks(ctx context.Context, db store.Queryer, id entity.UserID) (entity.Tasks, error) {
+func (mock *TaskListerMock) ListTasks(ctx context.Context, db store.Queryer, id entity.UserID, f *entity.TaskFilter) (entity.Tasks, string, error) {
 	if mock.ListTasksFunc == nil {
 		panic("TaskListerMock.ListTasksFunc: method is nil but TaskLister.ListTasks was just called")
 	}
@@ -57,15 +61,17 @@ func (mock *TaskListerMock) ListTasks(ctx context.Context, db store.Queryer, id
 		Ctx context.Context
 		Db  store.Queryer
 		ID  entity.UserID
+		F   *entity.TaskFilter
 	}{
 		Ctx: ctx,
 		Db:  db,
 		ID:  id,
+		F:   f,
 	}
 	mock.lockListTasks.Lock()
 	mock.calls.ListTasks = append(mock.calls.ListTasks, callInfo)
 	mock.lockListTasks.Unlock()
-	return mock.ListTasksFunc(ctx, db, id)
+	return mock.ListTasksFunc(ctx, db, id, f)
 }
 
 // ListTasksCalls gets all the calls that were made to ListTasks.
@@ -76,11 +82,13 @@ func (mock *TaskListerMock) ListTasksCalls() []struct {
 	Ctx context.Context
 	Db  store.Queryer
 	ID  entity.UserID
+	F   *entity.TaskFilter
 } {
 	var calls []struct {
 		Ctx context.Context
 		Db  store.Queryer
 		ID  entity.UserID
+		F   *entity.TaskFilter
 	}
 	mock.lockListTasks.RLock()
 	calls = mock.calls.ListTasks
@@ -101,6 +109,12 @@ var _ TaskAdder = &TaskAdderMock{}
 //			AddTaskFunc: func(ctx context.Context, db store.Execer, t *entity.Task) error {
 //				panic("mock out the AddTask method")
 //			},
+//			GetProjectFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error) {
+//				panic("mock out the GetProject method")
+//			},
+//			ValidateTaskParentFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID, parentID entity.TaskID) error {
+//				panic("mock out the ValidateTaskParent method")
+//			},
 //		}
 //
 //		// use mockedTaskAdder in code that requires TaskAdder
@@ -111,6 +125,12 @@ type TaskAdderMock struct {
 	// AddTaskFunc mocks the AddTask method.
 	AddTaskFunc func(ctx context.Context, db store.Execer, t *entity.Task) error
 
+	// GetProjectFunc mocks the GetProject method.
+	GetProjectFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error)
+
+	// ValidateTaskParentFunc mocks the ValidateTaskParent method.
+	ValidateTaskParentFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID, parentID entity.TaskID) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// AddTask holds details about calls to the AddTask method.
@@ -122,8 +142,34 @@ type TaskAdderMock struct {
 			// T is the t argument value.
 			T *entity.Task
 		}
+		// GetProject holds details about calls to the GetProject method.
+		GetProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.ProjectID
+		}
+		// ValidateTaskParent holds details about calls to the ValidateTaskParent method.
+		ValidateTaskParent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
+			// ParentID is the parentID argument value.
+			ParentID entity.TaskID
+		}
 	}
-	lockAddTask sync.RWMutex
+	lockAddTask            sync.RWMutex
+	lockGetProject         sync.RWMutex
+	lockValidateTaskParent sync.RWMutex
 }
 
 // AddTask calls AddTaskFunc.
@@ -166,230 +212,7194 @@ func (mock *TaskAdderMock) AddTaskCalls() []struct {
 	return calls
 }
 
-// Ensure, that UserRegisterMock does implement UserRegister.
+// GetProject calls GetProjectFunc.
+func (mock *TaskAdderMock) GetProject(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error) {
+	if mock.GetProjectFunc == nil {
+		panic("TaskAdderMock.GetProjectFunc: method is nil but TaskAdder.GetProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.ProjectID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockGetProject.Lock()
+	mock.calls.GetProject = append(mock.calls.GetProject, callInfo)
+	mock.lockGetProject.Unlock()
+	return mock.GetProjectFunc(ctx, db, uid, id)
+}
+
+// GetProjectCalls gets all the calls that were made to GetProject.
+// Check the length with:
+//
+//	len(mockedTaskAdder.GetProjectCalls())
+func (mock *TaskAdderMock) GetProjectCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.ProjectID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.ProjectID
+	}
+	mock.lockGetProject.RLock()
+	calls = mock.calls.GetProject
+	mock.lockGetProject.RUnlock()
+	return calls
+}
+
+// ValidateTaskParent calls ValidateTaskParentFunc.
+func (mock *TaskAdderMock) ValidateTaskParent(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID, parentID entity.TaskID) error {
+	if mock.ValidateTaskParentFunc == nil {
+		panic("TaskAdderMock.ValidateTaskParentFunc: method is nil but TaskAdder.ValidateTaskParent was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Db       store.Queryer
+		UID      entity.UserID
+		ID       entity.TaskID
+		ParentID entity.TaskID
+	}{
+		Ctx:      ctx,
+		Db:       db,
+		UID:      uid,
+		ID:       id,
+		ParentID: parentID,
+	}
+	mock.lockValidateTaskParent.Lock()
+	mock.calls.ValidateTaskParent = append(mock.calls.ValidateTaskParent, callInfo)
+	mock.lockValidateTaskParent.Unlock()
+	return mock.ValidateTaskParentFunc(ctx, db, uid, id, parentID)
+}
+
+// ValidateTaskParentCalls gets all the calls that were made to ValidateTaskParent.
+// Check the length with:
+//
+//	len(mockedTaskAdder.ValidateTaskParentCalls())
+func (mock *TaskAdderMock) ValidateTaskParentCalls() []struct {
+	Ctx      context.Context
+	Db       store.Queryer
+	UID      entity.UserID
+	ID       entity.TaskID
+	ParentID entity.TaskID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Db       store.Queryer
+		UID      entity.UserID
+		ID       entity.TaskID
+		ParentID entity.TaskID
+	}
+	mock.lockValidateTaskParent.RLock()
+	calls = mock.calls.ValidateTaskParent
+	mock.lockValidateTaskParent.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskGetterMock does implement TaskGetter.
 // If this is not the case, regenerate this file with moq.
-var _ UserRegister = &UserRegisterMock{}
+var _ TaskGetter = &TaskGetterMock{}
 
-// UserRegisterMock is a mock implementation of UserRegister.
+// TaskGetterMock is a mock implementation of TaskGetter.
 //
-//	func TestSomethingThatUsesUserRegister(t *testing.T) {
+//	func TestSomethingThatUsesTaskGetter(t *testing.T) {
 //
-//		// make and configure a mocked UserRegister
-//		mockedUserRegister := &UserRegisterMock{
-//			RegisterUserFunc: func(ctx context.Context, db store.Execer, u *entity.User) error {
-//				panic("mock out the RegisterUser method")
+//		// make and configure a mocked TaskGetter
+//		mockedTaskGetter := &TaskGetterMock{
+//			GetTaskFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the GetTask method")
 //			},
 //		}
 //
-//		// use mockedUserRegister in code that requires UserRegister
+//		// use mockedTaskGetter in code that requires TaskGetter
 //		// and then make assertions.
 //
 //	}
-type UserRegisterMock struct {
-	// RegisterUserFunc mocks the RegisterUser method.
-	RegisterUserFunc func(ctx context.Context, db store.Execer, u *entity.User) error
+type TaskGetterMock struct {
+	// GetTaskFunc mocks the GetTask method.
+	GetTaskFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// RegisterUser holds details about calls to the RegisterUser method.
-		RegisterUser []struct {
+		// GetTask holds details about calls to the GetTask method.
+		GetTask []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Db is the db argument value.
-			Db store.Execer
-			// U is the u argument value.
-			U *entity.User
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
 		}
 	}
-	lockRegisterUser sync.RWMutex
+	lockGetTask sync.RWMutex
 }
 
-// RegisterUser calls RegisterUserFunc.
-func (mock *UserRegisterMock) RegisterUser(ctx context.Context, db store.Execer, u *entity.User) error {
-	if mock.RegisterUserFunc == nil {
-		panic("UserRegisterMock.RegisterUserFunc: method is nil but UserRegister.RegisterUser was just called")
+// GetTask calls GetTaskFunc.
+func (mock *TaskGetterMock) GetTask(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+	if mock.GetTaskFunc == nil {
+		panic("TaskGetterMock.GetTaskFunc: method is nil but TaskGetter.GetTask was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Db  store.Execer
-		U   *entity.User
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
 	}{
 		Ctx: ctx,
 		Db:  db,
-		U:   u,
+		UID: uid,
+		ID:  id,
 	}
-	mock.lockRegisterUser.Lock()
-	mock.calls.RegisterUser = append(mock.calls.RegisterUser, callInfo)
-	mock.lockRegisterUser.Unlock()
-	return mock.RegisterUserFunc(ctx, db, u)
+	mock.lockGetTask.Lock()
+	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
+	mock.lockGetTask.Unlock()
+	return mock.GetTaskFunc(ctx, db, uid, id)
 }
 
-// RegisterUserCalls gets all the calls that were made to RegisterUser.
+// GetTaskCalls gets all the calls that were made to GetTask.
 // Check the length with:
 //
-//	len(mockedUserRegister.RegisterUserCalls())
-func (mock *UserRegisterMock) RegisterUserCalls() []struct {
+//	len(mockedTaskGetter.GetTaskCalls())
+func (mock *TaskGetterMock) GetTaskCalls() []struct {
 	Ctx context.Context
-	Db  store.Execer
-	U   *entity.User
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.TaskID
 } {
 	var calls []struct {
 		Ctx context.Context
-		Db  store.Execer
-		U   *entity.User
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
 	}
-	mock.lockRegisterUser.RLock()
-	calls = mock.calls.RegisterUser
-	mock.lockRegisterUser.RUnlock()
+	mock.lockGetTask.RLock()
+	calls = mock.calls.GetTask
+	mock.lockGetTask.RUnlock()
 	return calls
 }
 
-// Ensure, that UserGetterMock does implement UserGetter.
+// Ensure, that TaskUpdaterMock does implement TaskUpdater.
 // If this is not the case, regenerate this file with moq.
-var _ UserGetter = &UserGetterMock{}
+var _ TaskUpdater = &TaskUpdaterMock{}
 
-// UserGetterMock is a mock implementation of UserGetter.
+// TaskUpdaterMock is a mock implementation of TaskUpdater.
 //
-//	func TestSomethingThatUsesUserGetter(t *testing.T) {
+//	func TestSomethingThatUsesTaskUpdater(t *testing.T) {
 //
-//		// make and configure a mocked UserGetter
-//		mockedUserGetter := &UserGetterMock{
-//			GetUserFunc: func(ctx context.Context, db store.Queryer, name string) (*entity.User, error) {
-//				panic("mock out the GetUser method")
+//		// make and configure a mocked TaskUpdater
+//		mockedTaskUpdater := &TaskUpdaterMock{
+//			GetTaskFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the GetTask method")
+//			},
+//			UpdateTaskFunc: func(ctx context.Context, db store.Execer, t *entity.Task) error {
+//				panic("mock out the UpdateTask method")
 //			},
 //		}
 //
-//		// use mockedUserGetter in code that requires UserGetter
+//		// use mockedTaskUpdater in code that requires TaskUpdater
 //		// and then make assertions.
 //
 //	}
-type UserGetterMock struct {
-	// GetUserFunc mocks the GetUser method.
-	GetUserFunc func(ctx context.Context, db store.Queryer, name string) (*entity.User, error)
+type TaskUpdaterMock struct {
+	// GetTaskFunc mocks the GetTask method.
+	GetTaskFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error)
+
+	// UpdateTaskFunc mocks the UpdateTask method.
+	UpdateTaskFunc func(ctx context.Context, db store.Execer, t *entity.Task) error
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// GetUser holds details about calls to the GetUser method.
-		GetUser []struct {
+		// GetTask holds details about calls to the GetTask method.
+		GetTask []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Db is the db argument value.
 			Db store.Queryer
-			// Name is the name argument value.
-			Name string
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+		// UpdateTask holds details about calls to the UpdateTask method.
+		UpdateTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// T is the t argument value.
+			T *entity.Task
 		}
 	}
-	lockGetUser sync.RWMutex
+	lockGetTask    sync.RWMutex
+	lockUpdateTask sync.RWMutex
 }
 
-// GetUser calls GetUserFunc.
-func (mock *UserGetterMock) GetUser(ctx context.Context, db store.Queryer, name string) (*entity.User, error) {
-	if mock.GetUserFunc == nil {
-		panic("UserGetterMock.GetUserFunc: method is nil but UserGetter.GetUser was just called")
+// GetTask calls GetTaskFunc.
+func (mock *TaskUpdaterMock) GetTask(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+	if mock.GetTaskFunc == nil {
+		panic("TaskUpdaterMock.GetTaskFunc: method is nil but TaskUpdater.GetTask was just called")
 	}
 	callInfo := struct {
-		Ctx  context.Context
-		Db   store.Queryer
-		Name string
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
 	}{
-		Ctx:  ctx,
-		Db:   db,
-		Name: name,
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
 	}
-	mock.lockGetUser.Lock()
-	mock.calls.GetUser = append(mock.calls.GetUser, callInfo)
-	mock.lockGetUser.Unlock()
-	return mock.GetUserFunc(ctx, db, name)
+	mock.lockGetTask.Lock()
+	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
+	mock.lockGetTask.Unlock()
+	return mock.GetTaskFunc(ctx, db, uid, id)
 }
 
-// GetUserCalls gets all the calls that were made to GetUser.
+// GetTaskCalls gets all the calls that were made to GetTask.
 // Check the length with:
 //
-//	len(mockedUserGetter.GetUserCalls())
-func (mock *UserGetterMock) GetUserCalls() []struct {
-	Ctx  context.Context
-	Db   store.Queryer
-	Name string
+//	len(mockedTaskUpdater.GetTaskCalls())
+func (mock *TaskUpdaterMock) GetTaskCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.TaskID
 } {
 	var calls []struct {
-		Ctx  context.Context
-		Db   store.Queryer
-		Name string
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
 	}
-	mock.lockGetUser.RLock()
-	calls = mock.calls.GetUser
-	mock.lockGetUser.RUnlock()
+	mock.lockGetTask.RLock()
+	calls = mock.calls.GetTask
+	mock.lockGetTask.RUnlock()
 	return calls
 }
 
-// Ensure, that TokenGeneratorMock does implement TokenGenerator.
+// UpdateTask calls UpdateTaskFunc.
+func (mock *TaskUpdaterMock) UpdateTask(ctx context.Context, db store.Execer, t *entity.Task) error {
+	if mock.UpdateTaskFunc == nil {
+		panic("TaskUpdaterMock.UpdateTaskFunc: method is nil but TaskUpdater.UpdateTask was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Task
+	}{
+		Ctx: ctx,
+		Db:  db,
+		T:   t,
+	}
+	mock.lockUpdateTask.Lock()
+	mock.calls.UpdateTask = append(mock.calls.UpdateTask, callInfo)
+	mock.lockUpdateTask.Unlock()
+	return mock.UpdateTaskFunc(ctx, db, t)
+}
+
+// UpdateTaskCalls gets all the calls that were made to UpdateTask.
+// Check the length with:
+//
+//	len(mockedTaskUpdater.UpdateTaskCalls())
+func (mock *TaskUpdaterMock) UpdateTaskCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	T   *entity.Task
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Task
+	}
+	mock.lockUpdateTask.RLock()
+	calls = mock.calls.UpdateTask
+	mock.lockUpdateTask.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskDeleterMock does implement TaskDeleter.
 // If this is not the case, regenerate this file with moq.
-var _ TokenGenerator = &TokenGeneratorMock{}
+var _ TaskDeleter = &TaskDeleterMock{}
 
-// TokenGeneratorMock is a mock implementation of TokenGenerator.
+// TaskDeleterMock is a mock implementation of TaskDeleter.
 //
-//	func TestSomethingThatUsesTokenGenerator(t *testing.T) {
+//	func TestSomethingThatUsesTaskDeleter(t *testing.T) {
 //
-//		// make and configure a mocked TokenGenerator
-//		mockedTokenGenerator := &TokenGeneratorMock{
-//			GenerateTokenFunc: func(ctx context.Context, u entity.User) ([]byte, error) {
-//				panic("mock out the GenerateToken method")
+//		// make and configure a mocked TaskDeleter
+//		mockedTaskDeleter := &TaskDeleterMock{
+//			DeleteTaskFunc: func(ctx context.Context, db store.ExecQueryer, uid entity.UserID, id entity.TaskID) error {
+//				panic("mock out the DeleteTask method")
 //			},
 //		}
 //
-//		// use mockedTokenGenerator in code that requires TokenGenerator
+//		// use mockedTaskDeleter in code that requires TaskDeleter
 //		// and then make assertions.
 //
 //	}
-type TokenGeneratorMock struct {
-	// GenerateTokenFunc mocks the GenerateToken method.
-	GenerateTokenFunc func(ctx context.Context, u entity.User) ([]byte, error)
+type TaskDeleterMock struct {
+	// DeleteTaskFunc mocks the DeleteTask method.
+	DeleteTaskFunc func(ctx context.Context, db store.ExecQueryer, uid entity.UserID, id entity.TaskID) error
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// GenerateToken holds details about calls to the GenerateToken method.
-		GenerateToken []struct {
+		// DeleteTask holds details about calls to the DeleteTask method.
+		DeleteTask []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// U is the u argument value.
-			U entity.User
+			// Db is the db argument value.
+			Db store.ExecQueryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
 		}
 	}
-	lockGenerateToken sync.RWMutex
+	lockDeleteTask sync.RWMutex
 }
 
-// GenerateToken calls GenerateTokenFunc.
-func (mock *TokenGeneratorMock) GenerateToken(ctx context.Context, u entity.User) ([]byte, error) {
-	if mock.GenerateTokenFunc == nil {
-		panic("TokenGeneratorMock.GenerateTokenFunc: method is nil but TokenGenerator.GenerateToken was just called")
+// DeleteTask calls DeleteTaskFunc.
+func (mock *TaskDeleterMock) DeleteTask(ctx context.Context, db store.ExecQueryer, uid entity.UserID, id entity.TaskID) error {
+	if mock.DeleteTaskFunc == nil {
+		panic("TaskDeleterMock.DeleteTaskFunc: method is nil but TaskDeleter.DeleteTask was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		U   entity.User
+		Db  store.ExecQueryer
+		UID entity.UserID
+		ID  entity.TaskID
 	}{
 		Ctx: ctx,
-		U:   u,
+		Db:  db,
+		UID: uid,
+		ID:  id,
 	}
-	mock.lockGenerateToken.Lock()
-	mock.calls.GenerateToken = append(mock.calls.GenerateToken, callInfo)
-	mock.lockGenerateToken.Unlock()
-	return mock.GenerateTokenFunc(ctx, u)
+	mock.lockDeleteTask.Lock()
+	mock.calls.DeleteTask = append(mock.calls.DeleteTask, callInfo)
+	mock.lockDeleteTask.Unlock()
+	return mock.DeleteTaskFunc(ctx, db, uid, id)
 }
 
-// GenerateTokenCalls gets all the calls that were made to GenerateToken.
+// DeleteTaskCalls gets all the calls that were made to DeleteTask.
 // Check the length with:
 //
-//	len(mockedTokenGenerator.GenerateTokenCalls())
-func (mock *TokenGeneratorMock) GenerateTokenCalls() []struct {
+//	len(mockedTaskDeleter.DeleteTaskCalls())
+func (mock *TaskDeleterMock) DeleteTaskCalls() []struct {
 	Ctx context.Context
-	U   entity.User
+	Db  store.ExecQueryer
+	UID entity.UserID
+	ID  entity.TaskID
 } {
 	var calls []struct {
 		Ctx context.Context
-		U   entity.User
+		Db  store.ExecQueryer
+		UID entity.UserID
+		ID  entity.TaskID
 	}
-	mock.lockGenerateToken.RLock()
-	calls = mock.calls.GenerateToken
-	mock.lockGenerateToken.RUnlock()
+	mock.lockDeleteTask.RLock()
+	calls = mock.calls.DeleteTask
+	mock.lockDeleteTask.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskTransitionerMock does implement TaskTransitioner.
+// If this is not the case, regenerate this file with moq.
+var _ TaskTransitioner = &TaskTransitionerMock{}
+
+// TaskTransitionerMock is a mock implementation of TaskTransitioner.
+//
+//	func TestSomethingThatUsesTaskTransitioner(t *testing.T) {
+//
+//		// make and configure a mocked TaskTransitioner
+//		mockedTaskTransitioner := &TaskTransitionerMock{
+//			AddNextOccurrenceFunc: func(ctx context.Context, db store.Execer, sourceID entity.TaskID, t *entity.Task) error {
+//				panic("mock out the AddNextOccurrence method")
+//			},
+//			AddTaskTransitionFunc: func(ctx context.Context, db store.Execer, tt *entity.TaskTransition) error {
+//				panic("mock out the AddTaskTransition method")
+//			},
+//			GetTaskForUpdateFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the GetTaskForUpdate method")
+//			},
+//			GetTaskProgressFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (entity.TaskProgress, error) {
+//				panic("mock out the GetTaskProgress method")
+//			},
+//			ListOpenBlockersFunc: func(ctx context.Context, db store.Queryer, id entity.TaskID) ([]entity.TaskID, error) {
+//				panic("mock out the ListOpenBlockers method")
+//			},
+//			UpdateTaskStatusFunc: func(ctx context.Context, db store.Execer, t *entity.Task) error {
+//				panic("mock out the UpdateTaskStatus method")
+//			},
+//		}
+//
+//		// use mockedTaskTransitioner in code that requires TaskTransitioner
+//		// and then make assertions.
+//
+//	}
+type TaskTransitionerMock struct {
+	// AddNextOccurrenceFunc mocks the AddNextOccurrence method.
+	AddNextOccurrenceFunc func(ctx context.Context, db store.Execer, sourceID entity.TaskID, t *entity.Task) error
+
+	// AddTaskTransitionFunc mocks the AddTaskTransition method.
+	AddTaskTransitionFunc func(ctx context.Context, db store.Execer, tt *entity.TaskTransition) error
+
+	// GetTaskForUpdateFunc mocks the GetTaskForUpdate method.
+	GetTaskForUpdateFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error)
+
+	// GetTaskProgressFunc mocks the GetTaskProgress method.
+	GetTaskProgressFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (entity.TaskProgress, error)
+
+	// ListOpenBlockersFunc mocks the ListOpenBlockers method.
+	ListOpenBlockersFunc func(ctx context.Context, db store.Queryer, id entity.TaskID) ([]entity.TaskID, error)
+
+	// UpdateTaskStatusFunc mocks the UpdateTaskStatus method.
+	UpdateTaskStatusFunc func(ctx context.Context, db store.Execer, t *entity.Task) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddNextOccurrence holds details about calls to the AddNextOccurrence method.
+		AddNextOccurrence []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// SourceID is the sourceID argument value.
+			SourceID entity.TaskID
+			// T is the t argument value.
+			T *entity.Task
+		}
+		// AddTaskTransition holds details about calls to the AddTaskTransition method.
+		AddTaskTransition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Tt is the tt argument value.
+			Tt *entity.TaskTransition
+		}
+		// GetTaskForUpdate holds details about calls to the GetTaskForUpdate method.
+		GetTaskForUpdate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+		// GetTaskProgress holds details about calls to the GetTaskProgress method.
+		GetTaskProgress []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+		// ListOpenBlockers holds details about calls to the ListOpenBlockers method.
+		ListOpenBlockers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+		// UpdateTaskStatus holds details about calls to the UpdateTaskStatus method.
+		UpdateTaskStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// T is the t argument value.
+			T *entity.Task
+		}
+	}
+	lockAddNextOccurrence sync.RWMutex
+	lockAddTaskTransition sync.RWMutex
+	lockGetTaskForUpdate  sync.RWMutex
+	lockGetTaskProgress   sync.RWMutex
+	lockListOpenBlockers  sync.RWMutex
+	lockUpdateTaskStatus  sync.RWMutex
+}
+
+// AddNextOccurrence calls AddNextOccurrenceFunc.
+func (mock *TaskTransitionerMock) AddNextOccurrence(ctx context.Context, db store.Execer, sourceID entity.TaskID, t *entity.Task) error {
+	if mock.AddNextOccurrenceFunc == nil {
+		panic("TaskTransitionerMock.AddNextOccurrenceFunc: method is nil but TaskTransitioner.AddNextOccurrence was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Db       store.Execer
+		SourceID entity.TaskID
+		T        *entity.Task
+	}{
+		Ctx:      ctx,
+		Db:       db,
+		SourceID: sourceID,
+		T:        t,
+	}
+	mock.lockAddNextOccurrence.Lock()
+	mock.calls.AddNextOccurrence = append(mock.calls.AddNextOccurrence, callInfo)
+	mock.lockAddNextOccurrence.Unlock()
+	return mock.AddNextOccurrenceFunc(ctx, db, sourceID, t)
+}
+
+// AddNextOccurrenceCalls gets all the calls that were made to AddNextOccurrence.
+// Check the length with:
+//
+//	len(mockedTaskTransitioner.AddNextOccurrenceCalls())
+func (mock *TaskTransitionerMock) AddNextOccurrenceCalls() []struct {
+	Ctx      context.Context
+	Db       store.Execer
+	SourceID entity.TaskID
+	T        *entity.Task
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Db       store.Execer
+		SourceID entity.TaskID
+		T        *entity.Task
+	}
+	mock.lockAddNextOccurrence.RLock()
+	calls = mock.calls.AddNextOccurrence
+	mock.lockAddNextOccurrence.RUnlock()
+	return calls
+}
+
+// AddTaskTransition calls AddTaskTransitionFunc.
+func (mock *TaskTransitionerMock) AddTaskTransition(ctx context.Context, db store.Execer, tt *entity.TaskTransition) error {
+	if mock.AddTaskTransitionFunc == nil {
+		panic("TaskTransitionerMock.AddTaskTransitionFunc: method is nil but TaskTransitioner.AddTaskTransition was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		Tt  *entity.TaskTransition
+	}{
+		Ctx: ctx,
+		Db:  db,
+		Tt:  tt,
+	}
+	mock.lockAddTaskTransition.Lock()
+	mock.calls.AddTaskTransition = append(mock.calls.AddTaskTransition, callInfo)
+	mock.lockAddTaskTransition.Unlock()
+	return mock.AddTaskTransitionFunc(ctx, db, tt)
+}
+
+// AddTaskTransitionCalls gets all the calls that were made to AddTaskTransition.
+// Check the length with:
+//
+//	len(mockedTaskTransitioner.AddTaskTransitionCalls())
+func (mock *TaskTransitionerMock) AddTaskTransitionCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	Tt  *entity.TaskTransition
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		Tt  *entity.TaskTransition
+	}
+	mock.lockAddTaskTransition.RLock()
+	calls = mock.calls.AddTaskTransition
+	mock.lockAddTaskTransition.RUnlock()
+	return calls
+}
+
+// GetTaskForUpdate calls GetTaskForUpdateFunc.
+func (mock *TaskTransitionerMock) GetTaskForUpdate(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+	if mock.GetTaskForUpdateFunc == nil {
+		panic("TaskTransitionerMock.GetTaskForUpdateFunc: method is nil but TaskTransitioner.GetTaskForUpdate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockGetTaskForUpdate.Lock()
+	mock.calls.GetTaskForUpdate = append(mock.calls.GetTaskForUpdate, callInfo)
+	mock.lockGetTaskForUpdate.Unlock()
+	return mock.GetTaskForUpdateFunc(ctx, db, uid, id)
+}
+
+// GetTaskForUpdateCalls gets all the calls that were made to GetTaskForUpdate.
+// Check the length with:
+//
+//	len(mockedTaskTransitioner.GetTaskForUpdateCalls())
+func (mock *TaskTransitionerMock) GetTaskForUpdateCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}
+	mock.lockGetTaskForUpdate.RLock()
+	calls = mock.calls.GetTaskForUpdate
+	mock.lockGetTaskForUpdate.RUnlock()
+	return calls
+}
+
+// GetTaskProgress calls GetTaskProgressFunc.
+func (mock *TaskTransitionerMock) GetTaskProgress(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (entity.TaskProgress, error) {
+	if mock.GetTaskProgressFunc == nil {
+		panic("TaskTransitionerMock.GetTaskProgressFunc: method is nil but TaskTransitioner.GetTaskProgress was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockGetTaskProgress.Lock()
+	mock.calls.GetTaskProgress = append(mock.calls.GetTaskProgress, callInfo)
+	mock.lockGetTaskProgress.Unlock()
+	return mock.GetTaskProgressFunc(ctx, db, uid, id)
+}
+
+// GetTaskProgressCalls gets all the calls that were made to GetTaskProgress.
+// Check the length with:
+//
+//	len(mockedTaskTransitioner.GetTaskProgressCalls())
+func (mock *TaskTransitionerMock) GetTaskProgressCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}
+	mock.lockGetTaskProgress.RLock()
+	calls = mock.calls.GetTaskProgress
+	mock.lockGetTaskProgress.RUnlock()
+	return calls
+}
+
+// ListOpenBlockers calls ListOpenBlockersFunc.
+func (mock *TaskTransitionerMock) ListOpenBlockers(ctx context.Context, db store.Queryer, id entity.TaskID) ([]entity.TaskID, error) {
+	if mock.ListOpenBlockersFunc == nil {
+		panic("TaskTransitionerMock.ListOpenBlockersFunc: method is nil but TaskTransitioner.ListOpenBlockers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockListOpenBlockers.Lock()
+	mock.calls.ListOpenBlockers = append(mock.calls.ListOpenBlockers, callInfo)
+	mock.lockListOpenBlockers.Unlock()
+	return mock.ListOpenBlockersFunc(ctx, db, id)
+}
+
+// ListOpenBlockersCalls gets all the calls that were made to ListOpenBlockers.
+// Check the length with:
+//
+//	len(mockedTaskTransitioner.ListOpenBlockersCalls())
+func (mock *TaskTransitionerMock) ListOpenBlockersCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.TaskID
+	}
+	mock.lockListOpenBlockers.RLock()
+	calls = mock.calls.ListOpenBlockers
+	mock.lockListOpenBlockers.RUnlock()
+	return calls
+}
+
+// UpdateTaskStatus calls UpdateTaskStatusFunc.
+func (mock *TaskTransitionerMock) UpdateTaskStatus(ctx context.Context, db store.Execer, t *entity.Task) error {
+	if mock.UpdateTaskStatusFunc == nil {
+		panic("TaskTransitionerMock.UpdateTaskStatusFunc: method is nil but TaskTransitioner.UpdateTaskStatus was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Task
+	}{
+		Ctx: ctx,
+		Db:  db,
+		T:   t,
+	}
+	mock.lockUpdateTaskStatus.Lock()
+	mock.calls.UpdateTaskStatus = append(mock.calls.UpdateTaskStatus, callInfo)
+	mock.lockUpdateTaskStatus.Unlock()
+	return mock.UpdateTaskStatusFunc(ctx, db, t)
+}
+
+// UpdateTaskStatusCalls gets all the calls that were made to UpdateTaskStatus.
+// Check the length with:
+//
+//	len(mockedTaskTransitioner.UpdateTaskStatusCalls())
+func (mock *TaskTransitionerMock) UpdateTaskStatusCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	T   *entity.Task
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Task
+	}
+	mock.lockUpdateTaskStatus.RLock()
+	calls = mock.calls.UpdateTaskStatus
+	mock.lockUpdateTaskStatus.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskChildListerMock does implement TaskChildLister.
+// If this is not the case, regenerate this file with moq.
+var _ TaskChildLister = &TaskChildListerMock{}
+
+// TaskChildListerMock is a mock implementation of TaskChildLister.
+//
+//	func TestSomethingThatUsesTaskChildLister(t *testing.T) {
+//
+//		// make and configure a mocked TaskChildLister
+//		mockedTaskChildLister := &TaskChildListerMock{
+//			GetTaskFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the GetTask method")
+//			},
+//			ListChildTasksFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (entity.Tasks, error) {
+//				panic("mock out the ListChildTasks method")
+//			},
+//		}
+//
+//		// use mockedTaskChildLister in code that requires TaskChildLister
+//		// and then make assertions.
+//
+//	}
+type TaskChildListerMock struct {
+	// GetTaskFunc mocks the GetTask method.
+	GetTaskFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error)
+
+	// ListChildTasksFunc mocks the ListChildTasks method.
+	ListChildTasksFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (entity.Tasks, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetTask holds details about calls to the GetTask method.
+		GetTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+		// ListChildTasks holds details about calls to the ListChildTasks method.
+		ListChildTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+	}
+	lockGetTask        sync.RWMutex
+	lockListChildTasks sync.RWMutex
+}
+
+// GetTask calls GetTaskFunc.
+func (mock *TaskChildListerMock) GetTask(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+	if mock.GetTaskFunc == nil {
+		panic("TaskChildListerMock.GetTaskFunc: method is nil but TaskChildLister.GetTask was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockGetTask.Lock()
+	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
+	mock.lockGetTask.Unlock()
+	return mock.GetTaskFunc(ctx, db, uid, id)
+}
+
+// GetTaskCalls gets all the calls that were made to GetTask.
+// Check the length with:
+//
+//	len(mockedTaskChildLister.GetTaskCalls())
+func (mock *TaskChildListerMock) GetTaskCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}
+	mock.lockGetTask.RLock()
+	calls = mock.calls.GetTask
+	mock.lockGetTask.RUnlock()
+	return calls
+}
+
+// ListChildTasks calls ListChildTasksFunc.
+func (mock *TaskChildListerMock) ListChildTasks(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (entity.Tasks, error) {
+	if mock.ListChildTasksFunc == nil {
+		panic("TaskChildListerMock.ListChildTasksFunc: method is nil but TaskChildLister.ListChildTasks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockListChildTasks.Lock()
+	mock.calls.ListChildTasks = append(mock.calls.ListChildTasks, callInfo)
+	mock.lockListChildTasks.Unlock()
+	return mock.ListChildTasksFunc(ctx, db, uid, id)
+}
+
+// ListChildTasksCalls gets all the calls that were made to ListChildTasks.
+// Check the length with:
+//
+//	len(mockedTaskChildLister.ListChildTasksCalls())
+func (mock *TaskChildListerMock) ListChildTasksCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}
+	mock.lockListChildTasks.RLock()
+	calls = mock.calls.ListChildTasks
+	mock.lockListChildTasks.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskMoverMock does implement TaskMover.
+// If this is not the case, regenerate this file with moq.
+var _ TaskMover = &TaskMoverMock{}
+
+// TaskMoverMock is a mock implementation of TaskMover.
+//
+//	func TestSomethingThatUsesTaskMover(t *testing.T) {
+//
+//		// make and configure a mocked TaskMover
+//		mockedTaskMover := &TaskMoverMock{
+//			GetTaskForUpdateFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the GetTaskForUpdate method")
+//			},
+//			UpdateTaskParentFunc: func(ctx context.Context, db store.Execer, t *entity.Task) error {
+//				panic("mock out the UpdateTaskParent method")
+//			},
+//			ValidateTaskParentFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID, parentID entity.TaskID) error {
+//				panic("mock out the ValidateTaskParent method")
+//			},
+//		}
+//
+//		// use mockedTaskMover in code that requires TaskMover
+//		// and then make assertions.
+//
+//	}
+type TaskMoverMock struct {
+	// GetTaskForUpdateFunc mocks the GetTaskForUpdate method.
+	GetTaskForUpdateFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error)
+
+	// UpdateTaskParentFunc mocks the UpdateTaskParent method.
+	UpdateTaskParentFunc func(ctx context.Context, db store.Execer, t *entity.Task) error
+
+	// ValidateTaskParentFunc mocks the ValidateTaskParent method.
+	ValidateTaskParentFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID, parentID entity.TaskID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetTaskForUpdate holds details about calls to the GetTaskForUpdate method.
+		GetTaskForUpdate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+		// UpdateTaskParent holds details about calls to the UpdateTaskParent method.
+		UpdateTaskParent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// T is the t argument value.
+			T *entity.Task
+		}
+		// ValidateTaskParent holds details about calls to the ValidateTaskParent method.
+		ValidateTaskParent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
+			// ParentID is the parentID argument value.
+			ParentID entity.TaskID
+		}
+	}
+	lockGetTaskForUpdate   sync.RWMutex
+	lockUpdateTaskParent   sync.RWMutex
+	lockValidateTaskParent sync.RWMutex
+}
+
+// GetTaskForUpdate calls GetTaskForUpdateFunc.
+func (mock *TaskMoverMock) GetTaskForUpdate(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+	if mock.GetTaskForUpdateFunc == nil {
+		panic("TaskMoverMock.GetTaskForUpdateFunc: method is nil but TaskMover.GetTaskForUpdate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockGetTaskForUpdate.Lock()
+	mock.calls.GetTaskForUpdate = append(mock.calls.GetTaskForUpdate, callInfo)
+	mock.lockGetTaskForUpdate.Unlock()
+	return mock.GetTaskForUpdateFunc(ctx, db, uid, id)
+}
+
+// GetTaskForUpdateCalls gets all the calls that were made to GetTaskForUpdate.
+// Check the length with:
+//
+//	len(mockedTaskMover.GetTaskForUpdateCalls())
+func (mock *TaskMoverMock) GetTaskForUpdateCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}
+	mock.lockGetTaskForUpdate.RLock()
+	calls = mock.calls.GetTaskForUpdate
+	mock.lockGetTaskForUpdate.RUnlock()
+	return calls
+}
+
+// UpdateTaskParent calls UpdateTaskParentFunc.
+func (mock *TaskMoverMock) UpdateTaskParent(ctx context.Context, db store.Execer, t *entity.Task) error {
+	if mock.UpdateTaskParentFunc == nil {
+		panic("TaskMoverMock.UpdateTaskParentFunc: method is nil but TaskMover.UpdateTaskParent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Task
+	}{
+		Ctx: ctx,
+		Db:  db,
+		T:   t,
+	}
+	mock.lockUpdateTaskParent.Lock()
+	mock.calls.UpdateTaskParent = append(mock.calls.UpdateTaskParent, callInfo)
+	mock.lockUpdateTaskParent.Unlock()
+	return mock.UpdateTaskParentFunc(ctx, db, t)
+}
+
+// UpdateTaskParentCalls gets all the calls that were made to UpdateTaskParent.
+// Check the length with:
+//
+//	len(mockedTaskMover.UpdateTaskParentCalls())
+func (mock *TaskMoverMock) UpdateTaskParentCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	T   *entity.Task
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Task
+	}
+	mock.lockUpdateTaskParent.RLock()
+	calls = mock.calls.UpdateTaskParent
+	mock.lockUpdateTaskParent.RUnlock()
+	return calls
+}
+
+// ValidateTaskParent calls ValidateTaskParentFunc.
+func (mock *TaskMoverMock) ValidateTaskParent(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID, parentID entity.TaskID) error {
+	if mock.ValidateTaskParentFunc == nil {
+		panic("TaskMoverMock.ValidateTaskParentFunc: method is nil but TaskMover.ValidateTaskParent was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Db       store.Queryer
+		UID      entity.UserID
+		ID       entity.TaskID
+		ParentID entity.TaskID
+	}{
+		Ctx:      ctx,
+		Db:       db,
+		UID:      uid,
+		ID:       id,
+		ParentID: parentID,
+	}
+	mock.lockValidateTaskParent.Lock()
+	mock.calls.ValidateTaskParent = append(mock.calls.ValidateTaskParent, callInfo)
+	mock.lockValidateTaskParent.Unlock()
+	return mock.ValidateTaskParentFunc(ctx, db, uid, id, parentID)
+}
+
+// ValidateTaskParentCalls gets all the calls that were made to ValidateTaskParent.
+// Check the length with:
+//
+//	len(mockedTaskMover.ValidateTaskParentCalls())
+func (mock *TaskMoverMock) ValidateTaskParentCalls() []struct {
+	Ctx      context.Context
+	Db       store.Queryer
+	UID      entity.UserID
+	ID       entity.TaskID
+	ParentID entity.TaskID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Db       store.Queryer
+		UID      entity.UserID
+		ID       entity.TaskID
+		ParentID entity.TaskID
+	}
+	mock.lockValidateTaskParent.RLock()
+	calls = mock.calls.ValidateTaskParent
+	mock.lockValidateTaskParent.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskDependencyAdderMock does implement TaskDependencyAdder.
+// If this is not the case, regenerate this file with moq.
+var _ TaskDependencyAdder = &TaskDependencyAdderMock{}
+
+// TaskDependencyAdderMock is a mock implementation of TaskDependencyAdder.
+//
+//	func TestSomethingThatUsesTaskDependencyAdder(t *testing.T) {
+//
+//		// make and configure a mocked TaskDependencyAdder
+//		mockedTaskDependencyAdder := &TaskDependencyAdderMock{
+//			AddTaskDependencyFunc: func(ctx context.Context, db store.Execer, uid entity.UserID, blockerID entity.TaskID, blockedID entity.TaskID) error {
+//				panic("mock out the AddTaskDependency method")
+//			},
+//			ListTaskDependenciesFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.TaskDependencies, error) {
+//				panic("mock out the ListTaskDependencies method")
+//			},
+//		}
+//
+//		// use mockedTaskDependencyAdder in code that requires TaskDependencyAdder
+//		// and then make assertions.
+//
+//	}
+type TaskDependencyAdderMock struct {
+	// AddTaskDependencyFunc mocks the AddTaskDependency method.
+	AddTaskDependencyFunc func(ctx context.Context, db store.Execer, uid entity.UserID, blockerID entity.TaskID, blockedID entity.TaskID) error
+
+	// ListTaskDependenciesFunc mocks the ListTaskDependencies method.
+	ListTaskDependenciesFunc func(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.TaskDependencies, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddTaskDependency holds details about calls to the AddTaskDependency method.
+		AddTaskDependency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// BlockerID is the blockerID argument value.
+			BlockerID entity.TaskID
+			// BlockedID is the blockedID argument value.
+			BlockedID entity.TaskID
+		}
+		// ListTaskDependencies holds details about calls to the ListTaskDependencies method.
+		ListTaskDependencies []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+		}
+	}
+	lockAddTaskDependency    sync.RWMutex
+	lockListTaskDependencies sync.RWMutex
+}
+
+// AddTaskDependency calls AddTaskDependencyFunc.
+func (mock *TaskDependencyAdderMock) AddTaskDependency(ctx context.Context, db store.Execer, uid entity.UserID, blockerID entity.TaskID, blockedID entity.TaskID) error {
+	if mock.AddTaskDependencyFunc == nil {
+		panic("TaskDependencyAdderMock.AddTaskDependencyFunc: method is nil but TaskDependencyAdder.AddTaskDependency was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Db        store.Execer
+		UID       entity.UserID
+		BlockerID entity.TaskID
+		BlockedID entity.TaskID
+	}{
+		Ctx:       ctx,
+		Db:        db,
+		UID:       uid,
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}
+	mock.lockAddTaskDependency.Lock()
+	mock.calls.AddTaskDependency = append(mock.calls.AddTaskDependency, callInfo)
+	mock.lockAddTaskDependency.Unlock()
+	return mock.AddTaskDependencyFunc(ctx, db, uid, blockerID, blockedID)
+}
+
+// AddTaskDependencyCalls gets all the calls that were made to AddTaskDependency.
+// Check the length with:
+//
+//	len(mockedTaskDependencyAdder.AddTaskDependencyCalls())
+func (mock *TaskDependencyAdderMock) AddTaskDependencyCalls() []struct {
+	Ctx       context.Context
+	Db        store.Execer
+	UID       entity.UserID
+	BlockerID entity.TaskID
+	BlockedID entity.TaskID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Db        store.Execer
+		UID       entity.UserID
+		BlockerID entity.TaskID
+		BlockedID entity.TaskID
+	}
+	mock.lockAddTaskDependency.RLock()
+	calls = mock.calls.AddTaskDependency
+	mock.lockAddTaskDependency.RUnlock()
+	return calls
+}
+
+// ListTaskDependencies calls ListTaskDependenciesFunc.
+func (mock *TaskDependencyAdderMock) ListTaskDependencies(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.TaskDependencies, error) {
+	if mock.ListTaskDependenciesFunc == nil {
+		panic("TaskDependencyAdderMock.ListTaskDependenciesFunc: method is nil but TaskDependencyAdder.ListTaskDependencies was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+	}
+	mock.lockListTaskDependencies.Lock()
+	mock.calls.ListTaskDependencies = append(mock.calls.ListTaskDependencies, callInfo)
+	mock.lockListTaskDependencies.Unlock()
+	return mock.ListTaskDependenciesFunc(ctx, db, uid)
+}
+
+// ListTaskDependenciesCalls gets all the calls that were made to ListTaskDependencies.
+// Check the length with:
+//
+//	len(mockedTaskDependencyAdder.ListTaskDependenciesCalls())
+func (mock *TaskDependencyAdderMock) ListTaskDependenciesCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+	}
+	mock.lockListTaskDependencies.RLock()
+	calls = mock.calls.ListTaskDependencies
+	mock.lockListTaskDependencies.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskDependencyDeleterMock does implement TaskDependencyDeleter.
+// If this is not the case, regenerate this file with moq.
+var _ TaskDependencyDeleter = &TaskDependencyDeleterMock{}
+
+// TaskDependencyDeleterMock is a mock implementation of TaskDependencyDeleter.
+//
+//	func TestSomethingThatUsesTaskDependencyDeleter(t *testing.T) {
+//
+//		// make and configure a mocked TaskDependencyDeleter
+//		mockedTaskDependencyDeleter := &TaskDependencyDeleterMock{
+//			DeleteTaskDependencyFunc: func(ctx context.Context, db store.Execer, uid entity.UserID, blockerID entity.TaskID, blockedID entity.TaskID) error {
+//				panic("mock out the DeleteTaskDependency method")
+//			},
+//		}
+//
+//		// use mockedTaskDependencyDeleter in code that requires TaskDependencyDeleter
+//		// and then make assertions.
+//
+//	}
+type TaskDependencyDeleterMock struct {
+	// DeleteTaskDependencyFunc mocks the DeleteTaskDependency method.
+	DeleteTaskDependencyFunc func(ctx context.Context, db store.Execer, uid entity.UserID, blockerID entity.TaskID, blockedID entity.TaskID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteTaskDependency holds details about calls to the DeleteTaskDependency method.
+		DeleteTaskDependency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// BlockerID is the blockerID argument value.
+			BlockerID entity.TaskID
+			// BlockedID is the blockedID argument value.
+			BlockedID entity.TaskID
+		}
+	}
+	lockDeleteTaskDependency sync.RWMutex
+}
+
+// DeleteTaskDependency calls DeleteTaskDependencyFunc.
+func (mock *TaskDependencyDeleterMock) DeleteTaskDependency(ctx context.Context, db store.Execer, uid entity.UserID, blockerID entity.TaskID, blockedID entity.TaskID) error {
+	if mock.DeleteTaskDependencyFunc == nil {
+		panic("TaskDependencyDeleterMock.DeleteTaskDependencyFunc: method is nil but TaskDependencyDeleter.DeleteTaskDependency was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Db        store.Execer
+		UID       entity.UserID
+		BlockerID entity.TaskID
+		BlockedID entity.TaskID
+	}{
+		Ctx:       ctx,
+		Db:        db,
+		UID:       uid,
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}
+	mock.lockDeleteTaskDependency.Lock()
+	mock.calls.DeleteTaskDependency = append(mock.calls.DeleteTaskDependency, callInfo)
+	mock.lockDeleteTaskDependency.Unlock()
+	return mock.DeleteTaskDependencyFunc(ctx, db, uid, blockerID, blockedID)
+}
+
+// DeleteTaskDependencyCalls gets all the calls that were made to DeleteTaskDependency.
+// Check the length with:
+//
+//	len(mockedTaskDependencyDeleter.DeleteTaskDependencyCalls())
+func (mock *TaskDependencyDeleterMock) DeleteTaskDependencyCalls() []struct {
+	Ctx       context.Context
+	Db        store.Execer
+	UID       entity.UserID
+	BlockerID entity.TaskID
+	BlockedID entity.TaskID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Db        store.Execer
+		UID       entity.UserID
+		BlockerID entity.TaskID
+		BlockedID entity.TaskID
+	}
+	mock.lockDeleteTaskDependency.RLock()
+	calls = mock.calls.DeleteTaskDependency
+	mock.lockDeleteTaskDependency.RUnlock()
+	return calls
+}
+
+// Ensure, that TrashListerMock does implement TrashLister.
+// If this is not the case, regenerate this file with moq.
+var _ TrashLister = &TrashListerMock{}
+
+// TrashListerMock is a mock implementation of TrashLister.
+//
+//	func TestSomethingThatUsesTrashLister(t *testing.T) {
+//
+//		// make and configure a mocked TrashLister
+//		mockedTrashLister := &TrashListerMock{
+//			ListTrashedTasksFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.Tasks, error) {
+//				panic("mock out the ListTrashedTasks method")
+//			},
+//		}
+//
+//		// use mockedTrashLister in code that requires TrashLister
+//		// and then make assertions.
+//
+//	}
+type TrashListerMock struct {
+	// ListTrashedTasksFunc mocks the ListTrashedTasks method.
+	ListTrashedTasksFunc func(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.Tasks, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListTrashedTasks holds details about calls to the ListTrashedTasks method.
+		ListTrashedTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+		}
+	}
+	lockListTrashedTasks sync.RWMutex
+}
+
+// ListTrashedTasks calls ListTrashedTasksFunc.
+func (mock *TrashListerMock) ListTrashedTasks(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.Tasks, error) {
+	if mock.ListTrashedTasksFunc == nil {
+		panic("TrashListerMock.ListTrashedTasksFunc: method is nil but TrashLister.ListTrashedTasks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+	}
+	mock.lockListTrashedTasks.Lock()
+	mock.calls.ListTrashedTasks = append(mock.calls.ListTrashedTasks, callInfo)
+	mock.lockListTrashedTasks.Unlock()
+	return mock.ListTrashedTasksFunc(ctx, db, uid)
+}
+
+// ListTrashedTasksCalls gets all the calls that were made to ListTrashedTasks.
+// Check the length with:
+//
+//	len(mockedTrashLister.ListTrashedTasksCalls())
+func (mock *TrashListerMock) ListTrashedTasksCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+	}
+	mock.lockListTrashedTasks.RLock()
+	calls = mock.calls.ListTrashedTasks
+	mock.lockListTrashedTasks.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskRestorerMock does implement TaskRestorer.
+// If this is not the case, regenerate this file with moq.
+var _ TaskRestorer = &TaskRestorerMock{}
+
+// TaskRestorerMock is a mock implementation of TaskRestorer.
+//
+//	func TestSomethingThatUsesTaskRestorer(t *testing.T) {
+//
+//		// make and configure a mocked TaskRestorer
+//		mockedTaskRestorer := &TaskRestorerMock{
+//			GetTaskFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the GetTask method")
+//			},
+//			RestoreTaskFunc: func(ctx context.Context, db store.ExecQueryer, uid entity.UserID, id entity.TaskID) error {
+//				panic("mock out the RestoreTask method")
+//			},
+//		}
+//
+//		// use mockedTaskRestorer in code that requires TaskRestorer
+//		// and then make assertions.
+//
+//	}
+type TaskRestorerMock struct {
+	// GetTaskFunc mocks the GetTask method.
+	GetTaskFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error)
+
+	// RestoreTaskFunc mocks the RestoreTask method.
+	RestoreTaskFunc func(ctx context.Context, db store.ExecQueryer, uid entity.UserID, id entity.TaskID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetTask holds details about calls to the GetTask method.
+		GetTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+		// RestoreTask holds details about calls to the RestoreTask method.
+		RestoreTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.ExecQueryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+	}
+	lockGetTask     sync.RWMutex
+	lockRestoreTask sync.RWMutex
+}
+
+// GetTask calls GetTaskFunc.
+func (mock *TaskRestorerMock) GetTask(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+	if mock.GetTaskFunc == nil {
+		panic("TaskRestorerMock.GetTaskFunc: method is nil but TaskRestorer.GetTask was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockGetTask.Lock()
+	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
+	mock.lockGetTask.Unlock()
+	return mock.GetTaskFunc(ctx, db, uid, id)
+}
+
+// GetTaskCalls gets all the calls that were made to GetTask.
+// Check the length with:
+//
+//	len(mockedTaskRestorer.GetTaskCalls())
+func (mock *TaskRestorerMock) GetTaskCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}
+	mock.lockGetTask.RLock()
+	calls = mock.calls.GetTask
+	mock.lockGetTask.RUnlock()
+	return calls
+}
+
+// RestoreTask calls RestoreTaskFunc.
+func (mock *TaskRestorerMock) RestoreTask(ctx context.Context, db store.ExecQueryer, uid entity.UserID, id entity.TaskID) error {
+	if mock.RestoreTaskFunc == nil {
+		panic("TaskRestorerMock.RestoreTaskFunc: method is nil but TaskRestorer.RestoreTask was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.ExecQueryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockRestoreTask.Lock()
+	mock.calls.RestoreTask = append(mock.calls.RestoreTask, callInfo)
+	mock.lockRestoreTask.Unlock()
+	return mock.RestoreTaskFunc(ctx, db, uid, id)
+}
+
+// RestoreTaskCalls gets all the calls that were made to RestoreTask.
+// Check the length with:
+//
+//	len(mockedTaskRestorer.RestoreTaskCalls())
+func (mock *TaskRestorerMock) RestoreTaskCalls() []struct {
+	Ctx context.Context
+	Db  store.ExecQueryer
+	UID entity.UserID
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.ExecQueryer
+		UID entity.UserID
+		ID  entity.TaskID
+	}
+	mock.lockRestoreTask.RLock()
+	calls = mock.calls.RestoreTask
+	mock.lockRestoreTask.RUnlock()
+	return calls
+}
+
+// Ensure, that TrashEmptierMock does implement TrashEmptier.
+// If this is not the case, regenerate this file with moq.
+var _ TrashEmptier = &TrashEmptierMock{}
+
+// TrashEmptierMock is a mock implementation of TrashEmptier.
+//
+//	func TestSomethingThatUsesTrashEmptier(t *testing.T) {
+//
+//		// make and configure a mocked TrashEmptier
+//		mockedTrashEmptier := &TrashEmptierMock{
+//			EmptyTrashFunc: func(ctx context.Context, db store.Execer, uid entity.UserID) (int64, error) {
+//				panic("mock out the EmptyTrash method")
+//			},
+//		}
+//
+//		// use mockedTrashEmptier in code that requires TrashEmptier
+//		// and then make assertions.
+//
+//	}
+type TrashEmptierMock struct {
+	// EmptyTrashFunc mocks the EmptyTrash method.
+	EmptyTrashFunc func(ctx context.Context, db store.Execer, uid entity.UserID) (int64, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// EmptyTrash holds details about calls to the EmptyTrash method.
+		EmptyTrash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UID is the uid argument value.
+			UID entity.UserID
+		}
+	}
+	lockEmptyTrash sync.RWMutex
+}
+
+// EmptyTrash calls EmptyTrashFunc.
+func (mock *TrashEmptierMock) EmptyTrash(ctx context.Context, db store.Execer, uid entity.UserID) (int64, error) {
+	if mock.EmptyTrashFunc == nil {
+		panic("TrashEmptierMock.EmptyTrashFunc: method is nil but TrashEmptier.EmptyTrash was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		UID entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+	}
+	mock.lockEmptyTrash.Lock()
+	mock.calls.EmptyTrash = append(mock.calls.EmptyTrash, callInfo)
+	mock.lockEmptyTrash.Unlock()
+	return mock.EmptyTrashFunc(ctx, db, uid)
+}
+
+// EmptyTrashCalls gets all the calls that were made to EmptyTrash.
+// Check the length with:
+//
+//	len(mockedTrashEmptier.EmptyTrashCalls())
+func (mock *TrashEmptierMock) EmptyTrashCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	UID entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		UID entity.UserID
+	}
+	mock.lockEmptyTrash.RLock()
+	calls = mock.calls.EmptyTrash
+	mock.lockEmptyTrash.RUnlock()
+	return calls
+}
+
+// Ensure, that TrashPurgerMock does implement TrashPurger.
+// If this is not the case, regenerate this file with moq.
+var _ TrashPurger = &TrashPurgerMock{}
+
+// TrashPurgerMock is a mock implementation of TrashPurger.
+//
+//	func TestSomethingThatUsesTrashPurger(t *testing.T) {
+//
+//		// make and configure a mocked TrashPurger
+//		mockedTrashPurger := &TrashPurgerMock{
+//			PurgeTrashFunc: func(ctx context.Context, db store.Execer, before time.Time) (int64, error) {
+//				panic("mock out the PurgeTrash method")
+//			},
+//		}
+//
+//		// use mockedTrashPurger in code that requires TrashPurger
+//		// and then make assertions.
+//
+//	}
+type TrashPurgerMock struct {
+	// PurgeTrashFunc mocks the PurgeTrash method.
+	PurgeTrashFunc func(ctx context.Context, db store.Execer, before time.Time) (int64, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// PurgeTrash holds details about calls to the PurgeTrash method.
+		PurgeTrash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Before is the before argument value.
+			Before time.Time
+		}
+	}
+	lockPurgeTrash sync.RWMutex
+}
+
+// PurgeTrash calls PurgeTrashFunc.
+func (mock *TrashPurgerMock) PurgeTrash(ctx context.Context, db store.Execer, before time.Time) (int64, error) {
+	if mock.PurgeTrashFunc == nil {
+		panic("TrashPurgerMock.PurgeTrashFunc: method is nil but TrashPurger.PurgeTrash was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		Before: before,
+	}
+	mock.lockPurgeTrash.Lock()
+	mock.calls.PurgeTrash = append(mock.calls.PurgeTrash, callInfo)
+	mock.lockPurgeTrash.Unlock()
+	return mock.PurgeTrashFunc(ctx, db, before)
+}
+
+// PurgeTrashCalls gets all the calls that were made to PurgeTrash.
+// Check the length with:
+//
+//	len(mockedTrashPurger.PurgeTrashCalls())
+func (mock *TrashPurgerMock) PurgeTrashCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		Before time.Time
+	}
+	mock.lockPurgeTrash.RLock()
+	calls = mock.calls.PurgeTrash
+	mock.lockPurgeTrash.RUnlock()
+	return calls
+}
+
+// Ensure, that TagAdderMock does implement TagAdder.
+// If this is not the case, regenerate this file with moq.
+var _ TagAdder = &TagAdderMock{}
+
+// TagAdderMock is a mock implementation of TagAdder.
+//
+//	func TestSomethingThatUsesTagAdder(t *testing.T) {
+//
+//		// make and configure a mocked TagAdder
+//		mockedTagAdder := &TagAdderMock{
+//			AddTagFunc: func(ctx context.Context, db store.Execer, t *entity.Tag) error {
+//				panic("mock out the AddTag method")
+//			},
+//		}
+//
+//		// use mockedTagAdder in code that requires TagAdder
+//		// and then make assertions.
+//
+//	}
+type TagAdderMock struct {
+	// AddTagFunc mocks the AddTag method.
+	AddTagFunc func(ctx context.Context, db store.Execer, t *entity.Tag) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddTag holds details about calls to the AddTag method.
+		AddTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// T is the t argument value.
+			T *entity.Tag
+		}
+	}
+	lockAddTag sync.RWMutex
+}
+
+// AddTag calls AddTagFunc.
+func (mock *TagAdderMock) AddTag(ctx context.Context, db store.Execer, t *entity.Tag) error {
+	if mock.AddTagFunc == nil {
+		panic("TagAdderMock.AddTagFunc: method is nil but TagAdder.AddTag was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Tag
+	}{
+		Ctx: ctx,
+		Db:  db,
+		T:   t,
+	}
+	mock.lockAddTag.Lock()
+	mock.calls.AddTag = append(mock.calls.AddTag, callInfo)
+	mock.lockAddTag.Unlock()
+	return mock.AddTagFunc(ctx, db, t)
+}
+
+// AddTagCalls gets all the calls that were made to AddTag.
+// Check the length with:
+//
+//	len(mockedTagAdder.AddTagCalls())
+func (mock *TagAdderMock) AddTagCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	T   *entity.Tag
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Tag
+	}
+	mock.lockAddTag.RLock()
+	calls = mock.calls.AddTag
+	mock.lockAddTag.RUnlock()
+	return calls
+}
+
+// Ensure, that TagListerMock does implement TagLister.
+// If this is not the case, regenerate this file with moq.
+var _ TagLister = &TagListerMock{}
+
+// TagListerMock is a mock implementation of TagLister.
+//
+//	func TestSomethingThatUsesTagLister(t *testing.T) {
+//
+//		// make and configure a mocked TagLister
+//		mockedTagLister := &TagListerMock{
+//			ListTagsFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.Tags, error) {
+//				panic("mock out the ListTags method")
+//			},
+//		}
+//
+//		// use mockedTagLister in code that requires TagLister
+//		// and then make assertions.
+//
+//	}
+type TagListerMock struct {
+	// ListTagsFunc mocks the ListTags method.
+	ListTagsFunc func(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.Tags, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListTags holds details about calls to the ListTags method.
+		ListTags []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+		}
+	}
+	lockListTags sync.RWMutex
+}
+
+// ListTags calls ListTagsFunc.
+func (mock *TagListerMock) ListTags(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.Tags, error) {
+	if mock.ListTagsFunc == nil {
+		panic("TagListerMock.ListTagsFunc: method is nil but TagLister.ListTags was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+	}
+	mock.lockListTags.Lock()
+	mock.calls.ListTags = append(mock.calls.ListTags, callInfo)
+	mock.lockListTags.Unlock()
+	return mock.ListTagsFunc(ctx, db, uid)
+}
+
+// ListTagsCalls gets all the calls that were made to ListTags.
+// Check the length with:
+//
+//	len(mockedTagLister.ListTagsCalls())
+func (mock *TagListerMock) ListTagsCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+	}
+	mock.lockListTags.RLock()
+	calls = mock.calls.ListTags
+	mock.lockListTags.RUnlock()
+	return calls
+}
+
+// Ensure, that TagUpdaterMock does implement TagUpdater.
+// If this is not the case, regenerate this file with moq.
+var _ TagUpdater = &TagUpdaterMock{}
+
+// TagUpdaterMock is a mock implementation of TagUpdater.
+//
+//	func TestSomethingThatUsesTagUpdater(t *testing.T) {
+//
+//		// make and configure a mocked TagUpdater
+//		mockedTagUpdater := &TagUpdaterMock{
+//			UpdateTagFunc: func(ctx context.Context, db store.Execer, t *entity.Tag) error {
+//				panic("mock out the UpdateTag method")
+//			},
+//		}
+//
+//		// use mockedTagUpdater in code that requires TagUpdater
+//		// and then make assertions.
+//
+//	}
+type TagUpdaterMock struct {
+	// UpdateTagFunc mocks the UpdateTag method.
+	UpdateTagFunc func(ctx context.Context, db store.Execer, t *entity.Tag) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// UpdateTag holds details about calls to the UpdateTag method.
+		UpdateTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// T is the t argument value.
+			T *entity.Tag
+		}
+	}
+	lockUpdateTag sync.RWMutex
+}
+
+// UpdateTag calls UpdateTagFunc.
+func (mock *TagUpdaterMock) UpdateTag(ctx context.Context, db store.Execer, t *entity.Tag) error {
+	if mock.UpdateTagFunc == nil {
+		panic("TagUpdaterMock.UpdateTagFunc: method is nil but TagUpdater.UpdateTag was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Tag
+	}{
+		Ctx: ctx,
+		Db:  db,
+		T:   t,
+	}
+	mock.lockUpdateTag.Lock()
+	mock.calls.UpdateTag = append(mock.calls.UpdateTag, callInfo)
+	mock.lockUpdateTag.Unlock()
+	return mock.UpdateTagFunc(ctx, db, t)
+}
+
+// UpdateTagCalls gets all the calls that were made to UpdateTag.
+// Check the length with:
+//
+//	len(mockedTagUpdater.UpdateTagCalls())
+func (mock *TagUpdaterMock) UpdateTagCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	T   *entity.Tag
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Tag
+	}
+	mock.lockUpdateTag.RLock()
+	calls = mock.calls.UpdateTag
+	mock.lockUpdateTag.RUnlock()
+	return calls
+}
+
+// Ensure, that TagDeleterMock does implement TagDeleter.
+// If this is not the case, regenerate this file with moq.
+var _ TagDeleter = &TagDeleterMock{}
+
+// TagDeleterMock is a mock implementation of TagDeleter.
+//
+//	func TestSomethingThatUsesTagDeleter(t *testing.T) {
+//
+//		// make and configure a mocked TagDeleter
+//		mockedTagDeleter := &TagDeleterMock{
+//			DeleteTagFunc: func(ctx context.Context, db store.Execer, uid entity.UserID, id entity.TagID) error {
+//				panic("mock out the DeleteTag method")
+//			},
+//		}
+//
+//		// use mockedTagDeleter in code that requires TagDeleter
+//		// and then make assertions.
+//
+//	}
+type TagDeleterMock struct {
+	// DeleteTagFunc mocks the DeleteTag method.
+	DeleteTagFunc func(ctx context.Context, db store.Execer, uid entity.UserID, id entity.TagID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteTag holds details about calls to the DeleteTag method.
+		DeleteTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.TagID
+		}
+	}
+	lockDeleteTag sync.RWMutex
+}
+
+// DeleteTag calls DeleteTagFunc.
+func (mock *TagDeleterMock) DeleteTag(ctx context.Context, db store.Execer, uid entity.UserID, id entity.TagID) error {
+	if mock.DeleteTagFunc == nil {
+		panic("TagDeleterMock.DeleteTagFunc: method is nil but TagDeleter.DeleteTag was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		UID entity.UserID
+		ID  entity.TagID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockDeleteTag.Lock()
+	mock.calls.DeleteTag = append(mock.calls.DeleteTag, callInfo)
+	mock.lockDeleteTag.Unlock()
+	return mock.DeleteTagFunc(ctx, db, uid, id)
+}
+
+// DeleteTagCalls gets all the calls that were made to DeleteTag.
+// Check the length with:
+//
+//	len(mockedTagDeleter.DeleteTagCalls())
+func (mock *TagDeleterMock) DeleteTagCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	UID entity.UserID
+	ID  entity.TagID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		UID entity.UserID
+		ID  entity.TagID
+	}
+	mock.lockDeleteTag.RLock()
+	calls = mock.calls.DeleteTag
+	mock.lockDeleteTag.RUnlock()
+	return calls
+}
+
+// Ensure, that TagAttacherMock does implement TagAttacher.
+// If this is not the case, regenerate this file with moq.
+var _ TagAttacher = &TagAttacherMock{}
+
+// TagAttacherMock is a mock implementation of TagAttacher.
+//
+//	func TestSomethingThatUsesTagAttacher(t *testing.T) {
+//
+//		// make and configure a mocked TagAttacher
+//		mockedTagAttacher := &TagAttacherMock{
+//			AttachTagFunc: func(ctx context.Context, db store.Execer, uid entity.UserID, taskID entity.TaskID, tagID entity.TagID) error {
+//				panic("mock out the AttachTag method")
+//			},
+//		}
+//
+//		// use mockedTagAttacher in code that requires TagAttacher
+//		// and then make assertions.
+//
+//	}
+type TagAttacherMock struct {
+	// AttachTagFunc mocks the AttachTag method.
+	AttachTagFunc func(ctx context.Context, db store.Execer, uid entity.UserID, taskID entity.TaskID, tagID entity.TagID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AttachTag holds details about calls to the AttachTag method.
+		AttachTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// TaskID is the taskID argument value.
+			TaskID entity.TaskID
+			// TagID is the tagID argument value.
+			TagID entity.TagID
+		}
+	}
+	lockAttachTag sync.RWMutex
+}
+
+// AttachTag calls AttachTagFunc.
+func (mock *TagAttacherMock) AttachTag(ctx context.Context, db store.Execer, uid entity.UserID, taskID entity.TaskID, tagID entity.TagID) error {
+	if mock.AttachTagFunc == nil {
+		panic("TagAttacherMock.AttachTagFunc: method is nil but TagAttacher.AttachTag was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UID    entity.UserID
+		TaskID entity.TaskID
+		TagID  entity.TagID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UID:    uid,
+		TaskID: taskID,
+		TagID:  tagID,
+	}
+	mock.lockAttachTag.Lock()
+	mock.calls.AttachTag = append(mock.calls.AttachTag, callInfo)
+	mock.lockAttachTag.Unlock()
+	return mock.AttachTagFunc(ctx, db, uid, taskID, tagID)
+}
+
+// AttachTagCalls gets all the calls that were made to AttachTag.
+// Check the length with:
+//
+//	len(mockedTagAttacher.AttachTagCalls())
+func (mock *TagAttacherMock) AttachTagCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	UID    entity.UserID
+	TaskID entity.TaskID
+	TagID  entity.TagID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UID    entity.UserID
+		TaskID entity.TaskID
+		TagID  entity.TagID
+	}
+	mock.lockAttachTag.RLock()
+	calls = mock.calls.AttachTag
+	mock.lockAttachTag.RUnlock()
+	return calls
+}
+
+// Ensure, that TagDetacherMock does implement TagDetacher.
+// If this is not the case, regenerate this file with moq.
+var _ TagDetacher = &TagDetacherMock{}
+
+// TagDetacherMock is a mock implementation of TagDetacher.
+//
+//	func TestSomethingThatUsesTagDetacher(t *testing.T) {
+//
+//		// make and configure a mocked TagDetacher
+//		mockedTagDetacher := &TagDetacherMock{
+//			DetachTagFunc: func(ctx context.Context, db store.Execer, uid entity.UserID, taskID entity.TaskID, tagID entity.TagID) error {
+//				panic("mock out the DetachTag method")
+//			},
+//		}
+//
+//		// use mockedTagDetacher in code that requires TagDetacher
+//		// and then make assertions.
+//
+//	}
+type TagDetacherMock struct {
+	// DetachTagFunc mocks the DetachTag method.
+	DetachTagFunc func(ctx context.Context, db store.Execer, uid entity.UserID, taskID entity.TaskID, tagID entity.TagID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DetachTag holds details about calls to the DetachTag method.
+		DetachTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// TaskID is the taskID argument value.
+			TaskID entity.TaskID
+			// TagID is the tagID argument value.
+			TagID entity.TagID
+		}
+	}
+	lockDetachTag sync.RWMutex
+}
+
+// DetachTag calls DetachTagFunc.
+func (mock *TagDetacherMock) DetachTag(ctx context.Context, db store.Execer, uid entity.UserID, taskID entity.TaskID, tagID entity.TagID) error {
+	if mock.DetachTagFunc == nil {
+		panic("TagDetacherMock.DetachTagFunc: method is nil but TagDetacher.DetachTag was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UID    entity.UserID
+		TaskID entity.TaskID
+		TagID  entity.TagID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UID:    uid,
+		TaskID: taskID,
+		TagID:  tagID,
+	}
+	mock.lockDetachTag.Lock()
+	mock.calls.DetachTag = append(mock.calls.DetachTag, callInfo)
+	mock.lockDetachTag.Unlock()
+	return mock.DetachTagFunc(ctx, db, uid, taskID, tagID)
+}
+
+// DetachTagCalls gets all the calls that were made to DetachTag.
+// Check the length with:
+//
+//	len(mockedTagDetacher.DetachTagCalls())
+func (mock *TagDetacherMock) DetachTagCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	UID    entity.UserID
+	TaskID entity.TaskID
+	TagID  entity.TagID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UID    entity.UserID
+		TaskID entity.TaskID
+		TagID  entity.TagID
+	}
+	mock.lockDetachTag.RLock()
+	calls = mock.calls.DetachTag
+	mock.lockDetachTag.RUnlock()
+	return calls
+}
+
+// Ensure, that ProjectAdderMock does implement ProjectAdder.
+// If this is not the case, regenerate this file with moq.
+var _ ProjectAdder = &ProjectAdderMock{}
+
+// ProjectAdderMock is a mock implementation of ProjectAdder.
+//
+//	func TestSomethingThatUsesProjectAdder(t *testing.T) {
+//
+//		// make and configure a mocked ProjectAdder
+//		mockedProjectAdder := &ProjectAdderMock{
+//			AddProjectFunc: func(ctx context.Context, db store.Execer, p *entity.Project) error {
+//				panic("mock out the AddProject method")
+//			},
+//		}
+//
+//		// use mockedProjectAdder in code that requires ProjectAdder
+//		// and then make assertions.
+//
+//	}
+type ProjectAdderMock struct {
+	// AddProjectFunc mocks the AddProject method.
+	AddProjectFunc func(ctx context.Context, db store.Execer, p *entity.Project) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddProject holds details about calls to the AddProject method.
+		AddProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// P is the p argument value.
+			P *entity.Project
+		}
+	}
+	lockAddProject sync.RWMutex
+}
+
+// AddProject calls AddProjectFunc.
+func (mock *ProjectAdderMock) AddProject(ctx context.Context, db store.Execer, p *entity.Project) error {
+	if mock.AddProjectFunc == nil {
+		panic("ProjectAdderMock.AddProjectFunc: method is nil but ProjectAdder.AddProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		P   *entity.Project
+	}{
+		Ctx: ctx,
+		Db:  db,
+		P:   p,
+	}
+	mock.lockAddProject.Lock()
+	mock.calls.AddProject = append(mock.calls.AddProject, callInfo)
+	mock.lockAddProject.Unlock()
+	return mock.AddProjectFunc(ctx, db, p)
+}
+
+// AddProjectCalls gets all the calls that were made to AddProject.
+// Check the length with:
+//
+//	len(mockedProjectAdder.AddProjectCalls())
+func (mock *ProjectAdderMock) AddProjectCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	P   *entity.Project
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		P   *entity.Project
+	}
+	mock.lockAddProject.RLock()
+	calls = mock.calls.AddProject
+	mock.lockAddProject.RUnlock()
+	return calls
+}
+
+// Ensure, that ProjectListerMock does implement ProjectLister.
+// If this is not the case, regenerate this file with moq.
+var _ ProjectLister = &ProjectListerMock{}
+
+// ProjectListerMock is a mock implementation of ProjectLister.
+//
+//	func TestSomethingThatUsesProjectLister(t *testing.T) {
+//
+//		// make and configure a mocked ProjectLister
+//		mockedProjectLister := &ProjectListerMock{
+//			ListProjectsFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, includeArchived bool) (entity.Projects, error) {
+//				panic("mock out the ListProjects method")
+//			},
+//		}
+//
+//		// use mockedProjectLister in code that requires ProjectLister
+//		// and then make assertions.
+//
+//	}
+type ProjectListerMock struct {
+	// ListProjectsFunc mocks the ListProjects method.
+	ListProjectsFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, includeArchived bool) (entity.Projects, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListProjects holds details about calls to the ListProjects method.
+		ListProjects []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// IncludeArchived is the includeArchived argument value.
+			IncludeArchived bool
+		}
+	}
+	lockListProjects sync.RWMutex
+}
+
+// ListProjects calls ListProjectsFunc.
+func (mock *ProjectListerMock) ListProjects(ctx context.Context, db store.Queryer, uid entity.UserID, includeArchived bool) (entity.Projects, error) {
+	if mock.ListProjectsFunc == nil {
+		panic("ProjectListerMock.ListProjectsFunc: method is nil but ProjectLister.ListProjects was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		Db              store.Queryer
+		UID             entity.UserID
+		IncludeArchived bool
+	}{
+		Ctx:             ctx,
+		Db:              db,
+		UID:             uid,
+		IncludeArchived: includeArchived,
+	}
+	mock.lockListProjects.Lock()
+	mock.calls.ListProjects = append(mock.calls.ListProjects, callInfo)
+	mock.lockListProjects.Unlock()
+	return mock.ListProjectsFunc(ctx, db, uid, includeArchived)
+}
+
+// ListProjectsCalls gets all the calls that were made to ListProjects.
+// Check the length with:
+//
+//	len(mockedProjectLister.ListProjectsCalls())
+func (mock *ProjectListerMock) ListProjectsCalls() []struct {
+	Ctx             context.Context
+	Db              store.Queryer
+	UID             entity.UserID
+	IncludeArchived bool
+} {
+	var calls []struct {
+		Ctx             context.Context
+		Db              store.Queryer
+		UID             entity.UserID
+		IncludeArchived bool
+	}
+	mock.lockListProjects.RLock()
+	calls = mock.calls.ListProjects
+	mock.lockListProjects.RUnlock()
+	return calls
+}
+
+// Ensure, that ProjectGetterMock does implement ProjectGetter.
+// If this is not the case, regenerate this file with moq.
+var _ ProjectGetter = &ProjectGetterMock{}
+
+// ProjectGetterMock is a mock implementation of ProjectGetter.
+//
+//	func TestSomethingThatUsesProjectGetter(t *testing.T) {
+//
+//		// make and configure a mocked ProjectGetter
+//		mockedProjectGetter := &ProjectGetterMock{
+//			GetProjectFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error) {
+//				panic("mock out the GetProject method")
+//			},
+//		}
+//
+//		// use mockedProjectGetter in code that requires ProjectGetter
+//		// and then make assertions.
+//
+//	}
+type ProjectGetterMock struct {
+	// GetProjectFunc mocks the GetProject method.
+	GetProjectFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetProject holds details about calls to the GetProject method.
+		GetProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.ProjectID
+		}
+	}
+	lockGetProject sync.RWMutex
+}
+
+// GetProject calls GetProjectFunc.
+func (mock *ProjectGetterMock) GetProject(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error) {
+	if mock.GetProjectFunc == nil {
+		panic("ProjectGetterMock.GetProjectFunc: method is nil but ProjectGetter.GetProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.ProjectID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockGetProject.Lock()
+	mock.calls.GetProject = append(mock.calls.GetProject, callInfo)
+	mock.lockGetProject.Unlock()
+	return mock.GetProjectFunc(ctx, db, uid, id)
+}
+
+// GetProjectCalls gets all the calls that were made to GetProject.
+// Check the length with:
+//
+//	len(mockedProjectGetter.GetProjectCalls())
+func (mock *ProjectGetterMock) GetProjectCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.ProjectID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.ProjectID
+	}
+	mock.lockGetProject.RLock()
+	calls = mock.calls.GetProject
+	mock.lockGetProject.RUnlock()
+	return calls
+}
+
+// Ensure, that ProjectUpdaterMock does implement ProjectUpdater.
+// If this is not the case, regenerate this file with moq.
+var _ ProjectUpdater = &ProjectUpdaterMock{}
+
+// ProjectUpdaterMock is a mock implementation of ProjectUpdater.
+//
+//	func TestSomethingThatUsesProjectUpdater(t *testing.T) {
+//
+//		// make and configure a mocked ProjectUpdater
+//		mockedProjectUpdater := &ProjectUpdaterMock{
+//			GetProjectFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error) {
+//				panic("mock out the GetProject method")
+//			},
+//			UpdateProjectFunc: func(ctx context.Context, db store.Execer, p *entity.Project) error {
+//				panic("mock out the UpdateProject method")
+//			},
+//		}
+//
+//		// use mockedProjectUpdater in code that requires ProjectUpdater
+//		// and then make assertions.
+//
+//	}
+type ProjectUpdaterMock struct {
+	// GetProjectFunc mocks the GetProject method.
+	GetProjectFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error)
+
+	// UpdateProjectFunc mocks the UpdateProject method.
+	UpdateProjectFunc func(ctx context.Context, db store.Execer, p *entity.Project) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetProject holds details about calls to the GetProject method.
+		GetProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.ProjectID
+		}
+		// UpdateProject holds details about calls to the UpdateProject method.
+		UpdateProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// P is the p argument value.
+			P *entity.Project
+		}
+	}
+	lockGetProject    sync.RWMutex
+	lockUpdateProject sync.RWMutex
+}
+
+// GetProject calls GetProjectFunc.
+func (mock *ProjectUpdaterMock) GetProject(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error) {
+	if mock.GetProjectFunc == nil {
+		panic("ProjectUpdaterMock.GetProjectFunc: method is nil but ProjectUpdater.GetProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.ProjectID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockGetProject.Lock()
+	mock.calls.GetProject = append(mock.calls.GetProject, callInfo)
+	mock.lockGetProject.Unlock()
+	return mock.GetProjectFunc(ctx, db, uid, id)
+}
+
+// GetProjectCalls gets all the calls that were made to GetProject.
+// Check the length with:
+//
+//	len(mockedProjectUpdater.GetProjectCalls())
+func (mock *ProjectUpdaterMock) GetProjectCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.ProjectID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.ProjectID
+	}
+	mock.lockGetProject.RLock()
+	calls = mock.calls.GetProject
+	mock.lockGetProject.RUnlock()
+	return calls
+}
+
+// UpdateProject calls UpdateProjectFunc.
+func (mock *ProjectUpdaterMock) UpdateProject(ctx context.Context, db store.Execer, p *entity.Project) error {
+	if mock.UpdateProjectFunc == nil {
+		panic("ProjectUpdaterMock.UpdateProjectFunc: method is nil but ProjectUpdater.UpdateProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		P   *entity.Project
+	}{
+		Ctx: ctx,
+		Db:  db,
+		P:   p,
+	}
+	mock.lockUpdateProject.Lock()
+	mock.calls.UpdateProject = append(mock.calls.UpdateProject, callInfo)
+	mock.lockUpdateProject.Unlock()
+	return mock.UpdateProjectFunc(ctx, db, p)
+}
+
+// UpdateProjectCalls gets all the calls that were made to UpdateProject.
+// Check the length with:
+//
+//	len(mockedProjectUpdater.UpdateProjectCalls())
+func (mock *ProjectUpdaterMock) UpdateProjectCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	P   *entity.Project
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		P   *entity.Project
+	}
+	mock.lockUpdateProject.RLock()
+	calls = mock.calls.UpdateProject
+	mock.lockUpdateProject.RUnlock()
+	return calls
+}
+
+// Ensure, that ProjectArchiverMock does implement ProjectArchiver.
+// If this is not the case, regenerate this file with moq.
+var _ ProjectArchiver = &ProjectArchiverMock{}
+
+// ProjectArchiverMock is a mock implementation of ProjectArchiver.
+//
+//	func TestSomethingThatUsesProjectArchiver(t *testing.T) {
+//
+//		// make and configure a mocked ProjectArchiver
+//		mockedProjectArchiver := &ProjectArchiverMock{
+//			ArchiveProjectFunc: func(ctx context.Context, db store.Execer, uid entity.UserID, id entity.ProjectID, archived bool) error {
+//				panic("mock out the ArchiveProject method")
+//			},
+//			GetProjectFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error) {
+//				panic("mock out the GetProject method")
+//			},
+//		}
+//
+//		// use mockedProjectArchiver in code that requires ProjectArchiver
+//		// and then make assertions.
+//
+//	}
+type ProjectArchiverMock struct {
+	// ArchiveProjectFunc mocks the ArchiveProject method.
+	ArchiveProjectFunc func(ctx context.Context, db store.Execer, uid entity.UserID, id entity.ProjectID, archived bool) error
+
+	// GetProjectFunc mocks the GetProject method.
+	GetProjectFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ArchiveProject holds details about calls to the ArchiveProject method.
+		ArchiveProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.ProjectID
+			// Archived is the archived argument value.
+			Archived bool
+		}
+		// GetProject holds details about calls to the GetProject method.
+		GetProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.ProjectID
+		}
+	}
+	lockArchiveProject sync.RWMutex
+	lockGetProject     sync.RWMutex
+}
+
+// ArchiveProject calls ArchiveProjectFunc.
+func (mock *ProjectArchiverMock) ArchiveProject(ctx context.Context, db store.Execer, uid entity.UserID, id entity.ProjectID, archived bool) error {
+	if mock.ArchiveProjectFunc == nil {
+		panic("ProjectArchiverMock.ArchiveProjectFunc: method is nil but ProjectArchiver.ArchiveProject was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Db       store.Execer
+		UID      entity.UserID
+		ID       entity.ProjectID
+		Archived bool
+	}{
+		Ctx:      ctx,
+		Db:       db,
+		UID:      uid,
+		ID:       id,
+		Archived: archived,
+	}
+	mock.lockArchiveProject.Lock()
+	mock.calls.ArchiveProject = append(mock.calls.ArchiveProject, callInfo)
+	mock.lockArchiveProject.Unlock()
+	return mock.ArchiveProjectFunc(ctx, db, uid, id, archived)
+}
+
+// ArchiveProjectCalls gets all the calls that were made to ArchiveProject.
+// Check the length with:
+//
+//	len(mockedProjectArchiver.ArchiveProjectCalls())
+func (mock *ProjectArchiverMock) ArchiveProjectCalls() []struct {
+	Ctx      context.Context
+	Db       store.Execer
+	UID      entity.UserID
+	ID       entity.ProjectID
+	Archived bool
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Db       store.Execer
+		UID      entity.UserID
+		ID       entity.ProjectID
+		Archived bool
+	}
+	mock.lockArchiveProject.RLock()
+	calls = mock.calls.ArchiveProject
+	mock.lockArchiveProject.RUnlock()
+	return calls
+}
+
+// GetProject calls GetProjectFunc.
+func (mock *ProjectArchiverMock) GetProject(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error) {
+	if mock.GetProjectFunc == nil {
+		panic("ProjectArchiverMock.GetProjectFunc: method is nil but ProjectArchiver.GetProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.ProjectID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockGetProject.Lock()
+	mock.calls.GetProject = append(mock.calls.GetProject, callInfo)
+	mock.lockGetProject.Unlock()
+	return mock.GetProjectFunc(ctx, db, uid, id)
+}
+
+// GetProjectCalls gets all the calls that were made to GetProject.
+// Check the length with:
+//
+//	len(mockedProjectArchiver.GetProjectCalls())
+func (mock *ProjectArchiverMock) GetProjectCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.ProjectID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.ProjectID
+	}
+	mock.lockGetProject.RLock()
+	calls = mock.calls.GetProject
+	mock.lockGetProject.RUnlock()
+	return calls
+}
+
+// Ensure, that ProjectDeleterMock does implement ProjectDeleter.
+// If this is not the case, regenerate this file with moq.
+var _ ProjectDeleter = &ProjectDeleterMock{}
+
+// ProjectDeleterMock is a mock implementation of ProjectDeleter.
+//
+//	func TestSomethingThatUsesProjectDeleter(t *testing.T) {
+//
+//		// make and configure a mocked ProjectDeleter
+//		mockedProjectDeleter := &ProjectDeleterMock{
+//			DeleteProjectFunc: func(ctx context.Context, db store.Execer, uid entity.UserID, id entity.ProjectID) error {
+//				panic("mock out the DeleteProject method")
+//			},
+//		}
+//
+//		// use mockedProjectDeleter in code that requires ProjectDeleter
+//		// and then make assertions.
+//
+//	}
+type ProjectDeleterMock struct {
+	// DeleteProjectFunc mocks the DeleteProject method.
+	DeleteProjectFunc func(ctx context.Context, db store.Execer, uid entity.UserID, id entity.ProjectID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteProject holds details about calls to the DeleteProject method.
+		DeleteProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.ProjectID
+		}
+	}
+	lockDeleteProject sync.RWMutex
+}
+
+// DeleteProject calls DeleteProjectFunc.
+func (mock *ProjectDeleterMock) DeleteProject(ctx context.Context, db store.Execer, uid entity.UserID, id entity.ProjectID) error {
+	if mock.DeleteProjectFunc == nil {
+		panic("ProjectDeleterMock.DeleteProjectFunc: method is nil but ProjectDeleter.DeleteProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		UID entity.UserID
+		ID  entity.ProjectID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockDeleteProject.Lock()
+	mock.calls.DeleteProject = append(mock.calls.DeleteProject, callInfo)
+	mock.lockDeleteProject.Unlock()
+	return mock.DeleteProjectFunc(ctx, db, uid, id)
+}
+
+// DeleteProjectCalls gets all the calls that were made to DeleteProject.
+// Check the length with:
+//
+//	len(mockedProjectDeleter.DeleteProjectCalls())
+func (mock *ProjectDeleterMock) DeleteProjectCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	UID entity.UserID
+	ID  entity.ProjectID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		UID entity.UserID
+		ID  entity.ProjectID
+	}
+	mock.lockDeleteProject.RLock()
+	calls = mock.calls.DeleteProject
+	mock.lockDeleteProject.RUnlock()
+	return calls
+}
+
+// Ensure, that ProjectTaskListerMock does implement ProjectTaskLister.
+// If this is not the case, regenerate this file with moq.
+var _ ProjectTaskLister = &ProjectTaskListerMock{}
+
+// ProjectTaskListerMock is a mock implementation of ProjectTaskLister.
+//
+//	func TestSomethingThatUsesProjectTaskLister(t *testing.T) {
+//
+//		// make and configure a mocked ProjectTaskLister
+//		mockedProjectTaskLister := &ProjectTaskListerMock{
+//			GetProjectFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error) {
+//				panic("mock out the GetProject method")
+//			},
+//			ListTasksFunc: func(ctx context.Context, db store.Queryer, id entity.UserID, f *entity.TaskFilter) (entity.Tasks, string, error) {
+//				panic("mock out the ListTasks method")
+//			},
+//		}
+//
+//		// use mockedProjectTaskLister in code that requires ProjectTaskLister
+//		// and then make assertions.
+//
+//	}
+type ProjectTaskListerMock struct {
+	// GetProjectFunc mocks the GetProject method.
+	GetProjectFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error)
+
+	// ListTasksFunc mocks the ListTasks method.
+	ListTasksFunc func(ctx context.Context, db store.Queryer, id entity.UserID, f *entity.TaskFilter) (entity.Tasks, string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetProject holds details about calls to the GetProject method.
+		GetProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.ProjectID
+		}
+		// ListTasks holds details about calls to the ListTasks method.
+		ListTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+			// F is the f argument value.
+			F *entity.TaskFilter
+		}
+	}
+	lockGetProject sync.RWMutex
+	lockListTasks  sync.RWMutex
+}
+
+// GetProject calls GetProjectFunc.
+func (mock *ProjectTaskListerMock) GetProject(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error) {
+	if mock.GetProjectFunc == nil {
+		panic("ProjectTaskListerMock.GetProjectFunc: method is nil but ProjectTaskLister.GetProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.ProjectID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockGetProject.Lock()
+	mock.calls.GetProject = append(mock.calls.GetProject, callInfo)
+	mock.lockGetProject.Unlock()
+	return mock.GetProjectFunc(ctx, db, uid, id)
+}
+
+// GetProjectCalls gets all the calls that were made to GetProject.
+// Check the length with:
+//
+//	len(mockedProjectTaskLister.GetProjectCalls())
+func (mock *ProjectTaskListerMock) GetProjectCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+	ID  entity.ProjectID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+		ID  entity.ProjectID
+	}
+	mock.lockGetProject.RLock()
+	calls = mock.calls.GetProject
+	mock.lockGetProject.RUnlock()
+	return calls
+}
+
+// ListTasks calls ListTasksFunc.
+func (mock *ProjectTaskListerMock) ListTasks(ctx context.Context, db store.Queryer, id entity.UserID, f *entity.TaskFilter) (entity.Tasks, string, error) {
+	if mock.ListTasksFunc == nil {
+		panic("ProjectTaskListerMock.ListTasksFunc: method is nil but ProjectTaskLister.ListTasks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+		F   *entity.TaskFilter
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+		F:   f,
+	}
+	mock.lockListTasks.Lock()
+	mock.calls.ListTasks = append(mock.calls.ListTasks, callInfo)
+	mock.lockListTasks.Unlock()
+	return mock.ListTasksFunc(ctx, db, id, f)
+}
+
+// ListTasksCalls gets all the calls that were made to ListTasks.
+// Check the length with:
+//
+//	len(mockedProjectTaskLister.ListTasksCalls())
+func (mock *ProjectTaskListerMock) ListTasksCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+	F   *entity.TaskFilter
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+		F   *entity.TaskFilter
+	}
+	mock.lockListTasks.RLock()
+	calls = mock.calls.ListTasks
+	mock.lockListTasks.RUnlock()
+	return calls
+}
+
+// Ensure, that UserRegisterMock does implement UserRegister.
+// If this is not the case, regenerate this file with moq.
+var _ UserRegister = &UserRegisterMock{}
+
+// UserRegisterMock is a mock implementation of UserRegister.
+//
+//	func TestSomethingThatUsesUserRegister(t *testing.T) {
+//
+//		// make and configure a mocked UserRegister
+//		mockedUserRegister := &UserRegisterMock{
+//			RegisterUserFunc: func(ctx context.Context, db store.Execer, u *entity.User) error {
+//				panic("mock out the RegisterUser method")
+//			},
+//		}
+//
+//		// use mockedUserRegister in code that requires UserRegister
+//		// and then make assertions.
+//
+//	}
+type UserRegisterMock struct {
+	// RegisterUserFunc mocks the RegisterUser method.
+	RegisterUserFunc func(ctx context.Context, db store.Execer, u *entity.User) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RegisterUser holds details about calls to the RegisterUser method.
+		RegisterUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// U is the u argument value.
+			U *entity.User
+		}
+	}
+	lockRegisterUser sync.RWMutex
+}
+
+// RegisterUser calls RegisterUserFunc.
+func (mock *UserRegisterMock) RegisterUser(ctx context.Context, db store.Execer, u *entity.User) error {
+	if mock.RegisterUserFunc == nil {
+		panic("UserRegisterMock.RegisterUserFunc: method is nil but UserRegister.RegisterUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}{
+		Ctx: ctx,
+		Db:  db,
+		U:   u,
+	}
+	mock.lockRegisterUser.Lock()
+	mock.calls.RegisterUser = append(mock.calls.RegisterUser, callInfo)
+	mock.lockRegisterUser.Unlock()
+	return mock.RegisterUserFunc(ctx, db, u)
+}
+
+// RegisterUserCalls gets all the calls that were made to RegisterUser.
+// Check the length with:
+//
+//	len(mockedUserRegister.RegisterUserCalls())
+func (mock *UserRegisterMock) RegisterUserCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	U   *entity.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}
+	mock.lockRegisterUser.RLock()
+	calls = mock.calls.RegisterUser
+	mock.lockRegisterUser.RUnlock()
+	return calls
+}
+
+// Ensure, that UserAuthenticatorMock does implement UserAuthenticator.
+// If this is not the case, regenerate this file with moq.
+var _ UserAuthenticator = &UserAuthenticatorMock{}
+
+// UserAuthenticatorMock is a mock implementation of UserAuthenticator.
+//
+//	func TestSomethingThatUsesUserAuthenticator(t *testing.T) {
+//
+//		// make and configure a mocked UserAuthenticator
+//		mockedUserAuthenticator := &UserAuthenticatorMock{
+//			GetUserFunc: func(ctx context.Context, db store.Queryer, name string) (*entity.User, error) {
+//				panic("mock out the GetUser method")
+//			},
+//			UpdatePasswordFunc: func(ctx context.Context, db store.Execer, u *entity.User) error {
+//				panic("mock out the UpdatePassword method")
+//			},
+//		}
+//
+//		// use mockedUserAuthenticator in code that requires UserAuthenticator
+//		// and then make assertions.
+//
+//	}
+type UserAuthenticatorMock struct {
+	// GetUserFunc mocks the GetUser method.
+	GetUserFunc func(ctx context.Context, db store.Queryer, name string) (*entity.User, error)
+
+	// UpdatePasswordFunc mocks the UpdatePassword method.
+	UpdatePasswordFunc func(ctx context.Context, db store.Execer, u *entity.User) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUser holds details about calls to the GetUser method.
+		GetUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Name is the name argument value.
+			Name string
+		}
+		// UpdatePassword holds details about calls to the UpdatePassword method.
+		UpdatePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// U is the u argument value.
+			U *entity.User
+		}
+	}
+	lockGetUser        sync.RWMutex
+	lockUpdatePassword sync.RWMutex
+}
+
+// GetUser calls GetUserFunc.
+func (mock *UserAuthenticatorMock) GetUser(ctx context.Context, db store.Queryer, name string) (*entity.User, error) {
+	if mock.GetUserFunc == nil {
+		panic("UserAuthenticatorMock.GetUserFunc: method is nil but UserAuthenticator.GetUser was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Db   store.Queryer
+		Name string
+	}{
+		Ctx:  ctx,
+		Db:   db,
+		Name: name,
+	}
+	mock.lockGetUser.Lock()
+	mock.calls.GetUser = append(mock.calls.GetUser, callInfo)
+	mock.lockGetUser.Unlock()
+	return mock.GetUserFunc(ctx, db, name)
+}
+
+// GetUserCalls gets all the calls that were made to GetUser.
+// Check the length with:
+//
+//	len(mockedUserAuthenticator.GetUserCalls())
+func (mock *UserAuthenticatorMock) GetUserCalls() []struct {
+	Ctx  context.Context
+	Db   store.Queryer
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Db   store.Queryer
+		Name string
+	}
+	mock.lockGetUser.RLock()
+	calls = mock.calls.GetUser
+	mock.lockGetUser.RUnlock()
+	return calls
+}
+
+// UpdatePassword calls UpdatePasswordFunc.
+func (mock *UserAuthenticatorMock) UpdatePassword(ctx context.Context, db store.Execer, u *entity.User) error {
+	if mock.UpdatePasswordFunc == nil {
+		panic("UserAuthenticatorMock.UpdatePasswordFunc: method is nil but UserAuthenticator.UpdatePassword was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}{
+		Ctx: ctx,
+		Db:  db,
+		U:   u,
+	}
+	mock.lockUpdatePassword.Lock()
+	mock.calls.UpdatePassword = append(mock.calls.UpdatePassword, callInfo)
+	mock.lockUpdatePassword.Unlock()
+	return mock.UpdatePasswordFunc(ctx, db, u)
+}
+
+// UpdatePasswordCalls gets all the calls that were made to UpdatePassword.
+// Check the length with:
+//
+//	len(mockedUserAuthenticator.UpdatePasswordCalls())
+func (mock *UserAuthenticatorMock) UpdatePasswordCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	U   *entity.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}
+	mock.lockUpdatePassword.RLock()
+	calls = mock.calls.UpdatePassword
+	mock.lockUpdatePassword.RUnlock()
+	return calls
+}
+
+// Ensure, that UserPasswordUpdaterMock does implement UserPasswordUpdater.
+// If this is not the case, regenerate this file with moq.
+var _ UserPasswordUpdater = &UserPasswordUpdaterMock{}
+
+// UserPasswordUpdaterMock is a mock implementation of UserPasswordUpdater.
+//
+//	func TestSomethingThatUsesUserPasswordUpdater(t *testing.T) {
+//
+//		// make and configure a mocked UserPasswordUpdater
+//		mockedUserPasswordUpdater := &UserPasswordUpdaterMock{
+//			DeletePersonalAccessTokensFunc: func(ctx context.Context, db store.Execer, uid entity.UserID) error {
+//				panic("mock out the DeletePersonalAccessTokens method")
+//			},
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//			UpdatePasswordFunc: func(ctx context.Context, db store.Execer, u *entity.User) error {
+//				panic("mock out the UpdatePassword method")
+//			},
+//		}
+//
+//		// use mockedUserPasswordUpdater in code that requires UserPasswordUpdater
+//		// and then make assertions.
+//
+//	}
+type UserPasswordUpdaterMock struct {
+	// DeletePersonalAccessTokensFunc mocks the DeletePersonalAccessTokens method.
+	DeletePersonalAccessTokensFunc func(ctx context.Context, db store.Execer, uid entity.UserID) error
+
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// UpdatePasswordFunc mocks the UpdatePassword method.
+	UpdatePasswordFunc func(ctx context.Context, db store.Execer, u *entity.User) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeletePersonalAccessTokens holds details about calls to the DeletePersonalAccessTokens method.
+		DeletePersonalAccessTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UID is the uid argument value.
+			UID entity.UserID
+		}
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+		// UpdatePassword holds details about calls to the UpdatePassword method.
+		UpdatePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// U is the u argument value.
+			U *entity.User
+		}
+	}
+	lockDeletePersonalAccessTokens sync.RWMutex
+	lockGetUserByID                sync.RWMutex
+	lockUpdatePassword             sync.RWMutex
+}
+
+// DeletePersonalAccessTokens calls DeletePersonalAccessTokensFunc.
+func (mock *UserPasswordUpdaterMock) DeletePersonalAccessTokens(ctx context.Context, db store.Execer, uid entity.UserID) error {
+	if mock.DeletePersonalAccessTokensFunc == nil {
+		panic("UserPasswordUpdaterMock.DeletePersonalAccessTokensFunc: method is nil but UserPasswordUpdater.DeletePersonalAccessTokens was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		UID entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+	}
+	mock.lockDeletePersonalAccessTokens.Lock()
+	mock.calls.DeletePersonalAccessTokens = append(mock.calls.DeletePersonalAccessTokens, callInfo)
+	mock.lockDeletePersonalAccessTokens.Unlock()
+	return mock.DeletePersonalAccessTokensFunc(ctx, db, uid)
+}
+
+// DeletePersonalAccessTokensCalls gets all the calls that were made to DeletePersonalAccessTokens.
+// Check the length with:
+//
+//	len(mockedUserPasswordUpdater.DeletePersonalAccessTokensCalls())
+func (mock *UserPasswordUpdaterMock) DeletePersonalAccessTokensCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	UID entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		UID entity.UserID
+	}
+	mock.lockDeletePersonalAccessTokens.RLock()
+	calls = mock.calls.DeletePersonalAccessTokens
+	mock.lockDeletePersonalAccessTokens.RUnlock()
+	return calls
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *UserPasswordUpdaterMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("UserPasswordUpdaterMock.GetUserByIDFunc: method is nil but UserPasswordUpdater.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedUserPasswordUpdater.GetUserByIDCalls())
+func (mock *UserPasswordUpdaterMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// UpdatePassword calls UpdatePasswordFunc.
+func (mock *UserPasswordUpdaterMock) UpdatePassword(ctx context.Context, db store.Execer, u *entity.User) error {
+	if mock.UpdatePasswordFunc == nil {
+		panic("UserPasswordUpdaterMock.UpdatePasswordFunc: method is nil but UserPasswordUpdater.UpdatePassword was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}{
+		Ctx: ctx,
+		Db:  db,
+		U:   u,
+	}
+	mock.lockUpdatePassword.Lock()
+	mock.calls.UpdatePassword = append(mock.calls.UpdatePassword, callInfo)
+	mock.lockUpdatePassword.Unlock()
+	return mock.UpdatePasswordFunc(ctx, db, u)
+}
+
+// UpdatePasswordCalls gets all the calls that were made to UpdatePassword.
+// Check the length with:
+//
+//	len(mockedUserPasswordUpdater.UpdatePasswordCalls())
+func (mock *UserPasswordUpdaterMock) UpdatePasswordCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	U   *entity.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}
+	mock.lockUpdatePassword.RLock()
+	calls = mock.calls.UpdatePassword
+	mock.lockUpdatePassword.RUnlock()
+	return calls
+}
+
+// Ensure, that PasswordResetRequirerMock does implement PasswordResetRequirer.
+// If this is not the case, regenerate this file with moq.
+var _ PasswordResetRequirer = &PasswordResetRequirerMock{}
+
+// PasswordResetRequirerMock is a mock implementation of PasswordResetRequirer.
+//
+//	func TestSomethingThatUsesPasswordResetRequirer(t *testing.T) {
+//
+//		// make and configure a mocked PasswordResetRequirer
+//		mockedPasswordResetRequirer := &PasswordResetRequirerMock{
+//			DeletePersonalAccessTokensFunc: func(ctx context.Context, db store.Execer, uid entity.UserID) error {
+//				panic("mock out the DeletePersonalAccessTokens method")
+//			},
+//			RequirePasswordResetFunc: func(ctx context.Context, db store.Execer, id entity.UserID) error {
+//				panic("mock out the RequirePasswordReset method")
+//			},
+//		}
+//
+//		// use mockedPasswordResetRequirer in code that requires PasswordResetRequirer
+//		// and then make assertions.
+//
+//	}
+type PasswordResetRequirerMock struct {
+	// DeletePersonalAccessTokensFunc mocks the DeletePersonalAccessTokens method.
+	DeletePersonalAccessTokensFunc func(ctx context.Context, db store.Execer, uid entity.UserID) error
+
+	// RequirePasswordResetFunc mocks the RequirePasswordReset method.
+	RequirePasswordResetFunc func(ctx context.Context, db store.Execer, id entity.UserID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeletePersonalAccessTokens holds details about calls to the DeletePersonalAccessTokens method.
+		DeletePersonalAccessTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UID is the uid argument value.
+			UID entity.UserID
+		}
+		// RequirePasswordReset holds details about calls to the RequirePasswordReset method.
+		RequirePasswordReset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+	}
+	lockDeletePersonalAccessTokens sync.RWMutex
+	lockRequirePasswordReset       sync.RWMutex
+}
+
+// DeletePersonalAccessTokens calls DeletePersonalAccessTokensFunc.
+func (mock *PasswordResetRequirerMock) DeletePersonalAccessTokens(ctx context.Context, db store.Execer, uid entity.UserID) error {
+	if mock.DeletePersonalAccessTokensFunc == nil {
+		panic("PasswordResetRequirerMock.DeletePersonalAccessTokensFunc: method is nil but PasswordResetRequirer.DeletePersonalAccessTokens was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		UID entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+	}
+	mock.lockDeletePersonalAccessTokens.Lock()
+	mock.calls.DeletePersonalAccessTokens = append(mock.calls.DeletePersonalAccessTokens, callInfo)
+	mock.lockDeletePersonalAccessTokens.Unlock()
+	return mock.DeletePersonalAccessTokensFunc(ctx, db, uid)
+}
+
+// DeletePersonalAccessTokensCalls gets all the calls that were made to DeletePersonalAccessTokens.
+// Check the length with:
+//
+//	len(mockedPasswordResetRequirer.DeletePersonalAccessTokensCalls())
+func (mock *PasswordResetRequirerMock) DeletePersonalAccessTokensCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	UID entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		UID entity.UserID
+	}
+	mock.lockDeletePersonalAccessTokens.RLock()
+	calls = mock.calls.DeletePersonalAccessTokens
+	mock.lockDeletePersonalAccessTokens.RUnlock()
+	return calls
+}
+
+// RequirePasswordReset calls RequirePasswordResetFunc.
+func (mock *PasswordResetRequirerMock) RequirePasswordReset(ctx context.Context, db store.Execer, id entity.UserID) error {
+	if mock.RequirePasswordResetFunc == nil {
+		panic("PasswordResetRequirerMock.RequirePasswordResetFunc: method is nil but PasswordResetRequirer.RequirePasswordReset was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockRequirePasswordReset.Lock()
+	mock.calls.RequirePasswordReset = append(mock.calls.RequirePasswordReset, callInfo)
+	mock.lockRequirePasswordReset.Unlock()
+	return mock.RequirePasswordResetFunc(ctx, db, id)
+}
+
+// RequirePasswordResetCalls gets all the calls that were made to RequirePasswordReset.
+// Check the length with:
+//
+//	len(mockedPasswordResetRequirer.RequirePasswordResetCalls())
+func (mock *PasswordResetRequirerMock) RequirePasswordResetCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		ID  entity.UserID
+	}
+	mock.lockRequirePasswordReset.RLock()
+	calls = mock.calls.RequirePasswordReset
+	mock.lockRequirePasswordReset.RUnlock()
+	return calls
+}
+
+// Ensure, that UserByEmailGetterMock does implement UserByEmailGetter.
+// If this is not the case, regenerate this file with moq.
+var _ UserByEmailGetter = &UserByEmailGetterMock{}
+
+// UserByEmailGetterMock is a mock implementation of UserByEmailGetter.
+//
+//	func TestSomethingThatUsesUserByEmailGetter(t *testing.T) {
+//
+//		// make and configure a mocked UserByEmailGetter
+//		mockedUserByEmailGetter := &UserByEmailGetterMock{
+//			GetUserByEmailFunc: func(ctx context.Context, db store.Queryer, email string) (*entity.User, error) {
+//				panic("mock out the GetUserByEmail method")
+//			},
+//		}
+//
+//		// use mockedUserByEmailGetter in code that requires UserByEmailGetter
+//		// and then make assertions.
+//
+//	}
+type UserByEmailGetterMock struct {
+	// GetUserByEmailFunc mocks the GetUserByEmail method.
+	GetUserByEmailFunc func(ctx context.Context, db store.Queryer, email string) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUserByEmail holds details about calls to the GetUserByEmail method.
+		GetUserByEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Email is the email argument value.
+			Email string
+		}
+	}
+	lockGetUserByEmail sync.RWMutex
+}
+
+// GetUserByEmail calls GetUserByEmailFunc.
+func (mock *UserByEmailGetterMock) GetUserByEmail(ctx context.Context, db store.Queryer, email string) (*entity.User, error) {
+	if mock.GetUserByEmailFunc == nil {
+		panic("UserByEmailGetterMock.GetUserByEmailFunc: method is nil but UserByEmailGetter.GetUserByEmail was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Db    store.Queryer
+		Email string
+	}{
+		Ctx:   ctx,
+		Db:    db,
+		Email: email,
+	}
+	mock.lockGetUserByEmail.Lock()
+	mock.calls.GetUserByEmail = append(mock.calls.GetUserByEmail, callInfo)
+	mock.lockGetUserByEmail.Unlock()
+	return mock.GetUserByEmailFunc(ctx, db, email)
+}
+
+// GetUserByEmailCalls gets all the calls that were made to GetUserByEmail.
+// Check the length with:
+//
+//	len(mockedUserByEmailGetter.GetUserByEmailCalls())
+func (mock *UserByEmailGetterMock) GetUserByEmailCalls() []struct {
+	Ctx   context.Context
+	Db    store.Queryer
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Db    store.Queryer
+		Email string
+	}
+	mock.lockGetUserByEmail.RLock()
+	calls = mock.calls.GetUserByEmail
+	mock.lockGetUserByEmail.RUnlock()
+	return calls
+}
+
+// Ensure, that UserByIDGetterMock does implement UserByIDGetter.
+// If this is not the case, regenerate this file with moq.
+var _ UserByIDGetter = &UserByIDGetterMock{}
+
+// UserByIDGetterMock is a mock implementation of UserByIDGetter.
+//
+//	func TestSomethingThatUsesUserByIDGetter(t *testing.T) {
+//
+//		// make and configure a mocked UserByIDGetter
+//		mockedUserByIDGetter := &UserByIDGetterMock{
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//		}
+//
+//		// use mockedUserByIDGetter in code that requires UserByIDGetter
+//		// and then make assertions.
+//
+//	}
+type UserByIDGetterMock struct {
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+	}
+	lockGetUserByID sync.RWMutex
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *UserByIDGetterMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("UserByIDGetterMock.GetUserByIDFunc: method is nil but UserByIDGetter.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedUserByIDGetter.GetUserByIDCalls())
+func (mock *UserByIDGetterMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// Ensure, that UserListerMock does implement UserLister.
+// If this is not the case, regenerate this file with moq.
+var _ UserLister = &UserListerMock{}
+
+// UserListerMock is a mock implementation of UserLister.
+//
+//	func TestSomethingThatUsesUserLister(t *testing.T) {
+//
+//		// make and configure a mocked UserLister
+//		mockedUserLister := &UserListerMock{
+//			ListUsersFunc: func(ctx context.Context, db store.Queryer, f *entity.UserFilter) (entity.Users, string, error) {
+//				panic("mock out the ListUsers method")
+//			},
+//		}
+//
+//		// use mockedUserLister in code that requires UserLister
+//		// and then make assertions.
+//
+//	}
+type UserListerMock struct {
+	// ListUsersFunc mocks the ListUsers method.
+	ListUsersFunc func(ctx context.Context, db store.Queryer, f *entity.UserFilter) (entity.Users, string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListUsers holds details about calls to the ListUsers method.
+		ListUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// F is the f argument value.
+			F *entity.UserFilter
+		}
+	}
+	lockListUsers sync.RWMutex
+}
+
+// ListUsers calls ListUsersFunc.
+func (mock *UserListerMock) ListUsers(ctx context.Context, db store.Queryer, f *entity.UserFilter) (entity.Users, string, error) {
+	if mock.ListUsersFunc == nil {
+		panic("UserListerMock.ListUsersFunc: method is nil but UserLister.ListUsers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		F   *entity.UserFilter
+	}{
+		Ctx: ctx,
+		Db:  db,
+		F:   f,
+	}
+	mock.lockListUsers.Lock()
+	mock.calls.ListUsers = append(mock.calls.ListUsers, callInfo)
+	mock.lockListUsers.Unlock()
+	return mock.ListUsersFunc(ctx, db, f)
+}
+
+// ListUsersCalls gets all the calls that were made to ListUsers.
+// Check the length with:
+//
+//	len(mockedUserLister.ListUsersCalls())
+func (mock *UserListerMock) ListUsersCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	F   *entity.UserFilter
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		F   *entity.UserFilter
+	}
+	mock.lockListUsers.RLock()
+	calls = mock.calls.ListUsers
+	mock.lockListUsers.RUnlock()
+	return calls
+}
+
+// Ensure, that UserRoleUpdaterMock does implement UserRoleUpdater.
+// If this is not the case, regenerate this file with moq.
+var _ UserRoleUpdater = &UserRoleUpdaterMock{}
+
+// UserRoleUpdaterMock is a mock implementation of UserRoleUpdater.
+//
+//	func TestSomethingThatUsesUserRoleUpdater(t *testing.T) {
+//
+//		// make and configure a mocked UserRoleUpdater
+//		mockedUserRoleUpdater := &UserRoleUpdaterMock{
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//			UpdateUserRoleFunc: func(ctx context.Context, db store.Execer, u *entity.User) error {
+//				panic("mock out the UpdateUserRole method")
+//			},
+//		}
+//
+//		// use mockedUserRoleUpdater in code that requires UserRoleUpdater
+//		// and then make assertions.
+//
+//	}
+type UserRoleUpdaterMock struct {
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// UpdateUserRoleFunc mocks the UpdateUserRole method.
+	UpdateUserRoleFunc func(ctx context.Context, db store.Execer, u *entity.User) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+		// UpdateUserRole holds details about calls to the UpdateUserRole method.
+		UpdateUserRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// U is the u argument value.
+			U *entity.User
+		}
+	}
+	lockGetUserByID    sync.RWMutex
+	lockUpdateUserRole sync.RWMutex
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *UserRoleUpdaterMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("UserRoleUpdaterMock.GetUserByIDFunc: method is nil but UserRoleUpdater.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedUserRoleUpdater.GetUserByIDCalls())
+func (mock *UserRoleUpdaterMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// UpdateUserRole calls UpdateUserRoleFunc.
+func (mock *UserRoleUpdaterMock) UpdateUserRole(ctx context.Context, db store.Execer, u *entity.User) error {
+	if mock.UpdateUserRoleFunc == nil {
+		panic("UserRoleUpdaterMock.UpdateUserRoleFunc: method is nil but UserRoleUpdater.UpdateUserRole was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}{
+		Ctx: ctx,
+		Db:  db,
+		U:   u,
+	}
+	mock.lockUpdateUserRole.Lock()
+	mock.calls.UpdateUserRole = append(mock.calls.UpdateUserRole, callInfo)
+	mock.lockUpdateUserRole.Unlock()
+	return mock.UpdateUserRoleFunc(ctx, db, u)
+}
+
+// UpdateUserRoleCalls gets all the calls that were made to UpdateUserRole.
+// Check the length with:
+//
+//	len(mockedUserRoleUpdater.UpdateUserRoleCalls())
+func (mock *UserRoleUpdaterMock) UpdateUserRoleCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	U   *entity.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}
+	mock.lockUpdateUserRole.RLock()
+	calls = mock.calls.UpdateUserRole
+	mock.lockUpdateUserRole.RUnlock()
+	return calls
+}
+
+// Ensure, that UserDisablerMock does implement UserDisabler.
+// If this is not the case, regenerate this file with moq.
+var _ UserDisabler = &UserDisablerMock{}
+
+// UserDisablerMock is a mock implementation of UserDisabler.
+//
+//	func TestSomethingThatUsesUserDisabler(t *testing.T) {
+//
+//		// make and configure a mocked UserDisabler
+//		mockedUserDisabler := &UserDisablerMock{
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//			UpdateUserDisabledFunc: func(ctx context.Context, db store.Execer, u *entity.User) error {
+//				panic("mock out the UpdateUserDisabled method")
+//			},
+//		}
+//
+//		// use mockedUserDisabler in code that requires UserDisabler
+//		// and then make assertions.
+//
+//	}
+type UserDisablerMock struct {
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// UpdateUserDisabledFunc mocks the UpdateUserDisabled method.
+	UpdateUserDisabledFunc func(ctx context.Context, db store.Execer, u *entity.User) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+		// UpdateUserDisabled holds details about calls to the UpdateUserDisabled method.
+		UpdateUserDisabled []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// U is the u argument value.
+			U *entity.User
+		}
+	}
+	lockGetUserByID        sync.RWMutex
+	lockUpdateUserDisabled sync.RWMutex
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *UserDisablerMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("UserDisablerMock.GetUserByIDFunc: method is nil but UserDisabler.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedUserDisabler.GetUserByIDCalls())
+func (mock *UserDisablerMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// UpdateUserDisabled calls UpdateUserDisabledFunc.
+func (mock *UserDisablerMock) UpdateUserDisabled(ctx context.Context, db store.Execer, u *entity.User) error {
+	if mock.UpdateUserDisabledFunc == nil {
+		panic("UserDisablerMock.UpdateUserDisabledFunc: method is nil but UserDisabler.UpdateUserDisabled was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}{
+		Ctx: ctx,
+		Db:  db,
+		U:   u,
+	}
+	mock.lockUpdateUserDisabled.Lock()
+	mock.calls.UpdateUserDisabled = append(mock.calls.UpdateUserDisabled, callInfo)
+	mock.lockUpdateUserDisabled.Unlock()
+	return mock.UpdateUserDisabledFunc(ctx, db, u)
+}
+
+// UpdateUserDisabledCalls gets all the calls that were made to UpdateUserDisabled.
+// Check the length with:
+//
+//	len(mockedUserDisabler.UpdateUserDisabledCalls())
+func (mock *UserDisablerMock) UpdateUserDisabledCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	U   *entity.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}
+	mock.lockUpdateUserDisabled.RLock()
+	calls = mock.calls.UpdateUserDisabled
+	mock.lockUpdateUserDisabled.RUnlock()
+	return calls
+}
+
+// Ensure, that ProfileUpdaterMock does implement ProfileUpdater.
+// If this is not the case, regenerate this file with moq.
+var _ ProfileUpdater = &ProfileUpdaterMock{}
+
+// ProfileUpdaterMock is a mock implementation of ProfileUpdater.
+//
+//	func TestSomethingThatUsesProfileUpdater(t *testing.T) {
+//
+//		// make and configure a mocked ProfileUpdater
+//		mockedProfileUpdater := &ProfileUpdaterMock{
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//			UpdateProfileFunc: func(ctx context.Context, db store.Execer, u *entity.User) error {
+//				panic("mock out the UpdateProfile method")
+//			},
+//		}
+//
+//		// use mockedProfileUpdater in code that requires ProfileUpdater
+//		// and then make assertions.
+//
+//	}
+type ProfileUpdaterMock struct {
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// UpdateProfileFunc mocks the UpdateProfile method.
+	UpdateProfileFunc func(ctx context.Context, db store.Execer, u *entity.User) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+		// UpdateProfile holds details about calls to the UpdateProfile method.
+		UpdateProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// U is the u argument value.
+			U *entity.User
+		}
+	}
+	lockGetUserByID   sync.RWMutex
+	lockUpdateProfile sync.RWMutex
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *ProfileUpdaterMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("ProfileUpdaterMock.GetUserByIDFunc: method is nil but ProfileUpdater.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedProfileUpdater.GetUserByIDCalls())
+func (mock *ProfileUpdaterMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// UpdateProfile calls UpdateProfileFunc.
+func (mock *ProfileUpdaterMock) UpdateProfile(ctx context.Context, db store.Execer, u *entity.User) error {
+	if mock.UpdateProfileFunc == nil {
+		panic("ProfileUpdaterMock.UpdateProfileFunc: method is nil but ProfileUpdater.UpdateProfile was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}{
+		Ctx: ctx,
+		Db:  db,
+		U:   u,
+	}
+	mock.lockUpdateProfile.Lock()
+	mock.calls.UpdateProfile = append(mock.calls.UpdateProfile, callInfo)
+	mock.lockUpdateProfile.Unlock()
+	return mock.UpdateProfileFunc(ctx, db, u)
+}
+
+// UpdateProfileCalls gets all the calls that were made to UpdateProfile.
+// Check the length with:
+//
+//	len(mockedProfileUpdater.UpdateProfileCalls())
+func (mock *ProfileUpdaterMock) UpdateProfileCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	U   *entity.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}
+	mock.lockUpdateProfile.RLock()
+	calls = mock.calls.UpdateProfile
+	mock.lockUpdateProfile.RUnlock()
+	return calls
+}
+
+// Ensure, that AccountDeleterMock does implement AccountDeleter.
+// If this is not the case, regenerate this file with moq.
+var _ AccountDeleter = &AccountDeleterMock{}
+
+// AccountDeleterMock is a mock implementation of AccountDeleter.
+//
+//	func TestSomethingThatUsesAccountDeleter(t *testing.T) {
+//
+//		// make and configure a mocked AccountDeleter
+//		mockedAccountDeleter := &AccountDeleterMock{
+//			DeleteUserFunc: func(ctx context.Context, db store.Execer, id entity.UserID) error {
+//				panic("mock out the DeleteUser method")
+//			},
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//		}
+//
+//		// use mockedAccountDeleter in code that requires AccountDeleter
+//		// and then make assertions.
+//
+//	}
+type AccountDeleterMock struct {
+	// DeleteUserFunc mocks the DeleteUser method.
+	DeleteUserFunc func(ctx context.Context, db store.Execer, id entity.UserID) error
+
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteUser holds details about calls to the DeleteUser method.
+		DeleteUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+	}
+	lockDeleteUser  sync.RWMutex
+	lockGetUserByID sync.RWMutex
+}
+
+// DeleteUser calls DeleteUserFunc.
+func (mock *AccountDeleterMock) DeleteUser(ctx context.Context, db store.Execer, id entity.UserID) error {
+	if mock.DeleteUserFunc == nil {
+		panic("AccountDeleterMock.DeleteUserFunc: method is nil but AccountDeleter.DeleteUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockDeleteUser.Lock()
+	mock.calls.DeleteUser = append(mock.calls.DeleteUser, callInfo)
+	mock.lockDeleteUser.Unlock()
+	return mock.DeleteUserFunc(ctx, db, id)
+}
+
+// DeleteUserCalls gets all the calls that were made to DeleteUser.
+// Check the length with:
+//
+//	len(mockedAccountDeleter.DeleteUserCalls())
+func (mock *AccountDeleterMock) DeleteUserCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		ID  entity.UserID
+	}
+	mock.lockDeleteUser.RLock()
+	calls = mock.calls.DeleteUser
+	mock.lockDeleteUser.RUnlock()
+	return calls
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *AccountDeleterMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("AccountDeleterMock.GetUserByIDFunc: method is nil but AccountDeleter.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedAccountDeleter.GetUserByIDCalls())
+func (mock *AccountDeleterMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// Ensure, that AccountExporterMock does implement AccountExporter.
+// If this is not the case, regenerate this file with moq.
+var _ AccountExporter = &AccountExporterMock{}
+
+// AccountExporterMock is a mock implementation of AccountExporter.
+//
+//	func TestSomethingThatUsesAccountExporter(t *testing.T) {
+//
+//		// make and configure a mocked AccountExporter
+//		mockedAccountExporter := &AccountExporterMock{
+//			ExportTasksFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID, afterID entity.TaskID, limit int) (entity.Tasks, error) {
+//				panic("mock out the ExportTasks method")
+//			},
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//		}
+//
+//		// use mockedAccountExporter in code that requires AccountExporter
+//		// and then make assertions.
+//
+//	}
+type AccountExporterMock struct {
+	// ExportTasksFunc mocks the ExportTasks method.
+	ExportTasksFunc func(ctx context.Context, db store.Queryer, uid entity.UserID, afterID entity.TaskID, limit int) (entity.Tasks, error)
+
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ExportTasks holds details about calls to the ExportTasks method.
+		ExportTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// AfterID is the afterID argument value.
+			AfterID entity.TaskID
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+	}
+	lockExportTasks sync.RWMutex
+	lockGetUserByID sync.RWMutex
+}
+
+// ExportTasks calls ExportTasksFunc.
+func (mock *AccountExporterMock) ExportTasks(ctx context.Context, db store.Queryer, uid entity.UserID, afterID entity.TaskID, limit int) (entity.Tasks, error) {
+	if mock.ExportTasksFunc == nil {
+		panic("AccountExporterMock.ExportTasksFunc: method is nil but AccountExporter.ExportTasks was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		UID     entity.UserID
+		AfterID entity.TaskID
+		Limit   int
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		UID:     uid,
+		AfterID: afterID,
+		Limit:   limit,
+	}
+	mock.lockExportTasks.Lock()
+	mock.calls.ExportTasks = append(mock.calls.ExportTasks, callInfo)
+	mock.lockExportTasks.Unlock()
+	return mock.ExportTasksFunc(ctx, db, uid, afterID, limit)
+}
+
+// ExportTasksCalls gets all the calls that were made to ExportTasks.
+// Check the length with:
+//
+//	len(mockedAccountExporter.ExportTasksCalls())
+func (mock *AccountExporterMock) ExportTasksCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	UID     entity.UserID
+	AfterID entity.TaskID
+	Limit   int
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		UID     entity.UserID
+		AfterID entity.TaskID
+		Limit   int
+	}
+	mock.lockExportTasks.RLock()
+	calls = mock.calls.ExportTasks
+	mock.lockExportTasks.RUnlock()
+	return calls
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *AccountExporterMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("AccountExporterMock.GetUserByIDFunc: method is nil but AccountExporter.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedAccountExporter.GetUserByIDCalls())
+func (mock *AccountExporterMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// Ensure, that TwoFactorSetupperMock does implement TwoFactorSetupper.
+// If this is not the case, regenerate this file with moq.
+var _ TwoFactorSetupper = &TwoFactorSetupperMock{}
+
+// TwoFactorSetupperMock is a mock implementation of TwoFactorSetupper.
+//
+//	func TestSomethingThatUsesTwoFactorSetupper(t *testing.T) {
+//
+//		// make and configure a mocked TwoFactorSetupper
+//		mockedTwoFactorSetupper := &TwoFactorSetupperMock{
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//			SaveTOTPSecretFunc: func(ctx context.Context, db store.Execer, id entity.UserID, secret string) error {
+//				panic("mock out the SaveTOTPSecret method")
+//			},
+//		}
+//
+//		// use mockedTwoFactorSetupper in code that requires TwoFactorSetupper
+//		// and then make assertions.
+//
+//	}
+type TwoFactorSetupperMock struct {
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// SaveTOTPSecretFunc mocks the SaveTOTPSecret method.
+	SaveTOTPSecretFunc func(ctx context.Context, db store.Execer, id entity.UserID, secret string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+		// SaveTOTPSecret holds details about calls to the SaveTOTPSecret method.
+		SaveTOTPSecret []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// ID is the id argument value.
+			ID entity.UserID
+			// Secret is the secret argument value.
+			Secret string
+		}
+	}
+	lockGetUserByID    sync.RWMutex
+	lockSaveTOTPSecret sync.RWMutex
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *TwoFactorSetupperMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("TwoFactorSetupperMock.GetUserByIDFunc: method is nil but TwoFactorSetupper.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedTwoFactorSetupper.GetUserByIDCalls())
+func (mock *TwoFactorSetupperMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// SaveTOTPSecret calls SaveTOTPSecretFunc.
+func (mock *TwoFactorSetupperMock) SaveTOTPSecret(ctx context.Context, db store.Execer, id entity.UserID, secret string) error {
+	if mock.SaveTOTPSecretFunc == nil {
+		panic("TwoFactorSetupperMock.SaveTOTPSecretFunc: method is nil but TwoFactorSetupper.SaveTOTPSecret was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		ID     entity.UserID
+		Secret string
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		ID:     id,
+		Secret: secret,
+	}
+	mock.lockSaveTOTPSecret.Lock()
+	mock.calls.SaveTOTPSecret = append(mock.calls.SaveTOTPSecret, callInfo)
+	mock.lockSaveTOTPSecret.Unlock()
+	return mock.SaveTOTPSecretFunc(ctx, db, id, secret)
+}
+
+// SaveTOTPSecretCalls gets all the calls that were made to SaveTOTPSecret.
+// Check the length with:
+//
+//	len(mockedTwoFactorSetupper.SaveTOTPSecretCalls())
+func (mock *TwoFactorSetupperMock) SaveTOTPSecretCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	ID     entity.UserID
+	Secret string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		ID     entity.UserID
+		Secret string
+	}
+	mock.lockSaveTOTPSecret.RLock()
+	calls = mock.calls.SaveTOTPSecret
+	mock.lockSaveTOTPSecret.RUnlock()
+	return calls
+}
+
+// Ensure, that TwoFactorConfirmerMock does implement TwoFactorConfirmer.
+// If this is not the case, regenerate this file with moq.
+var _ TwoFactorConfirmer = &TwoFactorConfirmerMock{}
+
+// TwoFactorConfirmerMock is a mock implementation of TwoFactorConfirmer.
+//
+//	func TestSomethingThatUsesTwoFactorConfirmer(t *testing.T) {
+//
+//		// make and configure a mocked TwoFactorConfirmer
+//		mockedTwoFactorConfirmer := &TwoFactorConfirmerMock{
+//			EnableTOTPFunc: func(ctx context.Context, db store.Execer, id entity.UserID, secret string) error {
+//				panic("mock out the EnableTOTP method")
+//			},
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//			ReplaceRecoveryCodesFunc: func(ctx context.Context, db store.Execer, id entity.UserID, hashes []string) error {
+//				panic("mock out the ReplaceRecoveryCodes method")
+//			},
+//		}
+//
+//		// use mockedTwoFactorConfirmer in code that requires TwoFactorConfirmer
+//		// and then make assertions.
+//
+//	}
+type TwoFactorConfirmerMock struct {
+	// EnableTOTPFunc mocks the EnableTOTP method.
+	EnableTOTPFunc func(ctx context.Context, db store.Execer, id entity.UserID, secret string) error
+
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// ReplaceRecoveryCodesFunc mocks the ReplaceRecoveryCodes method.
+	ReplaceRecoveryCodesFunc func(ctx context.Context, db store.Execer, id entity.UserID, hashes []string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// EnableTOTP holds details about calls to the EnableTOTP method.
+		EnableTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// ID is the id argument value.
+			ID entity.UserID
+			// Secret is the secret argument value.
+			Secret string
+		}
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+		// ReplaceRecoveryCodes holds details about calls to the ReplaceRecoveryCodes method.
+		ReplaceRecoveryCodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// ID is the id argument value.
+			ID entity.UserID
+			// Hashes is the hashes argument value.
+			Hashes []string
+		}
+	}
+	lockEnableTOTP           sync.RWMutex
+	lockGetUserByID          sync.RWMutex
+	lockReplaceRecoveryCodes sync.RWMutex
+}
+
+// EnableTOTP calls EnableTOTPFunc.
+func (mock *TwoFactorConfirmerMock) EnableTOTP(ctx context.Context, db store.Execer, id entity.UserID, secret string) error {
+	if mock.EnableTOTPFunc == nil {
+		panic("TwoFactorConfirmerMock.EnableTOTPFunc: method is nil but TwoFactorConfirmer.EnableTOTP was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		ID     entity.UserID
+		Secret string
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		ID:     id,
+		Secret: secret,
+	}
+	mock.lockEnableTOTP.Lock()
+	mock.calls.EnableTOTP = append(mock.calls.EnableTOTP, callInfo)
+	mock.lockEnableTOTP.Unlock()
+	return mock.EnableTOTPFunc(ctx, db, id, secret)
+}
+
+// EnableTOTPCalls gets all the calls that were made to EnableTOTP.
+// Check the length with:
+//
+//	len(mockedTwoFactorConfirmer.EnableTOTPCalls())
+func (mock *TwoFactorConfirmerMock) EnableTOTPCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	ID     entity.UserID
+	Secret string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		ID     entity.UserID
+		Secret string
+	}
+	mock.lockEnableTOTP.RLock()
+	calls = mock.calls.EnableTOTP
+	mock.lockEnableTOTP.RUnlock()
+	return calls
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *TwoFactorConfirmerMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("TwoFactorConfirmerMock.GetUserByIDFunc: method is nil but TwoFactorConfirmer.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedTwoFactorConfirmer.GetUserByIDCalls())
+func (mock *TwoFactorConfirmerMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// ReplaceRecoveryCodes calls ReplaceRecoveryCodesFunc.
+func (mock *TwoFactorConfirmerMock) ReplaceRecoveryCodes(ctx context.Context, db store.Execer, id entity.UserID, hashes []string) error {
+	if mock.ReplaceRecoveryCodesFunc == nil {
+		panic("TwoFactorConfirmerMock.ReplaceRecoveryCodesFunc: method is nil but TwoFactorConfirmer.ReplaceRecoveryCodes was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		ID     entity.UserID
+		Hashes []string
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		ID:     id,
+		Hashes: hashes,
+	}
+	mock.lockReplaceRecoveryCodes.Lock()
+	mock.calls.ReplaceRecoveryCodes = append(mock.calls.ReplaceRecoveryCodes, callInfo)
+	mock.lockReplaceRecoveryCodes.Unlock()
+	return mock.ReplaceRecoveryCodesFunc(ctx, db, id, hashes)
+}
+
+// ReplaceRecoveryCodesCalls gets all the calls that were made to ReplaceRecoveryCodes.
+// Check the length with:
+//
+//	len(mockedTwoFactorConfirmer.ReplaceRecoveryCodesCalls())
+func (mock *TwoFactorConfirmerMock) ReplaceRecoveryCodesCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	ID     entity.UserID
+	Hashes []string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		ID     entity.UserID
+		Hashes []string
+	}
+	mock.lockReplaceRecoveryCodes.RLock()
+	calls = mock.calls.ReplaceRecoveryCodes
+	mock.lockReplaceRecoveryCodes.RUnlock()
+	return calls
+}
+
+// Ensure, that RecoveryCodeUserMock does implement RecoveryCodeUser.
+// If this is not the case, regenerate this file with moq.
+var _ RecoveryCodeUser = &RecoveryCodeUserMock{}
+
+// RecoveryCodeUserMock is a mock implementation of RecoveryCodeUser.
+//
+//	func TestSomethingThatUsesRecoveryCodeUser(t *testing.T) {
+//
+//		// make and configure a mocked RecoveryCodeUser
+//		mockedRecoveryCodeUser := &RecoveryCodeUserMock{
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//			UpdatePasswordFunc: func(ctx context.Context, db store.Execer, u *entity.User) error {
+//				panic("mock out the UpdatePassword method")
+//			},
+//			UseRecoveryCodeFunc: func(ctx context.Context, db store.Execer, id entity.UserID, hash string) error {
+//				panic("mock out the UseRecoveryCode method")
+//			},
+//		}
+//
+//		// use mockedRecoveryCodeUser in code that requires RecoveryCodeUser
+//		// and then make assertions.
+//
+//	}
+type RecoveryCodeUserMock struct {
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// UpdatePasswordFunc mocks the UpdatePassword method.
+	UpdatePasswordFunc func(ctx context.Context, db store.Execer, u *entity.User) error
+
+	// UseRecoveryCodeFunc mocks the UseRecoveryCode method.
+	UseRecoveryCodeFunc func(ctx context.Context, db store.Execer, id entity.UserID, hash string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+		// UpdatePassword holds details about calls to the UpdatePassword method.
+		UpdatePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// U is the u argument value.
+			U *entity.User
+		}
+		// UseRecoveryCode holds details about calls to the UseRecoveryCode method.
+		UseRecoveryCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// ID is the id argument value.
+			ID entity.UserID
+			// Hash is the hash argument value.
+			Hash string
+		}
+	}
+	lockGetUserByID     sync.RWMutex
+	lockUpdatePassword  sync.RWMutex
+	lockUseRecoveryCode sync.RWMutex
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *RecoveryCodeUserMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("RecoveryCodeUserMock.GetUserByIDFunc: method is nil but RecoveryCodeUser.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeUser.GetUserByIDCalls())
+func (mock *RecoveryCodeUserMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// UpdatePassword calls UpdatePasswordFunc.
+func (mock *RecoveryCodeUserMock) UpdatePassword(ctx context.Context, db store.Execer, u *entity.User) error {
+	if mock.UpdatePasswordFunc == nil {
+		panic("RecoveryCodeUserMock.UpdatePasswordFunc: method is nil but RecoveryCodeUser.UpdatePassword was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}{
+		Ctx: ctx,
+		Db:  db,
+		U:   u,
+	}
+	mock.lockUpdatePassword.Lock()
+	mock.calls.UpdatePassword = append(mock.calls.UpdatePassword, callInfo)
+	mock.lockUpdatePassword.Unlock()
+	return mock.UpdatePasswordFunc(ctx, db, u)
+}
+
+// UpdatePasswordCalls gets all the calls that were made to UpdatePassword.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeUser.UpdatePasswordCalls())
+func (mock *RecoveryCodeUserMock) UpdatePasswordCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	U   *entity.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}
+	mock.lockUpdatePassword.RLock()
+	calls = mock.calls.UpdatePassword
+	mock.lockUpdatePassword.RUnlock()
+	return calls
+}
+
+// UseRecoveryCode calls UseRecoveryCodeFunc.
+func (mock *RecoveryCodeUserMock) UseRecoveryCode(ctx context.Context, db store.Execer, id entity.UserID, hash string) error {
+	if mock.UseRecoveryCodeFunc == nil {
+		panic("RecoveryCodeUserMock.UseRecoveryCodeFunc: method is nil but RecoveryCodeUser.UseRecoveryCode was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Db   store.Execer
+		ID   entity.UserID
+		Hash string
+	}{
+		Ctx:  ctx,
+		Db:   db,
+		ID:   id,
+		Hash: hash,
+	}
+	mock.lockUseRecoveryCode.Lock()
+	mock.calls.UseRecoveryCode = append(mock.calls.UseRecoveryCode, callInfo)
+	mock.lockUseRecoveryCode.Unlock()
+	return mock.UseRecoveryCodeFunc(ctx, db, id, hash)
+}
+
+// UseRecoveryCodeCalls gets all the calls that were made to UseRecoveryCode.
+// Check the length with:
+//
+//	len(mockedRecoveryCodeUser.UseRecoveryCodeCalls())
+func (mock *RecoveryCodeUserMock) UseRecoveryCodeCalls() []struct {
+	Ctx  context.Context
+	Db   store.Execer
+	ID   entity.UserID
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Db   store.Execer
+		ID   entity.UserID
+		Hash string
+	}
+	mock.lockUseRecoveryCode.RLock()
+	calls = mock.calls.UseRecoveryCode
+	mock.lockUseRecoveryCode.RUnlock()
+	return calls
+}
+
+// Ensure, that LoginAttemptStoreMock does implement LoginAttemptStore.
+// If this is not the case, regenerate this file with moq.
+var _ LoginAttemptStore = &LoginAttemptStoreMock{}
+
+// LoginAttemptStoreMock is a mock implementation of LoginAttemptStore.
+//
+//	func TestSomethingThatUsesLoginAttemptStore(t *testing.T) {
+//
+//		// make and configure a mocked LoginAttemptStore
+//		mockedLoginAttemptStore := &LoginAttemptStoreMock{
+//			IncrLoginFailuresFunc: func(ctx context.Context, key string, window time.Duration) (int64, error) {
+//				panic("mock out the IncrLoginFailures method")
+//			},
+//			LockLoginFunc: func(ctx context.Context, key string, d time.Duration) error {
+//				panic("mock out the LockLogin method")
+//			},
+//			LoginLockTTLFunc: func(ctx context.Context, key string) (time.Duration, error) {
+//				panic("mock out the LoginLockTTL method")
+//			},
+//			ResetLoginFailuresFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the ResetLoginFailures method")
+//			},
+//		}
+//
+//		// use mockedLoginAttemptStore in code that requires LoginAttemptStore
+//		// and then make assertions.
+//
+//	}
+type LoginAttemptStoreMock struct {
+	// IncrLoginFailuresFunc mocks the IncrLoginFailures method.
+	IncrLoginFailuresFunc func(ctx context.Context, key string, window time.Duration) (int64, error)
+
+	// LockLoginFunc mocks the LockLogin method.
+	LockLoginFunc func(ctx context.Context, key string, d time.Duration) error
+
+	// LoginLockTTLFunc mocks the LoginLockTTL method.
+	LoginLockTTLFunc func(ctx context.Context, key string) (time.Duration, error)
+
+	// ResetLoginFailuresFunc mocks the ResetLoginFailures method.
+	ResetLoginFailuresFunc func(ctx context.Context, key string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// IncrLoginFailures holds details about calls to the IncrLoginFailures method.
+		IncrLoginFailures []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Window is the window argument value.
+			Window time.Duration
+		}
+		// LockLogin holds details about calls to the LockLogin method.
+		LockLogin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// D is the d argument value.
+			D time.Duration
+		}
+		// LoginLockTTL holds details about calls to the LoginLockTTL method.
+		LoginLockTTL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// ResetLoginFailures holds details about calls to the ResetLoginFailures method.
+		ResetLoginFailures []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+	}
+	lockIncrLoginFailures  sync.RWMutex
+	lockLockLogin          sync.RWMutex
+	lockLoginLockTTL       sync.RWMutex
+	lockResetLoginFailures sync.RWMutex
+}
+
+// IncrLoginFailures calls IncrLoginFailuresFunc.
+func (mock *LoginAttemptStoreMock) IncrLoginFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	if mock.IncrLoginFailuresFunc == nil {
+		panic("LoginAttemptStoreMock.IncrLoginFailuresFunc: method is nil but LoginAttemptStore.IncrLoginFailures was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Key    string
+		Window time.Duration
+	}{
+		Ctx:    ctx,
+		Key:    key,
+		Window: window,
+	}
+	mock.lockIncrLoginFailures.Lock()
+	mock.calls.IncrLoginFailures = append(mock.calls.IncrLoginFailures, callInfo)
+	mock.lockIncrLoginFailures.Unlock()
+	return mock.IncrLoginFailuresFunc(ctx, key, window)
+}
+
+// IncrLoginFailuresCalls gets all the calls that were made to IncrLoginFailures.
+// Check the length with:
+//
+//	len(mockedLoginAttemptStore.IncrLoginFailuresCalls())
+func (mock *LoginAttemptStoreMock) IncrLoginFailuresCalls() []struct {
+	Ctx    context.Context
+	Key    string
+	Window time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Key    string
+		Window time.Duration
+	}
+	mock.lockIncrLoginFailures.RLock()
+	calls = mock.calls.IncrLoginFailures
+	mock.lockIncrLoginFailures.RUnlock()
+	return calls
+}
+
+// LockLogin calls LockLoginFunc.
+func (mock *LoginAttemptStoreMock) LockLogin(ctx context.Context, key string, d time.Duration) error {
+	if mock.LockLoginFunc == nil {
+		panic("LoginAttemptStoreMock.LockLoginFunc: method is nil but LoginAttemptStore.LockLogin was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+		D   time.Duration
+	}{
+		Ctx: ctx,
+		Key: key,
+		D:   d,
+	}
+	mock.lockLockLogin.Lock()
+	mock.calls.LockLogin = append(mock.calls.LockLogin, callInfo)
+	mock.lockLockLogin.Unlock()
+	return mock.LockLoginFunc(ctx, key, d)
+}
+
+// LockLoginCalls gets all the calls that were made to LockLogin.
+// Check the length with:
+//
+//	len(mockedLoginAttemptStore.LockLoginCalls())
+func (mock *LoginAttemptStoreMock) LockLoginCalls() []struct {
+	Ctx context.Context
+	Key string
+	D   time.Duration
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+		D   time.Duration
+	}
+	mock.lockLockLogin.RLock()
+	calls = mock.calls.LockLogin
+	mock.lockLockLogin.RUnlock()
+	return calls
+}
+
+// LoginLockTTL calls LoginLockTTLFunc.
+func (mock *LoginAttemptStoreMock) LoginLockTTL(ctx context.Context, key string) (time.Duration, error) {
+	if mock.LoginLockTTLFunc == nil {
+		panic("LoginAttemptStoreMock.LoginLockTTLFunc: method is nil but LoginAttemptStore.LoginLockTTL was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockLoginLockTTL.Lock()
+	mock.calls.LoginLockTTL = append(mock.calls.LoginLockTTL, callInfo)
+	mock.lockLoginLockTTL.Unlock()
+	return mock.LoginLockTTLFunc(ctx, key)
+}
+
+// LoginLockTTLCalls gets all the calls that were made to LoginLockTTL.
+// Check the length with:
+//
+//	len(mockedLoginAttemptStore.LoginLockTTLCalls())
+func (mock *LoginAttemptStoreMock) LoginLockTTLCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockLoginLockTTL.RLock()
+	calls = mock.calls.LoginLockTTL
+	mock.lockLoginLockTTL.RUnlock()
+	return calls
+}
+
+// ResetLoginFailures calls ResetLoginFailuresFunc.
+func (mock *LoginAttemptStoreMock) ResetLoginFailures(ctx context.Context, key string) error {
+	if mock.ResetLoginFailuresFunc == nil {
+		panic("LoginAttemptStoreMock.ResetLoginFailuresFunc: method is nil but LoginAttemptStore.ResetLoginFailures was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockResetLoginFailures.Lock()
+	mock.calls.ResetLoginFailures = append(mock.calls.ResetLoginFailures, callInfo)
+	mock.lockResetLoginFailures.Unlock()
+	return mock.ResetLoginFailuresFunc(ctx, key)
+}
+
+// ResetLoginFailuresCalls gets all the calls that were made to ResetLoginFailures.
+// Check the length with:
+//
+//	len(mockedLoginAttemptStore.ResetLoginFailuresCalls())
+func (mock *LoginAttemptStoreMock) ResetLoginFailuresCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockResetLoginFailures.RLock()
+	calls = mock.calls.ResetLoginFailures
+	mock.lockResetLoginFailures.RUnlock()
+	return calls
+}
+
+// Ensure, that PasswordResetTokenStoreMock does implement PasswordResetTokenStore.
+// If this is not the case, regenerate this file with moq.
+var _ PasswordResetTokenStore = &PasswordResetTokenStoreMock{}
+
+// PasswordResetTokenStoreMock is a mock implementation of PasswordResetTokenStore.
+//
+//	func TestSomethingThatUsesPasswordResetTokenStore(t *testing.T) {
+//
+//		// make and configure a mocked PasswordResetTokenStore
+//		mockedPasswordResetTokenStore := &PasswordResetTokenStoreMock{
+//			ConsumePasswordResetTokenFunc: func(ctx context.Context, hash string) (entity.UserID, error) {
+//				panic("mock out the ConsumePasswordResetToken method")
+//			},
+//			LoadPasswordResetTokenFunc: func(ctx context.Context, hash string) (entity.UserID, error) {
+//				panic("mock out the LoadPasswordResetToken method")
+//			},
+//			SavePasswordResetTokenFunc: func(ctx context.Context, hash string, userID entity.UserID, ttl time.Duration) error {
+//				panic("mock out the SavePasswordResetToken method")
+//			},
+//		}
+//
+//		// use mockedPasswordResetTokenStore in code that requires PasswordResetTokenStore
+//		// and then make assertions.
+//
+//	}
+type PasswordResetTokenStoreMock struct {
+	// ConsumePasswordResetTokenFunc mocks the ConsumePasswordResetToken method.
+	ConsumePasswordResetTokenFunc func(ctx context.Context, hash string) (entity.UserID, error)
+
+	// LoadPasswordResetTokenFunc mocks the LoadPasswordResetToken method.
+	LoadPasswordResetTokenFunc func(ctx context.Context, hash string) (entity.UserID, error)
+
+	// SavePasswordResetTokenFunc mocks the SavePasswordResetToken method.
+	SavePasswordResetTokenFunc func(ctx context.Context, hash string, userID entity.UserID, ttl time.Duration) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ConsumePasswordResetToken holds details about calls to the ConsumePasswordResetToken method.
+		ConsumePasswordResetToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// LoadPasswordResetToken holds details about calls to the LoadPasswordResetToken method.
+		LoadPasswordResetToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// SavePasswordResetToken holds details about calls to the SavePasswordResetToken method.
+		SavePasswordResetToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+	}
+	lockConsumePasswordResetToken sync.RWMutex
+	lockLoadPasswordResetToken    sync.RWMutex
+	lockSavePasswordResetToken    sync.RWMutex
+}
+
+// ConsumePasswordResetToken calls ConsumePasswordResetTokenFunc.
+func (mock *PasswordResetTokenStoreMock) ConsumePasswordResetToken(ctx context.Context, hash string) (entity.UserID, error) {
+	if mock.ConsumePasswordResetTokenFunc == nil {
+		panic("PasswordResetTokenStoreMock.ConsumePasswordResetTokenFunc: method is nil but PasswordResetTokenStore.ConsumePasswordResetToken was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash string
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockConsumePasswordResetToken.Lock()
+	mock.calls.ConsumePasswordResetToken = append(mock.calls.ConsumePasswordResetToken, callInfo)
+	mock.lockConsumePasswordResetToken.Unlock()
+	return mock.ConsumePasswordResetTokenFunc(ctx, hash)
+}
+
+// ConsumePasswordResetTokenCalls gets all the calls that were made to ConsumePasswordResetToken.
+// Check the length with:
+//
+//	len(mockedPasswordResetTokenStore.ConsumePasswordResetTokenCalls())
+func (mock *PasswordResetTokenStoreMock) ConsumePasswordResetTokenCalls() []struct {
+	Ctx  context.Context
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash string
+	}
+	mock.lockConsumePasswordResetToken.RLock()
+	calls = mock.calls.ConsumePasswordResetToken
+	mock.lockConsumePasswordResetToken.RUnlock()
+	return calls
+}
+
+// LoadPasswordResetToken calls LoadPasswordResetTokenFunc.
+func (mock *PasswordResetTokenStoreMock) LoadPasswordResetToken(ctx context.Context, hash string) (entity.UserID, error) {
+	if mock.LoadPasswordResetTokenFunc == nil {
+		panic("PasswordResetTokenStoreMock.LoadPasswordResetTokenFunc: method is nil but PasswordResetTokenStore.LoadPasswordResetToken was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash string
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockLoadPasswordResetToken.Lock()
+	mock.calls.LoadPasswordResetToken = append(mock.calls.LoadPasswordResetToken, callInfo)
+	mock.lockLoadPasswordResetToken.Unlock()
+	return mock.LoadPasswordResetTokenFunc(ctx, hash)
+}
+
+// LoadPasswordResetTokenCalls gets all the calls that were made to LoadPasswordResetToken.
+// Check the length with:
+//
+//	len(mockedPasswordResetTokenStore.LoadPasswordResetTokenCalls())
+func (mock *PasswordResetTokenStoreMock) LoadPasswordResetTokenCalls() []struct {
+	Ctx  context.Context
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash string
+	}
+	mock.lockLoadPasswordResetToken.RLock()
+	calls = mock.calls.LoadPasswordResetToken
+	mock.lockLoadPasswordResetToken.RUnlock()
+	return calls
+}
+
+// SavePasswordResetToken calls SavePasswordResetTokenFunc.
+func (mock *PasswordResetTokenStoreMock) SavePasswordResetToken(ctx context.Context, hash string, userID entity.UserID, ttl time.Duration) error {
+	if mock.SavePasswordResetTokenFunc == nil {
+		panic("PasswordResetTokenStoreMock.SavePasswordResetTokenFunc: method is nil but PasswordResetTokenStore.SavePasswordResetToken was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Hash   string
+		UserID entity.UserID
+		TTL    time.Duration
+	}{
+		Ctx:    ctx,
+		Hash:   hash,
+		UserID: userID,
+		TTL:    ttl,
+	}
+	mock.lockSavePasswordResetToken.Lock()
+	mock.calls.SavePasswordResetToken = append(mock.calls.SavePasswordResetToken, callInfo)
+	mock.lockSavePasswordResetToken.Unlock()
+	return mock.SavePasswordResetTokenFunc(ctx, hash, userID, ttl)
+}
+
+// SavePasswordResetTokenCalls gets all the calls that were made to SavePasswordResetToken.
+// Check the length with:
+//
+//	len(mockedPasswordResetTokenStore.SavePasswordResetTokenCalls())
+func (mock *PasswordResetTokenStoreMock) SavePasswordResetTokenCalls() []struct {
+	Ctx    context.Context
+	Hash   string
+	UserID entity.UserID
+	TTL    time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Hash   string
+		UserID entity.UserID
+		TTL    time.Duration
+	}
+	mock.lockSavePasswordResetToken.RLock()
+	calls = mock.calls.SavePasswordResetToken
+	mock.lockSavePasswordResetToken.RUnlock()
+	return calls
+}
+
+// Ensure, that LoginChallengeStoreMock does implement LoginChallengeStore.
+// If this is not the case, regenerate this file with moq.
+var _ LoginChallengeStore = &LoginChallengeStoreMock{}
+
+// LoginChallengeStoreMock is a mock implementation of LoginChallengeStore.
+//
+//	func TestSomethingThatUsesLoginChallengeStore(t *testing.T) {
+//
+//		// make and configure a mocked LoginChallengeStore
+//		mockedLoginChallengeStore := &LoginChallengeStoreMock{
+//			ConsumeLoginChallengeFunc: func(ctx context.Context, hash string) error {
+//				panic("mock out the ConsumeLoginChallenge method")
+//			},
+//			IncrLoginChallengeFailuresFunc: func(ctx context.Context, hash string) (int64, error) {
+//				panic("mock out the IncrLoginChallengeFailures method")
+//			},
+//			LoadLoginChallengeFunc: func(ctx context.Context, hash string) (entity.UserID, error) {
+//				panic("mock out the LoadLoginChallenge method")
+//			},
+//			SaveLoginChallengeFunc: func(ctx context.Context, hash string, userID entity.UserID, ttl time.Duration) error {
+//				panic("mock out the SaveLoginChallenge method")
+//			},
+//			UseTOTPStepFunc: func(ctx context.Context, userID entity.UserID, step int64, ttl time.Duration) (bool, error) {
+//				panic("mock out the UseTOTPStep method")
+//			},
+//		}
+//
+//		// use mockedLoginChallengeStore in code that requires LoginChallengeStore
+//		// and then make assertions.
+//
+//	}
+type LoginChallengeStoreMock struct {
+	// ConsumeLoginChallengeFunc mocks the ConsumeLoginChallenge method.
+	ConsumeLoginChallengeFunc func(ctx context.Context, hash string) error
+
+	// IncrLoginChallengeFailuresFunc mocks the IncrLoginChallengeFailures method.
+	IncrLoginChallengeFailuresFunc func(ctx context.Context, hash string) (int64, error)
+
+	// LoadLoginChallengeFunc mocks the LoadLoginChallenge method.
+	LoadLoginChallengeFunc func(ctx context.Context, hash string) (entity.UserID, error)
+
+	// SaveLoginChallengeFunc mocks the SaveLoginChallenge method.
+	SaveLoginChallengeFunc func(ctx context.Context, hash string, userID entity.UserID, ttl time.Duration) error
+
+	// UseTOTPStepFunc mocks the UseTOTPStep method.
+	UseTOTPStepFunc func(ctx context.Context, userID entity.UserID, step int64, ttl time.Duration) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ConsumeLoginChallenge holds details about calls to the ConsumeLoginChallenge method.
+		ConsumeLoginChallenge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// IncrLoginChallengeFailures holds details about calls to the IncrLoginChallengeFailures method.
+		IncrLoginChallengeFailures []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// LoadLoginChallenge holds details about calls to the LoadLoginChallenge method.
+		LoadLoginChallenge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// SaveLoginChallenge holds details about calls to the SaveLoginChallenge method.
+		SaveLoginChallenge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// UseTOTPStep holds details about calls to the UseTOTPStep method.
+		UseTOTPStep []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Step is the step argument value.
+			Step int64
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+	}
+	lockConsumeLoginChallenge      sync.RWMutex
+	lockIncrLoginChallengeFailures sync.RWMutex
+	lockLoadLoginChallenge         sync.RWMutex
+	lockSaveLoginChallenge         sync.RWMutex
+	lockUseTOTPStep                sync.RWMutex
+}
+
+// ConsumeLoginChallenge calls ConsumeLoginChallengeFunc.
+func (mock *LoginChallengeStoreMock) ConsumeLoginChallenge(ctx context.Context, hash string) error {
+	if mock.ConsumeLoginChallengeFunc == nil {
+		panic("LoginChallengeStoreMock.ConsumeLoginChallengeFunc: method is nil but LoginChallengeStore.ConsumeLoginChallenge was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash string
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockConsumeLoginChallenge.Lock()
+	mock.calls.ConsumeLoginChallenge = append(mock.calls.ConsumeLoginChallenge, callInfo)
+	mock.lockConsumeLoginChallenge.Unlock()
+	return mock.ConsumeLoginChallengeFunc(ctx, hash)
+}
+
+// ConsumeLoginChallengeCalls gets all the calls that were made to ConsumeLoginChallenge.
+// Check the length with:
+//
+//	len(mockedLoginChallengeStore.ConsumeLoginChallengeCalls())
+func (mock *LoginChallengeStoreMock) ConsumeLoginChallengeCalls() []struct {
+	Ctx  context.Context
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash string
+	}
+	mock.lockConsumeLoginChallenge.RLock()
+	calls = mock.calls.ConsumeLoginChallenge
+	mock.lockConsumeLoginChallenge.RUnlock()
+	return calls
+}
+
+// IncrLoginChallengeFailures calls IncrLoginChallengeFailuresFunc.
+func (mock *LoginChallengeStoreMock) IncrLoginChallengeFailures(ctx context.Context, hash string) (int64, error) {
+	if mock.IncrLoginChallengeFailuresFunc == nil {
+		panic("LoginChallengeStoreMock.IncrLoginChallengeFailuresFunc: method is nil but LoginChallengeStore.IncrLoginChallengeFailures was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash string
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockIncrLoginChallengeFailures.Lock()
+	mock.calls.IncrLoginChallengeFailures = append(mock.calls.IncrLoginChallengeFailures, callInfo)
+	mock.lockIncrLoginChallengeFailures.Unlock()
+	return mock.IncrLoginChallengeFailuresFunc(ctx, hash)
+}
+
+// IncrLoginChallengeFailuresCalls gets all the calls that were made to IncrLoginChallengeFailures.
+// Check the length with:
+//
+//	len(mockedLoginChallengeStore.IncrLoginChallengeFailuresCalls())
+func (mock *LoginChallengeStoreMock) IncrLoginChallengeFailuresCalls() []struct {
+	Ctx  context.Context
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash string
+	}
+	mock.lockIncrLoginChallengeFailures.RLock()
+	calls = mock.calls.IncrLoginChallengeFailures
+	mock.lockIncrLoginChallengeFailures.RUnlock()
+	return calls
+}
+
+// LoadLoginChallenge calls LoadLoginChallengeFunc.
+func (mock *LoginChallengeStoreMock) LoadLoginChallenge(ctx context.Context, hash string) (entity.UserID, error) {
+	if mock.LoadLoginChallengeFunc == nil {
+		panic("LoginChallengeStoreMock.LoadLoginChallengeFunc: method is nil but LoginChallengeStore.LoadLoginChallenge was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash string
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockLoadLoginChallenge.Lock()
+	mock.calls.LoadLoginChallenge = append(mock.calls.LoadLoginChallenge, callInfo)
+	mock.lockLoadLoginChallenge.Unlock()
+	return mock.LoadLoginChallengeFunc(ctx, hash)
+}
+
+// LoadLoginChallengeCalls gets all the calls that were made to LoadLoginChallenge.
+// Check the length with:
+//
+//	len(mockedLoginChallengeStore.LoadLoginChallengeCalls())
+func (mock *LoginChallengeStoreMock) LoadLoginChallengeCalls() []struct {
+	Ctx  context.Context
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash string
+	}
+	mock.lockLoadLoginChallenge.RLock()
+	calls = mock.calls.LoadLoginChallenge
+	mock.lockLoadLoginChallenge.RUnlock()
+	return calls
+}
+
+// SaveLoginChallenge calls SaveLoginChallengeFunc.
+func (mock *LoginChallengeStoreMock) SaveLoginChallenge(ctx context.Context, hash string, userID entity.UserID, ttl time.Duration) error {
+	if mock.SaveLoginChallengeFunc == nil {
+		panic("LoginChallengeStoreMock.SaveLoginChallengeFunc: method is nil but LoginChallengeStore.SaveLoginChallenge was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Hash   string
+		UserID entity.UserID
+		TTL    time.Duration
+	}{
+		Ctx:    ctx,
+		Hash:   hash,
+		UserID: userID,
+		TTL:    ttl,
+	}
+	mock.lockSaveLoginChallenge.Lock()
+	mock.calls.SaveLoginChallenge = append(mock.calls.SaveLoginChallenge, callInfo)
+	mock.lockSaveLoginChallenge.Unlock()
+	return mock.SaveLoginChallengeFunc(ctx, hash, userID, ttl)
+}
+
+// SaveLoginChallengeCalls gets all the calls that were made to SaveLoginChallenge.
+// Check the length with:
+//
+//	len(mockedLoginChallengeStore.SaveLoginChallengeCalls())
+func (mock *LoginChallengeStoreMock) SaveLoginChallengeCalls() []struct {
+	Ctx    context.Context
+	Hash   string
+	UserID entity.UserID
+	TTL    time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Hash   string
+		UserID entity.UserID
+		TTL    time.Duration
+	}
+	mock.lockSaveLoginChallenge.RLock()
+	calls = mock.calls.SaveLoginChallenge
+	mock.lockSaveLoginChallenge.RUnlock()
+	return calls
+}
+
+// UseTOTPStep calls UseTOTPStepFunc.
+func (mock *LoginChallengeStoreMock) UseTOTPStep(ctx context.Context, userID entity.UserID, step int64, ttl time.Duration) (bool, error) {
+	if mock.UseTOTPStepFunc == nil {
+		panic("LoginChallengeStoreMock.UseTOTPStepFunc: method is nil but LoginChallengeStore.UseTOTPStep was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+		Step   int64
+		TTL    time.Duration
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Step:   step,
+		TTL:    ttl,
+	}
+	mock.lockUseTOTPStep.Lock()
+	mock.calls.UseTOTPStep = append(mock.calls.UseTOTPStep, callInfo)
+	mock.lockUseTOTPStep.Unlock()
+	return mock.UseTOTPStepFunc(ctx, userID, step, ttl)
+}
+
+// UseTOTPStepCalls gets all the calls that were made to UseTOTPStep.
+// Check the length with:
+//
+//	len(mockedLoginChallengeStore.UseTOTPStepCalls())
+func (mock *LoginChallengeStoreMock) UseTOTPStepCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+	Step   int64
+	TTL    time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+		Step   int64
+		TTL    time.Duration
+	}
+	mock.lockUseTOTPStep.RLock()
+	calls = mock.calls.UseTOTPStep
+	mock.lockUseTOTPStep.RUnlock()
+	return calls
+}
+
+// Ensure, that OIDCUserProvisionerMock does implement OIDCUserProvisioner.
+// If this is not the case, regenerate this file with moq.
+var _ OIDCUserProvisioner = &OIDCUserProvisionerMock{}
+
+// OIDCUserProvisionerMock is a mock implementation of OIDCUserProvisioner.
+//
+//	func TestSomethingThatUsesOIDCUserProvisioner(t *testing.T) {
+//
+//		// make and configure a mocked OIDCUserProvisioner
+//		mockedOIDCUserProvisioner := &OIDCUserProvisionerMock{
+//			AddUserIdentityFunc: func(ctx context.Context, db store.Execer, i *entity.UserIdentity) error {
+//				panic("mock out the AddUserIdentity method")
+//			},
+//			GetUserByIdentityFunc: func(ctx context.Context, db store.Queryer, issuer string, subject string) (*entity.User, error) {
+//				panic("mock out the GetUserByIdentity method")
+//			},
+//			RegisterUserFunc: func(ctx context.Context, db store.Execer, u *entity.User) error {
+//				panic("mock out the RegisterUser method")
+//			},
+//		}
+//
+//		// use mockedOIDCUserProvisioner in code that requires OIDCUserProvisioner
+//		// and then make assertions.
+//
+//	}
+type OIDCUserProvisionerMock struct {
+	// AddUserIdentityFunc mocks the AddUserIdentity method.
+	AddUserIdentityFunc func(ctx context.Context, db store.Execer, i *entity.UserIdentity) error
+
+	// GetUserByIdentityFunc mocks the GetUserByIdentity method.
+	GetUserByIdentityFunc func(ctx context.Context, db store.Queryer, issuer string, subject string) (*entity.User, error)
+
+	// RegisterUserFunc mocks the RegisterUser method.
+	RegisterUserFunc func(ctx context.Context, db store.Execer, u *entity.User) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddUserIdentity holds details about calls to the AddUserIdentity method.
+		AddUserIdentity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// I is the i argument value.
+			I *entity.UserIdentity
+		}
+		// GetUserByIdentity holds details about calls to the GetUserByIdentity method.
+		GetUserByIdentity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Issuer is the issuer argument value.
+			Issuer string
+			// Subject is the subject argument value.
+			Subject string
+		}
+		// RegisterUser holds details about calls to the RegisterUser method.
+		RegisterUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// U is the u argument value.
+			U *entity.User
+		}
+	}
+	lockAddUserIdentity   sync.RWMutex
+	lockGetUserByIdentity sync.RWMutex
+	lockRegisterUser      sync.RWMutex
+}
+
+// AddUserIdentity calls AddUserIdentityFunc.
+func (mock *OIDCUserProvisionerMock) AddUserIdentity(ctx context.Context, db store.Execer, i *entity.UserIdentity) error {
+	if mock.AddUserIdentityFunc == nil {
+		panic("OIDCUserProvisionerMock.AddUserIdentityFunc: method is nil but OIDCUserProvisioner.AddUserIdentity was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		I   *entity.UserIdentity
+	}{
+		Ctx: ctx,
+		Db:  db,
+		I:   i,
+	}
+	mock.lockAddUserIdentity.Lock()
+	mock.calls.AddUserIdentity = append(mock.calls.AddUserIdentity, callInfo)
+	mock.lockAddUserIdentity.Unlock()
+	return mock.AddUserIdentityFunc(ctx, db, i)
+}
+
+// AddUserIdentityCalls gets all the calls that were made to AddUserIdentity.
+// Check the length with:
+//
+//	len(mockedOIDCUserProvisioner.AddUserIdentityCalls())
+func (mock *OIDCUserProvisionerMock) AddUserIdentityCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	I   *entity.UserIdentity
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		I   *entity.UserIdentity
+	}
+	mock.lockAddUserIdentity.RLock()
+	calls = mock.calls.AddUserIdentity
+	mock.lockAddUserIdentity.RUnlock()
+	return calls
+}
+
+// GetUserByIdentity calls GetUserByIdentityFunc.
+func (mock *OIDCUserProvisionerMock) GetUserByIdentity(ctx context.Context, db store.Queryer, issuer string, subject string) (*entity.User, error) {
+	if mock.GetUserByIdentityFunc == nil {
+		panic("OIDCUserProvisionerMock.GetUserByIdentityFunc: method is nil but OIDCUserProvisioner.GetUserByIdentity was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		Issuer  string
+		Subject string
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		Issuer:  issuer,
+		Subject: subject,
+	}
+	mock.lockGetUserByIdentity.Lock()
+	mock.calls.GetUserByIdentity = append(mock.calls.GetUserByIdentity, callInfo)
+	mock.lockGetUserByIdentity.Unlock()
+	return mock.GetUserByIdentityFunc(ctx, db, issuer, subject)
+}
+
+// GetUserByIdentityCalls gets all the calls that were made to GetUserByIdentity.
+// Check the length with:
+//
+//	len(mockedOIDCUserProvisioner.GetUserByIdentityCalls())
+func (mock *OIDCUserProvisionerMock) GetUserByIdentityCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	Issuer  string
+	Subject string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		Issuer  string
+		Subject string
+	}
+	mock.lockGetUserByIdentity.RLock()
+	calls = mock.calls.GetUserByIdentity
+	mock.lockGetUserByIdentity.RUnlock()
+	return calls
+}
+
+// RegisterUser calls RegisterUserFunc.
+func (mock *OIDCUserProvisionerMock) RegisterUser(ctx context.Context, db store.Execer, u *entity.User) error {
+	if mock.RegisterUserFunc == nil {
+		panic("OIDCUserProvisionerMock.RegisterUserFunc: method is nil but OIDCUserProvisioner.RegisterUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}{
+		Ctx: ctx,
+		Db:  db,
+		U:   u,
+	}
+	mock.lockRegisterUser.Lock()
+	mock.calls.RegisterUser = append(mock.calls.RegisterUser, callInfo)
+	mock.lockRegisterUser.Unlock()
+	return mock.RegisterUserFunc(ctx, db, u)
+}
+
+// RegisterUserCalls gets all the calls that were made to RegisterUser.
+// Check the length with:
+//
+//	len(mockedOIDCUserProvisioner.RegisterUserCalls())
+func (mock *OIDCUserProvisionerMock) RegisterUserCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	U   *entity.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		U   *entity.User
+	}
+	mock.lockRegisterUser.RLock()
+	calls = mock.calls.RegisterUser
+	mock.lockRegisterUser.RUnlock()
+	return calls
+}
+
+// Ensure, that OIDCProviderMock does implement OIDCProvider.
+// If this is not the case, regenerate this file with moq.
+var _ OIDCProvider = &OIDCProviderMock{}
+
+// OIDCProviderMock is a mock implementation of OIDCProvider.
+//
+//	func TestSomethingThatUsesOIDCProvider(t *testing.T) {
+//
+//		// make and configure a mocked OIDCProvider
+//		mockedOIDCProvider := &OIDCProviderMock{
+//			AuthCodeURLFunc: func(ctx context.Context, req *oidc.AuthRequest) (string, error) {
+//				panic("mock out the AuthCodeURL method")
+//			},
+//			ExchangeFunc: func(ctx context.Context, code string, req *oidc.AuthRequest) (*oidc.Identity, error) {
+//				panic("mock out the Exchange method")
+//			},
+//		}
+//
+//		// use mockedOIDCProvider in code that requires OIDCProvider
+//		// and then make assertions.
+//
+//	}
+type OIDCProviderMock struct {
+	// AuthCodeURLFunc mocks the AuthCodeURL method.
+	AuthCodeURLFunc func(ctx context.Context, req *oidc.AuthRequest) (string, error)
+
+	// ExchangeFunc mocks the Exchange method.
+	ExchangeFunc func(ctx context.Context, code string, req *oidc.AuthRequest) (*oidc.Identity, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AuthCodeURL holds details about calls to the AuthCodeURL method.
+		AuthCodeURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *oidc.AuthRequest
+		}
+		// Exchange holds details about calls to the Exchange method.
+		Exchange []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+			// Req is the req argument value.
+			Req *oidc.AuthRequest
+		}
+	}
+	lockAuthCodeURL sync.RWMutex
+	lockExchange    sync.RWMutex
+}
+
+// AuthCodeURL calls AuthCodeURLFunc.
+func (mock *OIDCProviderMock) AuthCodeURL(ctx context.Context, req *oidc.AuthRequest) (string, error) {
+	if mock.AuthCodeURLFunc == nil {
+		panic("OIDCProviderMock.AuthCodeURLFunc: method is nil but OIDCProvider.AuthCodeURL was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *oidc.AuthRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockAuthCodeURL.Lock()
+	mock.calls.AuthCodeURL = append(mock.calls.AuthCodeURL, callInfo)
+	mock.lockAuthCodeURL.Unlock()
+	return mock.AuthCodeURLFunc(ctx, req)
+}
+
+// AuthCodeURLCalls gets all the calls that were made to AuthCodeURL.
+// Check the length with:
+//
+//	len(mockedOIDCProvider.AuthCodeURLCalls())
+func (mock *OIDCProviderMock) AuthCodeURLCalls() []struct {
+	Ctx context.Context
+	Req *oidc.AuthRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *oidc.AuthRequest
+	}
+	mock.lockAuthCodeURL.RLock()
+	calls = mock.calls.AuthCodeURL
+	mock.lockAuthCodeURL.RUnlock()
+	return calls
+}
+
+// Exchange calls ExchangeFunc.
+func (mock *OIDCProviderMock) Exchange(ctx context.Context, code string, req *oidc.AuthRequest) (*oidc.Identity, error) {
+	if mock.ExchangeFunc == nil {
+		panic("OIDCProviderMock.ExchangeFunc: method is nil but OIDCProvider.Exchange was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Code string
+		Req  *oidc.AuthRequest
+	}{
+		Ctx:  ctx,
+		Code: code,
+		Req:  req,
+	}
+	mock.lockExchange.Lock()
+	mock.calls.Exchange = append(mock.calls.Exchange, callInfo)
+	mock.lockExchange.Unlock()
+	return mock.ExchangeFunc(ctx, code, req)
+}
+
+// ExchangeCalls gets all the calls that were made to Exchange.
+// Check the length with:
+//
+//	len(mockedOIDCProvider.ExchangeCalls())
+func (mock *OIDCProviderMock) ExchangeCalls() []struct {
+	Ctx  context.Context
+	Code string
+	Req  *oidc.AuthRequest
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Code string
+		Req  *oidc.AuthRequest
+	}
+	mock.lockExchange.RLock()
+	calls = mock.calls.Exchange
+	mock.lockExchange.RUnlock()
+	return calls
+}
+
+// Ensure, that OIDCStateStoreMock does implement OIDCStateStore.
+// If this is not the case, regenerate this file with moq.
+var _ OIDCStateStore = &OIDCStateStoreMock{}
+
+// OIDCStateStoreMock is a mock implementation of OIDCStateStore.
+//
+//	func TestSomethingThatUsesOIDCStateStore(t *testing.T) {
+//
+//		// make and configure a mocked OIDCStateStore
+//		mockedOIDCStateStore := &OIDCStateStoreMock{
+//			ConsumeOIDCStateFunc: func(ctx context.Context, hash string) (string, string, error) {
+//				panic("mock out the ConsumeOIDCState method")
+//			},
+//			SaveOIDCStateFunc: func(ctx context.Context, hash string, nonce string, verifier string, ttl time.Duration) error {
+//				panic("mock out the SaveOIDCState method")
+//			},
+//		}
+//
+//		// use mockedOIDCStateStore in code that requires OIDCStateStore
+//		// and then make assertions.
+//
+//	}
+type OIDCStateStoreMock struct {
+	// ConsumeOIDCStateFunc mocks the ConsumeOIDCState method.
+	ConsumeOIDCStateFunc func(ctx context.Context, hash string) (string, string, error)
+
+	// SaveOIDCStateFunc mocks the SaveOIDCState method.
+	SaveOIDCStateFunc func(ctx context.Context, hash string, nonce string, verifier string, ttl time.Duration) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ConsumeOIDCState holds details about calls to the ConsumeOIDCState method.
+		ConsumeOIDCState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// SaveOIDCState holds details about calls to the SaveOIDCState method.
+		SaveOIDCState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hash is the hash argument value.
+			Hash string
+			// Nonce is the nonce argument value.
+			Nonce string
+			// Verifier is the verifier argument value.
+			Verifier string
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+	}
+	lockConsumeOIDCState sync.RWMutex
+	lockSaveOIDCState    sync.RWMutex
+}
+
+// ConsumeOIDCState calls ConsumeOIDCStateFunc.
+func (mock *OIDCStateStoreMock) ConsumeOIDCState(ctx context.Context, hash string) (string, string, error) {
+	if mock.ConsumeOIDCStateFunc == nil {
+		panic("OIDCStateStoreMock.ConsumeOIDCStateFunc: method is nil but OIDCStateStore.ConsumeOIDCState was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Hash string
+	}{
+		Ctx:  ctx,
+		Hash: hash,
+	}
+	mock.lockConsumeOIDCState.Lock()
+	mock.calls.ConsumeOIDCState = append(mock.calls.ConsumeOIDCState, callInfo)
+	mock.lockConsumeOIDCState.Unlock()
+	return mock.ConsumeOIDCStateFunc(ctx, hash)
+}
+
+// ConsumeOIDCStateCalls gets all the calls that were made to ConsumeOIDCState.
+// Check the length with:
+//
+//	len(mockedOIDCStateStore.ConsumeOIDCStateCalls())
+func (mock *OIDCStateStoreMock) ConsumeOIDCStateCalls() []struct {
+	Ctx  context.Context
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Hash string
+	}
+	mock.lockConsumeOIDCState.RLock()
+	calls = mock.calls.ConsumeOIDCState
+	mock.lockConsumeOIDCState.RUnlock()
+	return calls
+}
+
+// SaveOIDCState calls SaveOIDCStateFunc.
+func (mock *OIDCStateStoreMock) SaveOIDCState(ctx context.Context, hash string, nonce string, verifier string, ttl time.Duration) error {
+	if mock.SaveOIDCStateFunc == nil {
+		panic("OIDCStateStoreMock.SaveOIDCStateFunc: method is nil but OIDCStateStore.SaveOIDCState was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Hash     string
+		Nonce    string
+		Verifier string
+		TTL      time.Duration
+	}{
+		Ctx:      ctx,
+		Hash:     hash,
+		Nonce:    nonce,
+		Verifier: verifier,
+		TTL:      ttl,
+	}
+	mock.lockSaveOIDCState.Lock()
+	mock.calls.SaveOIDCState = append(mock.calls.SaveOIDCState, callInfo)
+	mock.lockSaveOIDCState.Unlock()
+	return mock.SaveOIDCStateFunc(ctx, hash, nonce, verifier, ttl)
+}
+
+// SaveOIDCStateCalls gets all the calls that were made to SaveOIDCState.
+// Check the length with:
+//
+//	len(mockedOIDCStateStore.SaveOIDCStateCalls())
+func (mock *OIDCStateStoreMock) SaveOIDCStateCalls() []struct {
+	Ctx      context.Context
+	Hash     string
+	Nonce    string
+	Verifier string
+	TTL      time.Duration
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Hash     string
+		Nonce    string
+		Verifier string
+		TTL      time.Duration
+	}
+	mock.lockSaveOIDCState.RLock()
+	calls = mock.calls.SaveOIDCState
+	mock.lockSaveOIDCState.RUnlock()
+	return calls
+}
+
+// Ensure, that TokenGeneratorMock does implement TokenGenerator.
+// If this is not the case, regenerate this file with moq.
+var _ TokenGenerator = &TokenGeneratorMock{}
+
+// TokenGeneratorMock is a mock implementation of TokenGenerator.
+//
+//	func TestSomethingThatUsesTokenGenerator(t *testing.T) {
+//
+//		// make and configure a mocked TokenGenerator
+//		mockedTokenGenerator := &TokenGeneratorMock{
+//			GenerateRefreshTokenFunc: func(ctx context.Context, u entity.User) (string, error) {
+//				panic("mock out the GenerateRefreshToken method")
+//			},
+//			GenerateTokenFunc: func(ctx context.Context, u entity.User) ([]byte, error) {
+//				panic("mock out the GenerateToken method")
+//			},
+//		}
+//
+//		// use mockedTokenGenerator in code that requires TokenGenerator
+//		// and then make assertions.
+//
+//	}
+type TokenGeneratorMock struct {
+	// GenerateRefreshTokenFunc mocks the GenerateRefreshToken method.
+	GenerateRefreshTokenFunc func(ctx context.Context, u entity.User) (string, error)
+
+	// GenerateTokenFunc mocks the GenerateToken method.
+	GenerateTokenFunc func(ctx context.Context, u entity.User) ([]byte, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GenerateRefreshToken holds details about calls to the GenerateRefreshToken method.
+		GenerateRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// U is the u argument value.
+			U entity.User
+		}
+		// GenerateToken holds details about calls to the GenerateToken method.
+		GenerateToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// U is the u argument value.
+			U entity.User
+		}
+	}
+	lockGenerateRefreshToken sync.RWMutex
+	lockGenerateToken        sync.RWMutex
+}
+
+// GenerateRefreshToken calls GenerateRefreshTokenFunc.
+func (mock *TokenGeneratorMock) GenerateRefreshToken(ctx context.Context, u entity.User) (string, error) {
+	if mock.GenerateRefreshTokenFunc == nil {
+		panic("TokenGeneratorMock.GenerateRefreshTokenFunc: method is nil but TokenGenerator.GenerateRefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		U   entity.User
+	}{
+		Ctx: ctx,
+		U:   u,
+	}
+	mock.lockGenerateRefreshToken.Lock()
+	mock.calls.GenerateRefreshToken = append(mock.calls.GenerateRefreshToken, callInfo)
+	mock.lockGenerateRefreshToken.Unlock()
+	return mock.GenerateRefreshTokenFunc(ctx, u)
+}
+
+// GenerateRefreshTokenCalls gets all the calls that were made to GenerateRefreshToken.
+// Check the length with:
+//
+//	len(mockedTokenGenerator.GenerateRefreshTokenCalls())
+func (mock *TokenGeneratorMock) GenerateRefreshTokenCalls() []struct {
+	Ctx context.Context
+	U   entity.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		U   entity.User
+	}
+	mock.lockGenerateRefreshToken.RLock()
+	calls = mock.calls.GenerateRefreshToken
+	mock.lockGenerateRefreshToken.RUnlock()
+	return calls
+}
+
+// GenerateToken calls GenerateTokenFunc.
+func (mock *TokenGeneratorMock) GenerateToken(ctx context.Context, u entity.User) ([]byte, error) {
+	if mock.GenerateTokenFunc == nil {
+		panic("TokenGeneratorMock.GenerateTokenFunc: method is nil but TokenGenerator.GenerateToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		U   entity.User
+	}{
+		Ctx: ctx,
+		U:   u,
+	}
+	mock.lockGenerateToken.Lock()
+	mock.calls.GenerateToken = append(mock.calls.GenerateToken, callInfo)
+	mock.lockGenerateToken.Unlock()
+	return mock.GenerateTokenFunc(ctx, u)
+}
+
+// GenerateTokenCalls gets all the calls that were made to GenerateToken.
+// Check the length with:
+//
+//	len(mockedTokenGenerator.GenerateTokenCalls())
+func (mock *TokenGeneratorMock) GenerateTokenCalls() []struct {
+	Ctx context.Context
+	U   entity.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		U   entity.User
+	}
+	mock.lockGenerateToken.RLock()
+	calls = mock.calls.GenerateToken
+	mock.lockGenerateToken.RUnlock()
+	return calls
+}
+
+// Ensure, that TokenRefresherMock does implement TokenRefresher.
+// If this is not the case, regenerate this file with moq.
+var _ TokenRefresher = &TokenRefresherMock{}
+
+// TokenRefresherMock is a mock implementation of TokenRefresher.
+//
+//	func TestSomethingThatUsesTokenRefresher(t *testing.T) {
+//
+//		// make and configure a mocked TokenRefresher
+//		mockedTokenRefresher := &TokenRefresherMock{
+//			GenerateTokenFunc: func(ctx context.Context, u entity.User) ([]byte, error) {
+//				panic("mock out the GenerateToken method")
+//			},
+//			RotateRefreshTokenFunc: func(ctx context.Context, token string) (string, entity.UserID, error) {
+//				panic("mock out the RotateRefreshToken method")
+//			},
+//		}
+//
+//		// use mockedTokenRefresher in code that requires TokenRefresher
+//		// and then make assertions.
+//
+//	}
+type TokenRefresherMock struct {
+	// GenerateTokenFunc mocks the GenerateToken method.
+	GenerateTokenFunc func(ctx context.Context, u entity.User) ([]byte, error)
+
+	// RotateRefreshTokenFunc mocks the RotateRefreshToken method.
+	RotateRefreshTokenFunc func(ctx context.Context, token string) (string, entity.UserID, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GenerateToken holds details about calls to the GenerateToken method.
+		GenerateToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// U is the u argument value.
+			U entity.User
+		}
+		// RotateRefreshToken holds details about calls to the RotateRefreshToken method.
+		RotateRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+	}
+	lockGenerateToken      sync.RWMutex
+	lockRotateRefreshToken sync.RWMutex
+}
+
+// GenerateToken calls GenerateTokenFunc.
+func (mock *TokenRefresherMock) GenerateToken(ctx context.Context, u entity.User) ([]byte, error) {
+	if mock.GenerateTokenFunc == nil {
+		panic("TokenRefresherMock.GenerateTokenFunc: method is nil but TokenRefresher.GenerateToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		U   entity.User
+	}{
+		Ctx: ctx,
+		U:   u,
+	}
+	mock.lockGenerateToken.Lock()
+	mock.calls.GenerateToken = append(mock.calls.GenerateToken, callInfo)
+	mock.lockGenerateToken.Unlock()
+	return mock.GenerateTokenFunc(ctx, u)
+}
+
+// GenerateTokenCalls gets all the calls that were made to GenerateToken.
+// Check the length with:
+//
+//	len(mockedTokenRefresher.GenerateTokenCalls())
+func (mock *TokenRefresherMock) GenerateTokenCalls() []struct {
+	Ctx context.Context
+	U   entity.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		U   entity.User
+	}
+	mock.lockGenerateToken.RLock()
+	calls = mock.calls.GenerateToken
+	mock.lockGenerateToken.RUnlock()
+	return calls
+}
+
+// RotateRefreshToken calls RotateRefreshTokenFunc.
+func (mock *TokenRefresherMock) RotateRefreshToken(ctx context.Context, token string) (string, entity.UserID, error) {
+	if mock.RotateRefreshTokenFunc == nil {
+		panic("TokenRefresherMock.RotateRefreshTokenFunc: method is nil but TokenRefresher.RotateRefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockRotateRefreshToken.Lock()
+	mock.calls.RotateRefreshToken = append(mock.calls.RotateRefreshToken, callInfo)
+	mock.lockRotateRefreshToken.Unlock()
+	return mock.RotateRefreshTokenFunc(ctx, token)
+}
+
+// RotateRefreshTokenCalls gets all the calls that were made to RotateRefreshToken.
+// Check the length with:
+//
+//	len(mockedTokenRefresher.RotateRefreshTokenCalls())
+func (mock *TokenRefresherMock) RotateRefreshTokenCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockRotateRefreshToken.RLock()
+	calls = mock.calls.RotateRefreshToken
+	mock.lockRotateRefreshToken.RUnlock()
+	return calls
+}
+
+// Ensure, that TokenRevokerMock does implement TokenRevoker.
+// If this is not the case, regenerate this file with moq.
+var _ TokenRevoker = &TokenRevokerMock{}
+
+// TokenRevokerMock is a mock implementation of TokenRevoker.
+//
+//	func TestSomethingThatUsesTokenRevoker(t *testing.T) {
+//
+//		// make and configure a mocked TokenRevoker
+//		mockedTokenRevoker := &TokenRevokerMock{
+//			RevokeAllTokensFunc: func(ctx context.Context, uid entity.UserID) error {
+//				panic("mock out the RevokeAllTokens method")
+//			},
+//			RevokeTokenFunc: func(ctx context.Context, jti string) error {
+//				panic("mock out the RevokeToken method")
+//			},
+//		}
+//
+//		// use mockedTokenRevoker in code that requires TokenRevoker
+//		// and then make assertions.
+//
+//	}
+type TokenRevokerMock struct {
+	// RevokeAllTokensFunc mocks the RevokeAllTokens method.
+	RevokeAllTokensFunc func(ctx context.Context, uid entity.UserID) error
+
+	// RevokeTokenFunc mocks the RevokeToken method.
+	RevokeTokenFunc func(ctx context.Context, jti string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RevokeAllTokens holds details about calls to the RevokeAllTokens method.
+		RevokeAllTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UID is the uid argument value.
+			UID entity.UserID
+		}
+		// RevokeToken holds details about calls to the RevokeToken method.
+		RevokeToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Jti is the jti argument value.
+			Jti string
+		}
+	}
+	lockRevokeAllTokens sync.RWMutex
+	lockRevokeToken     sync.RWMutex
+}
+
+// RevokeAllTokens calls RevokeAllTokensFunc.
+func (mock *TokenRevokerMock) RevokeAllTokens(ctx context.Context, uid entity.UserID) error {
+	if mock.RevokeAllTokensFunc == nil {
+		panic("TokenRevokerMock.RevokeAllTokensFunc: method is nil but TokenRevoker.RevokeAllTokens was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		UID entity.UserID
+	}{
+		Ctx: ctx,
+		UID: uid,
+	}
+	mock.lockRevokeAllTokens.Lock()
+	mock.calls.RevokeAllTokens = append(mock.calls.RevokeAllTokens, callInfo)
+	mock.lockRevokeAllTokens.Unlock()
+	return mock.RevokeAllTokensFunc(ctx, uid)
+}
+
+// RevokeAllTokensCalls gets all the calls that were made to RevokeAllTokens.
+// Check the length with:
+//
+//	len(mockedTokenRevoker.RevokeAllTokensCalls())
+func (mock *TokenRevokerMock) RevokeAllTokensCalls() []struct {
+	Ctx context.Context
+	UID entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		UID entity.UserID
+	}
+	mock.lockRevokeAllTokens.RLock()
+	calls = mock.calls.RevokeAllTokens
+	mock.lockRevokeAllTokens.RUnlock()
+	return calls
+}
+
+// RevokeToken calls RevokeTokenFunc.
+func (mock *TokenRevokerMock) RevokeToken(ctx context.Context, jti string) error {
+	if mock.RevokeTokenFunc == nil {
+		panic("TokenRevokerMock.RevokeTokenFunc: method is nil but TokenRevoker.RevokeToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Jti string
+	}{
+		Ctx: ctx,
+		Jti: jti,
+	}
+	mock.lockRevokeToken.Lock()
+	mock.calls.RevokeToken = append(mock.calls.RevokeToken, callInfo)
+	mock.lockRevokeToken.Unlock()
+	return mock.RevokeTokenFunc(ctx, jti)
+}
+
+// RevokeTokenCalls gets all the calls that were made to RevokeToken.
+// Check the length with:
+//
+//	len(mockedTokenRevoker.RevokeTokenCalls())
+func (mock *TokenRevokerMock) RevokeTokenCalls() []struct {
+	Ctx context.Context
+	Jti string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Jti string
+	}
+	mock.lockRevokeToken.RLock()
+	calls = mock.calls.RevokeToken
+	mock.lockRevokeToken.RUnlock()
+	return calls
+}
+
+// Ensure, that UserDisabledMarkerMock does implement UserDisabledMarker.
+// If this is not the case, regenerate this file with moq.
+var _ UserDisabledMarker = &UserDisabledMarkerMock{}
+
+// UserDisabledMarkerMock is a mock implementation of UserDisabledMarker.
+//
+//	func TestSomethingThatUsesUserDisabledMarker(t *testing.T) {
+//
+//		// make and configure a mocked UserDisabledMarker
+//		mockedUserDisabledMarker := &UserDisabledMarkerMock{
+//			DisableUserFunc: func(ctx context.Context, uid entity.UserID) error {
+//				panic("mock out the DisableUser method")
+//			},
+//			EnableUserFunc: func(ctx context.Context, uid entity.UserID) error {
+//				panic("mock out the EnableUser method")
+//			},
+//		}
+//
+//		// use mockedUserDisabledMarker in code that requires UserDisabledMarker
+//		// and then make assertions.
+//
+//	}
+type UserDisabledMarkerMock struct {
+	// DisableUserFunc mocks the DisableUser method.
+	DisableUserFunc func(ctx context.Context, uid entity.UserID) error
+
+	// EnableUserFunc mocks the EnableUser method.
+	EnableUserFunc func(ctx context.Context, uid entity.UserID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DisableUser holds details about calls to the DisableUser method.
+		DisableUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UID is the uid argument value.
+			UID entity.UserID
+		}
+		// EnableUser holds details about calls to the EnableUser method.
+		EnableUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UID is the uid argument value.
+			UID entity.UserID
+		}
+	}
+	lockDisableUser sync.RWMutex
+	lockEnableUser  sync.RWMutex
+}
+
+// DisableUser calls DisableUserFunc.
+func (mock *UserDisabledMarkerMock) DisableUser(ctx context.Context, uid entity.UserID) error {
+	if mock.DisableUserFunc == nil {
+		panic("UserDisabledMarkerMock.DisableUserFunc: method is nil but UserDisabledMarker.DisableUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		UID entity.UserID
+	}{
+		Ctx: ctx,
+		UID: uid,
+	}
+	mock.lockDisableUser.Lock()
+	mock.calls.DisableUser = append(mock.calls.DisableUser, callInfo)
+	mock.lockDisableUser.Unlock()
+	return mock.DisableUserFunc(ctx, uid)
+}
+
+// DisableUserCalls gets all the calls that were made to DisableUser.
+// Check the length with:
+//
+//	len(mockedUserDisabledMarker.DisableUserCalls())
+func (mock *UserDisabledMarkerMock) DisableUserCalls() []struct {
+	Ctx context.Context
+	UID entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		UID entity.UserID
+	}
+	mock.lockDisableUser.RLock()
+	calls = mock.calls.DisableUser
+	mock.lockDisableUser.RUnlock()
+	return calls
+}
+
+// EnableUser calls EnableUserFunc.
+func (mock *UserDisabledMarkerMock) EnableUser(ctx context.Context, uid entity.UserID) error {
+	if mock.EnableUserFunc == nil {
+		panic("UserDisabledMarkerMock.EnableUserFunc: method is nil but UserDisabledMarker.EnableUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		UID entity.UserID
+	}{
+		Ctx: ctx,
+		UID: uid,
+	}
+	mock.lockEnableUser.Lock()
+	mock.calls.EnableUser = append(mock.calls.EnableUser, callInfo)
+	mock.lockEnableUser.Unlock()
+	return mock.EnableUserFunc(ctx, uid)
+}
+
+// EnableUserCalls gets all the calls that were made to EnableUser.
+// Check the length with:
+//
+//	len(mockedUserDisabledMarker.EnableUserCalls())
+func (mock *UserDisabledMarkerMock) EnableUserCalls() []struct {
+	Ctx context.Context
+	UID entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		UID entity.UserID
+	}
+	mock.lockEnableUser.RLock()
+	calls = mock.calls.EnableUser
+	mock.lockEnableUser.RUnlock()
+	return calls
+}
+
+// Ensure, that SessionListerMock does implement SessionLister.
+// If this is not the case, regenerate this file with moq.
+var _ SessionLister = &SessionListerMock{}
+
+// SessionListerMock is a mock implementation of SessionLister.
+//
+//	func TestSomethingThatUsesSessionLister(t *testing.T) {
+//
+//		// make and configure a mocked SessionLister
+//		mockedSessionLister := &SessionListerMock{
+//			ListSessionsFunc: func(ctx context.Context, uid entity.UserID) (entity.Sessions, error) {
+//				panic("mock out the ListSessions method")
+//			},
+//		}
+//
+//		// use mockedSessionLister in code that requires SessionLister
+//		// and then make assertions.
+//
+//	}
+type SessionListerMock struct {
+	// ListSessionsFunc mocks the ListSessions method.
+	ListSessionsFunc func(ctx context.Context, uid entity.UserID) (entity.Sessions, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListSessions holds details about calls to the ListSessions method.
+		ListSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UID is the uid argument value.
+			UID entity.UserID
+		}
+	}
+	lockListSessions sync.RWMutex
+}
+
+// ListSessions calls ListSessionsFunc.
+func (mock *SessionListerMock) ListSessions(ctx context.Context, uid entity.UserID) (entity.Sessions, error) {
+	if mock.ListSessionsFunc == nil {
+		panic("SessionListerMock.ListSessionsFunc: method is nil but SessionLister.ListSessions was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		UID entity.UserID
+	}{
+		Ctx: ctx,
+		UID: uid,
+	}
+	mock.lockListSessions.Lock()
+	mock.calls.ListSessions = append(mock.calls.ListSessions, callInfo)
+	mock.lockListSessions.Unlock()
+	return mock.ListSessionsFunc(ctx, uid)
+}
+
+// ListSessionsCalls gets all the calls that were made to ListSessions.
+// Check the length with:
+//
+//	len(mockedSessionLister.ListSessionsCalls())
+func (mock *SessionListerMock) ListSessionsCalls() []struct {
+	Ctx context.Context
+	UID entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		UID entity.UserID
+	}
+	mock.lockListSessions.RLock()
+	calls = mock.calls.ListSessions
+	mock.lockListSessions.RUnlock()
+	return calls
+}
+
+// Ensure, that SessionRevokerMock does implement SessionRevoker.
+// If this is not the case, regenerate this file with moq.
+var _ SessionRevoker = &SessionRevokerMock{}
+
+// SessionRevokerMock is a mock implementation of SessionRevoker.
+//
+//	func TestSomethingThatUsesSessionRevoker(t *testing.T) {
+//
+//		// make and configure a mocked SessionRevoker
+//		mockedSessionRevoker := &SessionRevokerMock{
+//			RevokeSessionFunc: func(ctx context.Context, uid entity.UserID, sid string) error {
+//				panic("mock out the RevokeSession method")
+//			},
+//		}
+//
+//		// use mockedSessionRevoker in code that requires SessionRevoker
+//		// and then make assertions.
+//
+//	}
+type SessionRevokerMock struct {
+	// RevokeSessionFunc mocks the RevokeSession method.
+	RevokeSessionFunc func(ctx context.Context, uid entity.UserID, sid string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RevokeSession holds details about calls to the RevokeSession method.
+		RevokeSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UID is the uid argument value.
+			UID entity.UserID
+			// Sid is the sid argument value.
+			Sid string
+		}
+	}
+	lockRevokeSession sync.RWMutex
+}
+
+// RevokeSession calls RevokeSessionFunc.
+func (mock *SessionRevokerMock) RevokeSession(ctx context.Context, uid entity.UserID, sid string) error {
+	if mock.RevokeSessionFunc == nil {
+		panic("SessionRevokerMock.RevokeSessionFunc: method is nil but SessionRevoker.RevokeSession was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		UID entity.UserID
+		Sid string
+	}{
+		Ctx: ctx,
+		UID: uid,
+		Sid: sid,
+	}
+	mock.lockRevokeSession.Lock()
+	mock.calls.RevokeSession = append(mock.calls.RevokeSession, callInfo)
+	mock.lockRevokeSession.Unlock()
+	return mock.RevokeSessionFunc(ctx, uid, sid)
+}
+
+// RevokeSessionCalls gets all the calls that were made to RevokeSession.
+// Check the length with:
+//
+//	len(mockedSessionRevoker.RevokeSessionCalls())
+func (mock *SessionRevokerMock) RevokeSessionCalls() []struct {
+	Ctx context.Context
+	UID entity.UserID
+	Sid string
+} {
+	var calls []struct {
+		Ctx context.Context
+		UID entity.UserID
+		Sid string
+	}
+	mock.lockRevokeSession.RLock()
+	calls = mock.calls.RevokeSession
+	mock.lockRevokeSession.RUnlock()
+	return calls
+}
+
+// Ensure, that PersonalAccessTokenAdderMock does implement PersonalAccessTokenAdder.
+// If this is not the case, regenerate this file with moq.
+var _ PersonalAccessTokenAdder = &PersonalAccessTokenAdderMock{}
+
+// PersonalAccessTokenAdderMock is a mock implementation of PersonalAccessTokenAdder.
+//
+//	func TestSomethingThatUsesPersonalAccessTokenAdder(t *testing.T) {
+//
+//		// make and configure a mocked PersonalAccessTokenAdder
+//		mockedPersonalAccessTokenAdder := &PersonalAccessTokenAdderMock{
+//			AddPersonalAccessTokenFunc: func(ctx context.Context, db store.Execer, t *entity.PersonalAccessToken) error {
+//				panic("mock out the AddPersonalAccessToken method")
+//			},
+//		}
+//
+//		// use mockedPersonalAccessTokenAdder in code that requires PersonalAccessTokenAdder
+//		// and then make assertions.
+//
+//	}
+type PersonalAccessTokenAdderMock struct {
+	// AddPersonalAccessTokenFunc mocks the AddPersonalAccessToken method.
+	AddPersonalAccessTokenFunc func(ctx context.Context, db store.Execer, t *entity.PersonalAccessToken) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddPersonalAccessToken holds details about calls to the AddPersonalAccessToken method.
+		AddPersonalAccessToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// T is the t argument value.
+			T *entity.PersonalAccessToken
+		}
+	}
+	lockAddPersonalAccessToken sync.RWMutex
+}
+
+// AddPersonalAccessToken calls AddPersonalAccessTokenFunc.
+func (mock *PersonalAccessTokenAdderMock) AddPersonalAccessToken(ctx context.Context, db store.Execer, t *entity.PersonalAccessToken) error {
+	if mock.AddPersonalAccessTokenFunc == nil {
+		panic("PersonalAccessTokenAdderMock.AddPersonalAccessTokenFunc: method is nil but PersonalAccessTokenAdder.AddPersonalAccessToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.PersonalAccessToken
+	}{
+		Ctx: ctx,
+		Db:  db,
+		T:   t,
+	}
+	mock.lockAddPersonalAccessToken.Lock()
+	mock.calls.AddPersonalAccessToken = append(mock.calls.AddPersonalAccessToken, callInfo)
+	mock.lockAddPersonalAccessToken.Unlock()
+	return mock.AddPersonalAccessTokenFunc(ctx, db, t)
+}
+
+// AddPersonalAccessTokenCalls gets all the calls that were made to AddPersonalAccessToken.
+// Check the length with:
+//
+//	len(mockedPersonalAccessTokenAdder.AddPersonalAccessTokenCalls())
+func (mock *PersonalAccessTokenAdderMock) AddPersonalAccessTokenCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	T   *entity.PersonalAccessToken
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.PersonalAccessToken
+	}
+	mock.lockAddPersonalAccessToken.RLock()
+	calls = mock.calls.AddPersonalAccessToken
+	mock.lockAddPersonalAccessToken.RUnlock()
+	return calls
+}
+
+// Ensure, that PersonalAccessTokenListerMock does implement PersonalAccessTokenLister.
+// If this is not the case, regenerate this file with moq.
+var _ PersonalAccessTokenLister = &PersonalAccessTokenListerMock{}
+
+// PersonalAccessTokenListerMock is a mock implementation of PersonalAccessTokenLister.
+//
+//	func TestSomethingThatUsesPersonalAccessTokenLister(t *testing.T) {
+//
+//		// make and configure a mocked PersonalAccessTokenLister
+//		mockedPersonalAccessTokenLister := &PersonalAccessTokenListerMock{
+//			ListPersonalAccessTokensFunc: func(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.PersonalAccessTokens, error) {
+//				panic("mock out the ListPersonalAccessTokens method")
+//			},
+//		}
+//
+//		// use mockedPersonalAccessTokenLister in code that requires PersonalAccessTokenLister
+//		// and then make assertions.
+//
+//	}
+type PersonalAccessTokenListerMock struct {
+	// ListPersonalAccessTokensFunc mocks the ListPersonalAccessTokens method.
+	ListPersonalAccessTokensFunc func(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.PersonalAccessTokens, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListPersonalAccessTokens holds details about calls to the ListPersonalAccessTokens method.
+		ListPersonalAccessTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UID is the uid argument value.
+			UID entity.UserID
+		}
+	}
+	lockListPersonalAccessTokens sync.RWMutex
+}
+
+// ListPersonalAccessTokens calls ListPersonalAccessTokensFunc.
+func (mock *PersonalAccessTokenListerMock) ListPersonalAccessTokens(ctx context.Context, db store.Queryer, uid entity.UserID) (entity.PersonalAccessTokens, error) {
+	if mock.ListPersonalAccessTokensFunc == nil {
+		panic("PersonalAccessTokenListerMock.ListPersonalAccessTokensFunc: method is nil but PersonalAccessTokenLister.ListPersonalAccessTokens was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+	}
+	mock.lockListPersonalAccessTokens.Lock()
+	mock.calls.ListPersonalAccessTokens = append(mock.calls.ListPersonalAccessTokens, callInfo)
+	mock.lockListPersonalAccessTokens.Unlock()
+	return mock.ListPersonalAccessTokensFunc(ctx, db, uid)
+}
+
+// ListPersonalAccessTokensCalls gets all the calls that were made to ListPersonalAccessTokens.
+// Check the length with:
+//
+//	len(mockedPersonalAccessTokenLister.ListPersonalAccessTokensCalls())
+func (mock *PersonalAccessTokenListerMock) ListPersonalAccessTokensCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	UID entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		UID entity.UserID
+	}
+	mock.lockListPersonalAccessTokens.RLock()
+	calls = mock.calls.ListPersonalAccessTokens
+	mock.lockListPersonalAccessTokens.RUnlock()
+	return calls
+}
+
+// Ensure, that PersonalAccessTokenGetterMock does implement PersonalAccessTokenGetter.
+// If this is not the case, regenerate this file with moq.
+var _ PersonalAccessTokenGetter = &PersonalAccessTokenGetterMock{}
+
+// PersonalAccessTokenGetterMock is a mock implementation of PersonalAccessTokenGetter.
+//
+//	func TestSomethingThatUsesPersonalAccessTokenGetter(t *testing.T) {
+//
+//		// make and configure a mocked PersonalAccessTokenGetter
+//		mockedPersonalAccessTokenGetter := &PersonalAccessTokenGetterMock{
+//			GetPersonalAccessTokenByHashFunc: func(ctx context.Context, db store.Queryer, hash string) (*entity.PersonalAccessToken, error) {
+//				panic("mock out the GetPersonalAccessTokenByHash method")
+//			},
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//		}
+//
+//		// use mockedPersonalAccessTokenGetter in code that requires PersonalAccessTokenGetter
+//		// and then make assertions.
+//
+//	}
+type PersonalAccessTokenGetterMock struct {
+	// GetPersonalAccessTokenByHashFunc mocks the GetPersonalAccessTokenByHash method.
+	GetPersonalAccessTokenByHashFunc func(ctx context.Context, db store.Queryer, hash string) (*entity.PersonalAccessToken, error)
+
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetPersonalAccessTokenByHash holds details about calls to the GetPersonalAccessTokenByHash method.
+		GetPersonalAccessTokenByHash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+	}
+	lockGetPersonalAccessTokenByHash sync.RWMutex
+	lockGetUserByID                  sync.RWMutex
+}
+
+// GetPersonalAccessTokenByHash calls GetPersonalAccessTokenByHashFunc.
+func (mock *PersonalAccessTokenGetterMock) GetPersonalAccessTokenByHash(ctx context.Context, db store.Queryer, hash string) (*entity.PersonalAccessToken, error) {
+	if mock.GetPersonalAccessTokenByHashFunc == nil {
+		panic("PersonalAccessTokenGetterMock.GetPersonalAccessTokenByHashFunc: method is nil but PersonalAccessTokenGetter.GetPersonalAccessTokenByHash was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Db   store.Queryer
+		Hash string
+	}{
+		Ctx:  ctx,
+		Db:   db,
+		Hash: hash,
+	}
+	mock.lockGetPersonalAccessTokenByHash.Lock()
+	mock.calls.GetPersonalAccessTokenByHash = append(mock.calls.GetPersonalAccessTokenByHash, callInfo)
+	mock.lockGetPersonalAccessTokenByHash.Unlock()
+	return mock.GetPersonalAccessTokenByHashFunc(ctx, db, hash)
+}
+
+// GetPersonalAccessTokenByHashCalls gets all the calls that were made to GetPersonalAccessTokenByHash.
+// Check the length with:
+//
+//	len(mockedPersonalAccessTokenGetter.GetPersonalAccessTokenByHashCalls())
+func (mock *PersonalAccessTokenGetterMock) GetPersonalAccessTokenByHashCalls() []struct {
+	Ctx  context.Context
+	Db   store.Queryer
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Db   store.Queryer
+		Hash string
+	}
+	mock.lockGetPersonalAccessTokenByHash.RLock()
+	calls = mock.calls.GetPersonalAccessTokenByHash
+	mock.lockGetPersonalAccessTokenByHash.RUnlock()
+	return calls
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *PersonalAccessTokenGetterMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("PersonalAccessTokenGetterMock.GetUserByIDFunc: method is nil but PersonalAccessTokenGetter.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedPersonalAccessTokenGetter.GetUserByIDCalls())
+func (mock *PersonalAccessTokenGetterMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// Ensure, that PersonalAccessTokenDeleterMock does implement PersonalAccessTokenDeleter.
+// If this is not the case, regenerate this file with moq.
+var _ PersonalAccessTokenDeleter = &PersonalAccessTokenDeleterMock{}
+
+// PersonalAccessTokenDeleterMock is a mock implementation of PersonalAccessTokenDeleter.
+//
+//	func TestSomethingThatUsesPersonalAccessTokenDeleter(t *testing.T) {
+//
+//		// make and configure a mocked PersonalAccessTokenDeleter
+//		mockedPersonalAccessTokenDeleter := &PersonalAccessTokenDeleterMock{
+//			DeletePersonalAccessTokenFunc: func(ctx context.Context, db store.Execer, uid entity.UserID, id entity.PersonalAccessTokenID) error {
+//				panic("mock out the DeletePersonalAccessToken method")
+//			},
+//		}
+//
+//		// use mockedPersonalAccessTokenDeleter in code that requires PersonalAccessTokenDeleter
+//		// and then make assertions.
+//
+//	}
+type PersonalAccessTokenDeleterMock struct {
+	// DeletePersonalAccessTokenFunc mocks the DeletePersonalAccessToken method.
+	DeletePersonalAccessTokenFunc func(ctx context.Context, db store.Execer, uid entity.UserID, id entity.PersonalAccessTokenID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeletePersonalAccessToken holds details about calls to the DeletePersonalAccessToken method.
+		DeletePersonalAccessToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UID is the uid argument value.
+			UID entity.UserID
+			// ID is the id argument value.
+			ID entity.PersonalAccessTokenID
+		}
+	}
+	lockDeletePersonalAccessToken sync.RWMutex
+}
+
+// DeletePersonalAccessToken calls DeletePersonalAccessTokenFunc.
+func (mock *PersonalAccessTokenDeleterMock) DeletePersonalAccessToken(ctx context.Context, db store.Execer, uid entity.UserID, id entity.PersonalAccessTokenID) error {
+	if mock.DeletePersonalAccessTokenFunc == nil {
+		panic("PersonalAccessTokenDeleterMock.DeletePersonalAccessTokenFunc: method is nil but PersonalAccessTokenDeleter.DeletePersonalAccessToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		UID entity.UserID
+		ID  entity.PersonalAccessTokenID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		UID: uid,
+		ID:  id,
+	}
+	mock.lockDeletePersonalAccessToken.Lock()
+	mock.calls.DeletePersonalAccessToken = append(mock.calls.DeletePersonalAccessToken, callInfo)
+	mock.lockDeletePersonalAccessToken.Unlock()
+	return mock.DeletePersonalAccessTokenFunc(ctx, db, uid, id)
+}
+
+// DeletePersonalAccessTokenCalls gets all the calls that were made to DeletePersonalAccessToken.
+// Check the length with:
+//
+//	len(mockedPersonalAccessTokenDeleter.DeletePersonalAccessTokenCalls())
+func (mock *PersonalAccessTokenDeleterMock) DeletePersonalAccessTokenCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	UID entity.UserID
+	ID  entity.PersonalAccessTokenID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		UID entity.UserID
+		ID  entity.PersonalAccessTokenID
+	}
+	mock.lockDeletePersonalAccessToken.RLock()
+	calls = mock.calls.DeletePersonalAccessToken
+	mock.lockDeletePersonalAccessToken.RUnlock()
 	return calls
 }
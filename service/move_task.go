@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type MoveTask struct {
+	DB   store.TxBeginner
+	Repo TaskMover
+}
+
+// MoveTask はタスクの親タスクを変更し、変更後のタスクを返却する
+// parentIDがnilの場合は親タスクを持たないタスクとする
+// handler/service.goの実装
+func (m *MoveTask) MoveTask(ctx context.Context, id entity.TaskID, parentID *entity.TaskID) (*entity.Task, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	tx, err := m.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Commit済の場合、Rollbackはsql.ErrTxDoneを返すのみのため戻り値は破棄する
+	defer func() { _ = tx.Rollback() }()
+
+	t, err := m.Repo.GetTaskForUpdate(ctx, tx, uid, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get: %w", err)
+	}
+	if parentID != nil {
+		if err := m.Repo.ValidateTaskParent(ctx, tx, uid, id, *parentID); err != nil {
+			return nil, fmt.Errorf("failed to validate parent: %w", err)
+		}
+	}
+	t.ParentID = parentID
+	if err := m.Repo.UpdateTaskParent(ctx, tx, t); err != nil {
+		return nil, fmt.Errorf("failed to update parent: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/oidc"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// ErrInvalidOIDCState はOpenID Connectのコールバックのstateが存在しない、期限切れ、または使用済であることを表す
+var ErrInvalidOIDCState = errors.New("invalid or expired oidc state")
+
+// errIdentityConflict は並行したログインにより、IdPのユーザが他のトランザクションで対応付けられたことを表す
+var errIdentityConflict = errors.New("identity provisioned concurrently")
+
+const (
+	// oidcUserNameMaxLength はIdPのユーザ情報から生成するユーザ名の最大文字数(重複時に付与する接尾辞を除く)
+	// ユーザ名のカラムの最大長から、接尾辞の"-"と16進数6桁を除いた長さとする
+	oidcUserNameMaxLength = 13
+	// oidcUserNameAttempts はユーザ名が重複した場合に、接尾辞を変えて登録を試行する回数
+	oidcUserNameAttempts = 3
+	// oidcDisplayNameMaxLength は表示名の最大文字数
+	oidcDisplayNameMaxLength = 64
+)
+
+type OIDCLogin struct {
+	DB             store.TxBeginner
+	Repo           OIDCUserProvisioner
+	Provider       OIDCProvider
+	States         OIDCStateStore
+	TokenGenerator TokenGenerator
+}
+
+// OIDCLogin はIdPからのコールバックのstateと認可コードを検証し、アクセストークンとリフレッシュトークンを発行する
+// IdPのユーザに対応するユーザが存在しない場合は、初回のログインとしてユーザを登録する
+// IdPで認証済のため、パスワードの再設定や二要素認証は要求しない
+// handler/service.goの実装
+func (o *OIDCLogin) OIDCLogin(ctx context.Context, state, code string) (*auth.TokenPair, error) {
+	nonce, verifier, err := o.States.ConsumeOIDCState(ctx, hashOIDCState(state))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrInvalidOIDCState
+		}
+		return nil, fmt.Errorf("failed to consume oidc state: %w", err)
+	}
+	id, err := o.Provider.Exchange(ctx, code, &oidc.AuthRequest{State: state, Nonce: nonce, Verifier: verifier})
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	u, err := o.provision(ctx, id)
+	if errors.Is(err, errIdentityConflict) {
+		// 並行したログインで登録されたユーザを取得し直す
+		u, err = o.provision(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if u.Disabled() {
+		return nil, auth.ErrUserDisabled
+	}
+
+	// パスワードを持たないユーザの再認証に使用するため、IdPでの認証日時をトークンに記録する
+	ctx = auth.SetAuthTime(ctx, id.AuthTime)
+	refresh, err := o.TokenGenerator.GenerateRefreshToken(ctx, *u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	// アクセストークンのセッションをリフレッシュトークンに関連付け、セッション単位で失効できるようにする
+	jwt, err := o.TokenGenerator.GenerateToken(auth.SetRefreshToken(ctx, refresh), *u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT: %w", err)
+	}
+	return &auth.TokenPair{AccessToken: string(jwt), RefreshToken: refresh}, nil
+}
+
+// provision はIdPのユーザに対応付けたユーザを取得し、存在しない場合はユーザを登録して対応付ける
+// 登録したユーザはパスワードを持たないため、パスワードによるログインはできない
+// メールアドレスは既存のユーザとの重複を避けるため登録しない
+func (o *OIDCLogin) provision(ctx context.Context, id *oidc.Identity) (*entity.User, error) {
+	tx, err := o.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Commit済の場合、Rollbackはsql.ErrTxDoneを返すのみのため戻り値は破棄する
+	defer func() { _ = tx.Rollback() }()
+
+	u, err := o.Repo.GetUserByIdentity(ctx, tx, id.Issuer, id.Subject)
+	if err == nil {
+		return u, nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	display := []rune(id.Name)
+	if len(display) > oidcDisplayNameMaxLength {
+		display = display[:oidcDisplayNameMaxLength]
+	}
+	u = &entity.User{
+		Role:        entity.DefaultRole,
+		DisplayName: string(display),
+		TimeZone:    entity.DefaultTimeZone,
+		Locale:      entity.DefaultLocale,
+	}
+	base := oidcUserName(id)
+	for i := 0; i < oidcUserNameAttempts; i++ {
+		u.Name = base
+		if i > 0 {
+			suffix, err := randomHex(3)
+			if err != nil {
+				return nil, err
+			}
+			u.Name = base + "-" + suffix
+		}
+		if err = o.Repo.RegisterUser(ctx, tx, u); !errors.Is(err, store.ErrAlreadyEntry) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to register user: %w", err)
+	}
+
+	if err := o.Repo.AddUserIdentity(ctx, tx, &entity.UserIdentity{
+		UserID: u.ID, Issuer: id.Issuer, Subject: id.Subject,
+	}); err != nil {
+		if errors.Is(err, store.ErrAlreadyEntry) {
+			return nil, errIdentityConflict
+		}
+		return nil, fmt.Errorf("failed to add identity: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return u, nil
+}
+
+// oidcUserName はIdPのユーザ情報からユーザ名を生成する
+// preferred_username、メールアドレスのローカル部の順に使用し、英数字と"._-"以外の文字は除外する
+func oidcUserName(id *oidc.Identity) string {
+	base := id.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(id.Email, "@")
+	}
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		}
+		return -1
+	}, base)
+	if len(name) > oidcUserNameMaxLength {
+		name = name[:oidcUserNameMaxLength]
+	}
+	if name == "" {
+		name = "user"
+	}
+	return name
+}
+
+// randomHex はnバイトの乱数を16進数文字列で返却する
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
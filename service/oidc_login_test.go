@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/oidc"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/jmoiron/sqlx"
+)
+
+// newOIDCStateStore はRedisの代わりにマップで認可リクエストのstateを管理するモックを生成する
+func newOIDCStateStore() *OIDCStateStoreMock {
+	var mu sync.Mutex
+	states := map[string][2]string{}
+	moq := &OIDCStateStoreMock{}
+	moq.SaveOIDCStateFunc = func(ctx context.Context, hash, nonce, verifier string, ttl time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		states[hash] = [2]string{nonce, verifier}
+		return nil
+	}
+	moq.ConsumeOIDCStateFunc = func(ctx context.Context, hash string) (string, string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		s, ok := states[hash]
+		if !ok {
+			return "", "", store.ErrNotFound
+		}
+		delete(states, hash)
+		return s[0], s[1], nil
+	}
+	return moq
+}
+
+// TestOIDCLogin テスト用のIdPを使用して、認可リクエストの開始からコールバックによるログインまでを確認する
+func TestOIDCLogin(t *testing.T) {
+	t.Parallel()
+
+	const clientID = "todo-app"
+	tests := map[string]struct {
+		claims map[string]any
+		// existing はIdPのユーザに対応付け済のユーザ(nilの場合は初回のログイン)
+		existing *entity.User
+		// taken は登録済のユーザ名
+		taken    map[string]bool
+		state    string // 空文字の場合は開始時のstateを使用する
+		wantName string
+		wantErr  error
+	}{
+		"firstLogin": {
+			claims:   map[string]any{"preferred_username": "alice", "name": "Alice Liddell"},
+			wantName: "alice",
+		},
+		"nameFromEmail": {
+			claims:   map[string]any{"email": "bob.smith+todo@example.com"},
+			wantName: "bob.smithtodo",
+		},
+		"nameTaken": {
+			claims:   map[string]any{"preferred_username": "alice"},
+			taken:    map[string]bool{"alice": true},
+			wantName: "alice-",
+		},
+		"existingUser": {
+			claims:   map[string]any{"preferred_username": "renamed"},
+			existing: &entity.User{ID: 10, Name: "carol"},
+			wantName: "carol",
+		},
+		"unknownState": {
+			state:   "unknown",
+			wantErr: ErrInvalidOIDCState,
+		},
+		"invalidIDToken": {
+			claims:  map[string]any{"aud": []string{"other-app"}},
+			wantErr: oidc.ErrAuthentication,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			idp := testutil.NewFakeIdP(t, clientID, clock.FixedClocker{}.Now())
+			for k, v := range tt.claims {
+				idp.Claims[k] = v
+			}
+			provider := &oidc.Provider{
+				Issuer:      idp.Issuer(),
+				ClientID:    clientID,
+				RedirectURL: "http://localhost/auth/oidc/callback",
+				Scopes:      []string{"openid"},
+				Client:      idp.Server.Client(),
+				Clocker:     clock.FixedClocker{},
+			}
+			states := newOIDCStateStore()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			mock.MatchExpectationsInOrder(false)
+			for i := 0; i < 2; i++ {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+				mock.ExpectRollback()
+			}
+
+			var registered *entity.User
+			repo := &OIDCUserProvisionerMock{}
+			repo.GetUserByIdentityFunc = func(ctx context.Context, db store.Queryer, issuer, subject string) (*entity.User, error) {
+				if issuer != idp.Issuer() || subject != "fake-subject" {
+					t.Errorf("unexpected identity %q of %q", subject, issuer)
+				}
+				if tt.existing != nil {
+					return tt.existing, nil
+				}
+				return nil, store.ErrNotFound
+			}
+			repo.RegisterUserFunc = func(ctx context.Context, db store.Execer, u *entity.User) error {
+				if tt.taken[u.Name] {
+					return store.ErrAlreadyEntry
+				}
+				u.ID = 20
+				registered = u
+				return nil
+			}
+			repo.AddUserIdentityFunc = func(ctx context.Context, db store.Execer, i *entity.UserIdentity) error {
+				if i.UserID != 20 || i.Issuer != idp.Issuer() || i.Subject != "fake-subject" {
+					t.Errorf("unexpected identity: %+v", i)
+				}
+				return nil
+			}
+			var issued entity.User
+			var authTime time.Time
+			tg := &TokenGeneratorMock{}
+			tg.GenerateTokenFunc = func(ctx context.Context, u entity.User) ([]byte, error) {
+				issued = u
+				authTime, _ = auth.GetAuthTime(ctx)
+				return []byte("access"), nil
+			}
+			tg.GenerateRefreshTokenFunc = func(ctx context.Context, u entity.User) (string, error) {
+				return "refresh", nil
+			}
+
+			start := &StartOIDCLogin{Provider: provider, States: states, TTL: time.Minute}
+			authURL, state, err := start.StartOIDCLogin(ctx)
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			code := idp.Authorize(t, authURL)
+			if tt.state != "" {
+				state = tt.state
+			}
+
+			sut := &OIDCLogin{
+				DB: sqlx.NewDb(db, "mysql"), Repo: repo, Provider: provider, States: states, TokenGenerator: tg,
+			}
+			got, err := sut.OIDCLogin(ctx, state, code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want %v, but got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.AccessToken != "access" || got.RefreshToken != "refresh" {
+				t.Errorf("unexpected tokens: %+v", got)
+			}
+			if len(issued.Name) < len(tt.wantName) || issued.Name[:len(tt.wantName)] != tt.wantName {
+				t.Errorf("want name %q, but got %q", tt.wantName, issued.Name)
+			}
+			// パスワードを持たないユーザの再認証に使用するため、IdPでの認証日時をトークンに記録する
+			if authTime.IsZero() {
+				t.Error("want auth time passed to token generator, but got none")
+			}
+			if tt.existing == nil {
+				// 初回のログインで登録したユーザはパスワードを持たない
+				if registered == nil || registered.Password != "" || registered.Role != entity.DefaultRole {
+					t.Errorf("unexpected registered user: %+v", registered)
+				}
+				if err := registered.ComparePassword(""); err == nil {
+					t.Error("want password login to be rejected, but got nil")
+				}
+			}
+			// stateは1回のみ使用できる
+			if _, err := sut.OIDCLogin(ctx, state, code); !errors.Is(err, ErrInvalidOIDCState) {
+				t.Errorf("want %v for reused state, but got %v", ErrInvalidOIDCState, err)
+			}
+		})
+	}
+}
+
+func TestOIDCUserName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		id   *oidc.Identity
+		want string
+	}{
+		"preferredUsername": {id: &oidc.Identity{PreferredUsername: "alice", Email: "bob@example.com"}, want: "alice"},
+		"email":             {id: &oidc.Identity{Email: "bob@example.com"}, want: "bob"},
+		"invalidChars":      {id: &oidc.Identity{PreferredUsername: "山田 taro!"}, want: "taro"},
+		"tooLong":           {id: &oidc.Identity{PreferredUsername: "abcdefghijklmnopqrstuvwxyz"}, want: "abcdefghijklm"},
+		"empty":             {id: &oidc.Identity{Subject: "123"}, want: "user"},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			if got := oidcUserName(tt.id); got != tt.want {
+				t.Errorf("want %q, but got %q", tt.want, got)
+			}
+		})
+	}
+}
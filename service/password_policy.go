@@ -0,0 +1,113 @@
+package service
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+//go:embed common_passwords.txt
+var rawCommonPasswords string
+
+// commonPasswords は拒否するパスワードの集合(小文字に正規化済)
+var commonPasswords = parseCommonPasswords(rawCommonPasswords)
+
+// maxPasswordBytes はbcryptでハッシュ化できるパスワードのバイト数の上限
+const maxPasswordBytes = 72
+
+// ErrWeakPassword はパスワードがパスワードポリシーを満たさないことを表す
+var ErrWeakPassword = errors.New("weak password")
+
+// PasswordPolicy はユーザが設定するパスワードの要件を表す
+// ゼロ値の項目は要件として扱わないが、頻出するパスワードおよびbcryptの上限を超えるパスワードは常に拒否する
+type PasswordPolicy struct {
+	MinLength  int // 最小文字数
+	MaxLength  int // 最大文字数
+	MinClasses int // 英大文字、英小文字、数字、記号のうち含める必要のある文字種の数
+}
+
+// Validate はパスワードがポリシーを満たすかを検証し、満たさない場合はErrWeakPasswordをラップしたエラーを返却する
+// ユーザ名と同一のパスワードも拒否する
+func (p PasswordPolicy) Validate(name, password string) error {
+	n := utf8.RuneCountInString(password)
+	switch {
+	case p.MinLength > 0 && n < p.MinLength:
+		return fmt.Errorf("%w: must be at least %d characters", ErrWeakPassword, p.MinLength)
+	case p.MaxLength > 0 && n > p.MaxLength:
+		return fmt.Errorf("%w: must be at most %d characters", ErrWeakPassword, p.MaxLength)
+	case len(password) > maxPasswordBytes:
+		return fmt.Errorf("%w: must be at most %d bytes", ErrWeakPassword, maxPasswordBytes)
+	}
+	if c := countClasses(password); c < p.MinClasses {
+		return fmt.Errorf("%w: must contain at least %d of uppercase, lowercase, digits and symbols",
+			ErrWeakPassword, p.MinClasses)
+	}
+	lower := strings.ToLower(password)
+	if commonPasswords[lower] {
+		return fmt.Errorf("%w: too common", ErrWeakPassword)
+	}
+	if name != "" && lower == strings.ToLower(name) {
+		return fmt.Errorf("%w: must differ from user name", ErrWeakPassword)
+	}
+	return nil
+}
+
+// validateNewPassword は変更後のパスワードがポリシーを満たし、かつ変更前と異なることを検証する
+func validateNewPassword(p PasswordPolicy, name, current, password string) error {
+	if password == current {
+		return fmt.Errorf("%w: must differ from current password", ErrWeakPassword)
+	}
+	return p.Validate(name, password)
+}
+
+// countClasses はパスワードに含まれる文字種の数を返却する
+func countClasses(password string) int {
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	n := 0
+	for _, ok := range []bool{upper, lower, digit, symbol} {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
+
+func parseCommonPasswords(raw string) map[string]bool {
+	m := map[string]bool{}
+	s := bufio.NewScanner(strings.NewReader(raw))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		m[strings.ToLower(line)] = true
+	}
+	return m
+}
+
+// hashPassword はパスワードをbcryptでハッシュ化する
+func hashPassword(password string) (string, error) {
+	pw, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("cannot hash password: %w", err)
+	}
+	return string(pw), nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	sut := PasswordPolicy{MinLength: 10, MaxLength: 64, MinClasses: 3}
+	tests := map[string]struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		"ok":          {name: "john", password: "Correct-h0rse"},
+		"tooShort":    {name: "john", password: "Sh0rt-pw", wantErr: true},
+		"tooLong":     {name: "john", password: "Aa1" + strings.Repeat("x", 62), wantErr: true},
+		"fewClasses":  {name: "john", password: "onlylowercase", wantErr: true},
+		"common":      {name: "john", password: "Password123", wantErr: true},
+		"sameAsName":  {name: "Correct-H0rse", password: "correct-h0rse", wantErr: true},
+		"overBcrypt":  {name: "john", password: strings.Repeat("あ", 25) + "Aa1", wantErr: true},
+		"multibyteOK": {name: "john", password: "パスワードAa1-xyz"},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			err := sut.Validate(tt.name, tt.password)
+			if tt.wantErr && !errors.Is(err, ErrWeakPassword) {
+				t.Errorf("want %v, but got %v", ErrWeakPassword, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("want no error, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicy_zeroValue(t *testing.T) {
+	t.Parallel()
+
+	// ゼロ値でも頻出するパスワードは拒否する
+	if err := (PasswordPolicy{}).Validate("john", "qwerty"); !errors.Is(err, ErrWeakPassword) {
+		t.Errorf("want %v, but got %v", ErrWeakPassword, err)
+	}
+	if err := (PasswordPolicy{}).Validate("john", "x"); err != nil {
+		t.Errorf("want no error, but got %v", err)
+	}
+}
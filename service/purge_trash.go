@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// PurgeTrash は保持期間を過ぎたゴミ箱のタスクを定期的に完全に削除する
+type PurgeTrash struct {
+	DB        store.Execer
+	Repo      TrashPurger
+	Clocker   clock.Clocker
+	Retention time.Duration // ゴミ箱での保持期間
+	Interval  time.Duration // 削除処理の実行間隔
+}
+
+// Run はctxがキャンセルされるまでInterval間隔で削除処理を実行する
+// 起動直後に1回実行し、個々の削除処理の失敗はログに出力して処理を継続する
+func (p *PurgeTrash) Run(ctx context.Context) error {
+	if p.Interval <= 0 {
+		return fmt.Errorf("invalid trash purge interval %s: must be positive", p.Interval)
+	}
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for {
+		if n, err := p.Purge(ctx); err != nil {
+			log.Printf("failed to purge trash: %v", err)
+		} else if n > 0 {
+			log.Printf("purged %d trashed tasks", n)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge はゴミ箱への移動から保持期間を過ぎたタスクを完全に削除し、削除した件数を返却する
+func (p *PurgeTrash) Purge(ctx context.Context) (int64, error) {
+	before := p.Clocker.Now().Add(-p.Retention)
+	n, err := p.Repo.PurgeTrash(ctx, p.DB, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge trash before %s: %w", before.Format(time.RFC3339), err)
+	}
+	return n, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+func TestPurgeTrash_Purge(t *testing.T) {
+	t.Parallel()
+
+	c := clock.FixedClocker{}
+	moq := &TrashPurgerMock{}
+	moq.PurgeTrashFunc = func(ctx context.Context, db store.Execer, before time.Time) (int64, error) {
+		// 保持期間より前にゴミ箱へ移動したタスクのみを削除対象とする
+		if want := c.Now().Add(-72 * time.Hour); !before.Equal(want) {
+			t.Errorf("want before %v, but got %v", want, before)
+		}
+		return 2, nil
+	}
+	sut := &PurgeTrash{Repo: moq, Clocker: c, Retention: 72 * time.Hour, Interval: time.Hour}
+
+	n, err := sut.Purge(context.Background())
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if n != 2 {
+		t.Errorf("want 2, but got %d", n)
+	}
+}
+
+func TestPurgeTrash_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	called := make(chan struct{}, 1)
+	moq := &TrashPurgerMock{}
+	moq.PurgeTrashFunc = func(ctx context.Context, db store.Execer, before time.Time) (int64, error) {
+		select {
+		case called <- struct{}{}:
+		default:
+		}
+		return 0, nil
+	}
+	sut := &PurgeTrash{Repo: moq, Clocker: clock.FixedClocker{}, Retention: time.Hour, Interval: time.Millisecond}
+
+	done := make(chan error, 1)
+	go func() { done <- sut.Run(ctx) }()
+
+	// 起動直後に削除処理が実行されることを確認後、キャンセルにより終了することを検証する
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("purge was not executed")
+	}
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("want no error, but got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("purger did not stop after cancel")
+	}
+}
+
+func TestPurgeTrash_Run_invalidInterval(t *testing.T) {
+	t.Parallel()
+
+	sut := &PurgeTrash{Repo: &TrashPurgerMock{}, Clocker: clock.FixedClocker{}, Retention: time.Hour}
+	if err := sut.Run(context.Background()); err == nil {
+		t.Error("want error for zero interval, but got nil")
+	}
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+// ErrReauthenticationRequired はパスワードを持たないユーザが、直近の認証を経ずに取り消しできない操作を要求したことを表す
+var ErrReauthenticationRequired = errors.New("recent login is required")
+
+// reauthenticate は取り消しできない操作の前にユーザ本人であることを再確認する
+// パスワードを持つユーザはパスワードを検証し、OpenID Connectで登録したパスワードを持たないユーザは
+// IdPでの認証日時が現在日時からmaxAge以内であることを検証する
+func reauthenticate(ctx context.Context, u *entity.User, password string, now time.Time, maxAge time.Duration) error {
+	if u.HasPassword() {
+		if err := u.ComparePassword(password); err != nil {
+			return ErrInvalidCredentials
+		}
+		return nil
+	}
+	at, ok := auth.GetAuthTime(ctx)
+	if !ok || now.Sub(at) > maxAge {
+		return ErrReauthenticationRequired
+	}
+	return nil
+}
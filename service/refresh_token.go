@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type RefreshToken struct {
+	DB             store.Queryer
+	Repo           UserByIDGetter
+	TokenRefresher TokenRefresher
+}
+
+// RefreshToken はリフレッシュトークンをローテーションし、新しいアクセストークンと併せて返却する
+// アクセストークンのクレームにはDBから再取得した最新のユーザ情報を使用し、無効化済のユーザには発行しない
+// handler/service.goの実装
+func (r *RefreshToken) RefreshToken(ctx context.Context, token string) (*auth.TokenPair, error) {
+	refresh, uid, err := r.TokenRefresher.RotateRefreshToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	u, err := r.Repo.GetUserByID(ctx, r.DB, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a user: %w", err)
+	}
+	if u.Disabled() {
+		return nil, auth.ErrUserDisabled
+	}
+	jwt, err := r.TokenRefresher.GenerateToken(auth.SetRefreshToken(ctx, refresh), *u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT: %w", err)
+	}
+	return &auth.TokenPair{AccessToken: string(jwt), RefreshToken: refresh}, nil
+}
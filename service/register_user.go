@@ -6,23 +6,35 @@ import (
 
 	"github.com/ac0mz/go_todo_app/entity"
 	"github.com/ac0mz/go_todo_app/store"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type RegisterUser struct {
-	DB   store.Execer
-	Repo UserRegister
+	DB     store.Execer
+	Repo   UserRegister
+	Policy PasswordPolicy
 }
 
-func (r *RegisterUser) RegisterUser(ctx context.Context, name, password, role string) (*entity.User, error) {
-	pw, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// RegisterUser はユーザを登録する
+// 公開APIから登録するユーザには、既定のロールのみを付与する
+// パスワードがポリシーを満たさない場合はErrWeakPasswordをラップしたエラーを返却する
+// emailはパスワード再設定に使用するメールアドレスで、空文字の場合は登録しない
+func (r *RegisterUser) RegisterUser(ctx context.Context, name, password, email string) (*entity.User, error) {
+	if err := r.Policy.Validate(name, password); err != nil {
+		return nil, err
+	}
+	pw, err := hashPassword(password)
 	if err != nil {
-		return nil, fmt.Errorf("cannot hash password: %w", err)
+		return nil, err
 	}
 	u := &entity.User{
 		Name:     name,
-		Password: string(pw),
-		Role:     role,
+		Password: pw,
+		Role:     entity.DefaultRole,
+		TimeZone: entity.DefaultTimeZone,
+		Locale:   entity.DefaultLocale,
+	}
+	if email != "" {
+		u.Email = &email
 	}
 
 	if err := r.Repo.RegisterUser(ctx, r.DB, u); err != nil {
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type RequirePasswordReset struct {
+	DB      store.Execer
+	Repo    PasswordResetRequirer
+	Revoker TokenRevoker
+}
+
+// RequirePasswordReset は指定したユーザの次回ログイン時にパスワードの再設定を必須とする
+// 現在のパスワードで発行済のトークンを利用させないため、対象ユーザのトークンは
+// パーソナルアクセストークンを含めすべて失効させる
+// handler/service.goの実装
+func (r *RequirePasswordReset) RequirePasswordReset(ctx context.Context, id entity.UserID) error {
+	if err := r.Repo.RequirePasswordReset(ctx, r.DB, id); err != nil {
+		return fmt.Errorf("failed to require password reset: %w", err)
+	}
+	if err := r.Repo.DeletePersonalAccessTokens(ctx, r.DB, id); err != nil {
+		return fmt.Errorf("failed to delete personal access tokens: %w", err)
+	}
+	if err := r.Revoker.RevokeAllTokens(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// ErrInvalidResetToken はパスワード再設定トークンが存在しない、期限切れ、または使用済であることを表す
+var ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+
+type ResetPassword struct {
+	DB      store.ExecQueryer
+	Repo    UserPasswordUpdater
+	Tokens  PasswordResetTokenStore
+	Policy  PasswordPolicy
+	Revoker TokenRevoker
+}
+
+// ResetPassword はパスワード再設定トークンを消費し、対応するユーザのパスワードを更新する
+// パスワードがポリシーを満たさない場合はトークンを消費せず、再度の指定を可能とする
+// 更新後はパーソナルアクセストークンを含め発行済のトークンをすべて失効させる
+// handler/service.goの実装
+func (r *ResetPassword) ResetPassword(ctx context.Context, token, password string) error {
+	hash := hashPasswordResetToken(token)
+	uid, err := r.Tokens.LoadPasswordResetToken(ctx, hash)
+	if err != nil {
+		return r.tokenError(err)
+	}
+	u, err := r.Repo.GetUserByID(ctx, r.DB, uid)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := r.Policy.Validate(u.Name, password); err != nil {
+		return err
+	}
+	// 並行して同一のトークンが使用された場合に備え、消費はアトミックに実施する
+	consumed, err := r.Tokens.ConsumePasswordResetToken(ctx, hash)
+	if err != nil {
+		return r.tokenError(err)
+	}
+	if consumed != uid {
+		return ErrInvalidResetToken
+	}
+
+	pw, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	u.Password = pw
+	u.PasswordResetRequired = false
+	if err := r.Repo.UpdatePassword(ctx, r.DB, u); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	if err := r.Repo.DeletePersonalAccessTokens(ctx, r.DB, uid); err != nil {
+		return fmt.Errorf("failed to delete personal access tokens: %w", err)
+	}
+	if err := r.Revoker.RevokeAllTokens(ctx, uid); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	return nil
+}
+
+// tokenError はトークンが見つからない場合にErrInvalidResetTokenへ変換する
+func (r *ResetPassword) tokenError(err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrInvalidResetToken
+	}
+	return fmt.Errorf("failed to load password reset token: %w", err)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+func TestResetPassword(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	token, hash, err := newPasswordResetToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Redisの代わりにマップでトークンを管理する
+	saved := map[string]entity.UserID{hash: 1}
+	tokens := &PasswordResetTokenStoreMock{}
+	tokens.LoadPasswordResetTokenFunc = func(ctx context.Context, h string) (entity.UserID, error) {
+		uid, ok := saved[h]
+		if !ok {
+			return 0, store.ErrNotFound
+		}
+		return uid, nil
+	}
+	tokens.ConsumePasswordResetTokenFunc = func(ctx context.Context, h string) (entity.UserID, error) {
+		uid, ok := saved[h]
+		if !ok {
+			return 0, store.ErrNotFound
+		}
+		delete(saved, h)
+		return uid, nil
+	}
+	repo := &UserPasswordUpdaterMock{}
+	repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+		return &entity.User{ID: id, Name: "john", PasswordResetRequired: true}, nil
+	}
+	repo.UpdatePasswordFunc = func(ctx context.Context, db store.Execer, u *entity.User) error {
+		if u.PasswordResetRequired || u.ComparePassword("N3w-passphrase") != nil {
+			t.Errorf("want new password stored and reset flag cleared, but got %+v", u)
+		}
+		return nil
+	}
+	repo.DeletePersonalAccessTokensFunc = func(ctx context.Context, db store.Execer, uid entity.UserID) error {
+		return nil
+	}
+	revoker := &TokenRevokerMock{}
+	revoker.RevokeAllTokensFunc = func(ctx context.Context, uid entity.UserID) error {
+		return nil
+	}
+	sut := &ResetPassword{
+		Repo: repo, Tokens: tokens, Policy: PasswordPolicy{MinLength: 10, MinClasses: 3}, Revoker: revoker,
+	}
+
+	// ポリシーを満たさない場合はトークンを消費しない
+	if err := sut.ResetPassword(ctx, token, "weak"); !errors.Is(err, ErrWeakPassword) {
+		t.Fatalf("want %v, but got %v", ErrWeakPassword, err)
+	}
+	if err := sut.ResetPassword(ctx, token, "N3w-passphrase"); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if n := len(revoker.RevokeAllTokensCalls()); n != 1 {
+		t.Errorf("want tokens revoked, but got %d calls", n)
+	}
+	if n := len(repo.DeletePersonalAccessTokensCalls()); n != 1 {
+		t.Errorf("want personal access tokens revoked, but got %d calls", n)
+	}
+	// 使用済のトークンは再度使用できない
+	if err := sut.ResetPassword(ctx, token, "N3w-passphrase"); !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("want %v, but got %v", ErrInvalidResetToken, err)
+	}
+	if err := sut.ResetPassword(ctx, "unknown", "N3w-passphrase"); !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("want %v, but got %v", ErrInvalidResetToken, err)
+	}
+}
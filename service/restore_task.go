@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type RestoreTask struct {
+	DB   store.TxBeginner
+	Repo TaskRestorer
+}
+
+// RestoreTask はゴミ箱にあるログインユーザのタスクを復元し、復元後のタスクを返却する
+// handler/service.goの実装
+func (r *RestoreTask) RestoreTask(ctx context.Context, id entity.TaskID) (*entity.Task, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Commit済の場合、Rollbackはsql.ErrTxDoneを返すのみのため戻り値は破棄する
+	defer func() { _ = tx.Rollback() }()
+
+	if err := r.Repo.RestoreTask(ctx, tx, uid, id); err != nil {
+		return nil, fmt.Errorf("failed to restore: %w", err)
+	}
+	t, err := r.Repo.GetTask(ctx, tx, uid, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// ErrOwnUserDisable は管理者が自身の無効化の有無を変更しようとした場合のエラー
+// 管理者が不在となることを防ぐため、自身を無効化することはできない
+var ErrOwnUserDisable = errors.New("cannot disable or enable own account")
+
+type SetUserDisabled struct {
+	DB      store.ExecQueryer
+	Repo    UserDisabler
+	Marker  UserDisabledMarker
+	Clocker clock.Clocker
+}
+
+// SetUserDisabled は指定したユーザを無効化または有効化し、更新後のユーザを返却する
+// 無効化したユーザは、発行済のトークンをすべて失効させた上で以降の認証を拒否する
+// 管理者権限の確認は、呼び出し元のミドルウェアで実施する
+// handler/service.goの実装
+func (s *SetUserDisabled) SetUserDisabled(ctx context.Context, id entity.UserID, disabled bool) (*entity.User, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	if uid == id {
+		return nil, fmt.Errorf("user %d: %w", id, ErrOwnUserDisable)
+	}
+
+	u, err := s.Repo.GetUserByID(ctx, s.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	// DBが既に要求した状態の場合も、前回の要求でKVSへの反映に失敗した可能性があるためKVSへは反映し直す
+	if u.Disabled() != disabled {
+		if disabled {
+			now := s.Clocker.Now()
+			u.DisabledAt = &now
+		} else {
+			u.DisabledAt = nil
+		}
+		if err := s.Repo.UpdateUserDisabled(ctx, s.DB, u); err != nil {
+			return nil, fmt.Errorf("failed to update user: %w", err)
+		}
+	}
+	// DBの更新後にKVSへ反映し、反映に失敗した場合もログインは拒否される状態とする
+	if disabled {
+		err = s.Marker.DisableUser(ctx, id)
+	} else {
+		err = s.Marker.EnableUser(ctx, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply user status: %w", err)
+	}
+	return u, nil
+}
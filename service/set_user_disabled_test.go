@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+func TestSetUserDisabled(t *testing.T) {
+	t.Parallel()
+
+	now := clock.FixedClocker{}.Now()
+	tests := map[string]struct {
+		current    bool
+		disabled   bool
+		id         entity.UserID
+		wantErr    error
+		wantUpdate bool   // DBを更新するか
+		wantApply  string // KVSへ反映した操作(反映しない場合は空文字)
+	}{
+		"disable": {disabled: true, id: 2, wantUpdate: true, wantApply: "disable"},
+		"enable":  {current: true, id: 2, wantUpdate: true, wantApply: "enable"},
+		// DBが既に要求した状態の場合も、KVSへの反映を再試行する
+		"alreadyDisabled": {current: true, disabled: true, id: 2, wantApply: "disable"},
+		"alreadyEnabled":  {id: 2, wantApply: "enable"},
+		"ownAccount":      {disabled: true, id: 1, wantErr: ErrOwnUserDisable},
+		"notFoundErr":     {disabled: true, id: 3, wantErr: store.ErrNotFound},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := auth.SetUserID(context.Background(), 1)
+			repo := &UserDisablerMock{}
+			repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+				if id == 3 {
+					return nil, store.ErrNotFound
+				}
+				u := &entity.User{ID: id}
+				if tt.current {
+					u.DisabledAt = &now
+				}
+				return u, nil
+			}
+			repo.UpdateUserDisabledFunc = func(ctx context.Context, db store.Execer, u *entity.User) error {
+				return nil
+			}
+			var applied string
+			marker := &UserDisabledMarkerMock{}
+			marker.DisableUserFunc = func(ctx context.Context, uid entity.UserID) error {
+				applied = "disable"
+				return nil
+			}
+			marker.EnableUserFunc = func(ctx context.Context, uid entity.UserID) error {
+				applied = "enable"
+				return nil
+			}
+
+			sut := &SetUserDisabled{Repo: repo, Marker: marker, Clocker: clock.FixedClocker{}}
+			got, err := sut.SetUserDisabled(ctx, tt.id, tt.disabled)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("want %v, but got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if got.Disabled() != tt.disabled {
+				t.Errorf("want disabled %v, but got %v", tt.disabled, got.DisabledAt)
+			}
+			if applied != tt.wantApply {
+				t.Errorf("want applied %q, but got %q", tt.wantApply, applied)
+			}
+			updated := len(repo.UpdateUserDisabledCalls()) > 0
+			if updated != tt.wantUpdate {
+				t.Errorf("want updated %v, but got %v", tt.wantUpdate, updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// ErrTwoFactorAlreadyEnabled は二要素認証が有効化済であることを表す
+var ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+type SetupTwoFactor struct {
+	DB     store.ExecQueryer
+	Repo   TwoFactorSetupper
+	Issuer string // 認証アプリに表示する発行者名
+}
+
+// SetupTwoFactor はログインユーザのTOTPのシークレットを生成し、シークレットと認証アプリ登録用のURIを返却する
+// 二要素認証はConfirmTwoFactorでコードを確認するまで有効化しない
+// 有効化前に再度呼び出した場合はシークレットを再生成する
+// handler/service.goの実装
+func (st *SetupTwoFactor) SetupTwoFactor(ctx context.Context) (string, string, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return "", "", fmt.Errorf("user_id not found")
+	}
+	u, err := st.Repo.GetUserByID(ctx, st.DB, uid)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if u.TOTPEnabled {
+		return "", "", ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+	if err := st.Repo.SaveTOTPSecret(ctx, st.DB, uid, secret); err != nil {
+		// 取得後に他のリクエストで有効化された場合
+		if errors.Is(err, store.ErrNotFound) {
+			return "", "", ErrTwoFactorAlreadyEnabled
+		}
+		return "", "", fmt.Errorf("failed to save totp secret: %w", err)
+	}
+	return secret, auth.TOTPURI(st.Issuer, u.Name, secret), nil
+}
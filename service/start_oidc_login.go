@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/oidc"
+)
+
+type StartOIDCLogin struct {
+	Provider OIDCProvider
+	States   OIDCStateStore
+	TTL      time.Duration // 認可リクエストを開始してからコールバックまでの有効期間
+}
+
+// StartOIDCLogin はOpenID Connectの認可リクエストを生成し、ユーザをリダイレクトさせるIdPの認可エンドポイントのURLと、
+// リクエストのstateを返却する
+// コールバックで検証に使用するnonceとPKCEのcode_verifierは、stateのハッシュ値に対応付けて保存する
+// handler/service.goの実装
+func (s *StartOIDCLogin) StartOIDCLogin(ctx context.Context) (string, string, error) {
+	req, err := oidc.NewAuthRequest()
+	if err != nil {
+		return "", "", err
+	}
+	authURL, err := s.Provider.AuthCodeURL(ctx, req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build authorization url: %w", err)
+	}
+	if err := s.States.SaveOIDCState(ctx, hashOIDCState(req.State), req.Nonce, req.Verifier, s.TTL); err != nil {
+		return "", "", fmt.Errorf("failed to save oidc state: %w", err)
+	}
+	return authURL, req.State, nil
+}
+
+// hashOIDCState は認可リクエストのstateのSHA-256ハッシュ値を16進数文字列で返却する
+func hashOIDCState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
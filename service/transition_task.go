@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type TransitionTask struct {
+	DB      store.TxBeginner
+	Repo    TaskTransitioner
+	Clocker clock.Clocker // 繰り返しタスクの次回の期限の算出に使用する
+}
+
+// TransitionTask は遷移表に従ってタスクのステータスを遷移させ、遷移の履歴を記録する
+// 遷移が許可されていない場合は*entity.TransitionErrorを返却する
+// forceがfalseの場合、未完了の子タスクを持つタスクは完了できず*entity.OpenChildrenErrorを返却する
+// 未完了のタスクに依存しているタスクは着手できず*entity.BlockedErrorを返却する
+// 繰り返しタスクを完了した場合は、次回の期限を設定したタスクを併せて登録する
+// handler/service.goの実装
+func (tt *TransitionTask) TransitionTask(
+	ctx context.Context, id entity.TaskID, to entity.TaskStatus, force bool,
+) (*entity.Task, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	tx, err := tt.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Commit済の場合、Rollbackはsql.ErrTxDoneを返すのみのため戻り値は破棄する
+	defer func() { _ = tx.Rollback() }()
+
+	// 遷移判定から更新までの間に他のリクエストでステータスが変更されないよう行ロックを取得する
+	t, err := tt.Repo.GetTaskForUpdate(ctx, tx, uid, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get: %w", err)
+	}
+	from := t.Status
+	if err := t.Transition(to, auth.IsAdmin(ctx)); err != nil {
+		return nil, err
+	}
+	if to == entity.TaskStatusDoing {
+		blockers, err := tt.Repo.ListOpenBlockers(ctx, tx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blockers: %w", err)
+		}
+		if len(blockers) > 0 {
+			return nil, &entity.BlockedError{TaskID: id, Blockers: blockers}
+		}
+	}
+	if to == entity.TaskStatusDone && !force {
+		p, err := tt.Repo.GetTaskProgress(ctx, tx, uid, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get progress: %w", err)
+		}
+		if p.Open() > 0 {
+			return nil, &entity.OpenChildrenError{TaskID: id, Open: p.Open()}
+		}
+	}
+	if err := tt.Repo.UpdateTaskStatus(ctx, tx, t); err != nil {
+		return nil, fmt.Errorf("failed to update status: %w", err)
+	}
+	history := &entity.TaskTransition{TaskID: t.ID, UserID: uid, From: from, To: to}
+	if err := tt.Repo.AddTaskTransition(ctx, tx, history); err != nil {
+		return nil, fmt.Errorf("failed to record transition: %w", err)
+	}
+
+	if to == entity.TaskStatusDone && t.Recurrence != nil {
+		if err := tt.addNextOccurrence(ctx, tx, t); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return t, nil
+}
+
+// addNextOccurrence は繰り返しタスクの次回分のタスクを登録する
+// 期限が未設定の場合は現在日時を起点とし、繰り返しの終了日時を過ぎる場合は登録しない
+// 完了を取り消して再度完了した場合など、次回分を生成済の場合は登録しない
+func (tt *TransitionTask) addNextOccurrence(ctx context.Context, db store.Execer, t *entity.Task) error {
+	now := tt.Clocker.Now()
+	prev := now
+	if t.DueAt != nil {
+		prev = *t.DueAt
+	}
+	due, ok := t.Recurrence.NextAfter(prev, now)
+	if !ok {
+		return nil
+	}
+	next := &entity.Task{
+		UserID:      t.UserID,
+		ProjectID:   t.ProjectID,
+		ParentID:    t.ParentID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      entity.TaskStatusTodo,
+		Priority:    t.Priority,
+		DueAt:       &due,
+		Recurrence:  t.Recurrence,
+	}
+	if err := tt.Repo.AddNextOccurrence(ctx, db, t.ID, next); err != nil {
+		if errors.Is(err, store.ErrAlreadyEntry) {
+			return nil
+		}
+		return fmt.Errorf("failed to add next occurrence: %w", err)
+	}
+	return nil
+}
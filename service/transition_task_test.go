@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+	"github.com/jmoiron/sqlx"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+// newTaskTransitioner はタスクを1件のみ保持し、次回分の生成元を記録するモックを生成する
+// 同一の生成元から次回分を登録した場合は、一意制約と同様にstore.ErrAlreadyEntryを返却する
+func newTaskTransitioner(task *entity.Task) (*TaskTransitionerMock, *entity.Tasks) {
+	added := &entity.Tasks{}
+	sources := map[entity.TaskID]bool{}
+	moq := &TaskTransitionerMock{}
+	moq.GetTaskForUpdateFunc = func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+		t := *task
+		return &t, nil
+	}
+	moq.UpdateTaskStatusFunc = func(ctx context.Context, db store.Execer, t *entity.Task) error {
+		task.Status = t.Status
+		return nil
+	}
+	moq.AddTaskTransitionFunc = func(ctx context.Context, db store.Execer, tt *entity.TaskTransition) error {
+		return nil
+	}
+	moq.GetTaskProgressFunc = func(ctx context.Context, db store.Queryer, uid entity.UserID, id entity.TaskID) (entity.TaskProgress, error) {
+		return entity.TaskProgress{}, nil
+	}
+	moq.ListOpenBlockersFunc = func(ctx context.Context, db store.Queryer, id entity.TaskID) ([]entity.TaskID, error) {
+		return nil, nil
+	}
+	moq.AddNextOccurrenceFunc = func(ctx context.Context, db store.Execer, sourceID entity.TaskID, t *entity.Task) error {
+		if sources[sourceID] {
+			return fmt.Errorf("next occurrence of task %d: %w", sourceID, store.ErrAlreadyEntry)
+		}
+		sources[sourceID] = true
+		*added = append(*added, t)
+		return nil
+	}
+	return moq, added
+}
+
+// newTxDB はトランザクションの開始と終了のみを受け付けるDBを生成する
+func newTxDB(t *testing.T, n int) *sqlx.DB {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	for i := 0; i < n; i++ {
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+	}
+	return sqlx.NewDb(db, "mysql")
+}
+
+func TestTransitionTask_recurrence(t *testing.T) {
+	t.Parallel()
+
+	now := clock.FixedClocker{}.Now() // 2022-08-23 23:59:59 UTC
+	at := func(s string) *time.Time {
+		d, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &d
+	}
+	tests := map[string]struct {
+		rule    string // 空文字の場合は繰り返さないタスク
+		due     *time.Time
+		to      entity.TaskStatus
+		wantDue *time.Time // nilの場合は次回分を登録しない
+	}{
+		"daily":        {rule: "FREQ=DAILY", due: at("2022-08-23T22:00:00Z"), to: entity.TaskStatusDone, wantDue: at("2022-08-24T22:00:00Z")},
+		"overdue":      {rule: "FREQ=DAILY", due: at("2022-08-20T10:00:00Z"), to: entity.TaskStatusDone, wantDue: at("2022-08-24T10:00:00Z")},
+		"weekly":       {rule: "FREQ=WEEKLY;BYDAY=MO,FR", due: at("2022-08-22T09:00:00Z"), to: entity.TaskStatusDone, wantDue: at("2022-08-26T09:00:00Z")},
+		"noDue":        {rule: "FREQ=DAILY", to: entity.TaskStatusDone, wantDue: at("2022-08-24T23:59:59Z")},
+		"untilPassed":  {rule: "FREQ=DAILY;UNTIL=20220823", due: at("2022-08-23T10:00:00Z"), to: entity.TaskStatusDone},
+		"notRecurring": {due: at("2022-08-23T10:00:00Z"), to: entity.TaskStatusDone},
+		"notDone":      {rule: "FREQ=DAILY", due: at("2022-08-23T10:00:00Z"), to: entity.TaskStatusDoing},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			task := &entity.Task{ID: 1, UserID: 10, Title: "recurring", Status: entity.TaskStatusTodo, DueAt: tt.due}
+			if tt.rule != "" {
+				r, err := entity.ParseRecurrence(tt.rule)
+				if err != nil {
+					t.Fatal(err)
+				}
+				task.Recurrence = r
+			}
+			repo, added := newTaskTransitioner(task)
+			sut := &TransitionTask{DB: newTxDB(t, 1), Repo: repo, Clocker: clock.FixedClocker{}}
+
+			ctx := auth.SetUserID(context.Background(), 10)
+			if _, err := sut.TransitionTask(ctx, 1, tt.to, false); err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if tt.wantDue == nil {
+				if len(*added) != 0 {
+					t.Errorf("want no next occurrence, but got %+v", (*added)[0])
+				}
+				return
+			}
+			if len(*added) != 1 {
+				t.Fatalf("want 1 next occurrence, but got %d", len(*added))
+			}
+			next := (*added)[0]
+			if next.Status != entity.TaskStatusTodo || next.DueAt == nil || !next.DueAt.Equal(*tt.wantDue) {
+				t.Errorf("want todo due at %s, but got %s due at %v", tt.wantDue, next.Status, next.DueAt)
+			}
+			if next.Recurrence.String() != task.Recurrence.String() {
+				t.Errorf("want recurrence %q, but got %q", task.Recurrence, next.Recurrence)
+			}
+			if !now.Before(*next.DueAt) {
+				t.Errorf("want due after %s, but got %s", now, next.DueAt)
+			}
+		})
+	}
+}
+
+// TestTransitionTask_reopen 完了を取り消して再度完了しても、次回分が重複して登録されないことを確認する
+func TestTransitionTask_reopen(t *testing.T) {
+	t.Parallel()
+
+	r, err := entity.ParseRecurrence("FREQ=DAILY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := &entity.Task{ID: 1, UserID: 10, Title: "recurring", Status: entity.TaskStatusTodo, Recurrence: r}
+	repo, added := newTaskTransitioner(task)
+	sut := &TransitionTask{DB: newTxDB(t, 3), Repo: repo, Clocker: clock.FixedClocker{}}
+
+	// 完了済のタスクの再開は管理者のみ許可されている
+	ctx := auth.SetUserID(context.Background(), 10)
+	token, err := jwt.NewBuilder().Claim(auth.RoleKey, string(entity.RoleAdmin)).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = auth.SetRole(ctx, token)
+	for _, to := range []entity.TaskStatus{entity.TaskStatusDone, entity.TaskStatusTodo, entity.TaskStatusDone} {
+		if _, err := sut.TransitionTask(ctx, 1, to, false); err != nil {
+			t.Fatalf("transition to %s: want no error, but got %v", to, err)
+		}
+	}
+	if len(*added) != 1 {
+		t.Errorf("want 1 next occurrence, but got %d", len(*added))
+	}
+	if calls := len(repo.AddNextOccurrenceCalls()); calls != 2 {
+		t.Errorf("want 2 attempts, but got %d", calls)
+	}
+}
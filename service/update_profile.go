@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type UpdateProfile struct {
+	DB   store.ExecQueryer
+	Repo ProfileUpdater
+}
+
+// UpdateProfile はログインユーザの表示名、タイムゾーン、ロケールのうち、nilでない項目のみを更新する
+// 値の形式はハンドラで検証済であることを前提とする
+// handler/service.goの実装
+func (up *UpdateProfile) UpdateProfile(ctx context.Context, displayName, timeZone, locale *string) (*entity.User, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	u, err := up.Repo.GetUserByID(ctx, up.DB, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if displayName != nil {
+		u.DisplayName = *displayName
+	}
+	if timeZone != nil {
+		u.TimeZone = *timeZone
+	}
+	if locale != nil {
+		u.Locale = *locale
+	}
+	if err := up.Repo.UpdateProfile(ctx, up.DB, u); err != nil {
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+	return u, nil
+}
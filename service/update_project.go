@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type UpdateProject struct {
+	DB   store.ExecQueryer
+	Repo ProjectUpdater
+}
+
+// UpdateProject は一意のユーザに紐付いたプロジェクトの名前を変更し、変更後のプロジェクトを返却する
+// handler/service.goの実装
+func (u *UpdateProject) UpdateProject(ctx context.Context, id entity.ProjectID, name string) (*entity.Project, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	p, err := u.Repo.GetProject(ctx, u.DB, uid, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get: %w", err)
+	}
+	p.Name = name
+	if err := u.Repo.UpdateProject(ctx, u.DB, p); err != nil {
+		return nil, fmt.Errorf("failed to update: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type UpdateTag struct {
+	DB   store.Execer
+	Repo TagUpdater
+}
+
+// UpdateTag は一意のユーザに紐付いたタグの名前を変更する
+// handler/service.goの実装
+func (u *UpdateTag) UpdateTag(ctx context.Context, id entity.TagID, name string) (*entity.Tag, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	t := &entity.Tag{ID: id, UserID: uid, Name: name}
+	if err := u.Repo.UpdateTag(ctx, u.DB, t); err != nil {
+		return nil, fmt.Errorf("failed to update: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+type UpdateTask struct {
+	DB   store.ExecQueryer
+	Repo TaskUpdater
+}
+
+// UpdateTask は一意のユーザに紐付いたタスクのタイトルを更新し、更新後のタスクを返却する
+// handler/service.goの実装
+func (u *UpdateTask) UpdateTask(ctx context.Context, id entity.TaskID, title string) (*entity.Task, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	// 他ユーザのタスクを更新しないよう、所有者の確認を兼ねて取得する
+	t, err := u.Repo.GetTask(ctx, u.DB, uid, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get: %w", err)
+	}
+	t.Title = title
+	if err := u.Repo.UpdateTask(ctx, u.DB, t); err != nil {
+		return nil, fmt.Errorf("failed to update: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+// ErrOwnRoleChange は管理者が自身のロールを変更しようとした場合のエラー
+// 管理者が不在となることを防ぐため、自身のロールは変更できない
+var ErrOwnRoleChange = errors.New("cannot change own role")
+
+type UpdateUserRole struct {
+	DB      store.ExecQueryer
+	Repo    UserRoleUpdater
+	Revoker TokenRevoker
+}
+
+// UpdateUserRole は指定したユーザのロールを更新し、更新後のユーザを返却する
+// ロールの付与権限の確認は、呼び出し元のミドルウェアで実施する
+// トークンに埋め込まれた変更前のロールを利用させないため、対象ユーザのトークンはすべて失効させる
+// handler/service.goの実装
+func (u *UpdateUserRole) UpdateUserRole(ctx context.Context, id entity.UserID, role entity.Role) (*entity.User, error) {
+	uid, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	if uid == id {
+		return nil, fmt.Errorf("user %d: %w", id, ErrOwnRoleChange)
+	}
+
+	user, err := u.Repo.GetUserByID(ctx, u.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role == role {
+		return user, nil
+	}
+	user.Role = role
+	if err := u.Repo.UpdateUserRole(ctx, u.DB, user); err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+	if err := u.Revoker.RevokeAllTokens(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	return user, nil
+}
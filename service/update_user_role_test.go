@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/auth"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/store"
+)
+
+func TestUpdateUserRole(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		current    entity.Role
+		role       entity.Role
+		id         entity.UserID
+		wantErr    error
+		wantRevoke bool
+	}{
+		"promote":     {current: entity.RoleUser, role: entity.RoleAdmin, id: 2, wantRevoke: true},
+		"unchanged":   {current: entity.RoleUser, role: entity.RoleUser, id: 2},
+		"ownRole":     {current: entity.RoleAdmin, role: entity.RoleUser, id: 1, wantErr: ErrOwnRoleChange},
+		"notFoundErr": {role: entity.RoleAdmin, id: 3, wantErr: store.ErrNotFound},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := auth.SetUserID(context.Background(), 1)
+			repo := &UserRoleUpdaterMock{}
+			repo.GetUserByIDFunc = func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+				if id == 3 {
+					return nil, store.ErrNotFound
+				}
+				return &entity.User{ID: id, Role: tt.current}, nil
+			}
+			updated := false
+			repo.UpdateUserRoleFunc = func(ctx context.Context, db store.Execer, u *entity.User) error {
+				updated = true
+				return nil
+			}
+			var revoked entity.UserID
+			revoker := &TokenRevokerMock{}
+			revoker.RevokeAllTokensFunc = func(ctx context.Context, uid entity.UserID) error {
+				revoked = uid
+				return nil
+			}
+
+			sut := &UpdateUserRole{Repo: repo, Revoker: revoker}
+			got, err := sut.UpdateUserRole(ctx, tt.id, tt.role)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("want %v, but got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if got.Role != tt.role {
+				t.Errorf("want role %q, but got %q", tt.role, got.Role)
+			}
+			if updated != tt.wantRevoke || (revoked == tt.id) != tt.wantRevoke {
+				t.Errorf("want updated and revoked %v, but got updated %v, revoked user %d", tt.wantRevoke, updated, revoked)
+			}
+		})
+	}
+}
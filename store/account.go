@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+// deleteUserStatements はユーザの削除時に実行するSQL
+// 外部キー制約はユーザに紐づくデータの削除漏れを防ぐためRESTRICTのまま維持し、
+// 参照する側のテーブルから順にユーザのデータを明示的に削除する
+var deleteUserStatements = []string{
+	// 他のユーザのタスクに対する遷移履歴も、実行者の情報としてユーザに紐づくため削除する
+	`DELETE FROM task_transitions WHERE user_id = ?;`,
+	// 親子関係によるCASCADEの連鎖は階層の深さに制限があるため、先に親子関係を解除する
+	`UPDATE tasks SET parent_id = NULL WHERE user_id = ? AND parent_id IS NOT NULL;`,
+	// タスクの遷移履歴、タグの付与、依存関係はtasksからCASCADEで削除する
+	`DELETE FROM tasks WHERE user_id = ?;`,
+	`DELETE FROM tags WHERE user_id = ?;`,
+	`DELETE FROM projects WHERE user_id = ?;`,
+	`DELETE FROM personal_access_tokens WHERE user_id = ?;`,
+	`DELETE FROM recovery_codes WHERE user_id = ?;`,
+	`DELETE FROM user_identities WHERE user_id = ?;`,
+}
+
+const (
+	deleteUser = `DELETE FROM users WHERE id = ?;`
+	// exportTasks はゴミ箱のタスクを含むユーザのすべてのタスクを、ID順にキーセットページネーションで取得する
+	exportTasks = `SELECT ` + taskColumns + ` FROM tasks WHERE user_id = ? AND id > ? ORDER BY id LIMIT ?;`
+)
+
+// DeleteUser はユーザと、ユーザが作成したすべてのデータを削除する
+// 複数のSQLを実行するため、dbにはトランザクションを指定する
+// 削除対象のユーザが存在しない場合はErrNotFoundを返却する
+func (r *Repository) DeleteUser(ctx context.Context, db Execer, id entity.UserID) error {
+	for _, q := range deleteUserStatements {
+		if _, err := db.ExecContext(ctx, q, id); err != nil {
+			return err
+		}
+	}
+	result, err := db.ExecContext(ctx, deleteUser, id)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("user %d", id))
+}
+
+// ExportTasks はゴミ箱のタスクを含むユーザのタスクのうち、IDがafterIDより大きいものをID順に最大limit件取得する
+// 付与されたタグを併せて格納する
+func (r *Repository) ExportTasks(
+	ctx context.Context, db Queryer, uid entity.UserID, afterID entity.TaskID, limit int,
+) (entity.Tasks, error) {
+	tasks := entity.Tasks{}
+	if err := db.SelectContext(ctx, &tasks, exportTasks, uid, afterID, limit); err != nil {
+		return nil, err
+	}
+	if err := r.fillTags(ctx, db, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
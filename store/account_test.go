@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestRepository_DeleteUser(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		affected int64
+		wantErr  error
+	}{
+		"ok":       {affected: 1},
+		"notFound": {affected: 0, wantErr: ErrNotFound},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+
+			// モック設定
+			// 外部キー制約に違反しないよう、参照する側のテーブルから順に削除する
+			for _, q := range deleteUserStatements {
+				mock.ExpectExec(regexp.QuoteMeta(q)).
+					WithArgs(entity.UserID(3)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+			mock.ExpectExec(regexp.QuoteMeta(deleteUser)).
+				WithArgs(entity.UserID(3)).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			xdb := sqlx.NewDb(db, "mysql")
+			r := &Repository{Clocker: clock.FixedClocker{}}
+			err = r.DeleteUser(ctx, xdb, 3)
+
+			// 検証
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %v, but got %v", tt.wantErr, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package store
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/ac0mz/go_todo_app/config"
@@ -25,9 +27,60 @@ type KVS struct {
 	Cli *redis.Client
 }
 
-func (k KVS) Save(ctx context.Context, key string, userID entity.UserID) error {
+// sessionIndexKey はユーザごとに発行済のトークンのキーを格納するソート済集合のキーを返却する
+func sessionIndexKey(userID entity.UserID) string {
+	return fmt.Sprintf("user_sessions:%d", userID)
+}
+
+// Save はキーにユーザIDを有効期間ttlで登録し、併せてユーザごとの索引にキーを追加する
+func (k KVS) Save(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
 	id := int64(userID)
-	return k.Cli.Set(ctx, key, id, 30*time.Minute).Err()
+	_, err := k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key, id, ttl)
+		addIndex(ctx, pipe, sessionIndexKey(userID), ttl, key)
+		return nil
+	})
+	return err
+}
+
+// indexMembers は期限切れのメンバーを除外した上で、有効期限をスコアとしてメンバーをソート済集合に追加する
+// 登録済のメンバーの期限はGTにより延長のみ行い、集合自体の期限もメンバーのうち最も遅い期限まで延長する
+// 時刻はアプリケーションサーバ間の時刻のずれを避けるため、Redisの時刻を使用する
+var indexMembers = redis.NewScript(`
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local ttl = tonumber(ARGV[1])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now)
+for i = 2, #ARGV do
+	redis.call("ZADD", KEYS[1], "GT", now + ttl, ARGV[i])
+end
+if redis.call("PTTL", KEYS[1]) < ttl then
+	redis.call("PEXPIRE", KEYS[1], ttl)
+end
+return 0
+`)
+
+// addIndex はmembersを有効期間ttlで索引に追加する
+// 自然に期限切れとなったキーは追加の都度索引から除外し、索引が際限なく肥大化することを防ぐ
+func addIndex(ctx context.Context, pipe redis.Pipeliner, idx string, ttl time.Duration, members ...string) {
+	args := make([]any, 0, len(members)+1)
+	args = append(args, ttl.Milliseconds())
+	for _, m := range members {
+		args = append(args, m)
+	}
+	// パイプラインではスクリプトのキャッシュの有無を確認できないため、EVALで送信する
+	indexMembers.Eval(ctx, pipe, []string{idx}, args...)
+}
+
+// indexedMembers は索引に含まれる期限切れでないキーを取得する
+func (k KVS) indexedMembers(ctx context.Context, idx string) ([]string, error) {
+	t, err := k.Cli.Time(ctx).Result()
+	if err != nil {
+		return nil, err
+	}
+	return k.Cli.ZRangeByScore(ctx, idx, &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(t.UnixMilli(), 10), Max: "+inf",
+	}).Result()
 }
 
 func (k KVS) Load(ctx context.Context, key string) (entity.UserID, error) {
@@ -37,3 +90,112 @@ func (k KVS) Load(ctx context.Context, key string) (entity.UserID, error) {
 	}
 	return entity.UserID(id), nil
 }
+
+// Delete はキーと、キーに対応するセッション情報を削除し、ユーザごとの索引からも除外する
+// キーが存在しない場合はErrNotFoundを返却する
+func (k KVS) Delete(ctx context.Context, key string) error {
+	uid, err := k.Load(ctx, key)
+	if err != nil {
+		return err
+	}
+	_, err = k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key, sessionKey(key))
+		pipe.ZRem(ctx, sessionIndexKey(uid), key, sessionKey(key))
+		return nil
+	})
+	return err
+}
+
+// DeleteAll はユーザの索引に含まれるキーをすべて削除する
+// 索引は取得したキーのみを除外し、処理中に並行して登録されたキーは削除しない
+func (k KVS) DeleteAll(ctx context.Context, userID entity.UserID) error {
+	idx := sessionIndexKey(userID)
+	// 期限切れのキーは削除不要だが、索引からは併せて除外するためすべて取得する
+	keys, err := k.Cli.ZRange(ctx, idx, 0, -1).Result()
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(keys))
+	for _, key := range keys {
+		members = append(members, key)
+	}
+	_, err = k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, keys...)
+		pipe.ZRem(ctx, idx, members...)
+		return nil
+	})
+	return err
+}
+
+// ErrTokenReused はローテーション済のリフレッシュトークンが再度使用されたことを表す
+var ErrTokenReused = errors.New("token reused")
+
+// refreshFamilyKey はリフレッシュトークンのファミリーの状態を格納するキーを返却する
+func refreshFamilyKey(family string) string {
+	return "refresh_family:" + family
+}
+
+// rotateRefreshFamily はファミリーの現行トークンのハッシュ値を比較し、一致した場合のみ新しいハッシュ値に置き換える
+// 一致しない場合はローテーション済のトークンの再使用とみなし、ファミリーを削除する
+// 戻り値は{状態, ユーザID}とし、状態はファミリーが存在しない場合に-1、再使用の場合に0、置き換えた場合に1とする
+var rotateRefreshFamily = redis.NewScript(`
+local current = redis.call("HGET", KEYS[1], "current")
+if not current then
+	return {-1, 0}
+end
+local uid = tonumber(redis.call("HGET", KEYS[1], "user_id"))
+if current ~= ARGV[1] then
+	redis.call("DEL", KEYS[1])
+	return {0, uid}
+end
+redis.call("HSET", KEYS[1], "current", ARGV[2])
+redis.call("PEXPIRE", KEYS[1], ARGV[3])
+return {1, uid}
+`)
+
+// SaveRefreshFamily はリフレッシュトークンのファミリーを作成し、現行トークンのハッシュ値を登録する
+// ファミリーはユーザごとの索引に追加し、DeleteAllの削除対象とする
+func (k KVS) SaveRefreshFamily(
+	ctx context.Context, family string, userID entity.UserID, hash string, ttl time.Duration,
+) error {
+	key := refreshFamilyKey(family)
+	_, err := k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "user_id", int64(userID), "current", hash)
+		pipe.Expire(ctx, key, ttl)
+		addIndex(ctx, pipe, sessionIndexKey(userID), ttl, key)
+		return nil
+	})
+	return err
+}
+
+// RotateRefreshFamily はファミリーの現行トークンをoldHashからnewHashに置き換え、ファミリーのユーザIDを返却する
+// 比較と置き換えはスクリプトにより不可分に実行するため、同一トークンによる並行したローテーションは1件のみ成功する
+// ファミリーが存在しない場合はErrNotFound、oldHashが現行トークンでない場合はファミリーを削除してErrTokenReusedを返却する
+// 再使用の場合も、呼び出し元がファミリーのセッションを失効できるようにユーザIDを返却する
+func (k KVS) RotateRefreshFamily(
+	ctx context.Context, family, oldHash, newHash string, ttl time.Duration,
+) (entity.UserID, error) {
+	key := refreshFamilyKey(family)
+	r, err := rotateRefreshFamily.Run(ctx, k.Cli, []string{key}, oldHash, newHash, ttl.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, err
+	}
+	uid := entity.UserID(r[1])
+	switch r[0] {
+	case -1:
+		return 0, fmt.Errorf("refresh family %q: %w", family, ErrNotFound)
+	case 0:
+		return uid, fmt.Errorf("refresh family %q: %w", family, ErrTokenReused)
+	}
+	// ファミリーの期限延長に合わせて索引のファミリーと索引自体の期限も延長する
+	if _, err := k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		addIndex(ctx, pipe, sessionIndexKey(uid), ttl, key)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return uid, nil
+}
@@ -25,7 +25,7 @@ func TestKVS_Save(t *testing.T) {
 	sut := &KVS{Cli: cli}
 
 	// 検証
-	if err := sut.Save(ctx, key, uid); err != nil {
+	if err := sut.Save(ctx, key, uid, 30*time.Minute); err != nil {
 		t.Errorf("want no error, but got %v", err)
 	}
 }
@@ -72,3 +72,144 @@ func TestKVS_Load(t *testing.T) {
 		}
 	})
 }
+
+func TestKVS_Delete(t *testing.T) {
+	t.Parallel()
+
+	// データ準備
+	key := "TestKVS_Delete"
+	uid := entity.UserID(1235)
+	ctx := context.Background()
+	cli := testutil.OpenRedisForTest(t)
+	t.Cleanup(func() {
+		cli.Del(ctx, key, sessionIndexKey(uid))
+	})
+	sut := &KVS{Cli: cli}
+	if err := sut.Save(ctx, key, uid, 30*time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+
+	// 実行と検証
+	if err := sut.Delete(ctx, key); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if _, err := sut.Load(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want: %v, but got: %v", ErrNotFound, err)
+	}
+	if err := cli.ZScore(ctx, sessionIndexKey(uid), key).Err(); err == nil {
+		t.Errorf("want %q removed from session index", key)
+	}
+	if err := sut.Delete(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want: %v, but got: %v", ErrNotFound, err)
+	}
+}
+
+// TestKVS_Save_pruneIndex 有効期限が切れたキーが、以降の登録時にユーザごとの索引から除外されることを確認する
+func TestKVS_Save_pruneIndex(t *testing.T) {
+	t.Parallel()
+
+	// データ準備
+	expired, alive := "TestKVS_Save_pruneIndex_expired", "TestKVS_Save_pruneIndex_alive"
+	uid := entity.UserID(1238)
+	ctx := context.Background()
+	cli := testutil.OpenRedisForTest(t)
+	t.Cleanup(func() {
+		cli.Del(ctx, expired, alive, sessionIndexKey(uid))
+	})
+	sut := &KVS{Cli: cli}
+	if err := sut.Save(ctx, expired, uid, 10*time.Millisecond); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	// 実行と検証
+	if err := sut.Save(ctx, alive, uid, 30*time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	got, err := cli.ZRange(ctx, sessionIndexKey(uid), 0, -1).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != alive {
+		t.Errorf("want only %q indexed, but got %v", alive, got)
+	}
+	// 索引の期限は最も遅いキーの期限まで延長されること
+	if ttl := cli.PTTL(ctx, sessionIndexKey(uid)).Val(); ttl < 29*time.Minute {
+		t.Errorf("want index ttl extended to about %s, but got %s", 30*time.Minute, ttl)
+	}
+}
+
+func TestKVS_DeleteAll(t *testing.T) {
+	t.Parallel()
+
+	// データ準備
+	keys := []string{"TestKVS_DeleteAll_1", "TestKVS_DeleteAll_2"}
+	other := "TestKVS_DeleteAll_other"
+	uid := entity.UserID(1236)
+	ctx := context.Background()
+	cli := testutil.OpenRedisForTest(t)
+	t.Cleanup(func() {
+		cli.Del(ctx, keys[0], keys[1], other, sessionIndexKey(uid), sessionIndexKey(uid+1))
+	})
+	sut := &KVS{Cli: cli}
+	for _, key := range keys {
+		if err := sut.Save(ctx, key, uid, 30*time.Minute); err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+	}
+	// 他ユーザのキーは削除されないこと
+	if err := sut.Save(ctx, other, uid+1, 30*time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+
+	// 実行と検証
+	if err := sut.DeleteAll(ctx, uid); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	for _, key := range keys {
+		if _, err := sut.Load(ctx, key); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: want: %v, but got: %v", key, ErrNotFound, err)
+		}
+	}
+	if got, err := sut.Load(ctx, other); err != nil || got != uid+1 {
+		t.Errorf("want %d, but got %d (err = %v)", uid+1, got, err)
+	}
+}
+
+func TestKVS_RotateRefreshFamily(t *testing.T) {
+	t.Parallel()
+
+	// データ準備
+	family := "TestKVS_RotateRefreshFamily"
+	uid := entity.UserID(1237)
+	ttl := time.Hour
+	ctx := context.Background()
+	cli := testutil.OpenRedisForTest(t)
+	t.Cleanup(func() {
+		cli.Del(ctx, refreshFamilyKey(family), sessionIndexKey(uid))
+	})
+	sut := &KVS{Cli: cli}
+	if err := sut.SaveRefreshFamily(ctx, family, uid, "hash1", ttl); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+
+	// 実行と検証
+	got, err := sut.RotateRefreshFamily(ctx, family, "hash1", "hash2", ttl)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if got != uid {
+		t.Errorf("want: %d, but got: %d", uid, got)
+	}
+	// ローテーション済のハッシュ値による再使用はファミリーを削除し、セッションの失効に使用するユーザIDを返却する
+	got, err = sut.RotateRefreshFamily(ctx, family, "hash1", "hash3", ttl)
+	if !errors.Is(err, ErrTokenReused) {
+		t.Errorf("want: %v, but got: %v", ErrTokenReused, err)
+	}
+	if got != uid {
+		t.Errorf("want: %d, but got: %d", uid, got)
+	}
+	if _, err := sut.RotateRefreshFamily(ctx, family, "hash2", "hash3", ttl); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want: %v, but got: %v", ErrNotFound, err)
+	}
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// loginFailuresKey はログイン失敗回数を格納するキーを返却する
+func loginFailuresKey(key string) string {
+	return "login_failures:" + key
+}
+
+// loginLockKey はログインの一時停止を表すキーを返却する
+func loginLockKey(key string) string {
+	return "login_lock:" + key
+}
+
+// IncrLoginFailures はログイン失敗回数を加算し、加算後の回数を返却する
+// 失敗回数は最初の失敗からwindowの経過後に消去する
+func (k KVS) IncrLoginFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, loginFailuresKey(key))
+		pipe.ExpireNX(ctx, loginFailuresKey(key), window)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// ResetLoginFailures はログイン失敗回数を消去する
+func (k KVS) ResetLoginFailures(ctx context.Context, key string) error {
+	return k.Cli.Del(ctx, loginFailuresKey(key)).Err()
+}
+
+// LockLogin はログインを期間dの間停止する
+// 停止中の場合は停止期間を延長のみし、短縮はしない
+func (k KVS) LockLogin(ctx context.Context, key string, d time.Duration) error {
+	_, err := k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SetNX(ctx, loginLockKey(key), 1, d)
+		pipe.ExpireGT(ctx, loginLockKey(key), d)
+		return nil
+	})
+	return err
+}
+
+// LoginLockTTL はログインの停止が解除されるまでの残り期間を返却する
+// 停止していない場合は0を返却する
+func (k KVS) LoginLockTTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := k.Cli.PTTL(ctx, loginLockKey(key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	// キーが存在しない場合は負の値が返却される
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestKVS_LoginAttempts(t *testing.T) {
+	t.Parallel()
+
+	key := "TestKVS_LoginAttempts"
+	ctx := context.Background()
+	cli := testutil.OpenRedisForTest(t)
+	t.Cleanup(func() {
+		cli.Del(ctx, loginFailuresKey(key), loginLockKey(key))
+	})
+	sut := &KVS{Cli: cli}
+
+	for want := int64(1); want <= 3; want++ {
+		got, err := sut.IncrLoginFailures(ctx, key, time.Minute)
+		if err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+		if got != want {
+			t.Errorf("want %d failures, but got %d", want, got)
+		}
+	}
+	if err := sut.ResetLoginFailures(ctx, key); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if got, _ := sut.IncrLoginFailures(ctx, key, time.Minute); got != 1 {
+		t.Errorf("want failures reset, but got %d", got)
+	}
+
+	if ttl, err := sut.LoginLockTTL(ctx, key); err != nil || ttl != 0 {
+		t.Fatalf("want no lock, but got %s, %v", ttl, err)
+	}
+	if err := sut.LockLogin(ctx, key, time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	// 停止期間は短縮しない
+	if err := sut.LockLogin(ctx, key, 10*time.Second); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if ttl, err := sut.LoginLockTTL(ctx, key); err != nil || ttl <= 10*time.Second {
+		t.Errorf("want lock longer than %s, but got %s, %v", 10*time.Second, ttl, err)
+	}
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/go-redis/redis/v8"
+)
+
+// loginChallengeKey は二要素認証のチャレンジのハッシュ値に対応するキーを返却する
+func loginChallengeKey(hash string) string {
+	return "login_challenge:" + hash
+}
+
+// totpUsedKey は使用済のTOTPの時間ステップを表すキーを返却する
+func totpUsedKey(userID entity.UserID, step int64) string {
+	return fmt.Sprintf("totp_used:%d:%d", userID, step)
+}
+
+// 二要素認証のチャレンジを格納するハッシュのフィールド
+const (
+	challengeUserField     = "user_id"
+	challengeFailuresField = "failures"
+)
+
+// SaveLoginChallenge は二要素認証のチャレンジのハッシュ値にユーザIDを有効期間ttlで登録する
+func (k KVS) SaveLoginChallenge(ctx context.Context, hash string, userID entity.UserID, ttl time.Duration) error {
+	_, err := k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, loginChallengeKey(hash), challengeUserField, int64(userID), challengeFailuresField, 0)
+		pipe.Expire(ctx, loginChallengeKey(hash), ttl)
+		return nil
+	})
+	return err
+}
+
+// LoadLoginChallenge は二要素認証のチャレンジのハッシュ値に対応するユーザIDを取得する
+// チャレンジが存在しない、または期限切れの場合はErrNotFoundを返却する
+func (k KVS) LoadLoginChallenge(ctx context.Context, hash string) (entity.UserID, error) {
+	id, err := k.Cli.HGet(ctx, loginChallengeKey(hash), challengeUserField).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, fmt.Errorf("login challenge: %w", ErrNotFound)
+		}
+		return 0, err
+	}
+	return entity.UserID(id), nil
+}
+
+// IncrLoginChallengeFailures はチャレンジに対するコードの検証失敗回数を加算し、加算後の回数を返却する
+// チャレンジが存在しない場合はErrNotFoundを返却する
+func (k KVS) IncrLoginChallengeFailures(ctx context.Context, hash string) (int64, error) {
+	// 期限切れ後にフィールドのみのキーが作成されないよう、存在を確認してから加算する
+	n, err := k.Cli.Exists(ctx, loginChallengeKey(hash)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("login challenge: %w", ErrNotFound)
+	}
+	return k.Cli.HIncrBy(ctx, loginChallengeKey(hash), challengeFailuresField, 1).Result()
+}
+
+// ConsumeLoginChallenge は二要素認証のチャレンジを削除する
+// 削除をもって使用済とするため、同一のチャレンジは1回のみ使用できる
+// チャレンジが存在しない、または使用済の場合はErrNotFoundを返却する
+func (k KVS) ConsumeLoginChallenge(ctx context.Context, hash string) error {
+	n, err := k.Cli.Del(ctx, loginChallengeKey(hash)).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("login challenge: %w", ErrNotFound)
+	}
+	return nil
+}
+
+// UseTOTPStep はユーザのTOTPの時間ステップを有効期間ttlで使用済とする
+// 使用済であった場合はfalseを返却する
+func (k KVS) UseTOTPStep(ctx context.Context, userID entity.UserID, step int64, ttl time.Duration) (bool, error) {
+	return k.Cli.SetNX(ctx, totpUsedKey(userID, step), 1, ttl).Result()
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestKVS_LoginChallenge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uid := entity.UserID(1234)
+	hash := "TestKVS_LoginChallenge"
+	cli := testutil.OpenRedisForTest(t)
+	t.Cleanup(func() {
+		cli.Del(ctx, loginChallengeKey(hash), totpUsedKey(uid, 1))
+	})
+	sut := &KVS{Cli: cli}
+
+	if err := sut.SaveLoginChallenge(ctx, hash, uid, time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if got, err := sut.LoadLoginChallenge(ctx, hash); err != nil || got != uid {
+		t.Fatalf("want %d, but got %d, %v", uid, got, err)
+	}
+	for want := int64(1); want <= 2; want++ {
+		if got, err := sut.IncrLoginChallengeFailures(ctx, hash); err != nil || got != want {
+			t.Fatalf("want %d failures, but got %d, %v", want, got, err)
+		}
+	}
+	if err := sut.ConsumeLoginChallenge(ctx, hash); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if err := sut.ConsumeLoginChallenge(ctx, hash); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want %v for consumed challenge, but got %v", ErrNotFound, err)
+	}
+	if _, err := sut.IncrLoginChallengeFailures(ctx, hash); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want %v for consumed challenge, but got %v", ErrNotFound, err)
+	}
+
+	// 同一の時間ステップは1回のみ使用できる
+	if ok, err := sut.UseTOTPStep(ctx, uid, 1, time.Minute); err != nil || !ok {
+		t.Fatalf("want first use accepted, but got %v, %v", ok, err)
+	}
+	if ok, err := sut.UseTOTPStep(ctx, uid, 1, time.Minute); err != nil || ok {
+		t.Errorf("want reuse rejected, but got %v, %v", ok, err)
+	}
+}
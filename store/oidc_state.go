@@ -0,0 +1,45 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// oidcStateKey はOpenID Connectの認可リクエストのstateのハッシュ値に対応するキーを返却する
+func oidcStateKey(hash string) string {
+	return "oidc_state:" + hash
+}
+
+// SaveOIDCState は認可リクエストのstateのハッシュ値に、コールバックで検証に使用するnonceとPKCEのcode_verifierを有効期間ttlで登録する
+func (k KVS) SaveOIDCState(ctx context.Context, hash, nonce, verifier string, ttl time.Duration) error {
+	key := oidcStateKey(hash)
+	_, err := k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "nonce", nonce, "verifier", verifier)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
+
+// ConsumeOIDCState はstateのハッシュ値に対応する値を削除し、nonceとcode_verifierを返却する
+// 取得と削除をアトミックに実行するため、同一のstateは1回のみ使用できる
+// stateが存在しない、期限切れ、または使用済の場合はErrNotFoundを返却する
+func (k KVS) ConsumeOIDCState(ctx context.Context, hash string) (string, string, error) {
+	key := oidcStateKey(hash)
+	var get *redis.StringStringMapCmd
+	if _, err := k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.HGetAll(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	}); err != nil {
+		return "", "", err
+	}
+	m := get.Val()
+	if len(m) == 0 {
+		return "", "", fmt.Errorf("oidc state: %w", ErrNotFound)
+	}
+	return m["nonce"], m["verifier"], nil
+}
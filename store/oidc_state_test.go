@@ -0,0 +1,31 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestKVS_OIDCState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	hash := "TestKVS_OIDCState"
+	cli := testutil.OpenRedisForTest(t)
+	t.Cleanup(func() { cli.Del(ctx, oidcStateKey(hash)) })
+	sut := &KVS{Cli: cli}
+
+	if err := sut.SaveOIDCState(ctx, hash, "nonce", "verifier", time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	nonce, verifier, err := sut.ConsumeOIDCState(ctx, hash)
+	if err != nil || nonce != "nonce" || verifier != "verifier" {
+		t.Fatalf("want nonce and verifier, but got %q, %q, %v", nonce, verifier, err)
+	}
+	if _, _, err := sut.ConsumeOIDCState(ctx, hash); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want %v for consumed state, but got %v", ErrNotFound, err)
+	}
+}
@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/go-redis/redis/v8"
+)
+
+// passwordResetKey はパスワード再設定トークンのハッシュ値に対応するキーを返却する
+func passwordResetKey(hash string) string {
+	return "password_reset:" + hash
+}
+
+// passwordResetUserKey はユーザごとに有効なパスワード再設定トークンのハッシュ値を格納するキーを返却する
+func passwordResetUserKey(userID entity.UserID) string {
+	return fmt.Sprintf("password_reset_user:%d", userID)
+}
+
+// SavePasswordResetToken はパスワード再設定トークンのハッシュ値にユーザIDを有効期間ttlで登録する
+// ユーザに発行済のトークンは無効とし、有効なトークンは常に最後に発行した1件のみとする
+func (k KVS) SavePasswordResetToken(ctx context.Context, hash string, userID entity.UserID, ttl time.Duration) error {
+	userKey := passwordResetUserKey(userID)
+	old, err := k.Cli.Get(ctx, userKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+	_, err = k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if old != "" {
+			pipe.Del(ctx, passwordResetKey(old))
+		}
+		pipe.Set(ctx, passwordResetKey(hash), int64(userID), ttl)
+		pipe.Set(ctx, userKey, hash, ttl)
+		return nil
+	})
+	return err
+}
+
+// LoadPasswordResetToken はパスワード再設定トークンのハッシュ値に対応するユーザIDを取得する
+// トークンが存在しない、または期限切れの場合はErrNotFoundを返却する
+func (k KVS) LoadPasswordResetToken(ctx context.Context, hash string) (entity.UserID, error) {
+	id, err := k.Cli.Get(ctx, passwordResetKey(hash)).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, fmt.Errorf("password reset token: %w", ErrNotFound)
+		}
+		return 0, err
+	}
+	return entity.UserID(id), nil
+}
+
+// ConsumePasswordResetToken はパスワード再設定トークンを削除し、対応するユーザIDを返却する
+// 取得と削除をアトミックに実行するため、同一のトークンは1回のみ使用できる
+// トークンが存在しない、または使用済の場合はErrNotFoundを返却する
+func (k KVS) ConsumePasswordResetToken(ctx context.Context, hash string) (entity.UserID, error) {
+	id, err := k.Cli.GetDel(ctx, passwordResetKey(hash)).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, fmt.Errorf("password reset token: %w", ErrNotFound)
+		}
+		return 0, err
+	}
+	return entity.UserID(id), nil
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestKVS_PasswordResetToken(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uid := entity.UserID(1234)
+	oldHash, newHash := "TestKVS_PasswordResetToken_old", "TestKVS_PasswordResetToken_new"
+	cli := testutil.OpenRedisForTest(t)
+	t.Cleanup(func() {
+		cli.Del(ctx, passwordResetKey(oldHash), passwordResetKey(newHash), passwordResetUserKey(uid))
+	})
+	sut := &KVS{Cli: cli}
+
+	if err := sut.SavePasswordResetToken(ctx, oldHash, uid, time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	// 再発行により発行済のトークンは無効となる
+	if err := sut.SavePasswordResetToken(ctx, newHash, uid, time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if _, err := sut.LoadPasswordResetToken(ctx, oldHash); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want %v for reissued token, but got %v", ErrNotFound, err)
+	}
+	if got, err := sut.ConsumePasswordResetToken(ctx, newHash); err != nil || got != uid {
+		t.Fatalf("want %d, but got %d, %v", uid, got, err)
+	}
+	if _, err := sut.ConsumePasswordResetToken(ctx, newHash); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want %v for consumed token, but got %v", ErrNotFound, err)
+	}
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+const (
+	// patColumns はentity.PersonalAccessTokenにマッピングするカラムの一覧
+	patColumns = `id, user_id, name, token_hash, scopes, expires_at, created, modified`
+
+	insertPAT = `INSERT INTO personal_access_tokens
+			 (user_id, name, token_hash, scopes, expires_at, created, modified)
+			 VALUES (?, ?, ?, ?, ?, ?, ?);`
+	selectPATs   = `SELECT ` + patColumns + ` FROM personal_access_tokens WHERE user_id = ? ORDER BY id;`
+	getPATByHash = `SELECT ` + patColumns + ` FROM personal_access_tokens WHERE token_hash = ?;`
+	deletePAT    = `DELETE FROM personal_access_tokens WHERE id = ? AND user_id = ?;`
+	deletePATs   = `DELETE FROM personal_access_tokens WHERE user_id = ?;`
+)
+
+// AddPersonalAccessToken は1件のパーソナルアクセストークンを登録し、
+// 引数で渡された*entity.PersonalAccessToken.IDに発行されたIDを格納する
+func (r *Repository) AddPersonalAccessToken(ctx context.Context, db Execer, t *entity.PersonalAccessToken) error {
+	t.Created = r.Clocker.Now()
+	t.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, insertPAT,
+		t.UserID, t.Name, t.TokenHash, t.Scopes, t.ExpiresAt, t.Created, t.Modified)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	t.ID = entity.PersonalAccessTokenID(id)
+	return nil
+}
+
+// ListPersonalAccessTokens はユーザが発行したパーソナルアクセストークンを発行順にすべて取得する
+// 有効期限を過ぎたトークンも、ユーザが削除するまで一覧に含める
+func (r *Repository) ListPersonalAccessTokens(
+	ctx context.Context, db Queryer, uid entity.UserID,
+) (entity.PersonalAccessTokens, error) {
+	ts := entity.PersonalAccessTokens{}
+	if err := db.SelectContext(ctx, &ts, selectPATs, uid); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// GetPersonalAccessTokenByHash はハッシュ値に一致するパーソナルアクセストークンを取得する
+// 該当するトークンが存在しない場合はErrNotFoundを返却し、有効期限の判定は呼び出し元で実施する
+func (r *Repository) GetPersonalAccessTokenByHash(
+	ctx context.Context, db Queryer, hash string,
+) (*entity.PersonalAccessToken, error) {
+	t := &entity.PersonalAccessToken{}
+	if err := db.GetContext(ctx, t, getPATByHash, hash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("personal access token: %w", ErrNotFound)
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// DeletePersonalAccessToken はユーザが発行した1件のパーソナルアクセストークンを削除する
+// 削除対象が存在しない場合はErrNotFoundを返却する
+func (r *Repository) DeletePersonalAccessToken(
+	ctx context.Context, db Execer, uid entity.UserID, id entity.PersonalAccessTokenID,
+) error {
+	result, err := db.ExecContext(ctx, deletePAT, id, uid)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("personal access token %d", id))
+}
+
+// DeletePersonalAccessTokens はユーザが発行したパーソナルアクセストークンをすべて削除する
+// パスワードの変更時等に使用し、削除対象が存在しない場合もエラーとしない
+func (r *Repository) DeletePersonalAccessTokens(ctx context.Context, db Execer, uid entity.UserID) error {
+	_, err := db.ExecContext(ctx, deletePATs, uid)
+	return err
+}
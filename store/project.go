@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+const (
+	projectColumns = `id, user_id, name, archived_at, created, modified`
+
+	insertProject     = `INSERT INTO projects (user_id, name, created, modified) VALUES (?, ?, ?, ?);`
+	selectProjects    = `SELECT ` + projectColumns + ` FROM projects WHERE user_id = ? AND archived_at IS NULL ORDER BY id;`
+	selectAllProjects = `SELECT ` + projectColumns + ` FROM projects WHERE user_id = ? ORDER BY id;`
+	getProject        = `SELECT ` + projectColumns + ` FROM projects WHERE id = ? AND user_id = ?;`
+	updateProject     = `UPDATE projects SET name = ?, modified = ? WHERE id = ? AND user_id = ?;`
+	archiveProject    = `UPDATE projects SET archived_at = ?, modified = ? WHERE id = ? AND user_id = ?;`
+	deleteProject     = `DELETE FROM projects WHERE id = ? AND user_id = ?;`
+)
+
+// AddProject は1件のプロジェクトを登録し、引数で渡された*entity.Project.IDに発行されたIDを格納する
+func (r *Repository) AddProject(ctx context.Context, db Execer, p *entity.Project) error {
+	p.Created = r.Clocker.Now()
+	p.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, insertProject, p.UserID, p.Name, p.Created, p.Modified)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	p.ID = entity.ProjectID(id)
+	return nil
+}
+
+// ListProjects はユーザに紐付いたプロジェクトを取得する
+// includeArchivedがfalseの場合はアーカイブ済のプロジェクトを除外する
+func (r *Repository) ListProjects(
+	ctx context.Context, db Queryer, uid entity.UserID, includeArchived bool,
+) (entity.Projects, error) {
+	q := selectProjects
+	if includeArchived {
+		q = selectAllProjects
+	}
+	projects := entity.Projects{}
+	if err := db.SelectContext(ctx, &projects, q, uid); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// GetProject はユーザに紐付いた1件のプロジェクトを取得する
+// 他ユーザのプロジェクトは存在しないプロジェクトと同様にErrNotFoundを返却する
+func (r *Repository) GetProject(ctx context.Context, db Queryer, uid entity.UserID, id entity.ProjectID) (*entity.Project, error) {
+	p := &entity.Project{}
+	if err := db.GetContext(ctx, p, getProject, id, uid); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("project %d: %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// UpdateProject はユーザに紐付いた1件のプロジェクト名を更新する
+func (r *Repository) UpdateProject(ctx context.Context, db Execer, p *entity.Project) error {
+	p.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, updateProject, p.Name, p.Modified, p.ID, p.UserID)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("project %d", p.ID))
+}
+
+// ArchiveProject はユーザに紐付いた1件のプロジェクトのアーカイブ状態を変更する
+// archivedがfalseの場合はアーカイブを解除する
+func (r *Repository) ArchiveProject(
+	ctx context.Context, db Execer, uid entity.UserID, id entity.ProjectID, archived bool,
+) error {
+	now := r.Clocker.Now()
+	var archivedAt *time.Time
+	if archived {
+		archivedAt = &now
+	}
+	result, err := db.ExecContext(ctx, archiveProject, archivedAt, now, id, uid)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("project %d", id))
+}
+
+// DeleteProject はユーザに紐付いた1件のプロジェクトを削除する
+// 所属していたタスクは外部キー制約によりプロジェクト未所属となる
+func (r *Repository) DeleteProject(ctx context.Context, db Execer, uid entity.UserID, id entity.ProjectID) error {
+	result, err := db.ExecContext(ctx, deleteProject, id, uid)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("project %d", id))
+}
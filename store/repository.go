@@ -9,7 +9,7 @@ import (
 
 	"github.com/ac0mz/go_todo_app/clock"
 	"github.com/ac0mz/go_todo_app/config"
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -27,6 +27,12 @@ var (
 	ErrAlreadyEntry = errors.New("duplicate entry")
 )
 
+// isDuplicateEntry はMySQLの一意制約違反エラーであるかを判定する
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeMySQLDuplicateEntry
+}
+
 func New(ctx context.Context, cfg *config.Config) (*sqlx.DB, func(), error) {
 	db, err := sql.Open(
 		driverName,
@@ -52,6 +58,12 @@ type Beginner interface {
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
 }
 
+// TxBeginner はsqlxのトランザクション開始操作を扱う
+// 複数のSQLを一貫して実行する処理で使用する
+type TxBeginner interface {
+	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
+}
+
 // Preparer is an interface used by Preparex.
 // prepared statementとしてSQLを扱う(標準パッケージのsql.Stmtをラップする)
 type Preparer interface {
@@ -76,15 +88,26 @@ type Queryer interface {
 	SelectContext(ctx context.Context, dest interface{}, query string, args ...any) error
 }
 
+// ExecQueryer は参照系と書き込み系の両方の操作を扱う
+// 参照結果を元に更新する処理で使用する
+type ExecQueryer interface {
+	Execer
+	Queryer
+}
+
 var (
 	// interfaceが期待通りに宣言されていることの検証用コード
 	// *sqlx.DB型をnilで初期化した値を右辺で作成後、各interfaceに代入することでコンパイラに検証させる
 	// 以下の書き方で作成する場合は、ポインタ型の値を作成する方法と異なりメモリアロケーションが発生しない
-	_ Beginner = (*sqlx.DB)(nil)
-	_ Preparer = (*sqlx.DB)(nil)
-	_ Execer   = (*sqlx.DB)(nil)
-	_ Queryer  = (*sqlx.DB)(nil)
-	_ Queryer  = (*sqlx.Tx)(nil)
+	_ Beginner   = (*sqlx.DB)(nil)
+	_ TxBeginner = (*sqlx.DB)(nil)
+	_ Preparer   = (*sqlx.DB)(nil)
+	_ Execer     = (*sqlx.DB)(nil)
+	_ Queryer    = (*sqlx.DB)(nil)
+	_ Queryer    = (*sqlx.Tx)(nil)
+
+	_ ExecQueryer = (*sqlx.DB)(nil)
+	_ ExecQueryer = (*sqlx.Tx)(nil)
 )
 
 // Repository はすべてのDB操作を扱う
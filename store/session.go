@@ -0,0 +1,194 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/go-redis/redis/v8"
+)
+
+// sessionKeyPrefix はセッション情報を格納するキーの接頭辞
+const sessionKeyPrefix = "session:"
+
+// sessionKey はセッションIDに対応するセッション情報を格納するキーを返却する
+func sessionKey(sid string) string {
+	return sessionKeyPrefix + sid
+}
+
+// sessionTokensKey はセッションで発行したアクセストークンのJWT IDを格納する集合のキーを返却する
+func sessionTokensKey(sid string) string {
+	return "session_tokens:" + sid
+}
+
+// セッション情報を格納するハッシュのフィールド
+const (
+	sessionUserField      = "user_id"
+	sessionUserAgentField = "user_agent"
+	sessionIPField        = "ip"
+	sessionCreatedField   = "created"
+	sessionLastSeenField  = "last_seen"
+	sessionFamilyField    = "refresh_family"
+)
+
+// SaveSession はセッション情報を有効期間ttlで登録し、JWT IDをセッションのアクセストークンとして追加する
+// 登録済のセッションの場合は作成日時を維持し、その他の情報と有効期間を更新する
+// ユーザごとの索引に追加することで、DeleteAllによる全トークンの失効時に併せて削除する
+func (k KVS) SaveSession(ctx context.Context, s *entity.Session, jti string, ttl time.Duration) error {
+	key := sessionKey(s.ID)
+	tokens := sessionTokensKey(s.ID)
+	idx := sessionIndexKey(s.UserID)
+	_, err := k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSetNX(ctx, key, sessionCreatedField, s.Created.UnixNano())
+		pipe.HSet(ctx, key,
+			sessionUserField, int64(s.UserID),
+			sessionUserAgentField, s.UserAgent,
+			sessionIPField, s.IP,
+			sessionLastSeenField, s.LastSeen.UnixNano(),
+			sessionFamilyField, s.RefreshFamily,
+		)
+		pipe.Expire(ctx, key, ttl)
+		addIndex(ctx, pipe, tokens, ttl, jti)
+		addIndex(ctx, pipe, idx, ttl, key, tokens)
+		return nil
+	})
+	return err
+}
+
+// LoadSession はセッションIDに対応するセッション情報を取得する
+// セッションが存在しない、または期限切れの場合はErrNotFoundを返却する
+func (k KVS) LoadSession(ctx context.Context, sid string) (*entity.Session, error) {
+	m, err := k.Cli.HGetAll(ctx, sessionKey(sid)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(m) == 0 {
+		return nil, fmt.Errorf("session %q: %w", sid, ErrNotFound)
+	}
+	return parseSession(sid, m)
+}
+
+// ListSessions はユーザの有効なセッションを作成日時の新しい順に取得する
+func (k KVS) ListSessions(ctx context.Context, userID entity.UserID) (entity.Sessions, error) {
+	keys, err := k.indexedMembers(ctx, sessionIndexKey(userID))
+	if err != nil {
+		return nil, err
+	}
+	cmds := map[string]*redis.StringStringMapCmd{}
+	if _, err := k.Cli.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			// 索引にはトークンやリフレッシュトークンのファミリーのキーも含まれる
+			if strings.HasPrefix(key, sessionKeyPrefix) {
+				cmds[strings.TrimPrefix(key, sessionKeyPrefix)] = pipe.HGetAll(ctx, key)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sessions := entity.Sessions{}
+	for sid, cmd := range cmds {
+		// 索引に残っていても、期限切れのセッションは除外する
+		if len(cmd.Val()) == 0 {
+			continue
+		}
+		s, err := parseSession(sid, cmd.Val())
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].Created.Equal(sessions[j].Created) {
+			return sessions[i].Created.After(sessions[j].Created)
+		}
+		return sessions[i].ID < sessions[j].ID
+	})
+	return sessions, nil
+}
+
+// touchSession は最終アクセス日時を、前回の記録からinterval以上経過している場合のみ更新する
+// 判定と更新をスクリプトにより1回の通信で実行し、期限切れのセッションは再作成しない
+var touchSession = redis.NewScript(`
+local last = redis.call("HGET", KEYS[1], "last_seen")
+if not last then
+	return 0
+end
+if tonumber(ARGV[1]) - tonumber(last) < tonumber(ARGV[2]) then
+	return 0
+end
+redis.call("HSET", KEYS[1], "last_seen", ARGV[1])
+return 1
+`)
+
+// TouchSession はセッションの最終アクセス日時をnowに更新する
+// 前回の更新からinterval未満の場合は書き込みを行わず、falseを返却する
+func (k KVS) TouchSession(ctx context.Context, sid string, now time.Time, interval time.Duration) (bool, error) {
+	n, err := touchSession.Run(ctx, k.Cli, []string{sessionKey(sid)}, now.UnixNano(), interval.Nanoseconds()).Int64()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// DeleteSession はセッションと、セッションで発行したすべてのアクセストークン、および関連するリフレッシュトークンのファミリーを削除する
+// ユーザのセッションでない場合、または存在しない場合はErrNotFoundを返却する
+func (k KVS) DeleteSession(ctx context.Context, userID entity.UserID, sid string) error {
+	s, err := k.LoadSession(ctx, sid)
+	if err != nil {
+		return err
+	}
+	// 他のユーザのセッションの存在有無を推測させないため、存在しない場合と同様に扱う
+	if s.UserID != userID {
+		return fmt.Errorf("session %q: %w", sid, ErrNotFound)
+	}
+	jtis, err := k.Cli.ZRange(ctx, sessionTokensKey(sid), 0, -1).Result()
+	if err != nil {
+		return err
+	}
+	// sidクレーム導入前のセッションは、セッションIDをJWT IDとしている
+	keys := append([]string{sid, sessionKey(sid), sessionTokensKey(sid)}, jtis...)
+	if s.RefreshFamily != "" {
+		keys = append(keys, refreshFamilyKey(s.RefreshFamily))
+	}
+	members := make([]any, 0, len(keys))
+	for _, key := range keys {
+		members = append(members, key)
+	}
+	_, err = k.Cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, keys...)
+		pipe.ZRem(ctx, sessionIndexKey(userID), members...)
+		return nil
+	})
+	return err
+}
+
+// parseSession はハッシュの値からセッション情報を復元する
+func parseSession(sid string, m map[string]string) (*entity.Session, error) {
+	uid, err := strconv.ParseInt(m[sessionUserField], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("session %q: invalid user_id: %w", sid, err)
+	}
+	created, err := strconv.ParseInt(m[sessionCreatedField], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("session %q: invalid created: %w", sid, err)
+	}
+	lastSeen, err := strconv.ParseInt(m[sessionLastSeenField], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("session %q: invalid last_seen: %w", sid, err)
+	}
+	return &entity.Session{
+		ID:            sid,
+		UserID:        entity.UserID(uid),
+		UserAgent:     m[sessionUserAgentField],
+		IP:            m[sessionIPField],
+		Created:       time.Unix(0, created).UTC(),
+		LastSeen:      time.Unix(0, lastSeen).UTC(),
+		RefreshFamily: m[sessionFamilyField],
+	}, nil
+}
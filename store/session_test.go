@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/testutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestKVS_Session(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uid, other := entity.UserID(4321), entity.UserID(4322)
+	now := clock.FixedClocker{}.Now()
+	cli := testutil.OpenRedisForTest(t)
+	older := &entity.Session{
+		ID: "TestKVS_Session_older", UserID: uid, UserAgent: "agent-a", IP: "192.0.2.1",
+		Created: now.Add(-time.Hour), LastSeen: now.Add(-time.Hour), RefreshFamily: "TestKVS_Session_family",
+	}
+	newer := &entity.Session{
+		ID: "TestKVS_Session_newer", UserID: uid, UserAgent: "agent-b", IP: "192.0.2.2",
+		Created: now, LastSeen: now,
+	}
+	jtis := []string{"TestKVS_Session_jti1", "TestKVS_Session_jti2", "TestKVS_Session_jti3"}
+	t.Cleanup(func() {
+		cli.Del(ctx,
+			sessionKey(older.ID), sessionKey(newer.ID), refreshFamilyKey(older.RefreshFamily),
+			sessionTokensKey(older.ID), sessionTokensKey(newer.ID),
+			sessionIndexKey(uid), sessionIndexKey(other),
+		)
+		cli.Del(ctx, jtis...)
+	})
+	sut := &KVS{Cli: cli}
+
+	for i, s := range []*entity.Session{older, newer} {
+		if err := cli.Set(ctx, jtis[i], int64(uid), time.Minute).Err(); err != nil {
+			t.Fatal(err)
+		}
+		if err := sut.SaveSession(ctx, s, jtis[i], time.Minute); err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+	}
+	// リフレッシュトークンのローテーション後も作成日時を維持する
+	if err := cli.Set(ctx, jtis[2], int64(uid), time.Minute).Err(); err != nil {
+		t.Fatal(err)
+	}
+	rotated := *older
+	rotated.Created, rotated.LastSeen = now.Add(-time.Minute), now.Add(-time.Minute)
+	if err := sut.SaveSession(ctx, &rotated, jtis[2], time.Hour); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	older.LastSeen = rotated.LastSeen
+	if ttl := cli.TTL(ctx, sessionKey(older.ID)).Val(); ttl <= time.Minute {
+		t.Errorf("want ttl extended to %v, but got %v", time.Hour, ttl)
+	}
+	got, err := sut.ListSessions(ctx, uid)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	// 作成日時の新しい順に並ぶ
+	if d := cmp.Diff(got, entity.Sessions{newer, older}); d != "" {
+		t.Errorf("differs: (-got +want)\n%s", d)
+	}
+
+	// 前回の更新から間隔内のアクセスは記録しない
+	if ok, err := sut.TouchSession(ctx, newer.ID, now.Add(30*time.Second), time.Minute); err != nil || ok {
+		t.Errorf("want not touched, but got %v, %v", ok, err)
+	}
+	later := now.Add(2 * time.Minute)
+	if ok, err := sut.TouchSession(ctx, newer.ID, later, time.Minute); err != nil || !ok {
+		t.Errorf("want touched, but got %v, %v", ok, err)
+	}
+	if s, err := sut.LoadSession(ctx, newer.ID); err != nil || !s.LastSeen.Equal(later) {
+		t.Errorf("want last_seen %v, but got %+v, %v", later, s, err)
+	}
+	// 存在しないセッションは再作成しない
+	if ok, err := sut.TouchSession(ctx, "TestKVS_Session_missing", later, time.Minute); err != nil || ok {
+		t.Errorf("want not touched, but got %v, %v", ok, err)
+	}
+
+	// 他のユーザのセッションは削除できない
+	if err := sut.DeleteSession(ctx, other, older.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want %v, but got %v", ErrNotFound, err)
+	}
+	if err := sut.DeleteSession(ctx, uid, older.ID); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if _, err := sut.LoadSession(ctx, older.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want %v for deleted session, but got %v", ErrNotFound, err)
+	}
+	// ローテーション前後のいずれのアクセストークンも失効し、他のセッションのトークンは維持する
+	for _, jti := range []string{jtis[0], jtis[2]} {
+		if _, err := sut.Load(ctx, jti); !errors.Is(err, ErrNotFound) {
+			t.Errorf("want %v for token %q, but got %v", ErrNotFound, jti, err)
+		}
+	}
+	if _, err := sut.Load(ctx, jtis[1]); err != nil {
+		t.Errorf("want no error for token of other session, but got %v", err)
+	}
+	if err := sut.DeleteSession(ctx, uid, older.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want %v for deleted session, but got %v", ErrNotFound, err)
+	}
+}
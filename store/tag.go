@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	insertTag  = `INSERT INTO tags (user_id, name, created, modified) VALUES (?, ?, ?, ?);`
+	selectTags = `SELECT id, user_id, name, created, modified FROM tags WHERE user_id = ? ORDER BY name;`
+	updateTag  = `UPDATE tags SET name = ?, modified = ? WHERE id = ? AND user_id = ?;`
+	deleteTag  = `DELETE FROM tags WHERE id = ? AND user_id = ?;`
+	// attachTag はタスクとタグが共にユーザの所有である場合のみ関連を登録する
+	attachTag = `INSERT INTO task_tags (task_id, tag_id, created)
+			 SELECT t.id, g.id, ? FROM tasks t INNER JOIN tags g ON g.user_id = t.user_id
+			 WHERE t.id = ? AND g.id = ? AND t.user_id = ? AND t.deleted_at IS NULL;`
+	detachTag = `DELETE tt FROM task_tags tt INNER JOIN tasks t ON t.id = tt.task_id
+			 WHERE tt.task_id = ? AND tt.tag_id = ? AND t.user_id = ?;`
+	selectTaskTags = `SELECT tt.task_id, g.id, g.user_id, g.name, g.created, g.modified
+			 FROM task_tags tt INNER JOIN tags g ON g.id = tt.tag_id
+			 WHERE tt.task_id IN (?) ORDER BY g.name;`
+)
+
+// AddTag は1件のタグを登録し、引数で渡された*entity.Tag.IDに発行されたIDを格納する
+// 同一ユーザに同名のタグが存在する場合はErrAlreadyEntryを返却する
+func (r *Repository) AddTag(ctx context.Context, db Execer, t *entity.Tag) error {
+	t.Created = r.Clocker.Now()
+	t.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, insertTag, t.UserID, t.Name, t.Created, t.Modified)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("cannot create same name tag: %w", ErrAlreadyEntry)
+		}
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	t.ID = entity.TagID(id)
+	return nil
+}
+
+// ListTags はユーザに紐付いたタグをタグ名の昇順ですべて取得する
+func (r *Repository) ListTags(ctx context.Context, db Queryer, uid entity.UserID) (entity.Tags, error) {
+	tags := entity.Tags{}
+	if err := db.SelectContext(ctx, &tags, selectTags, uid); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// UpdateTag はユーザに紐付いた1件のタグ名を更新する
+// 更新対象が存在しない場合はErrNotFound、同名のタグが存在する場合はErrAlreadyEntryを返却する
+func (r *Repository) UpdateTag(ctx context.Context, db Execer, t *entity.Tag) error {
+	t.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, updateTag, t.Name, t.Modified, t.ID, t.UserID)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("cannot rename to same name tag: %w", ErrAlreadyEntry)
+		}
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("tag %d", t.ID))
+}
+
+// DeleteTag はユーザに紐付いた1件のタグを削除する
+// タスクとの関連は外部キー制約により併せて削除される
+func (r *Repository) DeleteTag(ctx context.Context, db Execer, uid entity.UserID, id entity.TagID) error {
+	result, err := db.ExecContext(ctx, deleteTag, id, uid)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("tag %d", id))
+}
+
+// AttachTag はタスクにタグを付与する
+// タスクまたはタグがユーザの所有でない場合はErrNotFound、付与済の場合はErrAlreadyEntryを返却する
+func (r *Repository) AttachTag(ctx context.Context, db Execer, uid entity.UserID, taskID entity.TaskID, tagID entity.TagID) error {
+	result, err := db.ExecContext(ctx, attachTag, r.Clocker.Now(), taskID, tagID, uid)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("tag %d already attached to task %d: %w", tagID, taskID, ErrAlreadyEntry)
+		}
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("task %d or tag %d", taskID, tagID))
+}
+
+// DetachTag はタスクからタグを外す
+// 対象の関連が存在しない場合はErrNotFoundを返却する
+func (r *Repository) DetachTag(ctx context.Context, db Execer, uid entity.UserID, taskID entity.TaskID, tagID entity.TagID) error {
+	result, err := db.ExecContext(ctx, detachTag, taskID, tagID, uid)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("tag %d on task %d", tagID, taskID))
+}
+
+// fillTags は複数タスクに付与されたタグを1回のSQLでまとめて取得し、各タスクに格納する
+// タスクごとにSQLを発行するN+1問題を回避するため、IN句で一括取得する
+func (r *Repository) fillTags(ctx context.Context, db Queryer, tasks entity.Tasks) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]entity.TaskID, 0, len(tasks))
+	byID := make(map[entity.TaskID]*entity.Task, len(tasks))
+	for _, t := range tasks {
+		t.Tags = entity.Tags{}
+		ids = append(ids, t.ID)
+		byID[t.ID] = t
+	}
+	q, args, err := sqlx.In(selectTaskTags, ids)
+	if err != nil {
+		return err
+	}
+	var rows []struct {
+		TaskID entity.TaskID `db:"task_id"`
+		entity.Tag
+	}
+	if err := db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return err
+	}
+	for i := range rows {
+		tag := rows[i].Tag
+		byID[rows[i].TaskID].Tags = append(byID[rows[i].TaskID].Tags, &tag)
+	}
+	return nil
+}
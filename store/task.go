@@ -2,31 +2,78 @@ package store
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 
 	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/jmoiron/sqlx"
 )
 
 const (
-	selectAllTasks = `SELECT id, user_id, title, status, created, modified FROM tasks WHERE user_id = ?;`
-	insertTask     = `INSERT INTO tasks (user_id, title, status, created, modified) VALUES (?, ?, ?, ?, ?);`
+	// taskColumns はentity.Taskにマッピングするカラムの一覧
+	taskColumns = `id, user_id, project_id, parent_id, title, description, status, priority, due_at, recurrence, created, modified, deleted_at`
+
+	insertTask = `INSERT INTO tasks
+			 (user_id, project_id, parent_id, title, description, status, priority, due_at, recurrence, created, modified)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	// insertNextOccurrence は繰り返しタスクの次回分を生成元のタスクIDとともに登録する
+	// 生成元のタスクIDは一意制約により、1件のタスクから次回分が重複して生成されることを防ぐ
+	insertNextOccurrence = `INSERT INTO tasks
+			 (user_id, project_id, parent_id, title, description, status, priority, due_at, recurrence,
+			 recurrence_source_id, created, modified)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	getTask          = `SELECT ` + taskColumns + ` FROM tasks WHERE id = ? AND user_id = ? AND deleted_at IS NULL;`
+	updateTask       = `UPDATE tasks SET title = ?, modified = ? WHERE id = ? AND user_id = ? AND deleted_at IS NULL;`
+	getTaskForUpdate = `SELECT ` + taskColumns + ` FROM tasks WHERE id = ? AND user_id = ? AND deleted_at IS NULL FOR UPDATE;`
+	updateTaskStatus = `UPDATE tasks SET status = ?, modified = ? WHERE id = ? AND user_id = ? AND deleted_at IS NULL;`
+	trashTasks       = `UPDATE tasks SET deleted_at = ?, modified = ? WHERE id IN (?) AND user_id = ? AND deleted_at IS NULL;`
 )
 
 // 以下はservice/interface.goの実装
 
-// ListTasks は*entity.Task型の値をすべて取得し、スライスで返却する
-func (r *Repository) ListTasks(ctx context.Context, db Queryer, id entity.UserID) (entity.Tasks, error) {
+// ListTasks は絞り込み条件に一致する*entity.Task型の値を1ページ分取得し、スライスで返却する
+// 後続のページが存在する場合は、次ページ取得用のカーソルを併せて返却する
+func (r *Repository) ListTasks(
+	ctx context.Context, db Queryer, id entity.UserID, f *entity.TaskFilter,
+) (entity.Tasks, string, error) {
+	limit := f.Limit
+	if limit <= 0 || limit > entity.MaxTaskListLimit {
+		limit = entity.DefaultTaskListLimit
+	}
+	// 期限切れの判定はRepository.Clockerの時刻情報を基準とする
+	q, args, err := buildListTasksQuery(id, f, limit, r.Clocker.Now())
+	if err != nil {
+		return nil, "", err
+	}
 	tasks := entity.Tasks{}
-	if err := db.SelectContext(ctx, &tasks, selectAllTasks, id); err != nil {
-		return nil, err
+	if err := db.SelectContext(ctx, &tasks, q, args...); err != nil {
+		return nil, "", err
 	}
-	return tasks, nil
+	next := ""
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+		next = encodeTaskCursor(taskSortKey(f), tasks[limit-1])
+	}
+	// 階層構造で取得する場合、付随情報は子孫タスクを含めて格納する
+	filled := tasks
+	if f.Tree {
+		if filled, err = r.fillDescendants(ctx, db, id, tasks); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := r.fillDetails(ctx, db, filled); err != nil {
+		return nil, "", err
+	}
+	return tasks, next, nil
 }
 
 // AddTask は1件のタスクを登録し、引数で渡された*entity.Task.IDに発行されたIDを格納する
 func (r *Repository) AddTask(ctx context.Context, db Execer, t *entity.Task) error {
 	t.Created = r.Clocker.Now()
 	t.Modified = r.Clocker.Now()
-	result, err := db.ExecContext(ctx, insertTask, t.UserID, t.Title, t.Status, t.Created, t.Modified)
+	result, err := db.ExecContext(ctx, insertTask,
+		t.UserID, t.ProjectID, t.ParentID, t.Title, t.Description, t.Status, t.Priority, t.DueAt, t.Recurrence, t.Created, t.Modified)
 	if err != nil {
 		return err
 	}
@@ -37,3 +84,127 @@ func (r *Repository) AddTask(ctx context.Context, db Execer, t *entity.Task) err
 	t.ID = entity.TaskID(id)
 	return nil
 }
+
+// AddNextOccurrence は繰り返しタスクsourceIDの次回分として1件のタスクを登録し、
+// 引数で渡された*entity.Task.IDに発行されたIDを格納する
+// sourceIDから次回分を生成済の場合はErrAlreadyEntryを返却する
+func (r *Repository) AddNextOccurrence(ctx context.Context, db Execer, sourceID entity.TaskID, t *entity.Task) error {
+	t.Created = r.Clocker.Now()
+	t.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, insertNextOccurrence,
+		t.UserID, t.ProjectID, t.ParentID, t.Title, t.Description, t.Status, t.Priority, t.DueAt, t.Recurrence,
+		sourceID, t.Created, t.Modified)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("next occurrence of task %d: %w", sourceID, ErrAlreadyEntry)
+		}
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	t.ID = entity.TaskID(id)
+	return nil
+}
+
+// GetTask はユーザに紐付いた1件のタスクを、付随情報と併せて取得する
+// 他ユーザのタスクは存在しないタスクと同様にErrNotFoundを返却する
+func (r *Repository) GetTask(ctx context.Context, db Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+	t := &entity.Task{}
+	if err := db.GetContext(ctx, t, getTask, id, uid); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("task %d: %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+	if err := r.fillDetails(ctx, db, entity.Tasks{t}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// UpdateTask はユーザに紐付いた1件のタスクのタイトルを更新する
+// 更新対象が存在しない場合はErrNotFoundを返却する
+func (r *Repository) UpdateTask(ctx context.Context, db Execer, t *entity.Task) error {
+	t.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, updateTask, t.Title, t.Modified, t.ID, t.UserID)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("task %d", t.ID))
+}
+
+// GetTaskForUpdate はユーザに紐付いた1件のタスクを行ロックを取得した上で取得する
+// トランザクション内で呼び出すことを前提とする
+func (r *Repository) GetTaskForUpdate(ctx context.Context, db Queryer, uid entity.UserID, id entity.TaskID) (*entity.Task, error) {
+	t := &entity.Task{}
+	if err := db.GetContext(ctx, t, getTaskForUpdate, id, uid); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("task %d: %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// UpdateTaskStatus はユーザに紐付いた1件のタスクのステータスを更新する
+// 遷移可否の判定は呼び出し元で実施する
+func (r *Repository) UpdateTaskStatus(ctx context.Context, db Execer, t *entity.Task) error {
+	t.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, updateTaskStatus, t.Status, t.Modified, t.ID, t.UserID)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("task %d", t.ID))
+}
+
+// DeleteTask はユーザに紐付いた1件のタスクを、子孫タスクと併せてゴミ箱へ移動する
+// 子孫タスクには同一の削除日時を設定し、RestoreTaskでまとめて復元できるようにする
+// 削除対象が存在しない場合はErrNotFoundを返却する
+func (r *Repository) DeleteTask(ctx context.Context, db ExecQueryer, uid entity.UserID, id entity.TaskID) error {
+	ids := []entity.TaskID{id}
+	level := ids
+	for depth := 1; depth < entity.MaxTaskDepth && len(level) > 0; depth++ {
+		children, err := r.selectChildTaskIDs(ctx, db, uid, level)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, children...)
+		level = children
+	}
+	now := r.Clocker.Now()
+	q, args, err := sqlx.In(trashTasks, now, now, ids, uid)
+	if err != nil {
+		return err
+	}
+	result, err := db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("task %d", id))
+}
+
+// fillDetails はタスクの付随情報として、付与されたタグ、子タスクの完了状況、依存先の完了状況を格納する
+// いずれも複数タスク分を1回のSQLでまとめて取得する
+func (r *Repository) fillDetails(ctx context.Context, db Queryer, tasks entity.Tasks) error {
+	if err := r.fillTags(ctx, db, tasks); err != nil {
+		return err
+	}
+	if err := r.fillProgress(ctx, db, tasks); err != nil {
+		return err
+	}
+	return r.fillBlocked(ctx, db, tasks)
+}
+
+// assertAffected はSQLの実行結果から影響を受けた行数を確認し、0件の場合はErrNotFoundを返却する
+func assertAffected(result sql.Result, target string) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%s: %w", target, ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// addTaskDependency は2つのタスクが共にユーザの所有である場合のみ依存関係を登録する
+	addTaskDependency = `INSERT INTO task_dependencies (blocker_id, blocked_id, created)
+			 SELECT b.id, t.id, ? FROM tasks b INNER JOIN tasks t ON t.user_id = b.user_id
+			 WHERE b.id = ? AND t.id = ? AND b.user_id = ? AND b.deleted_at IS NULL AND t.deleted_at IS NULL;`
+	deleteTaskDependency = `DELETE d FROM task_dependencies d INNER JOIN tasks t ON t.id = d.blocked_id
+			 WHERE d.blocker_id = ? AND d.blocked_id = ? AND t.user_id = ?;`
+	selectTaskDependencies = `SELECT d.blocker_id, d.blocked_id, d.created
+			 FROM task_dependencies d INNER JOIN tasks t ON t.id = d.blocked_id
+			 WHERE t.user_id = ?;`
+	selectOpenBlockers = `SELECT d.blocked_id, d.blocker_id
+			 FROM task_dependencies d INNER JOIN tasks b ON b.id = d.blocker_id
+			 WHERE d.blocked_id IN (?) AND b.status <> ? AND b.deleted_at IS NULL ORDER BY d.blocker_id;`
+)
+
+// AddTaskDependency はblockerIDのタスクが完了するまでblockedIDのタスクに着手できない依存関係を登録する
+// いずれかのタスクがユーザの所有でない場合はErrNotFound、登録済の場合はErrAlreadyEntryを返却する
+// 循環の検証は呼び出し元で実施する
+func (r *Repository) AddTaskDependency(
+	ctx context.Context, db Execer, uid entity.UserID, blockerID, blockedID entity.TaskID,
+) error {
+	result, err := db.ExecContext(ctx, addTaskDependency, r.Clocker.Now(), blockerID, blockedID, uid)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("task %d already blocked by %d: %w", blockedID, blockerID, ErrAlreadyEntry)
+		}
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("task %d or %d", blockerID, blockedID))
+}
+
+// DeleteTaskDependency はタスク間の依存関係を削除する
+// 対象の依存関係が存在しない場合はErrNotFoundを返却する
+func (r *Repository) DeleteTaskDependency(
+	ctx context.Context, db Execer, uid entity.UserID, blockerID, blockedID entity.TaskID,
+) error {
+	result, err := db.ExecContext(ctx, deleteTaskDependency, blockerID, blockedID, uid)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("dependency %d -> %d", blockerID, blockedID))
+}
+
+// ListTaskDependencies はユーザのタスク間の依存関係をすべて取得する
+func (r *Repository) ListTaskDependencies(ctx context.Context, db Queryer, uid entity.UserID) (entity.TaskDependencies, error) {
+	ds := entity.TaskDependencies{}
+	if err := db.SelectContext(ctx, &ds, selectTaskDependencies, uid); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// ListOpenBlockers はタスクが依存している未完了のタスクのIDを取得する
+func (r *Repository) ListOpenBlockers(ctx context.Context, db Queryer, id entity.TaskID) ([]entity.TaskID, error) {
+	blockers, err := r.selectOpenBlockers(ctx, db, []entity.TaskID{id})
+	if err != nil {
+		return nil, err
+	}
+	return blockers[id], nil
+}
+
+// fillBlocked は複数タスクが未完了のタスクに依存しているかを1回のSQLでまとめて判定し、各タスクに格納する
+func (r *Repository) fillBlocked(ctx context.Context, db Queryer, tasks entity.Tasks) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]entity.TaskID, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	blockers, err := r.selectOpenBlockers(ctx, db, ids)
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		t.Blocked = len(blockers[t.ID]) > 0
+	}
+	return nil
+}
+
+// selectOpenBlockers はタスクごとに依存している未完了のタスクのIDを取得する
+func (r *Repository) selectOpenBlockers(
+	ctx context.Context, db Queryer, ids []entity.TaskID,
+) (map[entity.TaskID][]entity.TaskID, error) {
+	q, args, err := sqlx.In(selectOpenBlockers, ids, entity.TaskStatusDone)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		BlockedID entity.TaskID `db:"blocked_id"`
+		BlockerID entity.TaskID `db:"blocker_id"`
+	}
+	if err := db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return nil, err
+	}
+	blockers := make(map[entity.TaskID][]entity.TaskID, len(rows))
+	for _, row := range rows {
+		blockers[row.BlockedID] = append(blockers[row.BlockedID], row.BlockerID)
+	}
+	return blockers, nil
+}
@@ -0,0 +1,174 @@
+package store
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const (
+	selectTasks = `SELECT ` + taskColumns + ` FROM tasks`
+)
+
+// taskSortColumns は並び順ごとのソート対象カラムと昇降順を定義する
+// ORDER BY句にはユーザ入力を直接埋め込まず、当該定義のカラム名のみを使用する
+var taskSortColumns = map[entity.TaskSortKey]struct {
+	column string
+	desc   bool
+}{
+	entity.TaskSortCreatedAsc:   {column: "created"},
+	entity.TaskSortCreatedDesc:  {column: "created", desc: true},
+	entity.TaskSortModifiedAsc:  {column: "modified"},
+	entity.TaskSortModifiedDesc: {column: "modified", desc: true},
+}
+
+// taskCursor はキーセットページングにおける前ページ末尾のタスクの位置を表す
+type taskCursor struct {
+	Sort entity.TaskSortKey `json:"s"`
+	At   time.Time          `json:"t"`
+	ID   entity.TaskID      `json:"id"`
+}
+
+// encodeTaskCursor は並び順と末尾のタスクから不透明なカーソル文字列を生成する
+func encodeTaskCursor(sort entity.TaskSortKey, t *entity.Task) string {
+	c := taskCursor{Sort: sort, At: t.Created, ID: t.ID}
+	if taskSortColumns[sort].column == "modified" {
+		c.At = t.Modified
+	}
+	b, _ := json.Marshal(c) // 構造体の値のみのためエラーは発生しない
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeTaskCursor はカーソル文字列を解析する
+// 指定された並び順と異なる並び順で発行されたカーソルはErrInvalidCursorとする
+func decodeTaskCursor(s string, sort entity.TaskSortKey) (*taskCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	c := &taskCursor{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if c.Sort != sort {
+		return nil, fmt.Errorf("%w: issued for sort %q", ErrInvalidCursor, c.Sort)
+	}
+	return c, nil
+}
+
+// taskSortKey は絞り込み条件の並び順を返却する
+// 並び順の指定がない場合は作成日時の昇順とする
+func taskSortKey(f *entity.TaskFilter) entity.TaskSortKey {
+	if f.Sort == "" {
+		return entity.TaskSortCreatedAsc
+	}
+	return f.Sort
+}
+
+// tagCondition はタグ名による絞り込み条件を組み立てる
+// すべて一致の場合は、指定したタグ名のうち付与されている種類数が指定数と一致するタスクに絞り込む
+func tagCondition(uid entity.UserID, f *entity.TaskFilter) (string, []any) {
+	names := make([]string, 0, len(f.Tags))
+	seen := make(map[string]bool, len(f.Tags))
+	for _, n := range f.Tags {
+		if !seen[n] {
+			seen[n] = true
+			names = append(names, n)
+		}
+	}
+	sub := `SELECT tt.task_id FROM task_tags tt INNER JOIN tags g ON g.id = tt.tag_id WHERE g.user_id = ? AND g.name IN (?)`
+	if !f.TagMatchAll {
+		return "id IN (" + sub + ")", []any{uid, names}
+	}
+	return "id IN (" + sub + " GROUP BY tt.task_id HAVING COUNT(DISTINCT g.id) = ?)", []any{uid, names, len(names)}
+}
+
+// escapeLike はLIKE句のワイルドカード文字をエスケープする
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// buildListTasksQuery はタスク一覧取得のSQLとプレースホルダの引数を組み立てる
+// 取得件数は次ページ有無の判定のためlimit+1件とし、期限切れの判定はnowを基準とする
+func buildListTasksQuery(uid entity.UserID, f *entity.TaskFilter, limit int, now time.Time) (string, []any, error) {
+	sort := taskSortKey(f)
+	col, ok := taskSortColumns[sort]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown sort key %q", sort)
+	}
+
+	// ゴミ箱のタスクは一覧に含めない
+	where := []string{"user_id = ?", "deleted_at IS NULL"}
+	args := []any{uid}
+	if len(f.Statuses) > 0 {
+		where = append(where, "status IN (?)")
+		args = append(args, f.Statuses)
+	}
+	for _, c := range []struct {
+		cond string
+		at   time.Time
+	}{
+		{"created >= ?", f.CreatedFrom},
+		{"created < ?", f.CreatedTo},
+		{"modified >= ?", f.ModifiedFrom},
+		{"modified < ?", f.ModifiedTo},
+		{"due_at < ?", f.DueBefore},
+	} {
+		if !c.at.IsZero() {
+			where = append(where, c.cond)
+			args = append(args, c.at)
+		}
+	}
+	if f.Overdue {
+		where = append(where, "due_at < ? AND status <> ?")
+		args = append(args, now, entity.TaskStatusDone)
+	}
+	switch {
+	case f.ProjectID != nil:
+		where = append(where, "project_id = ?")
+		args = append(args, *f.ProjectID)
+	case !f.IncludeArchived:
+		where = append(where, "(project_id IS NULL OR project_id NOT IN "+
+			"(SELECT p.id FROM projects p WHERE p.user_id = ? AND p.archived_at IS NOT NULL))")
+		args = append(args, uid)
+	}
+	if f.Tree {
+		where = append(where, "parent_id IS NULL")
+	}
+	if len(f.Tags) > 0 {
+		cond, tagArgs := tagCondition(uid, f)
+		where = append(where, cond)
+		args = append(args, tagArgs...)
+	}
+	if f.TitlePrefix != "" {
+		where = append(where, "title LIKE ?")
+		args = append(args, escapeLike(f.TitlePrefix)+"%")
+	}
+
+	op, dir := ">", "ASC"
+	if col.desc {
+		op, dir = "<", "DESC"
+	}
+	if f.Cursor != "" {
+		c, err := decodeTaskCursor(f.Cursor, sort)
+		if err != nil {
+			return "", nil, err
+		}
+		where = append(where, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", col.column, op))
+		args = append(args, c.At, c.At, c.ID)
+	}
+
+	q := fmt.Sprintf("%s WHERE %s ORDER BY %s %s, id %s LIMIT ?;",
+		selectTasks, strings.Join(where, " AND "), col.column, dir, dir)
+	args = append(args, limit+1)
+	// IN句のプレースホルダをスライスの要素数に展開する
+	return sqlx.In(q, args...)
+}
@@ -0,0 +1,135 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_buildListTasksQuery(t *testing.T) {
+	t.Parallel()
+
+	c := clock.FixedClocker{}
+	last := &entity.Task{ID: 10, Created: c.Now(), Modified: c.Now().Add(time.Hour)}
+	projectID := entity.ProjectID(7)
+
+	type want struct {
+		query string
+		args  []any
+	}
+	tests := map[string]struct {
+		filter *entity.TaskFilter
+		want   want
+	}{
+		"default": {
+			filter: &entity.TaskFilter{},
+			want: want{
+				query: `SELECT id, user_id, project_id, parent_id, title, description, status, priority, due_at, recurrence, created, modified, deleted_at FROM tasks ` +
+					`WHERE user_id = ? AND deleted_at IS NULL ` +
+					`AND (project_id IS NULL OR project_id NOT IN (SELECT p.id FROM projects p WHERE p.user_id = ? AND p.archived_at IS NOT NULL)) ` +
+					`ORDER BY created ASC, id ASC LIMIT ?;`,
+				args: []any{entity.UserID(1), entity.UserID(1), 51},
+			},
+		},
+		"filtered": {
+			filter: &entity.TaskFilter{
+				Statuses:    []entity.TaskStatus{entity.TaskStatusTodo, entity.TaskStatusDoing},
+				CreatedFrom: c.Now(),
+				ModifiedTo:  c.Now(),
+				TitlePrefix: "50%_off",
+				DueBefore:   c.Now().Add(time.Hour),
+				Overdue:     true,
+				ProjectID:   &projectID,
+			},
+			want: want{
+				query: `SELECT id, user_id, project_id, parent_id, title, description, status, priority, due_at, recurrence, created, modified, deleted_at FROM tasks ` +
+					`WHERE user_id = ? AND deleted_at IS NULL AND status IN (?, ?) AND created >= ? AND modified < ? AND due_at < ? ` +
+					`AND due_at < ? AND status <> ? AND project_id = ? AND title LIKE ? ` +
+					`ORDER BY created ASC, id ASC LIMIT ?;`,
+				args: []any{
+					entity.UserID(1), entity.TaskStatusTodo, entity.TaskStatusDoing,
+					c.Now(), c.Now(), c.Now().Add(time.Hour),
+					c.Now(), entity.TaskStatusDone, projectID, `50\%\_off%`, 51,
+				},
+			},
+		},
+		"tagAny": {
+			filter: &entity.TaskFilter{Tags: []string{"backend", "urgent-customer"}, IncludeArchived: true},
+			want: want{
+				query: `SELECT id, user_id, project_id, parent_id, title, description, status, priority, due_at, recurrence, created, modified, deleted_at FROM tasks ` +
+					`WHERE user_id = ? AND deleted_at IS NULL AND id IN (SELECT tt.task_id FROM task_tags tt INNER JOIN tags g ON g.id = tt.tag_id ` +
+					`WHERE g.user_id = ? AND g.name IN (?, ?)) ORDER BY created ASC, id ASC LIMIT ?;`,
+				args: []any{entity.UserID(1), entity.UserID(1), "backend", "urgent-customer", 51},
+			},
+		},
+		"tagAll": {
+			// 重複したタグ名は1件として数える
+			filter: &entity.TaskFilter{Tags: []string{"backend", "urgent-customer", "backend"}, TagMatchAll: true, IncludeArchived: true},
+			want: want{
+				query: `SELECT id, user_id, project_id, parent_id, title, description, status, priority, due_at, recurrence, created, modified, deleted_at FROM tasks ` +
+					`WHERE user_id = ? AND deleted_at IS NULL AND id IN (SELECT tt.task_id FROM task_tags tt INNER JOIN tags g ON g.id = tt.tag_id ` +
+					`WHERE g.user_id = ? AND g.name IN (?, ?) GROUP BY tt.task_id HAVING COUNT(DISTINCT g.id) = ?) ` +
+					`ORDER BY created ASC, id ASC LIMIT ?;`,
+				args: []any{entity.UserID(1), entity.UserID(1), "backend", "urgent-customer", 2, 51},
+			},
+		},
+		"cursorDesc": {
+			filter: &entity.TaskFilter{
+				Sort:   entity.TaskSortModifiedDesc,
+				Cursor: encodeTaskCursor(entity.TaskSortModifiedDesc, last),
+				// アーカイブ済プロジェクトのタスクも含める
+				IncludeArchived: true,
+			},
+			want: want{
+				query: `SELECT id, user_id, project_id, parent_id, title, description, status, priority, due_at, recurrence, created, modified, deleted_at FROM tasks ` +
+					`WHERE user_id = ? AND deleted_at IS NULL AND (modified < ? OR (modified = ? AND id < ?)) ` +
+					`ORDER BY modified DESC, id DESC LIMIT ?;`,
+				args: []any{entity.UserID(1), last.Modified, last.Modified, last.ID, 51},
+			},
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			gotQuery, gotArgs, err := buildListTasksQuery(1, tt.filter, entity.DefaultTaskListLimit, c.Now())
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if gotQuery != tt.want.query {
+				t.Errorf("want %q, but got %q", tt.want.query, gotQuery)
+			}
+			if d := cmp.Diff(gotArgs, tt.want.args); len(d) != 0 {
+				t.Errorf("differs: (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func Test_buildListTasksQuery_invalidCursor(t *testing.T) {
+	t.Parallel()
+
+	last := &entity.Task{ID: 10, Created: clock.FixedClocker{}.Now()}
+	tests := map[string]string{
+		"broken": "not-a-cursor",
+		// 異なる並び順で発行されたカーソルは使用できない
+		"sortMismatch": encodeTaskCursor(entity.TaskSortCreatedAsc, last),
+	}
+	for n, cursor := range tests {
+		cursor := cursor
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			f := &entity.TaskFilter{Sort: entity.TaskSortCreatedDesc, Cursor: cursor}
+			_, _, err := buildListTasksQuery(1, f, entity.DefaultTaskListLimit, clock.FixedClocker{}.Now())
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("want %v, but got %v", ErrInvalidCursor, err)
+			}
+		})
+	}
+}
@@ -2,13 +2,17 @@ package store
 
 import (
 	"context"
+	"database/sql/driver"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/ac0mz/go_todo_app/clock"
 	"github.com/ac0mz/go_todo_app/entity"
 	"github.com/ac0mz/go_todo_app/testutil"
 	"github.com/ac0mz/go_todo_app/testutil/fixture"
+	"github.com/go-sql-driver/mysql"
 	"github.com/google/go-cmp/cmp"
 	"github.com/jmoiron/sqlx"
 )
@@ -30,8 +34,8 @@ func TestRepository_ListTasks(t *testing.T) {
 	wantUserID, wants := prepareTask(ctx, t, tx)
 
 	// 実行
-	sut := &Repository{}
-	gots, err := sut.ListTasks(ctx, tx, wantUserID)
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	gots, _, err := sut.ListTasks(ctx, tx, wantUserID, &entity.TaskFilter{})
 	if err != nil {
 		t.Fatalf("unexecuted error: %v", err)
 	}
@@ -42,6 +46,70 @@ func TestRepository_ListTasks(t *testing.T) {
 	}
 }
 
+// TestRepository_ListTasks_tags はタスク件数に関わらず、タグなどの付随情報の取得がそれぞれ1回のSQLで完了することを検証する
+func TestRepository_ListTasks_tags(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// モック設定
+	c := clock.FixedClocker{}
+	cols := []string{"id", "user_id", "project_id", "parent_id", "title", "description", "status", "priority", "due_at", "recurrence", "created", "modified", "deleted_at"}
+	mock.ExpectQuery(`SELECT .+ FROM tasks WHERE user_id = \? AND deleted_at IS NULL AND \(project_id IS NULL .+\) ORDER BY created ASC, id ASC LIMIT \?`).
+		WithArgs(entity.UserID(3), entity.UserID(3), entity.DefaultTaskListLimit+1).
+		WillReturnRows(sqlmock.NewRows(cols).
+			AddRow(10, 3, nil, nil, "task 10", "", "todo", "normal", nil, nil, c.Now(), c.Now(), nil).
+			AddRow(11, 3, nil, nil, "task 11", "", "todo", "normal", nil, nil, c.Now(), c.Now(), nil).
+			AddRow(12, 3, nil, nil, "task 12", "", "todo", "normal", nil, nil, c.Now(), c.Now(), nil))
+	mock.ExpectQuery(`SELECT tt.task_id, .+ FROM task_tags tt .+ WHERE tt.task_id IN \(\?, \?, \?\)`).
+		WithArgs(entity.TaskID(10), entity.TaskID(11), entity.TaskID(12)).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "id", "user_id", "name", "created", "modified"}).
+			AddRow(10, 1, 3, "backend", c.Now(), c.Now()).
+			AddRow(12, 1, 3, "backend", c.Now(), c.Now()).
+			AddRow(12, 2, 3, "urgent-customer", c.Now(), c.Now()))
+	mock.ExpectQuery(`SELECT parent_id, COUNT\(\*\) .+ FROM tasks WHERE parent_id IN \(\?, \?, \?\) AND deleted_at IS NULL GROUP BY parent_id`).
+		WithArgs(entity.TaskStatusDone, entity.TaskID(10), entity.TaskID(11), entity.TaskID(12)).
+		WillReturnRows(sqlmock.NewRows([]string{"parent_id", "total", "done"}))
+	mock.ExpectQuery(`SELECT d.blocked_id, d.blocker_id FROM task_dependencies d .+ WHERE d.blocked_id IN \(\?, \?, \?\)`).
+		WithArgs(entity.TaskID(10), entity.TaskID(11), entity.TaskID(12), entity.TaskStatusDone).
+		WillReturnRows(sqlmock.NewRows([]string{"blocked_id", "blocker_id"}).AddRow(11, 10))
+
+	xdb := sqlx.NewDb(db, "mysql")
+	sut := &Repository{Clocker: c}
+	gots, _, err := sut.ListTasks(ctx, xdb, 3, &entity.TaskFilter{})
+	if err != nil {
+		t.Fatalf("unexecuted error: %v", err)
+	}
+
+	// 検証
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	wantTags := map[entity.TaskID][]string{
+		10: {"backend"},
+		11: {},
+		12: {"backend", "urgent-customer"},
+	}
+	for _, got := range gots {
+		names := []string{}
+		for _, tag := range got.Tags {
+			names = append(names, tag.Name)
+		}
+		if d := cmp.Diff(names, wantTags[got.ID]); len(d) != 0 {
+			t.Errorf("task %d differs: (-got +want)\n%s", got.ID, d)
+		}
+		// 未完了のタスク10に依存するタスク11のみ着手できない
+		if want := got.ID == 11; got.Blocked != want {
+			t.Errorf("task %d: want blocked %v, but got %v", got.ID, want, got.Blocked)
+		}
+	}
+}
+
 // prepareUser はDBテストデータの仕込みを実行する
 func prepareUser(ctx context.Context, t *testing.T, db Execer) entity.UserID {
 	t.Helper()
@@ -70,31 +138,35 @@ func prepareTask(ctx context.Context, t *testing.T, db Execer) (entity.UserID, e
 	userID := prepareUser(ctx, t, db)
 	otherUserID := prepareUser(ctx, t, db)
 	c := clock.FixedClocker{}
+	due := c.Now().Add(24 * time.Hour)
 	wants := entity.Tasks{
 		{
-			UserID: userID, Title: "want task 1", Status: "todo",
-			Created: c.Now(), Modified: c.Now(),
+			UserID: userID, Title: "want task 1", Description: "# want", Status: "todo",
+			Priority: "high", DueAt: &due, Created: c.Now(), Modified: c.Now(), Tags: entity.Tags{},
 		}, {
 			UserID: userID, Title: "want task 2", Status: "done",
-			Created: c.Now(), Modified: c.Now(),
+			Priority: "normal", Created: c.Now(), Modified: c.Now(), Tags: entity.Tags{},
 		},
 	}
 	tasks := entity.Tasks{
 		wants[0],
 		{
 			UserID: otherUserID, Title: "not want task", Status: "todo",
-			Created: c.Now(), Modified: c.Now(),
+			Priority: "normal", Created: c.Now(), Modified: c.Now(),
 		},
 		wants[1],
 	}
 	// DB登録
 	// ※INSERT文末尾のセミコロンを忘れるだけでpanicが発生するため要注意
 	result, err := db.ExecContext(ctx,
-		`INSERT INTO tasks (user_id, title, status, created, modified)
-				VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?), (?, ?, ?, ?, ?);`,
-		tasks[0].UserID, tasks[0].Title, tasks[0].Status, tasks[0].Created, tasks[0].Modified,
-		tasks[1].UserID, tasks[1].Title, tasks[1].Status, tasks[1].Created, tasks[1].Modified,
-		tasks[2].UserID, tasks[2].Title, tasks[2].Status, tasks[2].Created, tasks[2].Modified,
+		`INSERT INTO tasks (user_id, title, description, status, priority, due_at, created, modified)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?, ?, ?);`,
+		tasks[0].UserID, tasks[0].Title, tasks[0].Description, tasks[0].Status, tasks[0].Priority,
+		tasks[0].DueAt, tasks[0].Created, tasks[0].Modified,
+		tasks[1].UserID, tasks[1].Title, tasks[1].Description, tasks[1].Status, tasks[1].Priority,
+		tasks[1].DueAt, tasks[1].Created, tasks[1].Modified,
+		tasks[2].UserID, tasks[2].Title, tasks[2].Description, tasks[2].Status, tasks[2].Priority,
+		tasks[2].DueAt, tasks[2].Created, tasks[2].Modified,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -119,7 +191,8 @@ func TestRepository_AddTask(t *testing.T) {
 	c := clock.FixedClocker{}
 	var wantID int64 = 20
 	okTask := &entity.Task{
-		UserID: 3, Title: "ok task", Status: "todo", Created: c.Now(), Modified: c.Now(),
+		UserID: 3, Title: "ok task", Description: "ok description", Status: "todo", Priority: "normal",
+		Created: c.Now(), Modified: c.Now(),
 	}
 
 	db, mock, err := sqlmock.New()
@@ -131,10 +204,14 @@ func TestRepository_AddTask(t *testing.T) {
 	// モック設定
 	mock.ExpectExec(
 		// DATA-DOG/go-sqlmock の仕様上、エスケープが必要
-		`INSERT INTO tasks \(user_id, title, status, created, modified\)
-				VALUES \(\?, \?, \?, \?, \?\)`,
+		`INSERT INTO tasks
+			 \(user_id, project_id, parent_id, title, description, status, priority, due_at, recurrence, created, modified\)
+			 VALUES \(\?, \?, \?, \?, \?, \?, \?, \?, \?, \?, \?\)`,
 	).
-		WithArgs(okTask.UserID, okTask.Title, okTask.Status, okTask.Created, okTask.Modified).
+		WithArgs(
+			okTask.UserID, okTask.ProjectID, okTask.ParentID, okTask.Title, okTask.Description, okTask.Status, okTask.Priority,
+			okTask.DueAt, okTask.Recurrence, okTask.Created, okTask.Modified,
+		).
 		WillReturnResult(sqlmock.NewResult(wantID, 1))
 
 	xdb := sqlx.NewDb(db, "mysql")
@@ -149,3 +226,108 @@ func TestRepository_AddTask(t *testing.T) {
 		t.Errorf("differs: (-got +want)\n%d", okTask.ID)
 	}
 }
+
+func TestRepository_AddNextOccurrence(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c := clock.FixedClocker{}
+	next := &entity.Task{UserID: 3, Title: "recurring", Status: entity.TaskStatusTodo, Priority: entity.TaskPriorityNormal}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// 1回目は登録し、同一の生成元による2回目は一意制約違反とする
+	query := `INSERT INTO tasks
+			 \(user_id, project_id, parent_id, title, description, status, priority, due_at, recurrence,
+			 recurrence_source_id, created, modified\)`
+	mock.ExpectExec(query).
+		WithArgs(next.UserID, next.ProjectID, next.ParentID, next.Title, next.Description, next.Status, next.Priority,
+			next.DueAt, next.Recurrence, entity.TaskID(1), c.Now(), c.Now()).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec(query).
+		WillReturnError(&mysql.MySQLError{Number: ErrCodeMySQLDuplicateEntry})
+
+	xdb := sqlx.NewDb(db, "mysql")
+	r := &Repository{Clocker: c}
+	if err := r.AddNextOccurrence(ctx, xdb, 1, next); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if next.ID != 2 {
+		t.Errorf("want id 2, but got %d", next.ID)
+	}
+	if err := r.AddNextOccurrence(ctx, xdb, 1, &entity.Task{}); !errors.Is(err, ErrAlreadyEntry) {
+		t.Errorf("want %v, but got %v", ErrAlreadyEntry, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRepository_DeleteTask(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		children [][]int64      // 階層ごとに取得される子孫タスクのID
+		trashed  []driver.Value // ゴミ箱へ移動するタスクのID
+		affected int64
+		wantErr  error
+	}{
+		"ok": {
+			// 子孫タスクも併せてゴミ箱へ移動する
+			children: [][]int64{{11, 12}, {13}, {}},
+			trashed:  []driver.Value{entity.TaskID(10), entity.TaskID(11), entity.TaskID(12), entity.TaskID(13)},
+			affected: 4,
+		},
+		"notFound": {
+			children: [][]int64{{}},
+			trashed:  []driver.Value{entity.TaskID(10)},
+			affected: 0,
+			wantErr:  ErrNotFound,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+
+			// モック設定
+			for _, ids := range tt.children {
+				rows := sqlmock.NewRows([]string{"id"})
+				for _, id := range ids {
+					rows.AddRow(id)
+				}
+				mock.ExpectQuery(`SELECT id FROM tasks WHERE user_id = \? AND parent_id IN`).WillReturnRows(rows)
+			}
+			// 他ユーザのタスクは条件に一致しないため、影響行数0件となる
+			c := clock.FixedClocker{}
+			args := append([]driver.Value{c.Now(), c.Now()}, tt.trashed...)
+			args = append(args, entity.UserID(3))
+			mock.ExpectExec(`UPDATE tasks SET deleted_at = \?, modified = \? WHERE id IN \(.+\) AND user_id = \? AND deleted_at IS NULL`).
+				WithArgs(args...).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			xdb := sqlx.NewDb(db, "mysql")
+			r := &Repository{Clocker: c}
+			err = r.DeleteTask(ctx, xdb, 3, 10)
+
+			// 検証
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %v, but got %v", tt.wantErr, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
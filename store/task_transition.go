@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+const (
+	insertTaskTransition = `INSERT INTO task_transitions (task_id, user_id, from_status, to_status, created)
+			 VALUES (?, ?, ?, ?, ?);`
+)
+
+// AddTaskTransition はタスクのステータス遷移の履歴を1件登録する
+// 遷移日時はRepository.Clockerの時刻情報を元に設定する
+func (r *Repository) AddTaskTransition(ctx context.Context, db Execer, tt *entity.TaskTransition) error {
+	tt.Created = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, insertTaskTransition,
+		tt.TaskID, tt.UserID, tt.From, tt.To, tt.Created)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	tt.ID = entity.TaskTransitionID(id)
+	return nil
+}
@@ -0,0 +1,182 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrTaskCycle         = errors.New("task hierarchy cycle")
+	ErrTaskDepthExceeded = errors.New("task hierarchy too deep")
+)
+
+const (
+	selectChildTasks = `SELECT ` + taskColumns + ` FROM tasks
+			 WHERE user_id = ? AND parent_id IN (?) AND deleted_at IS NULL ORDER BY created ASC, id ASC;`
+	selectTaskParent   = `SELECT parent_id FROM tasks WHERE id = ? AND user_id = ? AND deleted_at IS NULL;`
+	selectChildTaskIDs = `SELECT id FROM tasks WHERE user_id = ? AND parent_id IN (?) AND deleted_at IS NULL;`
+	selectTaskProgress = `SELECT parent_id, COUNT(*) AS total, COALESCE(SUM(status = ?), 0) AS done
+			 FROM tasks WHERE parent_id IN (?) AND deleted_at IS NULL GROUP BY parent_id;`
+	updateTaskParent = `UPDATE tasks SET parent_id = ?, modified = ? WHERE id = ? AND user_id = ? AND deleted_at IS NULL;`
+)
+
+// ListChildTasks はユーザに紐付いたタスクの直下の子タスクを作成日時の昇順で取得する
+func (r *Repository) ListChildTasks(ctx context.Context, db Queryer, uid entity.UserID, id entity.TaskID) (entity.Tasks, error) {
+	tasks, err := r.selectChildTasks(ctx, db, uid, []entity.TaskID{id})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.fillDetails(ctx, db, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetTaskProgress はユーザに紐付いたタスクの子タスクの完了状況を取得する
+func (r *Repository) GetTaskProgress(ctx context.Context, db Queryer, uid entity.UserID, id entity.TaskID) (entity.TaskProgress, error) {
+	t := &entity.Task{ID: id}
+	if err := r.fillProgress(ctx, db, entity.Tasks{t}); err != nil {
+		return entity.TaskProgress{}, err
+	}
+	if t.Progress == nil {
+		return entity.TaskProgress{}, nil
+	}
+	return *t.Progress, nil
+}
+
+// ValidateTaskParent はタスクidの親をparentIDに設定可能であるかを検証する
+// 親子関係が循環する場合はErrTaskCycle、階層数がentity.MaxTaskDepthを超える場合はErrTaskDepthExceededを返却する
+// 新規に登録するタスクを検証する場合、idには0を指定する
+func (r *Repository) ValidateTaskParent(
+	ctx context.Context, db Queryer, uid entity.UserID, id, parentID entity.TaskID,
+) error {
+	// 親タスクから祖先を辿り、自身が含まれる場合は循環とみなす
+	depth := 0
+	for cur := &parentID; cur != nil; depth++ {
+		if *cur == id {
+			return fmt.Errorf("task %d cannot be a descendant of itself: %w", id, ErrTaskCycle)
+		}
+		if depth >= entity.MaxTaskDepth {
+			return fmt.Errorf("parent task %d: %w", parentID, ErrTaskDepthExceeded)
+		}
+		var next *entity.TaskID
+		if err := db.GetContext(ctx, &next, selectTaskParent, *cur, uid); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("task %d: %w", *cur, ErrNotFound)
+			}
+			return err
+		}
+		cur = next
+	}
+
+	// 移動するタスク自身の子孫の階層数を加算する
+	height := 1
+	if id != 0 {
+		level := []entity.TaskID{id}
+		for depth+height <= entity.MaxTaskDepth {
+			ids, err := r.selectChildTaskIDs(ctx, db, uid, level)
+			if err != nil {
+				return err
+			}
+			if len(ids) == 0 {
+				break
+			}
+			level = ids
+			height++
+		}
+	}
+	if depth+height > entity.MaxTaskDepth {
+		return fmt.Errorf("max depth is %d: %w", entity.MaxTaskDepth, ErrTaskDepthExceeded)
+	}
+	return nil
+}
+
+// UpdateTaskParent はユーザに紐付いた1件のタスクの親タスクを更新する
+// 設定可否の検証はValidateTaskParentで事前に実施する
+func (r *Repository) UpdateTaskParent(ctx context.Context, db Execer, t *entity.Task) error {
+	t.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, updateTaskParent, t.ParentID, t.Modified, t.ID, t.UserID)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("task %d", t.ID))
+}
+
+// fillDescendants は最上位のタスクの子孫タスクを階層ごとにまとめて取得してChildrenに格納し、取得したすべてのタスクを返却する
+// 子孫タスクには一覧取得時の絞り込み条件を適用しない
+func (r *Repository) fillDescendants(ctx context.Context, db Queryer, uid entity.UserID, roots entity.Tasks) (entity.Tasks, error) {
+	all := append(entity.Tasks{}, roots...)
+	level := roots
+	for depth := 1; depth < entity.MaxTaskDepth && len(level) > 0; depth++ {
+		ids := make([]entity.TaskID, 0, len(level))
+		for _, t := range level {
+			ids = append(ids, t.ID)
+		}
+		children, err := r.selectChildTasks(ctx, db, uid, ids)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, children...)
+		level = children
+	}
+	entity.NestTasks(all)
+	return all, nil
+}
+
+// fillProgress は複数タスクの子タスクの完了状況を1回のSQLでまとめて取得し、各タスクに格納する
+func (r *Repository) fillProgress(ctx context.Context, db Queryer, tasks entity.Tasks) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]entity.TaskID, 0, len(tasks))
+	byID := make(map[entity.TaskID]*entity.Task, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+		byID[t.ID] = t
+	}
+	q, args, err := sqlx.In(selectTaskProgress, entity.TaskStatusDone, ids)
+	if err != nil {
+		return err
+	}
+	var rows []struct {
+		ParentID entity.TaskID `db:"parent_id"`
+		entity.TaskProgress
+	}
+	if err := db.SelectContext(ctx, &rows, q, args...); err != nil {
+		return err
+	}
+	for i := range rows {
+		p := rows[i].TaskProgress
+		byID[rows[i].ParentID].Progress = &p
+	}
+	return nil
+}
+
+func (r *Repository) selectChildTasks(ctx context.Context, db Queryer, uid entity.UserID, ids []entity.TaskID) (entity.Tasks, error) {
+	q, args, err := sqlx.In(selectChildTasks, uid, ids)
+	if err != nil {
+		return nil, err
+	}
+	tasks := entity.Tasks{}
+	if err := db.SelectContext(ctx, &tasks, q, args...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *Repository) selectChildTaskIDs(ctx context.Context, db Queryer, uid entity.UserID, ids []entity.TaskID) ([]entity.TaskID, error) {
+	q, args, err := sqlx.In(selectChildTaskIDs, uid, ids)
+	if err != nil {
+		return nil, err
+	}
+	var children []entity.TaskID
+	if err := db.SelectContext(ctx, &children, q, args...); err != nil {
+		return nil, err
+	}
+	return children, nil
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestRepository_ValidateTaskParent(t *testing.T) {
+	t.Parallel()
+
+	// parents はタスクIDごとの親タスクIDを表す(0は親タスクなし)
+	type setup struct {
+		parents  []int64
+		children [][]int64
+	}
+	tests := map[string]struct {
+		id, parentID entity.TaskID
+		setup        setup
+		wantErr      error
+	}{
+		"ok": {
+			// 2 -> 1 の配下に子タスクを持たない5を移動する
+			id: 5, parentID: 2,
+			setup:   setup{parents: []int64{1, 0}, children: [][]int64{{}}},
+			wantErr: nil,
+		},
+		"newTask": {
+			id: 0, parentID: 2,
+			setup: setup{parents: []int64{0}},
+		},
+		"cycle": {
+			// 1の親を、1の子である3に設定しようとする
+			id: 1, parentID: 3,
+			setup:   setup{parents: []int64{1}},
+			wantErr: ErrTaskCycle,
+		},
+		"depthExceeded": {
+			// 4階層目の配下に、子タスクを持つ5を移動すると6階層となる
+			id: 5, parentID: 4,
+			setup:   setup{parents: []int64{3, 2, 1, 0}, children: [][]int64{{6}}},
+			wantErr: ErrTaskDepthExceeded,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+
+			// モック設定
+			cur := int64(tt.parentID)
+			for _, p := range tt.setup.parents {
+				rows := sqlmock.NewRows([]string{"parent_id"})
+				if p == 0 {
+					rows.AddRow(nil)
+				} else {
+					rows.AddRow(p)
+				}
+				mock.ExpectQuery(`SELECT parent_id FROM tasks WHERE id = \? AND user_id = \?`).
+					WithArgs(entity.TaskID(cur), entity.UserID(3)).
+					WillReturnRows(rows)
+				cur = p
+			}
+			for _, ids := range tt.setup.children {
+				rows := sqlmock.NewRows([]string{"id"})
+				for _, id := range ids {
+					rows.AddRow(id)
+				}
+				mock.ExpectQuery(`SELECT id FROM tasks WHERE user_id = \? AND parent_id IN`).WillReturnRows(rows)
+			}
+
+			xdb := sqlx.NewDb(db, "mysql")
+			r := &Repository{Clocker: clock.FixedClocker{}}
+			err = r.ValidateTaskParent(ctx, xdb, 3, tt.id, tt.parentID)
+
+			// 検証
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %v, but got %v", tt.wantErr, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
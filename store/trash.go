@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	selectTrashedTasks = `SELECT ` + taskColumns + ` FROM tasks
+			 WHERE user_id = ? AND deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC;`
+	getTrashedTask = `SELECT ` + taskColumns + ` FROM tasks
+			 WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL FOR UPDATE;`
+	selectTrashedChildTaskIDs = `SELECT id FROM tasks WHERE user_id = ? AND parent_id IN (?) AND deleted_at = ?;`
+	countActiveTask           = `SELECT COUNT(*) FROM tasks WHERE id = ? AND user_id = ? AND deleted_at IS NULL;`
+	restoreTask               = `UPDATE tasks SET parent_id = ?, deleted_at = NULL, modified = ? WHERE id = ? AND user_id = ?;`
+	restoreTasks              = `UPDATE tasks SET deleted_at = NULL, modified = ? WHERE id IN (?) AND user_id = ?;`
+	emptyTrash                = `DELETE FROM tasks WHERE user_id = ? AND deleted_at IS NOT NULL;`
+	purgeTrash                = `DELETE FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < ?;`
+)
+
+// ListTrashedTasks はユーザのゴミ箱にあるタスクを削除日時の新しい順にすべて取得する
+func (r *Repository) ListTrashedTasks(ctx context.Context, db Queryer, uid entity.UserID) (entity.Tasks, error) {
+	tasks := entity.Tasks{}
+	if err := db.SelectContext(ctx, &tasks, selectTrashedTasks, uid); err != nil {
+		return nil, err
+	}
+	if err := r.fillTags(ctx, db, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// RestoreTask はゴミ箱にあるユーザのタスクを、同時に削除された子孫タスクと併せて復元する
+// 親タスクがゴミ箱に残っている場合は、親タスクを持たないタスクとして復元する
+// トランザクション内で呼び出すことを前提とし、対象がゴミ箱に存在しない場合はErrNotFoundを返却する
+func (r *Repository) RestoreTask(ctx context.Context, db ExecQueryer, uid entity.UserID, id entity.TaskID) error {
+	t := &entity.Task{}
+	if err := db.GetContext(ctx, t, getTrashedTask, id, uid); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("trashed task %d: %w", id, ErrNotFound)
+		}
+		return err
+	}
+	if t.ParentID != nil {
+		var n int
+		if err := db.GetContext(ctx, &n, countActiveTask, *t.ParentID, uid); err != nil {
+			return err
+		}
+		if n == 0 {
+			t.ParentID = nil
+		}
+	}
+
+	var descendants []entity.TaskID
+	level := []entity.TaskID{id}
+	for depth := 1; depth < entity.MaxTaskDepth && len(level) > 0; depth++ {
+		q, args, err := sqlx.In(selectTrashedChildTaskIDs, uid, level, t.DeletedAt)
+		if err != nil {
+			return err
+		}
+		level = nil
+		if err := db.SelectContext(ctx, &level, q, args...); err != nil {
+			return err
+		}
+		descendants = append(descendants, level...)
+	}
+
+	now := r.Clocker.Now()
+	if _, err := db.ExecContext(ctx, restoreTask, t.ParentID, now, id, uid); err != nil {
+		return err
+	}
+	if len(descendants) == 0 {
+		return nil
+	}
+	q, args, err := sqlx.In(restoreTasks, now, descendants, uid)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, q, args...)
+	return err
+}
+
+// EmptyTrash はユーザのゴミ箱にあるタスクをすべて完全に削除し、削除した件数を返却する
+func (r *Repository) EmptyTrash(ctx context.Context, db Execer, uid entity.UserID) (int64, error) {
+	result, err := db.ExecContext(ctx, emptyTrash, uid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// PurgeTrash は全ユーザのゴミ箱にあるタスクのうち、削除日時がbeforeより前のタスクを完全に削除する
+// タグやステータス遷移履歴などの関連は外部キー制約により併せて削除される
+func (r *Repository) PurgeTrash(ctx context.Context, db Execer, before time.Time) (int64, error) {
+	result, err := db.ExecContext(ctx, purgeTrash, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ac0mz/go_todo_app/clock"
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestRepository_RestoreTask(t *testing.T) {
+	t.Parallel()
+
+	c := clock.FixedClocker{}
+	deletedAt := c.Now().AddDate(0, 0, -1)
+	columns := []string{
+		"id", "user_id", "project_id", "parent_id", "title", "description", "status", "priority",
+		"due_at", "recurrence", "created", "modified", "deleted_at",
+	}
+	tests := map[string]struct {
+		found        bool
+		parentActive bool
+		// children は階層ごとに同時に削除された子孫タスクのIDを表す
+		children   [][]int64
+		wantParent any
+		wantErr    error
+	}{
+		"withDescendants": {
+			found: true, parentActive: true,
+			children:   [][]int64{{2, 3}, {4}, {}},
+			wantParent: entity.TaskID(9),
+		},
+		"parentTrashed": {
+			// 親タスクがゴミ箱に残っている場合は親子関係を解除して復元する
+			found: true, parentActive: false,
+			children:   [][]int64{{}},
+			wantParent: nil,
+		},
+		"notFound": {
+			found:   false,
+			wantErr: ErrNotFound,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+
+			// モック設定
+			rows := sqlmock.NewRows(columns)
+			if tt.found {
+				rows.AddRow(1, 3, nil, 9, "trashed", "", "todo", "normal", nil, nil, c.Now(), c.Now(), deletedAt)
+			}
+			mock.ExpectQuery(`SELECT .+ FROM tasks\s+WHERE id = \? AND user_id = \? AND deleted_at IS NOT NULL FOR UPDATE`).
+				WithArgs(entity.TaskID(1), entity.UserID(3)).
+				WillReturnRows(rows)
+			if tt.found {
+				active := 0
+				if tt.parentActive {
+					active = 1
+				}
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM tasks WHERE id = \? AND user_id = \? AND deleted_at IS NULL`).
+					WithArgs(entity.TaskID(9), entity.UserID(3)).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(active))
+				for _, ids := range tt.children {
+					rows := sqlmock.NewRows([]string{"id"})
+					for _, id := range ids {
+						rows.AddRow(id)
+					}
+					mock.ExpectQuery(`SELECT id FROM tasks WHERE user_id = \? AND parent_id IN \(.+\) AND deleted_at = \?`).
+						WillReturnRows(rows)
+				}
+				mock.ExpectExec(`UPDATE tasks SET parent_id = \?, deleted_at = NULL, modified = \? WHERE id = \? AND user_id = \?`).
+					WithArgs(tt.wantParent, c.Now(), entity.TaskID(1), entity.UserID(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				var descendants []driver.Value
+				for _, ids := range tt.children {
+					for _, id := range ids {
+						descendants = append(descendants, entity.TaskID(id))
+					}
+				}
+				if len(descendants) > 0 {
+					args := append([]driver.Value{c.Now()}, descendants...)
+					args = append(args, entity.UserID(3))
+					mock.ExpectExec(`UPDATE tasks SET deleted_at = NULL, modified = \? WHERE id IN \(\?, \?, \?\) AND user_id = \?`).
+						WithArgs(args...).
+						WillReturnResult(sqlmock.NewResult(0, int64(len(descendants))))
+				}
+			}
+
+			xdb := sqlx.NewDb(db, "mysql")
+			r := &Repository{Clocker: c}
+			err = r.RestoreTask(ctx, xdb, 3, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want error %v, but got %v", tt.wantErr, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
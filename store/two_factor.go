@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+const (
+	updateTOTPSecret    = `UPDATE users SET totp_secret = ?, totp_enabled = FALSE, modified = ? WHERE id = ? AND totp_enabled = FALSE;`
+	enableTOTP          = `UPDATE users SET totp_enabled = TRUE, modified = ? WHERE id = ? AND totp_secret = ?;`
+	deleteRecoveryCodes = `DELETE FROM recovery_codes WHERE user_id = ?;`
+	insertRecoveryCode  = `INSERT INTO recovery_codes (user_id, code_hash, created) VALUES (?, ?, ?);`
+	useRecoveryCode     = `UPDATE recovery_codes SET used_at = ?
+			 WHERE user_id = ? AND code_hash = ? AND used_at IS NULL;`
+)
+
+// SaveTOTPSecret は二要素認証を有効化する前のTOTPのシークレットを保存する
+// 二要素認証が有効化済、または対象が存在しない場合はErrNotFoundを返却する
+func (r *Repository) SaveTOTPSecret(ctx context.Context, db Execer, id entity.UserID, secret string) error {
+	result, err := db.ExecContext(ctx, updateTOTPSecret, secret, r.Clocker.Now(), id)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("user %d without two-factor authentication", id))
+}
+
+// EnableTOTP は保存済のシークレットがsecretに一致する場合に二要素認証を有効化する
+// 確認中に別のシークレットが再設定された場合はErrNotFoundを返却する
+func (r *Repository) EnableTOTP(ctx context.Context, db Execer, id entity.UserID, secret string) error {
+	result, err := db.ExecContext(ctx, enableTOTP, r.Clocker.Now(), id, secret)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("user %d with pending totp secret", id))
+}
+
+// ReplaceRecoveryCodes はユーザのリカバリーコードを、ハッシュ値hashesのコードに置き換える
+// 削除と登録を同一のトランザクションで実行するため、dbにはトランザクションを指定する
+func (r *Repository) ReplaceRecoveryCodes(ctx context.Context, db Execer, id entity.UserID, hashes []string) error {
+	if _, err := db.ExecContext(ctx, deleteRecoveryCodes, id); err != nil {
+		return err
+	}
+	now := r.Clocker.Now()
+	for _, h := range hashes {
+		if _, err := db.ExecContext(ctx, insertRecoveryCode, id, h, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UseRecoveryCode はハッシュ値hashに一致する未使用のリカバリーコードを使用済とする
+// 該当するリカバリーコードが存在しない、または使用済の場合はErrNotFoundを返却する
+func (r *Repository) UseRecoveryCode(ctx context.Context, db Execer, id entity.UserID, hash string) error {
+	result, err := db.ExecContext(ctx, useRecoveryCode, r.Clocker.Now(), id, hash)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, "recovery code")
+}
@@ -2,17 +2,28 @@ package store
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 
 	"github.com/ac0mz/go_todo_app/entity"
-	"github.com/go-sql-driver/mysql"
 )
 
 const (
-	insertUser = `INSERT INTO users (name, password, role, created, modified)
-			 VALUES (?, ?, ?, ?, ?);`
-	getUser = `SELECT id, name, password, role, created, modified FROM users WHERE name = ?`
+	// userColumns はentity.Userにマッピングするカラムの一覧
+	userColumns = `id, name, password, role, email, display_name, time_zone, locale,
+			 password_reset_required, totp_secret, totp_enabled, disabled_at, created, modified`
+
+	insertUser = `INSERT INTO users (name, password, role, email, display_name, time_zone, locale, created, modified)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	getUser        = `SELECT ` + userColumns + ` FROM users WHERE name = ?`
+	getUserByID    = `SELECT ` + userColumns + ` FROM users WHERE id = ?`
+	getUserByEmail = `SELECT ` + userColumns + ` FROM users WHERE email = ?`
+	updateRole     = `UPDATE users SET role = ?, modified = ? WHERE id = ?;`
+	updatePassword = `UPDATE users SET password = ?, password_reset_required = ?, modified = ? WHERE id = ?;`
+	requireReset   = `UPDATE users SET password_reset_required = TRUE, modified = ? WHERE id = ?;`
+	updateProfile  = `UPDATE users SET display_name = ?, time_zone = ?, locale = ?, modified = ? WHERE id = ?;`
+	updateDisabled = `UPDATE users SET disabled_at = ?, modified = ? WHERE id = ?;`
 )
 
 func (r *Repository) RegisterUser(ctx context.Context, db Execer, u *entity.User) error {
@@ -20,11 +31,10 @@ func (r *Repository) RegisterUser(ctx context.Context, db Execer, u *entity.User
 	u.Modified = r.Clocker.Now()
 
 	result, err := db.ExecContext(ctx, insertUser,
-		u.Name, u.Password, u.Role, u.Created, u.Modified)
+		u.Name, u.Password, u.Role, u.Email, u.DisplayName, u.TimeZone, u.Locale, u.Created, u.Modified)
 	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeMySQLDuplicateEntry {
-			return fmt.Errorf("cannot create same name user: %w", ErrAlreadyEntry)
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("cannot create user with same name or email: %w", ErrAlreadyEntry)
 		}
 		return err
 	}
@@ -37,10 +47,96 @@ func (r *Repository) RegisterUser(ctx context.Context, db Execer, u *entity.User
 	return nil
 }
 
+// GetUser はユーザ名に一致するユーザを取得する
+// 該当するユーザが存在しない場合はErrNotFoundを返却する
 func (r *Repository) GetUser(ctx context.Context, db Queryer, name string) (*entity.User, error) {
 	u := &entity.User{}
 	if err := db.GetContext(ctx, u, getUser, name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user %q: %w", name, ErrNotFound)
+		}
+		return nil, err
+	}
+	return u, nil
+}
+
+// GetUserByID はユーザIDに一致するユーザを取得する
+// 該当するユーザが存在しない場合はErrNotFoundを返却する
+func (r *Repository) GetUserByID(ctx context.Context, db Queryer, id entity.UserID) (*entity.User, error) {
+	u := &entity.User{}
+	if err := db.GetContext(ctx, u, getUserByID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user %d: %w", id, ErrNotFound)
+		}
 		return nil, err
 	}
 	return u, nil
 }
+
+// GetUserByEmail はメールアドレスに一致するユーザを取得する
+// 該当するユーザが存在しない場合はErrNotFoundを返却する
+func (r *Repository) GetUserByEmail(ctx context.Context, db Queryer, email string) (*entity.User, error) {
+	u := &entity.User{}
+	if err := db.GetContext(ctx, u, getUserByEmail, email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user with email: %w", ErrNotFound)
+		}
+		return nil, err
+	}
+	return u, nil
+}
+
+// UpdateUserRole はユーザのロールを更新する
+// 更新対象が存在しない場合はErrNotFoundを返却する
+func (r *Repository) UpdateUserRole(ctx context.Context, db Execer, u *entity.User) error {
+	u.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, updateRole, u.Role, u.Modified, u.ID)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("user %d", u.ID))
+}
+
+// UpdatePassword はユーザのパスワードのハッシュ値と、パスワード再設定の要否を更新する
+// 更新対象が存在しない場合はErrNotFoundを返却する
+func (r *Repository) UpdatePassword(ctx context.Context, db Execer, u *entity.User) error {
+	u.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, updatePassword, u.Password, u.PasswordResetRequired, u.Modified, u.ID)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("user %d", u.ID))
+}
+
+// RequirePasswordReset はユーザの次回ログイン時にパスワードの再設定を必須とする
+// 更新対象が存在しない場合はErrNotFoundを返却する
+func (r *Repository) RequirePasswordReset(ctx context.Context, db Execer, id entity.UserID) error {
+	result, err := db.ExecContext(ctx, requireReset, r.Clocker.Now(), id)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("user %d", id))
+}
+
+// UpdateProfile はユーザの表示名、タイムゾーン、ロケールを更新する
+// 更新対象が存在しない場合はErrNotFoundを返却する
+func (r *Repository) UpdateProfile(ctx context.Context, db Execer, u *entity.User) error {
+	u.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, updateProfile, u.DisplayName, u.TimeZone, u.Locale, u.Modified, u.ID)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("user %d", u.ID))
+}
+
+// UpdateUserDisabled はユーザの無効化日時を更新する
+// u.DisabledAtがnilの場合はユーザを有効化する
+// 更新対象が存在しない場合はErrNotFoundを返却する
+func (r *Repository) UpdateUserDisabled(ctx context.Context, db Execer, u *entity.User) error {
+	u.Modified = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, updateDisabled, u.DisabledAt, u.Modified, u.ID)
+	if err != nil {
+		return err
+	}
+	return assertAffected(result, fmt.Sprintf("user %d", u.ID))
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+// userDisabledKey は無効化済のユーザを記録するキーを返却する
+func userDisabledKey(userID entity.UserID) string {
+	return fmt.Sprintf("user_disabled:%d", userID)
+}
+
+// SetUserDisabled はユーザの無効化の有無を記録する
+// 記録は再度有効化するまで保持するため、有効期限を設定しない
+func (k KVS) SetUserDisabled(ctx context.Context, userID entity.UserID, disabled bool) error {
+	key := userDisabledKey(userID)
+	if !disabled {
+		return k.Cli.Del(ctx, key).Err()
+	}
+	return k.Cli.Set(ctx, key, 1, 0).Err()
+}
+
+// IsUserDisabled はユーザが無効化済として記録されているかを判定する
+func (k KVS) IsUserDisabled(ctx context.Context, userID entity.UserID) (bool, error) {
+	n, err := k.Cli.Exists(ctx, userDisabledKey(userID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/ac0mz/go_todo_app/testutil"
+)
+
+func TestKVS_SetUserDisabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uid := entity.UserID(5321)
+	cli := testutil.OpenRedisForTest(t)
+	t.Cleanup(func() { cli.Del(ctx, userDisabledKey(uid)) })
+	sut := &KVS{Cli: cli}
+
+	for _, disabled := range []bool{true, false} {
+		if err := sut.SetUserDisabled(ctx, uid, disabled); err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+		got, err := sut.IsUserDisabled(ctx, uid)
+		if err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+		if got != disabled {
+			t.Errorf("want disabled %t, but got %t", disabled, got)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+const (
+	insertUserIdentity = `INSERT INTO user_identities (user_id, issuer, subject, created) VALUES (?, ?, ?, ?);`
+	getUserByIdentity  = `SELECT ` + userColumns + ` FROM users
+			 WHERE id = (SELECT user_id FROM user_identities WHERE issuer = ? AND subject = ?);`
+)
+
+// AddUserIdentity は外部のIdPのユーザとローカルのユーザの対応付けを登録し、
+// 引数で渡された*entity.UserIdentity.IDに発行されたIDを格納する
+// IdPのユーザが他のユーザに対応付け済の場合はErrAlreadyEntryを返却する
+func (r *Repository) AddUserIdentity(ctx context.Context, db Execer, i *entity.UserIdentity) error {
+	i.Created = r.Clocker.Now()
+	result, err := db.ExecContext(ctx, insertUserIdentity, i.UserID, i.Issuer, i.Subject, i.Created)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("identity %q of %q: %w", i.Subject, i.Issuer, ErrAlreadyEntry)
+		}
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	i.ID = entity.UserIdentityID(id)
+	return nil
+}
+
+// GetUserByIdentity は外部のIdPのユーザに対応付けたユーザを取得する
+// 該当するユーザが存在しない場合はErrNotFoundを返却する
+func (r *Repository) GetUserByIdentity(ctx context.Context, db Queryer, issuer, subject string) (*entity.User, error) {
+	u := &entity.User{}
+	if err := db.GetContext(ctx, u, getUserByIdentity, issuer, subject); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("identity %q of %q: %w", subject, issuer, ErrNotFound)
+		}
+		return nil, err
+	}
+	return u, nil
+}
@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ac0mz/go_todo_app/entity"
+)
+
+const selectUsers = `SELECT ` + userColumns + ` FROM users`
+
+// userCursor はキーセットページングにおける前ページ末尾のユーザの位置を表す
+type userCursor struct {
+	ID entity.UserID `json:"id"`
+}
+
+// encodeUserCursor は末尾のユーザから不透明なカーソル文字列を生成する
+func encodeUserCursor(u *entity.User) string {
+	b, _ := json.Marshal(userCursor{ID: u.ID}) // 構造体の値のみのためエラーは発生しない
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeUserCursor はカーソル文字列を解析する
+func decodeUserCursor(s string) (*userCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	c := &userCursor{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return c, nil
+}
+
+// ListUsers は絞り込み条件に一致するユーザを、ユーザIDの昇順で取得する
+// 戻り値の文字列は次ページ取得用のカーソルであり、後続のページが存在しない場合は空文字となる
+func (r *Repository) ListUsers(ctx context.Context, db Queryer, f *entity.UserFilter) (entity.Users, string, error) {
+	limit := f.Limit
+	if limit <= 0 || limit > entity.MaxUserListLimit {
+		limit = entity.DefaultUserListLimit
+	}
+	q, args, err := buildListUsersQuery(f, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	users := entity.Users{}
+	if err := db.SelectContext(ctx, &users, q, args...); err != nil {
+		return nil, "", err
+	}
+	next := ""
+	if len(users) > limit {
+		users = users[:limit]
+		next = encodeUserCursor(users[limit-1])
+	}
+	return users, next, nil
+}
+
+// buildListUsersQuery はユーザ一覧取得のSQLとプレースホルダの引数を組み立てる
+// 取得件数は次ページ有無の判定のためlimit+1件とする
+func buildListUsersQuery(f *entity.UserFilter, limit int) (string, []any, error) {
+	var where []string
+	var args []any
+	if f.Query != "" {
+		where = append(where, "(name LIKE ? OR display_name LIKE ? OR email LIKE ?)")
+		prefix := escapeLike(f.Query) + "%"
+		args = append(args, prefix, prefix, prefix)
+	}
+	if f.Role != "" {
+		where = append(where, "role = ?")
+		args = append(args, f.Role)
+	}
+	if f.Disabled != nil {
+		if *f.Disabled {
+			where = append(where, "disabled_at IS NOT NULL")
+		} else {
+			where = append(where, "disabled_at IS NULL")
+		}
+	}
+	if f.Cursor != "" {
+		c, err := decodeUserCursor(f.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		where = append(where, "id > ?")
+		args = append(args, c.ID)
+	}
+
+	q := selectUsers
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	q += " ORDER BY id ASC LIMIT ?;"
+	args = append(args, limit+1)
+	return q, args, nil
+}
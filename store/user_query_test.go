@@ -0,0 +1,72 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ac0mz/go_todo_app/entity"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_buildListUsersQuery(t *testing.T) {
+	t.Parallel()
+
+	disabled, enabled := true, false
+	type want struct {
+		query string
+		args  []any
+	}
+	tests := map[string]struct {
+		filter *entity.UserFilter
+		want   want
+	}{
+		"default": {
+			filter: &entity.UserFilter{},
+			want: want{
+				query: selectUsers + ` ORDER BY id ASC LIMIT ?;`,
+				args:  []any{51},
+			},
+		},
+		"filtered": {
+			filter: &entity.UserFilter{Query: "50%_off", Role: entity.RoleAdmin, Disabled: &disabled},
+			want: want{
+				query: selectUsers + ` WHERE (name LIKE ? OR display_name LIKE ? OR email LIKE ?) AND role = ? ` +
+					`AND disabled_at IS NOT NULL ORDER BY id ASC LIMIT ?;`,
+				args: []any{`50\%\_off%`, `50\%\_off%`, `50\%\_off%`, entity.RoleAdmin, 51},
+			},
+		},
+		"enabledWithCursor": {
+			filter: &entity.UserFilter{Disabled: &enabled, Cursor: encodeUserCursor(&entity.User{ID: 10})},
+			want: want{
+				query: selectUsers + ` WHERE disabled_at IS NULL AND id > ? ORDER BY id ASC LIMIT ?;`,
+				args:  []any{entity.UserID(10), 51},
+			},
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			q, args, err := buildListUsersQuery(tt.filter, entity.DefaultUserListLimit)
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if q != tt.want.query {
+				t.Errorf("want query\n%s\nbut got\n%s", tt.want.query, q)
+			}
+			if d := cmp.Diff(args, tt.want.args); d != "" {
+				t.Errorf("differs: (-got +want)\n%s", d)
+			}
+		})
+	}
+}
+
+func Test_buildListUsersQuery_invalidCursor(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := buildListUsersQuery(&entity.UserFilter{Cursor: "!!"}, entity.DefaultUserListLimit)
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("want %v, but got %v", ErrInvalidCursor, err)
+	}
+}
@@ -14,7 +14,7 @@ func User(u *entity.User) *entity.User {
 		ID:       entity.UserID(rand.Int()),
 		Name:     "ac0mz" + strconv.Itoa(rand.Int())[:5],
 		Password: "password",
-		Role:     "admin",
+		Role:     entity.RoleAdmin,
 		Created:  time.Now(),
 		Modified: time.Now(),
 	}
@@ -1,12 +1,14 @@
 package testutil
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
 	"os"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -55,3 +57,13 @@ func LoadFile(t *testing.T, path string) []byte {
 	}
 	return byteData
 }
+
+// WithURLParams はchiのURLパスパラメータを設定したリクエストを返却する
+// ルータを経由せずにハンドラを直接呼び出すテストで使用する
+func WithURLParams(r *http.Request, params map[string]string) *http.Request {
+	rctx := chi.NewRouteContext()
+	for k, v := range params {
+		rctx.URLParams.Add(k, v)
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
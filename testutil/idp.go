@@ -0,0 +1,185 @@
+package testutil
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+// FakeIdP はOpenID Connectの認可コードフローに対応したテスト用のIdP
+// ディスカバリ、公開鍵、トークンの各エンドポイントをhttptest.Serverで提供する
+type FakeIdP struct {
+	Server   *httptest.Server
+	ClientID string
+	Now      time.Time
+	// Claims はIDトークンに設定するクレーム(発行者、受信者、有効期限等の既定のクレームを上書きする)
+	Claims map[string]any
+
+	key   jwk.Key
+	mu    sync.Mutex
+	codes map[string]url.Values // 認可コードと認可リクエストのクエリパラメータ
+}
+
+// NewFakeIdP はテスト終了時に停止するFakeIdPを起動する
+func NewFakeIdP(t *testing.T, clientID string, now time.Time) *FakeIdP {
+	t.Helper()
+
+	f := &FakeIdP{
+		ClientID: clientID,
+		Now:      now,
+		Claims:   map[string]any{},
+		key:      newIdPKey(t, "fake-idp-1"),
+		codes:    map[string]url.Values{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{
+			"issuer":                 f.Issuer(),
+			"authorization_endpoint": f.Issuer() + "/authorize",
+			"token_endpoint":         f.Issuer() + "/token",
+			"jwks_uri":               f.Issuer() + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		pub, err := f.key.PublicKey()
+		f.mu.Unlock()
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		set := jwk.NewSet()
+		_ = set.AddKey(pub)
+		writeJSON(w, http.StatusOK, set)
+	})
+	mux.HandleFunc("/token", f.token)
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Server.Close)
+	return f
+}
+
+// Issuer はIdPの発行者を返却する
+func (f *FakeIdP) Issuer() string {
+	return f.Server.URL
+}
+
+// RotateKey はIDトークンの署名鍵を新しいkidの鍵に置き換える
+func (f *FakeIdP) RotateKey(t *testing.T, kid string) {
+	t.Helper()
+
+	key := newIdPKey(t, kid)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.key = key
+}
+
+// newIdPKey はkidを設定したRSAの秘密鍵を生成する
+func newIdPKey(t *testing.T, kid string) jwk.Key {
+	t.Helper()
+
+	raw, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := jwk.FromRaw(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := key.Set(jwk.KeyIDKey, kid); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// Authorize はユーザがIdPで認証し、認可に同意したものとして、認可URLに対する認可コードを発行する
+func (f *FakeIdP) Authorize(t *testing.T, authURL string) string {
+	t.Helper()
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	code := base64.RawURLEncoding.EncodeToString(b)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.codes[code] = u.Query()
+	return code
+}
+
+// token はPKCEのcode_verifierを検証した上で、認可コードをIDトークンと交換する
+// 認可コードは1度のみ使用できる
+func (f *FakeIdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	f.mu.Lock()
+	q, ok := f.codes[r.PostForm.Get("code")]
+	delete(f.codes, r.PostForm.Get("code"))
+	key := f.key
+	f.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok ||
+		r.PostForm.Get("grant_type") != "authorization_code" ||
+		r.PostForm.Get("client_id") != f.ClientID ||
+		r.PostForm.Get("redirect_uri") != q.Get("redirect_uri") ||
+		q.Get("code_challenge_method") != "S256" ||
+		base64.RawURLEncoding.EncodeToString(sum[:]) != q.Get("code_challenge") {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	token, err := jwt.NewBuilder().
+		Issuer(f.Issuer()).
+		Subject("fake-subject").
+		Audience([]string{f.ClientID}).
+		IssuedAt(f.Now).
+		Expiration(f.Now.Add(5*time.Minute)).
+		Claim("nonce", q.Get("nonce")).
+		Build()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	for k, v := range f.Claims {
+		if err := token.Set(k, v); err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+	}
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.RS256, key))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": "fake-access-token",
+		"token_type":   "Bearer",
+		"id_token":     string(signed),
+	})
+}
+
+// writeJSON は値をJSONでレスポンスに書き込む
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}